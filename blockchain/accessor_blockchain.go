@@ -301,10 +301,14 @@ func (blockchain *BlockChain) GetBTCChainID() string {
 	return blockchain.GetConfig().ChainParams.BTCRelayingHeaderChainID
 }
 
+func (blockchain *BlockChain) GetETHChainID() string {
+	return blockchain.GetConfig().ChainParams.ETHRelayingHeaderChainID
+}
+
 func (blockchain *BlockChain) GetBTCHeaderChain() *btcrelaying.BlockChain {
 	return blockchain.GetConfig().BTCChain
 }
 
-func (blockchain *BlockChain) GetPortalFeederAddress() string {
-	return blockchain.GetConfig().ChainParams.PortalFeederAddress
+func (blockchain *BlockChain) GetPortalFeederAddresses() []string {
+	return blockchain.GetConfig().ChainParams.PortalFeederAddresses
 }
@@ -255,6 +255,7 @@ func (blockchain *BlockChain) BuildResponseTransactionFromTxsWithMetadata(view *
 //in case readonly-key: return all outputcoin tx with amount value
 //in case payment-address: return all outputcoin tx with no amount value
 //- Param #2: coinType - which type of joinsplitdesc(COIN or BOND)
+//output coins of viewing keys registered to the output coin index are read from the index, see outcoinindex.go
 func (blockchain *BlockChain) GetListOutputCoinsByKeyset(keyset *incognitokey.KeySet, shardID byte, tokenID *common.Hash) ([]*privacy.OutputCoin, error) {
 	var outCointsInBytes [][]byte
 	var err error
@@ -262,6 +263,11 @@ func (blockchain *BlockChain) GetListOutputCoinsByKeyset(keyset *incognitokey.Ke
 	if keyset == nil {
 		return nil, NewBlockChainError(GetListOutputCoinsByKeysetError, fmt.Errorf("invalid key set, got keyset %+v", keyset))
 	}
+	if results, ok, err := blockchain.getIndexedOutputCoins(keyset, shardID, tokenID); err != nil {
+		Logger.log.Error(err)
+	} else if ok {
+		return results, nil
+	}
 	if blockchain.config.MemCache != nil {
 		// get from cache
 		cachedKey := memcache.GetListOutputcoinCachedKey(keyset.PaymentAddress.Pk[:], tokenID, shardID)
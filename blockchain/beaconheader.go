@@ -30,6 +30,12 @@ type BeaconHeader struct {
 	//for version 2
 	Proposer    string `json:"Proposer"`
 	ProposeTime int64  `json:"ProposeTime"`
+
+	//for version 3, state roots of the previous block, used to verify statedb proofs
+	ConsensusStateRoot common.Hash `json:"ConsensusStateRoot"`
+	FeatureStateRoot   common.Hash `json:"FeatureStateRoot"`
+	RewardStateRoot    common.Hash `json:"RewardStateRoot"`
+	SlashStateRoot     common.Hash `json:"SlashStateRoot"`
 }
 
 func (beaconHeader *BeaconHeader) toString() string {
@@ -49,10 +55,16 @@ func (beaconHeader *BeaconHeader) toString() string {
 	res += beaconHeader.ShardStateHash.String()
 	res += beaconHeader.InstructionHash.String()
 
-	if beaconHeader.Version == 2 {
+	if beaconHeader.Version >= 2 {
 		res += beaconHeader.Proposer
 		res += fmt.Sprintf("%v", beaconHeader.ProposeTime)
 	}
+	if beaconHeader.Version >= STATE_ROOT_BLOCK_VERSION {
+		res += beaconHeader.ConsensusStateRoot.String()
+		res += beaconHeader.FeatureStateRoot.String()
+		res += beaconHeader.RewardStateRoot.String()
+		res += beaconHeader.SlashStateRoot.String()
+	}
 	return res
 }
 
@@ -12,7 +12,8 @@ import (
 	"github.com/incognitochain/incognito-chain/metadata"
 )
 
-func (blockchain *BlockChain) processPDEInstructions(pdexStateDB *statedb.StateDB, beaconBlock *BeaconBlock) error {
+// processPDEInstructions updates pde state with pde instructions of beaconBlock, history events are collected to pdeHistory if it is not nil
+func (blockchain *BlockChain) processPDEInstructions(pdexStateDB *statedb.StateDB, beaconBlock *BeaconBlock, pdeHistory *pdeHistoryRecorder) error {
 	if !hasPDEInstruction(beaconBlock.Body.Instructions) {
 		return nil
 	}
@@ -36,11 +37,21 @@ func (blockchain *BlockChain) processPDEInstructions(pdexStateDB *statedb.StateD
 			continue // Not error, just not PDE instruction
 		}
 		var err error
+		var historyEvent *rawdbv2.PDEHistoryEvent
+		if pdeHistory != nil {
+			historyEvent = pdeHistory.begin(inst, beaconHeight, currentPDEState)
+		}
 		switch inst[0] {
 		case strconv.Itoa(metadata.PDEContributionMeta):
 			err = blockchain.processPDEContributionV2(pdexStateDB, beaconHeight, inst, currentPDEState)
 		case strconv.Itoa(metadata.PDETradeRequestMeta):
 			err = blockchain.processPDETrade(pdexStateDB, beaconHeight, inst, currentPDEState)
+		case strconv.Itoa(metadata.PDECrossPoolTradeRequestMeta):
+			err = blockchain.processPDECrossPoolTrade(pdexStateDB, beaconHeight, inst, currentPDEState)
+		case strconv.Itoa(metadata.PDELimitOrderRequestMeta):
+			err = blockchain.processPDELimitOrder(pdexStateDB, beaconHeight, inst, currentPDEState)
+		case strconv.Itoa(metadata.PDECancelOrderRequestMeta):
+			err = blockchain.processPDECancelOrder(pdexStateDB, beaconHeight, inst, currentPDEState)
 		case strconv.Itoa(metadata.PDEWithdrawalRequestMeta):
 			err = blockchain.processPDEWithdrawal(pdexStateDB, beaconHeight, inst, currentPDEState)
 		}
@@ -48,6 +59,9 @@ func (blockchain *BlockChain) processPDEInstructions(pdexStateDB *statedb.StateD
 			Logger.log.Error(err)
 			return nil
 		}
+		if historyEvent != nil {
+			pdeHistory.finish(historyEvent, beaconHeight, currentPDEState)
+		}
 	}
 	if reflect.DeepEqual(backUpCurrentPDEState, currentPDEState) {
 		return nil
@@ -73,6 +87,15 @@ func hasPDEInstruction(instructions [][]string) bool {
 		case strconv.Itoa(metadata.PDETradeRequestMeta):
 			hasPDEXInstruction = true
 			break
+		case strconv.Itoa(metadata.PDECrossPoolTradeRequestMeta):
+			hasPDEXInstruction = true
+			break
+		case strconv.Itoa(metadata.PDELimitOrderRequestMeta):
+			hasPDEXInstruction = true
+			break
+		case strconv.Itoa(metadata.PDECancelOrderRequestMeta):
+			hasPDEXInstruction = true
+			break
 		case strconv.Itoa(metadata.PDEWithdrawalRequestMeta):
 			hasPDEXInstruction = true
 			break
@@ -285,6 +308,42 @@ func (blockchain *BlockChain) processPDEContributionV2(pdexStateDB *statedb.Stat
 	return nil
 }
 
+// applyPDETradeAcceptedContent updates the pool pair and the trading fees of its shares with an accepted trade
+func applyPDETradeAcceptedContent(
+	beaconHeight uint64,
+	pdeTradeAcceptedContent metadata.PDETradeAcceptedContent,
+	currentPDEState *CurrentPDEState,
+) bool {
+	pdePoolForPairKey := string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, pdeTradeAcceptedContent.Token1IDStr, pdeTradeAcceptedContent.Token2IDStr))
+	pdePoolForPair, found := currentPDEState.PDEPoolPairs[pdePoolForPairKey]
+	if !found || pdePoolForPair == nil {
+		Logger.log.Errorf("WARNING: could not find out pdePoolForPair with token ids: %s & %s", pdeTradeAcceptedContent.Token1IDStr, pdeTradeAcceptedContent.Token2IDStr)
+		return false
+	}
+
+	if pdeTradeAcceptedContent.Token1PoolValueOperation.Operator == "+" {
+		pdePoolForPair.Token1PoolValue += pdeTradeAcceptedContent.Token1PoolValueOperation.Value
+		pdePoolForPair.Token2PoolValue -= pdeTradeAcceptedContent.Token2PoolValueOperation.Value
+	} else {
+		pdePoolForPair.Token1PoolValue -= pdeTradeAcceptedContent.Token1PoolValueOperation.Value
+		pdePoolForPair.Token2PoolValue += pdeTradeAcceptedContent.Token2PoolValueOperation.Value
+	}
+	// pool fee was added to the pool reserves along with the sell amount, it is credited to the shares of the pool
+	feeTokenIDStr := pdeTradeAcceptedContent.Token2IDStr
+	if pdeTradeAcceptedContent.Token1PoolValueOperation.Operator == "+" {
+		feeTokenIDStr = pdeTradeAcceptedContent.Token1IDStr
+	}
+	addPDETradingFee(
+		beaconHeight,
+		pdeTradeAcceptedContent.Token1IDStr,
+		pdeTradeAcceptedContent.Token2IDStr,
+		feeTokenIDStr,
+		pdeTradeAcceptedContent.PoolFee,
+		currentPDEState,
+	)
+	return true
+}
+
 func (blockchain *BlockChain) processPDETrade(pdexStateDB *statedb.StateDB, beaconHeight uint64, instruction []string, currentPDEState *CurrentPDEState) error {
 	if len(instruction) != 4 {
 		return nil // skip the instruction
@@ -319,19 +378,78 @@ func (blockchain *BlockChain) processPDETrade(pdexStateDB *statedb.StateDB, beac
 		return nil
 	}
 
-	pdePoolForPairKey := string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, pdeTradeAcceptedContent.Token1IDStr, pdeTradeAcceptedContent.Token2IDStr))
-	pdePoolForPair, found := currentPDEState.PDEPoolPairs[pdePoolForPairKey]
-	if !found || pdePoolForPair == nil {
-		Logger.log.Errorf("WARNING: could not find out pdePoolForPair with token ids: %s & %s", pdeTradeAcceptedContent.Token1IDStr, pdeTradeAcceptedContent.Token2IDStr)
+	if !applyPDETradeAcceptedContent(beaconHeight, pdeTradeAcceptedContent, currentPDEState) {
 		return nil
 	}
+	err = statedb.TrackPDEStatus(
+		pdexStateDB,
+		rawdbv2.PDETradeStatusPrefix,
+		pdeTradeAcceptedContent.RequestedTxID[:],
+		byte(common.PDETradeAcceptedStatus),
+	)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while tracking pde accepted trade status: %+v", err)
+	}
+	return nil
+}
 
-	if pdeTradeAcceptedContent.Token1PoolValueOperation.Operator == "+" {
-		pdePoolForPair.Token1PoolValue += pdeTradeAcceptedContent.Token1PoolValueOperation.Value
-		pdePoolForPair.Token2PoolValue -= pdeTradeAcceptedContent.Token2PoolValueOperation.Value
-	} else {
-		pdePoolForPair.Token1PoolValue -= pdeTradeAcceptedContent.Token1PoolValueOperation.Value
-		pdePoolForPair.Token2PoolValue += pdeTradeAcceptedContent.Token2PoolValueOperation.Value
+func (blockchain *BlockChain) processPDECrossPoolTrade(pdexStateDB *statedb.StateDB, beaconHeight uint64, instruction []string, currentPDEState *CurrentPDEState) error {
+	if len(instruction) != 4 {
+		return nil // skip the instruction
+	}
+	if instruction[2] == common.PDETradeRefundChainStatus {
+		contentBytes, err := base64.StdEncoding.DecodeString(instruction[3])
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while decoding content string of pde cross pool trade instruction: %+v", err)
+			return nil
+		}
+		var pdeTradeReqAction metadata.PDECrossPoolTradeRequestAction
+		err = json.Unmarshal(contentBytes, &pdeTradeReqAction)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while unmarshaling pde cross pool trade instruction: %+v", err)
+			return nil
+		}
+		err = statedb.TrackPDEStatus(
+			pdexStateDB,
+			rawdbv2.PDETradeStatusPrefix,
+			pdeTradeReqAction.TxReqID[:],
+			byte(common.PDETradeRefundStatus),
+		)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while tracking pde refund cross pool trade status: %+v", err)
+		}
+		return nil
+	}
+	var pdeTradeAcceptedContent metadata.PDECrossPoolTradeAcceptedContent
+	err := json.Unmarshal([]byte(instruction[3]), &pdeTradeAcceptedContent)
+	if err != nil {
+		Logger.log.Errorf("WARNING: an error occured while unmarshaling PDECrossPoolTradeAcceptedContent: %+v", err)
+		return nil
+	}
+
+	// hops are applied all together, none of them is applied if a pool pair of the route is missing
+	pdePoolForPairs := make([]*rawdbv2.PDEPoolForPair, len(pdeTradeAcceptedContent.Hops))
+	for i, hop := range pdeTradeAcceptedContent.Hops {
+		pdePoolForPairKey := string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, hop.Token1IDStr, hop.Token2IDStr))
+		pdePoolForPair, found := currentPDEState.PDEPoolPairs[pdePoolForPairKey]
+		if !found || pdePoolForPair == nil {
+			Logger.log.Errorf("WARNING: could not find out pdePoolForPair with token ids: %s & %s", hop.Token1IDStr, hop.Token2IDStr)
+			return nil
+		}
+		pdePoolForPairs[i] = pdePoolForPair
+	}
+	for i, hop := range pdeTradeAcceptedContent.Hops {
+		pdePoolForPair := pdePoolForPairs[i]
+		feeTokenIDStr := hop.Token2IDStr
+		if hop.Token1PoolValueOperation.Operator == "+" {
+			pdePoolForPair.Token1PoolValue += hop.Token1PoolValueOperation.Value
+			pdePoolForPair.Token2PoolValue -= hop.Token2PoolValueOperation.Value
+			feeTokenIDStr = hop.Token1IDStr
+		} else {
+			pdePoolForPair.Token1PoolValue -= hop.Token1PoolValueOperation.Value
+			pdePoolForPair.Token2PoolValue += hop.Token2PoolValueOperation.Value
+		}
+		addPDETradingFee(beaconHeight, hop.Token1IDStr, hop.Token2IDStr, feeTokenIDStr, hop.PoolFee, currentPDEState)
 	}
 	err = statedb.TrackPDEStatus(
 		pdexStateDB,
@@ -340,7 +458,7 @@ func (blockchain *BlockChain) processPDETrade(pdexStateDB *statedb.StateDB, beac
 		byte(common.PDETradeAcceptedStatus),
 	)
 	if err != nil {
-		Logger.log.Errorf("ERROR: an error occured while tracking pde accepted trade status: %+v", err)
+		Logger.log.Errorf("ERROR: an error occured while tracking pde accepted cross pool trade status: %+v", err)
 	}
 	return nil
 }
@@ -451,3 +569,120 @@ func deductSharesForWithdrawal(
 	}
 	currentPDEState.PDEShares[pdeShareKey] = adjustingAmt
 }
+
+// removePDELimitOrder takes an order out of the order book, it returns nil if the order is not open
+func removePDELimitOrder(orderID common.Hash, currentPDEState *CurrentPDEState) *rawdbv2.PDELimitOrder {
+	orderIDStr := orderID.String()
+	order, found := currentPDEState.PDELimitOrders[orderIDStr]
+	if !found || order == nil {
+		return nil
+	}
+	delete(currentPDEState.PDELimitOrders, orderIDStr)
+	currentPDEState.DeletedPDELimitOrders[orderIDStr] = order
+	return order
+}
+
+func (blockchain *BlockChain) processPDELimitOrder(pdexStateDB *statedb.StateDB, beaconHeight uint64, instruction []string, currentPDEState *CurrentPDEState) error {
+	if len(instruction) != 4 {
+		return nil // skip the instruction
+	}
+	var orderID common.Hash
+	var orderStatus int
+	switch instruction[2] {
+	case common.PDELimitOrderRefundChainStatus:
+		contentBytes, err := base64.StdEncoding.DecodeString(instruction[3])
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while decoding content string of pde limit order instruction: %+v", err)
+			return nil
+		}
+		var pdeLimitOrderReqAction metadata.PDELimitOrderRequestAction
+		err = json.Unmarshal(contentBytes, &pdeLimitOrderReqAction)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while unmarshaling pde limit order instruction: %+v", err)
+			return nil
+		}
+		orderID = pdeLimitOrderReqAction.TxReqID
+		orderStatus = common.PDELimitOrderRefundStatus
+
+	case common.PDELimitOrderOpenChainStatus:
+		var order rawdbv2.PDELimitOrder
+		err := json.Unmarshal([]byte(instruction[3]), &order)
+		if err != nil {
+			Logger.log.Errorf("WARNING: an error occured while unmarshaling PDELimitOrder: %+v", err)
+			return nil
+		}
+		if currentPDEState.PDELimitOrders == nil {
+			currentPDEState.PDELimitOrders = make(map[string]*rawdbv2.PDELimitOrder)
+		}
+		currentPDEState.PDELimitOrders[order.OrderID.String()] = &order
+		orderID = order.OrderID
+		orderStatus = common.PDELimitOrderOpenStatus
+
+	case common.PDELimitOrderFilledChainStatus:
+		var filledContent metadata.PDETradeAcceptedContent
+		err := json.Unmarshal([]byte(instruction[3]), &filledContent)
+		if err != nil {
+			Logger.log.Errorf("WARNING: an error occured while unmarshaling PDETradeAcceptedContent: %+v", err)
+			return nil
+		}
+		if removePDELimitOrder(filledContent.RequestedTxID, currentPDEState) == nil {
+			Logger.log.Errorf("WARNING: could not find out open pde limit order %s", filledContent.RequestedTxID.String())
+			return nil
+		}
+		if !applyPDETradeAcceptedContent(beaconHeight, filledContent, currentPDEState) {
+			return nil
+		}
+		orderID = filledContent.RequestedTxID
+		orderStatus = common.PDELimitOrderFilledStatus
+
+	case common.PDELimitOrderExpiredChainStatus:
+		var refundContent metadata.PDELimitOrderRefundContent
+		err := json.Unmarshal([]byte(instruction[3]), &refundContent)
+		if err != nil {
+			Logger.log.Errorf("WARNING: an error occured while unmarshaling PDELimitOrderRefundContent: %+v", err)
+			return nil
+		}
+		removePDELimitOrder(refundContent.OrderID, currentPDEState)
+		orderID = refundContent.OrderID
+		orderStatus = common.PDELimitOrderExpiredStatus
+
+	default:
+		return nil
+	}
+	err := statedb.TrackPDEStatus(
+		pdexStateDB,
+		rawdbv2.PDELimitOrderStatusPrefix,
+		orderID[:],
+		byte(orderStatus),
+	)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while tracking pde limit order status: %+v", err)
+	}
+	return nil
+}
+
+func (blockchain *BlockChain) processPDECancelOrder(pdexStateDB *statedb.StateDB, beaconHeight uint64, instruction []string, currentPDEState *CurrentPDEState) error {
+	if len(instruction) != 4 {
+		return nil // skip the instruction
+	}
+	if instruction[2] != common.PDECancelOrderAcceptedChainStatus {
+		return nil // the order stays open on rejected cancellations
+	}
+	var refundContent metadata.PDELimitOrderRefundContent
+	err := json.Unmarshal([]byte(instruction[3]), &refundContent)
+	if err != nil {
+		Logger.log.Errorf("WARNING: an error occured while unmarshaling PDELimitOrderRefundContent: %+v", err)
+		return nil
+	}
+	removePDELimitOrder(refundContent.OrderID, currentPDEState)
+	err = statedb.TrackPDEStatus(
+		pdexStateDB,
+		rawdbv2.PDELimitOrderStatusPrefix,
+		refundContent.OrderID[:],
+		byte(common.PDELimitOrderCancelledStatus),
+	)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while tracking pde cancelled limit order status: %+v", err)
+	}
+	return nil
+}
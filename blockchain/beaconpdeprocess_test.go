@@ -282,6 +282,30 @@ func (suite *PDEProcessSuite) TestPDEContributionOnExistedPairForExistedWaitingU
 	suite.Equal(newShares[shareKey3], uint64(10000000000))
 }
 
+func (suite *PDEProcessSuite) TestAddPDETradingFeeToShares() {
+	fmt.Println("Running testcase: TestAddPDETradingFeeToShares")
+	beaconHeight := uint64(1001)
+	token1IDStr := "0000000000000000000000000000000000000000000000000000000000000005"
+	token2IDStr := "0000000000000000000000000000000000000000000000000000000000000007"
+	share1Key := string(rawdbv2.BuildPDESharesKeyV2(beaconHeight-1, token1IDStr, token2IDStr, "contributor1"))
+	share2Key := string(rawdbv2.BuildPDESharesKeyV2(beaconHeight-1, token1IDStr, token2IDStr, "contributor2"))
+	otherShareKey := string(rawdbv2.BuildPDESharesKeyV2(beaconHeight-1, token1IDStr, "0000000000000000000000000000000000000000000000000000000000000009", "contributor1"))
+	suite.currentPDEState.PDEShares = map[string]uint64{
+		share1Key:     3000,
+		share2Key:     1000,
+		otherShareKey: 5000,
+	}
+	addPDETradingFee(beaconHeight-1, token2IDStr, token1IDStr, token1IDStr, 1000, suite.currentPDEState)
+	addPDETradingFee(beaconHeight-1, token1IDStr, token2IDStr, token2IDStr, 10, suite.currentPDEState)
+
+	suite.Equal(suite.currentPDEState.PDETradingFees[share1Key][token1IDStr], uint64(750))
+	suite.Equal(suite.currentPDEState.PDETradingFees[share2Key][token1IDStr], uint64(250))
+	suite.Equal(suite.currentPDEState.PDETradingFees[share1Key][token2IDStr], uint64(7))
+	suite.Equal(suite.currentPDEState.PDETradingFees[share2Key][token2IDStr], uint64(2))
+	_, found := suite.currentPDEState.PDETradingFees[otherShareKey]
+	suite.Equal(found, false)
+}
+
 // In order for 'go test' to run this suite, we need to create
 // a normal test function and pass our suite to suite.Run
 func TestPDEProcessSuite(t *testing.T) {
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
 	"math/big"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -403,6 +404,92 @@ func (blockchain *BlockChain) buildInstructionsForPDEContribution(
 	return [][]string{matchedNReturnedInst1, matchedNReturnedInst2}, nil
 }
 
+// computePDETradeReceiveAmount returns the amount received by selling sellAmount to a pool with the given token values,
+// along with the new pool value of the token to buy, ok is false if the pool cannot fill the trade
+func computePDETradeReceiveAmount(
+	tokenPoolValueToSell uint64,
+	tokenPoolValueToBuy uint64,
+	sellAmount uint64,
+) (uint64, uint64, bool) {
+	invariant := big.NewInt(0)
+	invariant.Mul(big.NewInt(int64(tokenPoolValueToSell)), big.NewInt(int64(tokenPoolValueToBuy)))
+	newTokenPoolValueToSell := big.NewInt(0)
+	newTokenPoolValueToSell.Add(big.NewInt(int64(tokenPoolValueToSell)), big.NewInt(int64(sellAmount)))
+
+	newTokenPoolValueToBuy := big.NewInt(0).Div(invariant, newTokenPoolValueToSell).Uint64()
+	modValue := big.NewInt(0).Mod(invariant, newTokenPoolValueToSell)
+	if modValue.Cmp(big.NewInt(0)) != 0 {
+		newTokenPoolValueToBuy++
+	}
+	if tokenPoolValueToBuy <= newTokenPoolValueToBuy {
+		return 0, 0, false
+	}
+	return tokenPoolValueToBuy - newTokenPoolValueToBuy, newTokenPoolValueToBuy, true
+}
+
+// applyPDETradeOnPoolPair swaps sellAmount of tokenIDToSellStr against the pool pair, the pool pair is updated on mem
+// only if at least minAcceptableAmount is received. The returned content has the pool changes of the trade,
+// its trader and request fields are left for the caller
+func (blockchain *BlockChain) applyPDETradeOnPoolPair(
+	beaconHeight uint64,
+	pdePoolPair *rawdbv2.PDEPoolForPair,
+	tokenIDToSellStr string,
+	sellAmount uint64,
+	tradingFee uint64,
+	minAcceptableAmount uint64,
+) (metadata.PDETradeAcceptedContent, bool) {
+	tokenPoolValueToBuy := pdePoolPair.Token1PoolValue
+	tokenPoolValueToSell := pdePoolPair.Token2PoolValue
+	if pdePoolPair.Token1IDStr == tokenIDToSellStr {
+		tokenPoolValueToSell = pdePoolPair.Token1PoolValue
+		tokenPoolValueToBuy = pdePoolPair.Token2PoolValue
+	}
+	// pool fee stays in the pool along with the sell amount, only the rest of it is swapped
+	poolFee := computePDEPoolFee(
+		sellAmount,
+		blockchain.getPDEPoolFeeRate(beaconHeight, pdePoolPair.Token1IDStr, pdePoolPair.Token2IDStr),
+	)
+	receiveAmt, newTokenPoolValueToBuy, ok := computePDETradeReceiveAmount(tokenPoolValueToSell, tokenPoolValueToBuy, sellAmount-poolFee)
+	if !ok || minAcceptableAmount > receiveAmt {
+		return metadata.PDETradeAcceptedContent{}, false
+	}
+
+	// update current pde state on mem
+	newTokenPoolValueToSell := tokenPoolValueToSell + sellAmount + tradingFee
+	pdePoolPair.Token1PoolValue = newTokenPoolValueToBuy
+	pdePoolPair.Token2PoolValue = newTokenPoolValueToSell
+	if pdePoolPair.Token1IDStr == tokenIDToSellStr {
+		pdePoolPair.Token1PoolValue = newTokenPoolValueToSell
+		pdePoolPair.Token2PoolValue = newTokenPoolValueToBuy
+	}
+
+	pdeTradeAcceptedContent := metadata.PDETradeAcceptedContent{
+		ReceiveAmount: receiveAmt,
+		Token1IDStr:   pdePoolPair.Token1IDStr,
+		Token2IDStr:   pdePoolPair.Token2IDStr,
+		PoolFee:       poolFee,
+	}
+	pdeTradeAcceptedContent.Token1PoolValueOperation = metadata.TokenPoolValueOperation{
+		Operator: "-",
+		Value:    receiveAmt,
+	}
+	pdeTradeAcceptedContent.Token2PoolValueOperation = metadata.TokenPoolValueOperation{
+		Operator: "+",
+		Value:    sellAmount + tradingFee,
+	}
+	if pdePoolPair.Token1IDStr == tokenIDToSellStr {
+		pdeTradeAcceptedContent.Token1PoolValueOperation = metadata.TokenPoolValueOperation{
+			Operator: "+",
+			Value:    sellAmount + tradingFee,
+		}
+		pdeTradeAcceptedContent.Token2PoolValueOperation = metadata.TokenPoolValueOperation{
+			Operator: "-",
+			Value:    receiveAmt,
+		}
+	}
+	return pdeTradeAcceptedContent, true
+}
+
 func (blockchain *BlockChain) buildInstructionsForPDETrade(
 	contentStr string,
 	shardID byte,
@@ -444,24 +531,15 @@ func (blockchain *BlockChain) buildInstructionsForPDETrade(
 		return [][]string{inst}, nil
 	}
 	// trade accepted
-	tokenPoolValueToBuy := pdePoolPair.Token1PoolValue
-	tokenPoolValueToSell := pdePoolPair.Token2PoolValue
-	if pdePoolPair.Token1IDStr == pdeTradeReqAction.Meta.TokenIDToSellStr {
-		tokenPoolValueToSell = pdePoolPair.Token1PoolValue
-		tokenPoolValueToBuy = pdePoolPair.Token2PoolValue
-	}
-	invariant := big.NewInt(0)
-	invariant.Mul(big.NewInt(int64(tokenPoolValueToSell)), big.NewInt(int64(tokenPoolValueToBuy)))
-	fee := pdeTradeReqAction.Meta.TradingFee
-	newTokenPoolValueToSell := big.NewInt(0)
-	newTokenPoolValueToSell.Add(big.NewInt(int64(tokenPoolValueToSell)), big.NewInt(int64(pdeTradeReqAction.Meta.SellAmount)))
-
-	newTokenPoolValueToBuy := big.NewInt(0).Div(invariant, newTokenPoolValueToSell).Uint64()
-	modValue := big.NewInt(0).Mod(invariant, newTokenPoolValueToSell)
-	if modValue.Cmp(big.NewInt(0)) != 0 {
-		newTokenPoolValueToBuy++
-	}
-	if tokenPoolValueToBuy <= newTokenPoolValueToBuy {
+	pdeTradeAcceptedContent, ok := blockchain.applyPDETradeOnPoolPair(
+		beaconHeight,
+		pdePoolPair,
+		pdeTradeReqAction.Meta.TokenIDToSellStr,
+		pdeTradeReqAction.Meta.SellAmount,
+		pdeTradeReqAction.Meta.TradingFee,
+		pdeTradeReqAction.Meta.MinAcceptableAmount,
+	)
+	if !ok {
 		inst := []string{
 			strconv.Itoa(metaType),
 			strconv.Itoa(int(shardID)),
@@ -470,57 +548,139 @@ func (blockchain *BlockChain) buildInstructionsForPDETrade(
 		}
 		return [][]string{inst}, nil
 	}
+	pdeTradeAcceptedContent.TraderAddressStr = pdeTradeReqAction.Meta.TraderAddressStr
+	pdeTradeAcceptedContent.TokenIDToBuyStr = pdeTradeReqAction.Meta.TokenIDToBuyStr
+	pdeTradeAcceptedContent.ShardID = shardID
+	pdeTradeAcceptedContent.RequestedTxID = pdeTradeReqAction.TxReqID
 
-	receiveAmt := tokenPoolValueToBuy - newTokenPoolValueToBuy
-	if pdeTradeReqAction.Meta.MinAcceptableAmount > receiveAmt {
-		inst := []string{
-			strconv.Itoa(metaType),
-			strconv.Itoa(int(shardID)),
-			common.PDETradeRefundChainStatus,
-			contentStr,
+	pdeTradeAcceptedContentBytes, err := json.Marshal(pdeTradeAcceptedContent)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while marshaling pdeTradeAcceptedContent: %+v", err)
+		return [][]string{}, nil
+	}
+	inst := []string{
+		strconv.Itoa(metaType),
+		strconv.Itoa(int(shardID)),
+		common.PDETradeAcceptedChainStatus,
+		string(pdeTradeAcceptedContentBytes),
+	}
+	return [][]string{inst}, nil
+}
+
+func (blockchain *BlockChain) buildInstructionsForPDECrossPoolTrade(
+	contentStr string,
+	shardID byte,
+	metaType int,
+	currentPDEState *CurrentPDEState,
+	beaconHeight uint64,
+) ([][]string, error) {
+	refundInst := []string{
+		strconv.Itoa(metaType),
+		strconv.Itoa(int(shardID)),
+		common.PDETradeRefundChainStatus,
+		contentStr,
+	}
+	if currentPDEState == nil ||
+		(currentPDEState.PDEPoolPairs == nil || len(currentPDEState.PDEPoolPairs) == 0) {
+		return [][]string{refundInst}, nil
+	}
+	contentBytes, err := base64.StdEncoding.DecodeString(contentStr)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while decoding content string of pde cross pool trade instruction: %+v", err)
+		return [][]string{}, nil
+	}
+	var pdeTradeReqAction metadata.PDECrossPoolTradeRequestAction
+	err = json.Unmarshal(contentBytes, &pdeTradeReqAction)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while unmarshaling pde cross pool trade instruction: %+v", err)
+		return [][]string{}, nil
+	}
+	route := pdeTradeReqAction.Meta.Route
+	if len(route) < 2 || len(route) > metadata.MaxPDECrossPoolTradeHops+1 {
+		return [][]string{refundInst}, nil
+	}
+
+	// all hops are simulated on copies of the pool pairs, they are only applied to the current pde state
+	// if the whole route goes through, a route can go through a pool pair more than once
+	updatedPoolPairs := make(map[string]*rawdbv2.PDEPoolForPair)
+	hops := make([]metadata.PDETradeHop, 0, len(route)-1)
+	fee := pdeTradeReqAction.Meta.TradingFee
+	sellAmount := pdeTradeReqAction.Meta.SellAmount
+	for i := 0; i < len(route)-1; i++ {
+		tokenIDToSellStr, tokenIDToBuyStr := route[i], route[i+1]
+		pairKey := string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, tokenIDToSellStr, tokenIDToBuyStr))
+		pdePoolPair, found := updatedPoolPairs[pairKey]
+		if !found {
+			currentPoolPair, found := currentPDEState.PDEPoolPairs[pairKey]
+			if !found || currentPoolPair == nil {
+				return [][]string{refundInst}, nil
+			}
+			poolPairCopy := *currentPoolPair
+			pdePoolPair = &poolPairCopy
+			updatedPoolPairs[pairKey] = pdePoolPair
 		}
-		return [][]string{inst}, nil
+		if pdePoolPair.Token1PoolValue == 0 || pdePoolPair.Token2PoolValue == 0 {
+			return [][]string{refundInst}, nil
+		}
+		tokenPoolValueToBuy := pdePoolPair.Token1PoolValue
+		tokenPoolValueToSell := pdePoolPair.Token2PoolValue
+		if pdePoolPair.Token1IDStr == tokenIDToSellStr {
+			tokenPoolValueToSell = pdePoolPair.Token1PoolValue
+			tokenPoolValueToBuy = pdePoolPair.Token2PoolValue
+		}
+		poolFee := computePDEPoolFee(
+			sellAmount,
+			blockchain.getPDEPoolFeeRate(beaconHeight, pdePoolPair.Token1IDStr, pdePoolPair.Token2IDStr),
+		)
+		receiveAmt, newTokenPoolValueToBuy, ok := computePDETradeReceiveAmount(tokenPoolValueToSell, tokenPoolValueToBuy, sellAmount-poolFee)
+		if !ok {
+			return [][]string{refundInst}, nil
+		}
+		// trading fee goes to the first pool pair of the route
+		addedAmount := sellAmount
+		if i == 0 {
+			addedAmount += fee
+		}
+		newTokenPoolValueToSell := tokenPoolValueToSell + addedAmount
+		hop := metadata.PDETradeHop{
+			Token1IDStr: pdePoolPair.Token1IDStr,
+			Token2IDStr: pdePoolPair.Token2IDStr,
+			PoolFee:     poolFee,
+		}
+		pdePoolPair.Token1PoolValue = newTokenPoolValueToBuy
+		pdePoolPair.Token2PoolValue = newTokenPoolValueToSell
+		hop.Token1PoolValueOperation = metadata.TokenPoolValueOperation{Operator: "-", Value: receiveAmt}
+		hop.Token2PoolValueOperation = metadata.TokenPoolValueOperation{Operator: "+", Value: addedAmount}
+		if pdePoolPair.Token1IDStr == tokenIDToSellStr {
+			pdePoolPair.Token1PoolValue = newTokenPoolValueToSell
+			pdePoolPair.Token2PoolValue = newTokenPoolValueToBuy
+			hop.Token1PoolValueOperation = metadata.TokenPoolValueOperation{Operator: "+", Value: addedAmount}
+			hop.Token2PoolValueOperation = metadata.TokenPoolValueOperation{Operator: "-", Value: receiveAmt}
+		}
+		hops = append(hops, hop)
+		sellAmount = receiveAmt
+	}
+	receiveAmt := sellAmount
+	if pdeTradeReqAction.Meta.MinAcceptableAmount > receiveAmt {
+		return [][]string{refundInst}, nil
 	}
 
 	// update current pde state on mem
-	newTokenPoolValueToSell.Add(newTokenPoolValueToSell, big.NewInt(int64(fee)))
-	pdePoolPair.Token1PoolValue = newTokenPoolValueToBuy
-	pdePoolPair.Token2PoolValue = newTokenPoolValueToSell.Uint64()
-	if pdePoolPair.Token1IDStr == pdeTradeReqAction.Meta.TokenIDToSellStr {
-		pdePoolPair.Token1PoolValue = newTokenPoolValueToSell.Uint64()
-		pdePoolPair.Token2PoolValue = newTokenPoolValueToBuy
+	for pairKey, pdePoolPair := range updatedPoolPairs {
+		currentPDEState.PDEPoolPairs[pairKey] = pdePoolPair
 	}
 
-	pdeTradeAcceptedContent := metadata.PDETradeAcceptedContent{
+	pdeTradeAcceptedContent := metadata.PDECrossPoolTradeAcceptedContent{
 		TraderAddressStr: pdeTradeReqAction.Meta.TraderAddressStr,
 		TokenIDToBuyStr:  pdeTradeReqAction.Meta.TokenIDToBuyStr,
 		ReceiveAmount:    receiveAmt,
-		Token1IDStr:      pdePoolPair.Token1IDStr,
-		Token2IDStr:      pdePoolPair.Token2IDStr,
+		Hops:             hops,
 		ShardID:          shardID,
 		RequestedTxID:    pdeTradeReqAction.TxReqID,
 	}
-	pdeTradeAcceptedContent.Token1PoolValueOperation = metadata.TokenPoolValueOperation{
-		Operator: "-",
-		Value:    receiveAmt,
-	}
-	pdeTradeAcceptedContent.Token2PoolValueOperation = metadata.TokenPoolValueOperation{
-		Operator: "+",
-		Value:    pdeTradeReqAction.Meta.SellAmount + fee,
-	}
-	if pdePoolPair.Token1IDStr == pdeTradeReqAction.Meta.TokenIDToSellStr {
-		pdeTradeAcceptedContent.Token1PoolValueOperation = metadata.TokenPoolValueOperation{
-			Operator: "+",
-			Value:    pdeTradeReqAction.Meta.SellAmount + fee,
-		}
-		pdeTradeAcceptedContent.Token2PoolValueOperation = metadata.TokenPoolValueOperation{
-			Operator: "-",
-			Value:    receiveAmt,
-		}
-	}
 	pdeTradeAcceptedContentBytes, err := json.Marshal(pdeTradeAcceptedContent)
 	if err != nil {
-		Logger.log.Errorf("ERROR: an error occured while marshaling pdeTradeAcceptedContent: %+v", err)
+		Logger.log.Errorf("ERROR: an error occured while marshaling pdeCrossPoolTradeAcceptedContent: %+v", err)
 		return [][]string{}, nil
 	}
 	inst := []string{
@@ -532,6 +692,272 @@ func (blockchain *BlockChain) buildInstructionsForPDETrade(
 	return [][]string{inst}, nil
 }
 
+func buildPDELimitOrderRefundInst(
+	order *rawdbv2.PDELimitOrder,
+	metaType int,
+	status string,
+) ([]string, error) {
+	refundContent := metadata.PDELimitOrderRefundContent{
+		OrderID:          order.OrderID,
+		TraderAddressStr: order.TraderAddressStr,
+		TokenIDStr:       order.TokenIDToSellStr,
+		Amount:           order.SellAmount + order.TradingFee,
+		ShardID:          order.ShardID,
+	}
+	refundContentBytes, err := json.Marshal(refundContent)
+	if err != nil {
+		return []string{}, err
+	}
+	return []string{
+		strconv.Itoa(metaType),
+		strconv.Itoa(int(order.ShardID)),
+		status,
+		string(refundContentBytes),
+	}, nil
+}
+
+func (blockchain *BlockChain) buildInstructionsForPDELimitOrder(
+	contentStr string,
+	shardID byte,
+	metaType int,
+	currentPDEState *CurrentPDEState,
+	beaconHeight uint64,
+) ([][]string, error) {
+	contentBytes, err := base64.StdEncoding.DecodeString(contentStr)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while decoding content string of pde limit order instruction: %+v", err)
+		return [][]string{}, nil
+	}
+	var pdeLimitOrderReqAction metadata.PDELimitOrderRequestAction
+	err = json.Unmarshal(contentBytes, &pdeLimitOrderReqAction)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while unmarshaling pde limit order instruction: %+v", err)
+		return [][]string{}, nil
+	}
+	refundInst := []string{
+		strconv.Itoa(metaType),
+		strconv.Itoa(int(shardID)),
+		common.PDELimitOrderRefundChainStatus,
+		contentStr,
+	}
+	if currentPDEState == nil || currentPDEState.PDEPoolPairs == nil {
+		return [][]string{refundInst}, nil
+	}
+	meta := pdeLimitOrderReqAction.Meta
+	pairKey := string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, meta.TokenIDToBuyStr, meta.TokenIDToSellStr))
+	pdePoolPair, found := currentPDEState.PDEPoolPairs[pairKey]
+	if !found || pdePoolPair == nil || pdePoolPair.Token1PoolValue == 0 || pdePoolPair.Token2PoolValue == 0 {
+		return [][]string{refundInst}, nil
+	}
+	orderIDStr := pdeLimitOrderReqAction.TxReqID.String()
+	if _, found := currentPDEState.PDELimitOrders[orderIDStr]; found {
+		return [][]string{refundInst}, nil
+	}
+	// the order book is matched and sorted at every beacon block, it is bounded per pool pair
+	if countPDELimitOrdersOfPair(currentPDEState.PDELimitOrders, meta.TokenIDToBuyStr, meta.TokenIDToSellStr) >= metadata.MaxPDELimitOrdersPerPair {
+		Logger.log.Warnf("WARN: pde limit order %+v is refunded: the order book of the pair is full", orderIDStr)
+		return [][]string{refundInst}, nil
+	}
+
+	// the order is active from the beacon block including it
+	order := rawdbv2.NewPDELimitOrder(
+		pdeLimitOrderReqAction.TxReqID,
+		meta.TraderAddressStr,
+		meta.TokenIDToBuyStr,
+		meta.TokenIDToSellStr,
+		meta.SellAmount,
+		meta.MinAcceptableAmount,
+		meta.TradingFee,
+		shardID,
+		beaconHeight+1+meta.ActiveHeights,
+	)
+	if currentPDEState.PDELimitOrders == nil {
+		currentPDEState.PDELimitOrders = make(map[string]*rawdbv2.PDELimitOrder)
+	}
+	currentPDEState.PDELimitOrders[orderIDStr] = order
+	orderBytes, err := json.Marshal(order)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while marshaling pde limit order: %+v", err)
+		return [][]string{}, nil
+	}
+	inst := []string{
+		strconv.Itoa(metaType),
+		strconv.Itoa(int(shardID)),
+		common.PDELimitOrderOpenChainStatus,
+		string(orderBytes),
+	}
+	return [][]string{inst}, nil
+}
+
+func (blockchain *BlockChain) buildInstructionsForPDECancelOrder(
+	contentStr string,
+	shardID byte,
+	metaType int,
+	currentPDEState *CurrentPDEState,
+	beaconHeight uint64,
+) ([][]string, error) {
+	contentBytes, err := base64.StdEncoding.DecodeString(contentStr)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while decoding content string of pde cancel order instruction: %+v", err)
+		return [][]string{}, nil
+	}
+	var pdeCancelOrderReqAction metadata.PDECancelOrderRequestAction
+	err = json.Unmarshal(contentBytes, &pdeCancelOrderReqAction)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while unmarshaling pde cancel order instruction: %+v", err)
+		return [][]string{}, nil
+	}
+	var order *rawdbv2.PDELimitOrder
+	found := false
+	// orders are keyed by the canonical string of their id
+	orderID, err := common.Hash{}.NewHashFromStr(pdeCancelOrderReqAction.Meta.OrderID)
+	if err == nil && currentPDEState != nil {
+		order, found = currentPDEState.PDELimitOrders[orderID.String()]
+	}
+	if !found || order == nil || order.TraderAddressStr != pdeCancelOrderReqAction.Meta.TraderAddressStr {
+		inst := []string{
+			strconv.Itoa(metaType),
+			strconv.Itoa(int(shardID)),
+			common.PDECancelOrderRejectedChainStatus,
+			contentStr,
+		}
+		return [][]string{inst}, nil
+	}
+
+	// update current pde state on mem
+	delete(currentPDEState.PDELimitOrders, orderID.String())
+	currentPDEState.DeletedPDELimitOrders[orderID.String()] = order
+
+	inst, err := buildPDELimitOrderRefundInst(order, metaType, common.PDECancelOrderAcceptedChainStatus)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while building pde cancel order instruction: %+v", err)
+		return [][]string{}, nil
+	}
+	return [][]string{inst}, nil
+}
+
+// countPDELimitOrdersOfPair counts open orders of the pool pair of the two tokens
+func countPDELimitOrdersOfPair(pdeLimitOrders map[string]*rawdbv2.PDELimitOrder, tokenIDStr1 string, tokenIDStr2 string) int {
+	count := 0
+	for _, order := range pdeLimitOrders {
+		if (order.TokenIDToBuyStr == tokenIDStr1 && order.TokenIDToSellStr == tokenIDStr2) ||
+			(order.TokenIDToBuyStr == tokenIDStr2 && order.TokenIDToSellStr == tokenIDStr1) {
+			count++
+		}
+	}
+	return count
+}
+
+// sortPDELimitOrders sorts open orders by pool pair, then by limit price from the lowest one, so that
+// orders asking for less are filled first when the pool price moves
+func sortPDELimitOrders(beaconHeight uint64, pdeLimitOrders map[string]*rawdbv2.PDELimitOrder) []*rawdbv2.PDELimitOrder {
+	orders := make([]*rawdbv2.PDELimitOrder, 0, len(pdeLimitOrders))
+	for _, order := range pdeLimitOrders {
+		orders = append(orders, order)
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		firstPairKey := string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, orders[i].TokenIDToBuyStr, orders[i].TokenIDToSellStr))
+		secondPairKey := string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, orders[j].TokenIDToBuyStr, orders[j].TokenIDToSellStr))
+		if firstPairKey != secondPairKey {
+			return firstPairKey < secondPairKey
+		}
+		// comparing a/b to c/d is equivalent with comparing a*d to c*b
+		firstItemPrice := big.NewInt(0)
+		firstItemPrice.Mul(
+			big.NewInt(int64(orders[i].MinAcceptableAmount)),
+			big.NewInt(int64(orders[j].SellAmount)),
+		)
+		secondItemPrice := big.NewInt(0)
+		secondItemPrice.Mul(
+			big.NewInt(int64(orders[j].MinAcceptableAmount)),
+			big.NewInt(int64(orders[i].SellAmount)),
+		)
+		if cmp := firstItemPrice.Cmp(secondItemPrice); cmp != 0 {
+			return cmp == -1
+		}
+		return orders[i].OrderID.String() < orders[j].OrderID.String()
+	})
+	return orders
+}
+
+// buildInstructionsForPDELimitOrderMatching fills open orders whose limit is reached by the current pool price,
+// an order is filled as a whole against the pool pair or stays open
+func (blockchain *BlockChain) buildInstructionsForPDELimitOrderMatching(
+	currentPDEState *CurrentPDEState,
+	beaconHeight uint64,
+) [][]string {
+	instructions := [][]string{}
+	if currentPDEState == nil || len(currentPDEState.PDELimitOrders) == 0 {
+		return instructions
+	}
+	for _, order := range sortPDELimitOrders(beaconHeight, currentPDEState.PDELimitOrders) {
+		pairKey := string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, order.TokenIDToBuyStr, order.TokenIDToSellStr))
+		pdePoolPair, found := currentPDEState.PDEPoolPairs[pairKey]
+		if !found || pdePoolPair == nil || pdePoolPair.Token1PoolValue == 0 || pdePoolPair.Token2PoolValue == 0 {
+			continue
+		}
+		filledContent, ok := blockchain.applyPDETradeOnPoolPair(
+			beaconHeight,
+			pdePoolPair,
+			order.TokenIDToSellStr,
+			order.SellAmount,
+			order.TradingFee,
+			order.MinAcceptableAmount,
+		)
+		if !ok {
+			continue
+		}
+		filledContent.TraderAddressStr = order.TraderAddressStr
+		filledContent.TokenIDToBuyStr = order.TokenIDToBuyStr
+		filledContent.ShardID = order.ShardID
+		filledContent.RequestedTxID = order.OrderID
+
+		orderIDStr := order.OrderID.String()
+		delete(currentPDEState.PDELimitOrders, orderIDStr)
+		currentPDEState.DeletedPDELimitOrders[orderIDStr] = order
+
+		filledContentBytes, err := json.Marshal(filledContent)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while marshaling filled pde limit order content: %+v", err)
+			continue
+		}
+		instructions = append(instructions, []string{
+			strconv.Itoa(metadata.PDELimitOrderRequestMeta),
+			strconv.Itoa(int(order.ShardID)),
+			common.PDELimitOrderFilledChainStatus,
+			string(filledContentBytes),
+		})
+	}
+	return instructions
+}
+
+// buildInstructionsForPDEExpiredOrders removes open orders reaching their expiry height from the order book and refunds them
+func buildInstructionsForPDEExpiredOrders(
+	currentPDEState *CurrentPDEState,
+	beaconHeight uint64,
+) [][]string {
+	instructions := [][]string{}
+	if currentPDEState == nil || len(currentPDEState.PDELimitOrders) == 0 {
+		return instructions
+	}
+	for _, order := range sortPDELimitOrders(beaconHeight, currentPDEState.PDELimitOrders) {
+		if order.ExpiryHeight > beaconHeight+1 {
+			continue
+		}
+		orderIDStr := order.OrderID.String()
+		delete(currentPDEState.PDELimitOrders, orderIDStr)
+		currentPDEState.DeletedPDELimitOrders[orderIDStr] = order
+
+		inst, err := buildPDELimitOrderRefundInst(order, metadata.PDELimitOrderRequestMeta, common.PDELimitOrderExpiredChainStatus)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while building expired pde limit order instruction: %+v", err)
+			continue
+		}
+		instructions = append(instructions, inst)
+	}
+	return instructions
+}
+
 func buildPDEWithdrawalAcceptedInst(
 	wdMeta metadata.PDEWithdrawalRequest,
 	shardID byte,
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/incognitochain/incognito-chain/common"
@@ -27,6 +28,8 @@ func (suite *PDEProducerSuite) SetupTest() {
 		WaitingPDEContributions: make(map[string]*rawdbv2.PDEContribution),
 		PDEPoolPairs:            make(map[string]*rawdbv2.PDEPoolForPair),
 		PDEShares:               make(map[string]uint64),
+		PDELimitOrders:          make(map[string]*rawdbv2.PDELimitOrder),
+		DeletedPDELimitOrders:   make(map[string]*rawdbv2.PDELimitOrder),
 	}
 }
 
@@ -636,9 +639,487 @@ func (suite *PDEProducerSuite) TestWithdrawExceededSharesOnToken2OfExistedPair()
 	suite.Equal(remainingTk3Shares, uint64(20000000000000))
 }
 
+func buildPDECrossPoolTradeReqAction(
+	route []string,
+	sellAmount uint64,
+	minAcceptableAmount uint64,
+	tradingFee uint64,
+	traderAddressStr string,
+) []string {
+	metadataBase := metadata.MetadataBase{
+		Type: metadata.PDECrossPoolTradeRequestMeta,
+	}
+	pdeCrossPoolTradeRequest := metadata.PDECrossPoolTradeRequest{
+		TokenIDToBuyStr:     route[len(route)-1],
+		TokenIDToSellStr:    route[0],
+		Route:               route,
+		SellAmount:          sellAmount,
+		MinAcceptableAmount: minAcceptableAmount,
+		TradingFee:          tradingFee,
+		TraderAddressStr:    traderAddressStr,
+	}
+	pdeCrossPoolTradeRequest.MetadataBase = metadataBase
+	actionContent := metadata.PDECrossPoolTradeRequestAction{
+		Meta:    pdeCrossPoolTradeRequest,
+		TxReqID: common.Hash{},
+		ShardID: 1,
+	}
+	actionContentBytes, _ := json.Marshal(actionContent)
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	return []string{strconv.Itoa(metadata.PDECrossPoolTradeRequestMeta), actionContentBase64Str}
+}
+
+func (suite *PDEProducerSuite) setupCrossPoolTradePairs(beaconHeight uint64) (string, string) {
+	pair1 := rawdbv2.PDEPoolForPair{
+		Token1IDStr:     "0000000000000000000000000000000000000000000000000000000000000005",
+		Token1PoolValue: 500000000000,
+		Token2IDStr:     "0000000000000000000000000000000000000000000000000000000000000007",
+		Token2PoolValue: 60000000000000,
+	}
+	pair2 := rawdbv2.PDEPoolForPair{
+		Token1IDStr:     "0000000000000000000000000000000000000000000000000000000000000007",
+		Token1PoolValue: 40000000000000,
+		Token2IDStr:     "0000000000000000000000000000000000000000000000000000000000000009",
+		Token2PoolValue: 2000000000000,
+	}
+	pair1Key := string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight-1, pair1.Token1IDStr, pair1.Token2IDStr))
+	pair2Key := string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight-1, pair2.Token1IDStr, pair2.Token2IDStr))
+	suite.currentPDEState.PDEPoolPairs = map[string]*rawdbv2.PDEPoolForPair{
+		pair1Key: &pair1,
+		pair2Key: &pair2,
+	}
+	return pair1Key, pair2Key
+}
+
+func (suite *PDEProducerSuite) TestCrossPoolTradeThroughTwoPairs() {
+	fmt.Println("Running testcase: TestCrossPoolTradeThroughTwoPairs")
+	beaconHeight := uint64(1001)
+	pair1Key, pair2Key := suite.setupCrossPoolTradePairs(beaconHeight)
+	reqAction := buildPDECrossPoolTradeReqAction(
+		[]string{
+			"0000000000000000000000000000000000000000000000000000000000000005",
+			"0000000000000000000000000000000000000000000000000000000000000007",
+			"0000000000000000000000000000000000000000000000000000000000000009",
+		},
+		10000000000,
+		57000000000,
+		1000000,
+		"12S2jM1TBbX2V5TBTvpJkJmsdaYxbCspGNedQkvJpYcbnV4gad7FDEbzY9P3zbpZRJTsGD5vxJRia3UiiUwMUbXbjfgezewq6rtPNtj",
+	)
+	metaType, _ := strconv.Atoi(reqAction[0])
+	contentStr := reqAction[1]
+	shardID := byte(1)
+	bc := &BlockChain{}
+
+	pdeTradeAcceptedContent := metadata.PDECrossPoolTradeAcceptedContent{
+		TraderAddressStr: "12S2jM1TBbX2V5TBTvpJkJmsdaYxbCspGNedQkvJpYcbnV4gad7FDEbzY9P3zbpZRJTsGD5vxJRia3UiiUwMUbXbjfgezewq6rtPNtj",
+		TokenIDToBuyStr:  "0000000000000000000000000000000000000000000000000000000000000009",
+		ReceiveAmount:    57142857142,
+		Hops: []metadata.PDETradeHop{
+			{
+				Token1IDStr:              "0000000000000000000000000000000000000000000000000000000000000005",
+				Token2IDStr:              "0000000000000000000000000000000000000000000000000000000000000007",
+				Token1PoolValueOperation: metadata.TokenPoolValueOperation{Operator: "+", Value: 10001000000},
+				Token2PoolValueOperation: metadata.TokenPoolValueOperation{Operator: "-", Value: 1176470588235},
+			},
+			{
+				Token1IDStr:              "0000000000000000000000000000000000000000000000000000000000000007",
+				Token2IDStr:              "0000000000000000000000000000000000000000000000000000000000000009",
+				Token1PoolValueOperation: metadata.TokenPoolValueOperation{Operator: "+", Value: 1176470588235},
+				Token2PoolValueOperation: metadata.TokenPoolValueOperation{Operator: "-", Value: 57142857142},
+			},
+		},
+		ShardID:       shardID,
+		RequestedTxID: common.Hash{},
+	}
+	pdeTradeAcceptedContentBytes, _ := json.Marshal(pdeTradeAcceptedContent)
+	newInsts, err := bc.buildInstructionsForPDECrossPoolTrade(contentStr, shardID, metaType, suite.currentPDEState, beaconHeight-1)
+
+	suite.Equal(err, nil)
+	suite.Equal(len(newInsts), 1)
+	suite.Equal(len(newInsts[0]), 4)
+	suite.Equal(newInsts[0][0], strconv.Itoa(metaType))
+	suite.Equal(newInsts[0][1], strconv.Itoa(int(shardID)))
+	suite.Equal(newInsts[0][2], "accepted")
+	suite.Equal(newInsts[0][3], string(pdeTradeAcceptedContentBytes))
+
+	suite.Equal(suite.currentPDEState.PDEPoolPairs[pair1Key].Token1PoolValue, uint64(500000000000+10001000000))
+	suite.Equal(suite.currentPDEState.PDEPoolPairs[pair1Key].Token2PoolValue, uint64(60000000000000-1176470588235))
+	suite.Equal(suite.currentPDEState.PDEPoolPairs[pair2Key].Token1PoolValue, uint64(40000000000000+1176470588235))
+	suite.Equal(suite.currentPDEState.PDEPoolPairs[pair2Key].Token2PoolValue, uint64(2000000000000-57142857142))
+}
+
+func (suite *PDEProducerSuite) TestCrossPoolTradeOnUnexistedHop() {
+	fmt.Println("Running testcase: TestCrossPoolTradeOnUnexistedHop")
+	beaconHeight := uint64(1001)
+	pair1Key, pair2Key := suite.setupCrossPoolTradePairs(beaconHeight)
+	reqAction := buildPDECrossPoolTradeReqAction(
+		[]string{
+			"0000000000000000000000000000000000000000000000000000000000000005",
+			"0000000000000000000000000000000000000000000000000000000000000007",
+			"0000000000000000000000000000000000000000000000000000000000000006",
+		},
+		10000000000,
+		0,
+		1000000,
+		"12S2jM1TBbX2V5TBTvpJkJmsdaYxbCspGNedQkvJpYcbnV4gad7FDEbzY9P3zbpZRJTsGD5vxJRia3UiiUwMUbXbjfgezewq6rtPNtj",
+	)
+	metaType, _ := strconv.Atoi(reqAction[0])
+	contentStr := reqAction[1]
+	shardID := byte(1)
+	bc := &BlockChain{}
+	newInsts, err := bc.buildInstructionsForPDECrossPoolTrade(contentStr, shardID, metaType, suite.currentPDEState, beaconHeight-1)
+	suite.Equal(err, nil)
+	suite.Equal(len(newInsts), 1)
+	suite.Equal(len(newInsts[0]), 4)
+	suite.Equal(newInsts[0][2], "refund")
+	suite.Equal(newInsts[0][3], contentStr)
+
+	// the first hop must not be applied
+	suite.Equal(suite.currentPDEState.PDEPoolPairs[pair1Key].Token1PoolValue, uint64(500000000000))
+	suite.Equal(suite.currentPDEState.PDEPoolPairs[pair1Key].Token2PoolValue, uint64(60000000000000))
+	suite.Equal(suite.currentPDEState.PDEPoolPairs[pair2Key].Token1PoolValue, uint64(40000000000000))
+	suite.Equal(suite.currentPDEState.PDEPoolPairs[pair2Key].Token2PoolValue, uint64(2000000000000))
+}
+
+func (suite *PDEProducerSuite) TestCrossPoolTradeBelowMinAcceptableAmount() {
+	fmt.Println("Running testcase: TestCrossPoolTradeBelowMinAcceptableAmount")
+	beaconHeight := uint64(1001)
+	pair1Key, pair2Key := suite.setupCrossPoolTradePairs(beaconHeight)
+	reqAction := buildPDECrossPoolTradeReqAction(
+		[]string{
+			"0000000000000000000000000000000000000000000000000000000000000005",
+			"0000000000000000000000000000000000000000000000000000000000000007",
+			"0000000000000000000000000000000000000000000000000000000000000009",
+		},
+		10000000000,
+		57142857143,
+		1000000,
+		"12S2jM1TBbX2V5TBTvpJkJmsdaYxbCspGNedQkvJpYcbnV4gad7FDEbzY9P3zbpZRJTsGD5vxJRia3UiiUwMUbXbjfgezewq6rtPNtj",
+	)
+	metaType, _ := strconv.Atoi(reqAction[0])
+	contentStr := reqAction[1]
+	shardID := byte(1)
+	bc := &BlockChain{}
+	newInsts, err := bc.buildInstructionsForPDECrossPoolTrade(contentStr, shardID, metaType, suite.currentPDEState, beaconHeight-1)
+	suite.Equal(err, nil)
+	suite.Equal(len(newInsts), 1)
+	suite.Equal(len(newInsts[0]), 4)
+	suite.Equal(newInsts[0][2], "refund")
+	suite.Equal(newInsts[0][3], contentStr)
+
+	suite.Equal(suite.currentPDEState.PDEPoolPairs[pair1Key].Token1PoolValue, uint64(500000000000))
+	suite.Equal(suite.currentPDEState.PDEPoolPairs[pair1Key].Token2PoolValue, uint64(60000000000000))
+	suite.Equal(suite.currentPDEState.PDEPoolPairs[pair2Key].Token1PoolValue, uint64(40000000000000))
+	suite.Equal(suite.currentPDEState.PDEPoolPairs[pair2Key].Token2PoolValue, uint64(2000000000000))
+}
+
+func buildPDELimitOrderReqAction(
+	tokenIDToBuyStr string,
+	tokenIDToSellStr string,
+	sellAmount uint64,
+	minAcceptableAmount uint64,
+	tradingFee uint64,
+	traderAddressStr string,
+	activeHeights uint64,
+	txReqID common.Hash,
+) []string {
+	metadataBase := metadata.MetadataBase{
+		Type: metadata.PDELimitOrderRequestMeta,
+	}
+	pdeLimitOrderRequest := metadata.PDELimitOrderRequest{
+		TokenIDToBuyStr:     tokenIDToBuyStr,
+		TokenIDToSellStr:    tokenIDToSellStr,
+		SellAmount:          sellAmount,
+		MinAcceptableAmount: minAcceptableAmount,
+		TradingFee:          tradingFee,
+		TraderAddressStr:    traderAddressStr,
+		ActiveHeights:       activeHeights,
+	}
+	pdeLimitOrderRequest.MetadataBase = metadataBase
+	actionContent := metadata.PDELimitOrderRequestAction{
+		Meta:    pdeLimitOrderRequest,
+		TxReqID: txReqID,
+		ShardID: 1,
+	}
+	actionContentBytes, _ := json.Marshal(actionContent)
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	return []string{strconv.Itoa(metadata.PDELimitOrderRequestMeta), actionContentBase64Str}
+}
+
+func buildPDECancelOrderReqAction(orderID string, traderAddressStr string) []string {
+	metadataBase := metadata.MetadataBase{
+		Type: metadata.PDECancelOrderRequestMeta,
+	}
+	pdeCancelOrderRequest := metadata.PDECancelOrderRequest{
+		OrderID:          orderID,
+		TraderAddressStr: traderAddressStr,
+	}
+	pdeCancelOrderRequest.MetadataBase = metadataBase
+	actionContent := metadata.PDECancelOrderRequestAction{
+		Meta:    pdeCancelOrderRequest,
+		TxReqID: common.HashH([]byte("cancel")),
+		ShardID: 1,
+	}
+	actionContentBytes, _ := json.Marshal(actionContent)
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	return []string{strconv.Itoa(metadata.PDECancelOrderRequestMeta), actionContentBase64Str}
+}
+
+func (suite *PDEProducerSuite) TestLimitOrderFilledWhenPoolPriceReached() {
+	fmt.Println("Running testcase: TestLimitOrderFilledWhenPoolPriceReached")
+	beaconHeight := uint64(1001)
+	pair1Key, _ := suite.setupCrossPoolTradePairs(beaconHeight)
+	orderID := common.HashH([]byte("order"))
+	reqAction := buildPDELimitOrderReqAction(
+		"0000000000000000000000000000000000000000000000000000000000000007",
+		"0000000000000000000000000000000000000000000000000000000000000005",
+		10000000000,
+		1200000000000,
+		1000000,
+		"12S2jM1TBbX2V5TBTvpJkJmsdaYxbCspGNedQkvJpYcbnV4gad7FDEbzY9P3zbpZRJTsGD5vxJRia3UiiUwMUbXbjfgezewq6rtPNtj",
+		10,
+		orderID,
+	)
+	metaType, _ := strconv.Atoi(reqAction[0])
+	shardID := byte(1)
+	bc := &BlockChain{}
+	newInsts, err := bc.buildInstructionsForPDELimitOrder(reqAction[1], shardID, metaType, suite.currentPDEState, beaconHeight-1)
+	suite.Equal(err, nil)
+	suite.Equal(len(newInsts), 1)
+	suite.Equal(newInsts[0][2], "open")
+	suite.Equal(len(suite.currentPDEState.PDELimitOrders), 1)
+	suite.Equal(suite.currentPDEState.PDELimitOrders[orderID.String()].ExpiryHeight, uint64(1011))
+
+	// the pool would only give 1176470588235 for the order
+	suite.Equal(len(bc.buildInstructionsForPDELimitOrderMatching(suite.currentPDEState, beaconHeight-1)), 0)
+	suite.Equal(len(suite.currentPDEState.PDELimitOrders), 1)
+
+	// the pool price moves up to the limit of the order
+	suite.currentPDEState.PDEPoolPairs[pair1Key].Token2PoolValue = 62000000000000
+	newInsts = bc.buildInstructionsForPDELimitOrderMatching(suite.currentPDEState, beaconHeight-1)
+	filledContent := metadata.PDETradeAcceptedContent{
+		TraderAddressStr:         "12S2jM1TBbX2V5TBTvpJkJmsdaYxbCspGNedQkvJpYcbnV4gad7FDEbzY9P3zbpZRJTsGD5vxJRia3UiiUwMUbXbjfgezewq6rtPNtj",
+		TokenIDToBuyStr:          "0000000000000000000000000000000000000000000000000000000000000007",
+		ReceiveAmount:            1215686274509,
+		Token1IDStr:              "0000000000000000000000000000000000000000000000000000000000000005",
+		Token2IDStr:              "0000000000000000000000000000000000000000000000000000000000000007",
+		Token1PoolValueOperation: metadata.TokenPoolValueOperation{Operator: "+", Value: 10001000000},
+		Token2PoolValueOperation: metadata.TokenPoolValueOperation{Operator: "-", Value: 1215686274509},
+		ShardID:                  shardID,
+		RequestedTxID:            orderID,
+	}
+	filledContentBytes, _ := json.Marshal(filledContent)
+	suite.Equal(len(newInsts), 1)
+	suite.Equal(newInsts[0][0], strconv.Itoa(metadata.PDELimitOrderRequestMeta))
+	suite.Equal(newInsts[0][1], strconv.Itoa(int(shardID)))
+	suite.Equal(newInsts[0][2], "filled")
+	suite.Equal(newInsts[0][3], string(filledContentBytes))
+	suite.Equal(len(suite.currentPDEState.PDELimitOrders), 0)
+	suite.NotNil(suite.currentPDEState.DeletedPDELimitOrders[orderID.String()])
+	suite.Equal(suite.currentPDEState.PDEPoolPairs[pair1Key].Token1PoolValue, uint64(500000000000+10001000000))
+	suite.Equal(suite.currentPDEState.PDEPoolPairs[pair1Key].Token2PoolValue, uint64(62000000000000-1215686274509))
+}
+
+func (suite *PDEProducerSuite) TestLimitOrderOnUnexistedPair() {
+	fmt.Println("Running testcase: TestLimitOrderOnUnexistedPair")
+	beaconHeight := uint64(1001)
+	suite.setupCrossPoolTradePairs(beaconHeight)
+	reqAction := buildPDELimitOrderReqAction(
+		"0000000000000000000000000000000000000000000000000000000000000006",
+		"0000000000000000000000000000000000000000000000000000000000000005",
+		10000000000,
+		1200000000000,
+		1000000,
+		"12S2jM1TBbX2V5TBTvpJkJmsdaYxbCspGNedQkvJpYcbnV4gad7FDEbzY9P3zbpZRJTsGD5vxJRia3UiiUwMUbXbjfgezewq6rtPNtj",
+		10,
+		common.HashH([]byte("order")),
+	)
+	metaType, _ := strconv.Atoi(reqAction[0])
+	bc := &BlockChain{}
+	newInsts, err := bc.buildInstructionsForPDELimitOrder(reqAction[1], byte(1), metaType, suite.currentPDEState, beaconHeight-1)
+	suite.Equal(err, nil)
+	suite.Equal(len(newInsts), 1)
+	suite.Equal(newInsts[0][2], "refund")
+	suite.Equal(newInsts[0][3], reqAction[1])
+	suite.Equal(len(suite.currentPDEState.PDELimitOrders), 0)
+}
+
+func (suite *PDEProducerSuite) TestLimitOrderExpired() {
+	fmt.Println("Running testcase: TestLimitOrderExpired")
+	beaconHeight := uint64(1001)
+	suite.setupCrossPoolTradePairs(beaconHeight)
+	orderID := common.HashH([]byte("order"))
+	reqAction := buildPDELimitOrderReqAction(
+		"0000000000000000000000000000000000000000000000000000000000000007",
+		"0000000000000000000000000000000000000000000000000000000000000005",
+		10000000000,
+		1200000000000,
+		1000000,
+		"12S2jM1TBbX2V5TBTvpJkJmsdaYxbCspGNedQkvJpYcbnV4gad7FDEbzY9P3zbpZRJTsGD5vxJRia3UiiUwMUbXbjfgezewq6rtPNtj",
+		2,
+		orderID,
+	)
+	metaType, _ := strconv.Atoi(reqAction[0])
+	bc := &BlockChain{}
+	_, err := bc.buildInstructionsForPDELimitOrder(reqAction[1], byte(1), metaType, suite.currentPDEState, beaconHeight-1)
+	suite.Equal(err, nil)
+
+	// still active at the next beacon height
+	suite.Equal(len(buildInstructionsForPDEExpiredOrders(suite.currentPDEState, beaconHeight)), 0)
+	suite.Equal(len(suite.currentPDEState.PDELimitOrders), 1)
+
+	newInsts := buildInstructionsForPDEExpiredOrders(suite.currentPDEState, beaconHeight+1)
+	refundContent := metadata.PDELimitOrderRefundContent{
+		OrderID:          orderID,
+		TraderAddressStr: "12S2jM1TBbX2V5TBTvpJkJmsdaYxbCspGNedQkvJpYcbnV4gad7FDEbzY9P3zbpZRJTsGD5vxJRia3UiiUwMUbXbjfgezewq6rtPNtj",
+		TokenIDStr:       "0000000000000000000000000000000000000000000000000000000000000005",
+		Amount:           10001000000,
+		ShardID:          1,
+	}
+	refundContentBytes, _ := json.Marshal(refundContent)
+	suite.Equal(len(newInsts), 1)
+	suite.Equal(newInsts[0][0], strconv.Itoa(metadata.PDELimitOrderRequestMeta))
+	suite.Equal(newInsts[0][2], "expired")
+	suite.Equal(newInsts[0][3], string(refundContentBytes))
+	suite.Equal(len(suite.currentPDEState.PDELimitOrders), 0)
+}
+
+func (suite *PDEProducerSuite) TestCancelLimitOrder() {
+	fmt.Println("Running testcase: TestCancelLimitOrder")
+	beaconHeight := uint64(1001)
+	suite.setupCrossPoolTradePairs(beaconHeight)
+	orderID := common.HashH([]byte("order"))
+	traderAddressStr := "12S2jM1TBbX2V5TBTvpJkJmsdaYxbCspGNedQkvJpYcbnV4gad7FDEbzY9P3zbpZRJTsGD5vxJRia3UiiUwMUbXbjfgezewq6rtPNtj"
+	reqAction := buildPDELimitOrderReqAction(
+		"0000000000000000000000000000000000000000000000000000000000000007",
+		"0000000000000000000000000000000000000000000000000000000000000005",
+		10000000000,
+		1200000000000,
+		1000000,
+		traderAddressStr,
+		10,
+		orderID,
+	)
+	metaType, _ := strconv.Atoi(reqAction[0])
+	bc := &BlockChain{}
+	_, err := bc.buildInstructionsForPDELimitOrder(reqAction[1], byte(1), metaType, suite.currentPDEState, beaconHeight-1)
+	suite.Equal(err, nil)
+
+	// only the trader of the order can cancel it
+	cancelAction := buildPDECancelOrderReqAction(orderID.String(), "12S5Lrs1XeQLbqN4ySyKtjAjd2d7sBP2tjFijzmp6avrrkQCNFMpkXm3FPzj2Wcu2ZNqJEmh9JriVuRErVwhuQnLmWSaggobEWsBEci")
+	newInsts, err := bc.buildInstructionsForPDECancelOrder(cancelAction[1], byte(1), metadata.PDECancelOrderRequestMeta, suite.currentPDEState, beaconHeight-1)
+	suite.Equal(err, nil)
+	suite.Equal(len(newInsts), 1)
+	suite.Equal(newInsts[0][2], "rejected")
+	suite.Equal(newInsts[0][3], cancelAction[1])
+	suite.Equal(len(suite.currentPDEState.PDELimitOrders), 1)
+
+	// the order is found whatever the case of its id in the request
+	cancelAction = buildPDECancelOrderReqAction(strings.ToUpper(orderID.String()), traderAddressStr)
+	newInsts, err = bc.buildInstructionsForPDECancelOrder(cancelAction[1], byte(1), metadata.PDECancelOrderRequestMeta, suite.currentPDEState, beaconHeight-1)
+	refundContent := metadata.PDELimitOrderRefundContent{
+		OrderID:          orderID,
+		TraderAddressStr: traderAddressStr,
+		TokenIDStr:       "0000000000000000000000000000000000000000000000000000000000000005",
+		Amount:           10001000000,
+		ShardID:          1,
+	}
+	refundContentBytes, _ := json.Marshal(refundContent)
+	suite.Equal(err, nil)
+	suite.Equal(len(newInsts), 1)
+	suite.Equal(newInsts[0][2], "accepted")
+	suite.Equal(newInsts[0][3], string(refundContentBytes))
+	suite.Equal(len(suite.currentPDEState.PDELimitOrders), 0)
+	suite.NotNil(suite.currentPDEState.DeletedPDELimitOrders[orderID.String()])
+}
+
+func (suite *PDEProducerSuite) TestLimitOrderOnFullPair() {
+	fmt.Println("Running testcase: TestLimitOrderOnFullPair")
+	beaconHeight := uint64(1001)
+	suite.setupCrossPoolTradePairs(beaconHeight)
+	suite.currentPDEState.PDELimitOrders = map[string]*rawdbv2.PDELimitOrder{}
+	for i := 0; i < metadata.MaxPDELimitOrdersPerPair; i++ {
+		orderID := common.HashH([]byte(strconv.Itoa(i)))
+		suite.currentPDEState.PDELimitOrders[orderID.String()] = &rawdbv2.PDELimitOrder{
+			OrderID:          orderID,
+			TokenIDToBuyStr:  "0000000000000000000000000000000000000000000000000000000000000005",
+			TokenIDToSellStr: "0000000000000000000000000000000000000000000000000000000000000007",
+		}
+	}
+	reqAction := buildPDELimitOrderReqAction(
+		"0000000000000000000000000000000000000000000000000000000000000007",
+		"0000000000000000000000000000000000000000000000000000000000000005",
+		10000000000,
+		1200000000000,
+		1000000,
+		"12S2jM1TBbX2V5TBTvpJkJmsdaYxbCspGNedQkvJpYcbnV4gad7FDEbzY9P3zbpZRJTsGD5vxJRia3UiiUwMUbXbjfgezewq6rtPNtj",
+		10,
+		common.HashH([]byte("order")),
+	)
+	metaType, _ := strconv.Atoi(reqAction[0])
+	bc := &BlockChain{}
+	newInsts, err := bc.buildInstructionsForPDELimitOrder(reqAction[1], byte(1), metaType, suite.currentPDEState, beaconHeight-1)
+	suite.Equal(err, nil)
+	suite.Equal(len(newInsts), 1)
+	suite.Equal(newInsts[0][2], "refund")
+	suite.Equal(len(suite.currentPDEState.PDELimitOrders), metadata.MaxPDELimitOrdersPerPair)
+}
+
 // In order for 'go test' to run this suite, we need to create
 // a normal test function and pass our suite to suite.Run
 func TestPDEProducerSuite(t *testing.T) {
 	fmt.Println("Initialized...")
 	suite.Run(t, new(PDEProducerSuite))
 }
+
+func (suite *PDEProducerSuite) TestBuyToken1WithPoolFeeOnExistedPair() {
+	fmt.Println("Running testcase: TestBuyToken1WithPoolFeeOnExistedPair")
+	beaconHeight := uint64(1001)
+	pair := rawdbv2.PDEPoolForPair{
+		Token1IDStr:     "0000000000000000000000000000000000000000000000000000000000000005",
+		Token1PoolValue: 500000000000,
+		Token2IDStr:     "0000000000000000000000000000000000000000000000000000000000000007",
+		Token2PoolValue: 60000000000000,
+	}
+	pairKey := string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight-1, pair.Token1IDStr, pair.Token2IDStr))
+	suite.currentPDEState.PDEPoolPairs = map[string]*rawdbv2.PDEPoolForPair{
+		pairKey: &pair,
+	}
+
+	reqAction := buildPDETradeReqAction(
+		"0000000000000000000000000000000000000000000000000000000000000005",
+		"0000000000000000000000000000000000000000000000000000000000000007",
+		10000000000,
+		"12S2jM1TBbX2V5TBTvpJkJmsdaYxbCspGNedQkvJpYcbnV4gad7FDEbzY9P3zbpZRJTsGD5vxJRia3UiiUwMUbXbjfgezewq6rtPNtj",
+	)
+	metaType, _ := strconv.Atoi(reqAction[0])
+	contentStr := reqAction[1]
+	shardID := byte(1)
+	bc := &BlockChain{
+		config: Config{
+			ChainParams: &Params{
+				PDEParams: map[uint64]PDEParams{
+					1000: {PoolFeeRate: 30},
+				},
+			},
+		},
+	}
+	newInsts, err := bc.buildInstructionsForPDETrade(contentStr, shardID, metaType, suite.currentPDEState, beaconHeight-1)
+	suite.Equal(err, nil)
+	suite.Equal(len(newInsts), 1)
+	suite.Equal(newInsts[0][2], "accepted")
+
+	var pdeTradeAcceptedContent metadata.PDETradeAcceptedContent
+	err = json.Unmarshal([]byte(newInsts[0][3]), &pdeTradeAcceptedContent)
+	suite.Equal(err, nil)
+	// 0.3% of the sell amount stays in the pool, the rest is swapped
+	suite.Equal(pdeTradeAcceptedContent.PoolFee, uint64(30000000))
+	suite.Equal(pdeTradeAcceptedContent.ReceiveAmount, uint64(83069529))
+	suite.Equal(pdeTradeAcceptedContent.Token2PoolValueOperation.Value, uint64(10000000000))
+
+	remainingTk1PoolVal := suite.currentPDEState.PDEPoolPairs[pairKey].Token1PoolValue
+	remainingTk2PoolVal := suite.currentPDEState.PDEPoolPairs[pairKey].Token2PoolValue
+	suite.Equal(remainingTk1PoolVal, uint64(500000000000-83069529))
+	suite.Equal(remainingTk2PoolVal, uint64(60000000000000+10000000000))
+}
@@ -94,7 +94,7 @@ func (blockchain *BlockChain) processPortalInstructions(portalStateDB *statedb.S
 	}
 
 	//save final exchangeRates
-	blockchain.pickExchangesRatesFinal(currentPortalState)
+	blockchain.pickExchangesRatesFinal(currentPortalState, beaconHeight, portalParams)
 
 	// update info of bridge portal token
 	for _, updatingInfo := range updatingInfoByTokenID {
@@ -578,6 +578,19 @@ func (blockchain *BlockChain) processPortalExchangeRates(
 
 		currentPortalState.ExchangeRatesRequests[portingExchangeRatesContent.TxReqID.String()] = newExchangeRates
 
+		// submission history of feeders is only kept once rates are aggregated over a window
+		if portalParams.ExchangeRatesAggregationWindow > 0 {
+			feeder := currentPortalState.getExchangeRatesFeeder(portingExchangeRatesContent.SenderAddress)
+			for _, rate := range portingExchangeRatesContent.Rates {
+				feeder.LastRates()[rate.PTokenID] = &statedb.FeederExchangeRate{
+					Rate:         rate.Rate,
+					BeaconHeight: beaconHeight,
+				}
+			}
+			feeder.SetLastSubmittedBeaconHeight(beaconHeight)
+			feeder.SetAcceptedSubmissions(feeder.AcceptedSubmissions() + 1)
+		}
+
 		Logger.log.Infof("Portal exchange rates, exchange rates request: total exchange rate request %v", len(currentPortalState.ExchangeRatesRequests))
 
 	case common.PortalExchangeRatesRejectedChainStatus:
@@ -601,105 +614,76 @@ func (blockchain *BlockChain) processPortalExchangeRates(
 			Logger.log.Errorf("ERROR: Save exchange rates error: %+v", err)
 			return nil
 		}
+
+		if portalParams.ExchangeRatesAggregationWindow > 0 {
+			feeder := currentPortalState.getExchangeRatesFeeder(portingExchangeRatesContent.SenderAddress)
+			feeder.SetLastSubmittedBeaconHeight(beaconHeight)
+			feeder.SetRejectedSubmissions(feeder.RejectedSubmissions() + 1)
+		}
 	}
 
 	return nil
 }
 
-func (blockchain *BlockChain) pickExchangesRatesFinal(currentPortalState *CurrentPortalState) {
+// pickExchangesRatesFinal finalizes exchange rates at the end of each aggregation window,
+// the final rate of a token is the median of the last rates submitted by feeders during the window.
+// Before the window is activated, the final rate is the median of the rates submitted in the beacon block
+func (blockchain *BlockChain) pickExchangesRatesFinal(currentPortalState *CurrentPortalState, beaconHeight uint64, portalParams PortalParams) {
 	//convert to slice
-	var btcExchangeRatesSlice []uint64
-	var bnbExchangeRatesSlice []uint64
-	var prvExchangeRatesSlice []uint64
-	for _, v := range currentPortalState.ExchangeRatesRequests {
-		for _, rate := range v.Rates {
-			switch rate.PTokenID {
-			case common.PortalBTCIDStr:
-				btcExchangeRatesSlice = append(btcExchangeRatesSlice, rate.Rate)
-				break
-			case common.PortalBNBIDStr:
-				bnbExchangeRatesSlice = append(bnbExchangeRatesSlice, rate.Rate)
-				break
-			case common.PRVIDStr:
-				prvExchangeRatesSlice = append(prvExchangeRatesSlice, rate.Rate)
-				break
-			}
+	exchangeRatesSlices := make(map[string][]uint64)
+	appendRate := func(tokenID string, rate uint64) {
+		if IsPortalExchangeRateToken(tokenID) {
+			exchangeRatesSlices[tokenID] = append(exchangeRatesSlices[tokenID], rate)
 		}
 	}
 
-	//sort
-	sort.SliceStable(btcExchangeRatesSlice, func(i, j int) bool {
-		return btcExchangeRatesSlice[i] < btcExchangeRatesSlice[j]
-	})
-
-	sort.SliceStable(bnbExchangeRatesSlice, func(i, j int) bool {
-		return bnbExchangeRatesSlice[i] < bnbExchangeRatesSlice[j]
-	})
-
-	sort.SliceStable(prvExchangeRatesSlice, func(i, j int) bool {
-		return prvExchangeRatesSlice[i] < prvExchangeRatesSlice[j]
-	})
-
-	exchangeRatesList := make(map[string]statedb.FinalExchangeRatesDetail)
-
-	var btcAmount uint64
-	var bnbAmount uint64
-	var prvAmount uint64
-
-	//get current value
-	if len(btcExchangeRatesSlice) > 0 {
-		btcAmount = calcMedian(btcExchangeRatesSlice)
-	}
-
-	if len(bnbExchangeRatesSlice) > 0 {
-		bnbAmount = calcMedian(bnbExchangeRatesSlice)
-
-	}
-
-	if len(prvExchangeRatesSlice) > 0 {
-		prvAmount = calcMedian(prvExchangeRatesSlice)
-	}
-
-	//todo: need refactor code, not need write this code
-	//update value when has exchange
-	if exchangeRatesState := currentPortalState.FinalExchangeRatesState; exchangeRatesState != nil {
-		var btcAmountPreState uint64
-		var bnbAmountPreState uint64
-		var prvAmountPreState uint64
-		if value, ok := exchangeRatesState.Rates()[common.PortalBTCIDStr]; ok {
-			btcAmountPreState = value.Amount
-		}
-
-		if value, ok := exchangeRatesState.Rates()[common.PortalBNBIDStr]; ok {
-			bnbAmountPreState = value.Amount
+	if portalParams.ExchangeRatesAggregationWindow == 0 {
+		for _, v := range currentPortalState.ExchangeRatesRequests {
+			for _, rate := range v.Rates {
+				appendRate(rate.PTokenID, rate.Rate)
+			}
 		}
-
-		if value, ok := exchangeRatesState.Rates()[common.PRVIDStr]; ok {
-			prvAmountPreState = value.Amount
+	} else {
+		if !isEndOfExchangeRatesWindow(beaconHeight, portalParams.ExchangeRatesAggregationWindow) {
+			return
+		}
+		for _, feeder := range currentPortalState.ExchangeRatesFeeders {
+			for tokenID, rate := range feeder.LastRates() {
+				if IsInExchangeRatesWindow(rate.BeaconHeight, beaconHeight, portalParams.ExchangeRatesAggregationWindow) {
+					appendRate(tokenID, rate.Rate)
+				}
+			}
 		}
-
-		//pick current value and pre value state
-		btcAmount = choicePrice(btcAmount, btcAmountPreState)
-		bnbAmount = choicePrice(bnbAmount, bnbAmountPreState)
-		prvAmount = choicePrice(prvAmount, prvAmountPreState)
 	}
 
-	//select
-	if btcAmount > 0 {
-		exchangeRatesList[common.PortalBTCIDStr] = statedb.FinalExchangeRatesDetail{
-			Amount: btcAmount,
+	exchangeRatesList := make(map[string]statedb.FinalExchangeRatesDetail)
+	for _, tokenID := range append(GetPortalTokenIDs(), common.PRVIDStr) {
+		exchangeRatesSlice := exchangeRatesSlices[tokenID]
+		//sort
+		sort.SliceStable(exchangeRatesSlice, func(i, j int) bool {
+			return exchangeRatesSlice[i] < exchangeRatesSlice[j]
+		})
+
+		//get current value
+		var amount uint64
+		if len(exchangeRatesSlice) > 0 {
+			amount = calcMedian(exchangeRatesSlice)
 		}
-	}
 
-	if bnbAmount > 0 {
-		exchangeRatesList[common.PortalBNBIDStr] = statedb.FinalExchangeRatesDetail{
-			Amount: bnbAmount,
+		//pick current value and pre value state
+		if exchangeRatesState := currentPortalState.FinalExchangeRatesState; exchangeRatesState != nil {
+			var amountPreState uint64
+			if value, ok := exchangeRatesState.Rates()[tokenID]; ok {
+				amountPreState = value.Amount
+			}
+			amount = choicePrice(amount, amountPreState)
 		}
-	}
 
-	if prvAmount > 0 {
-		exchangeRatesList[common.PRVIDStr] = statedb.FinalExchangeRatesDetail{
-			Amount: prvAmount,
+		//select
+		if amount > 0 {
+			exchangeRatesList[tokenID] = statedb.FinalExchangeRatesDetail{
+				Amount: amount,
+			}
 		}
 	}
 
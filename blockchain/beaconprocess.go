@@ -17,6 +17,7 @@ import (
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/incdb"
 	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/pubsub"
 	"github.com/pkg/errors"
 )
@@ -509,6 +510,7 @@ func (blockchain *BlockChain) verifyPreProcessingBeaconBlockForSigning(curView *
 //  - Validate Agg Signature
 //  - Beacon Best State has best block is previous block of new beacon block
 //  - Beacon Best State has height compatible with new beacon block
+//  - Block version is compatible with new beacon block height, state roots of version 3 are the ones of Beacon Best State
 //  - Beacon Best State has epoch compatible with new beacon block
 //  - Beacon Best State has best shard height compatible with shard state of new beacon block
 //  - New Stake public key must not found in beacon best state (candidate, pending validator, committee)
@@ -526,6 +528,14 @@ func (beaconBestState *BeaconBestState) verifyBestStateWithBeaconBlock(blockchai
 	if beaconBestState.BeaconHeight+1 != beaconBlock.Header.Height {
 		return NewBlockChainError(WrongBlockHeightError, errors.New("block height of new block should be :"+strconv.Itoa(int(beaconBlock.Header.Height+1))))
 	}
+	if err := blockchain.verifyBlockVersion(beaconBlock.Header.Version, beaconBlock.Header.Height); err != nil {
+		return err
+	}
+	if beaconBlock.Header.Version >= STATE_ROOT_BLOCK_VERSION {
+		if err := beaconBestState.verifyHeaderStateRoots(&beaconBlock.Header); err != nil {
+			return err
+		}
+	}
 	if beaconBlock.Header.Height%chainParamEpoch == 1 && beaconBestState.Epoch+1 != beaconBlock.Header.Epoch {
 		return NewBlockChainError(WrongEpochError, fmt.Errorf("Expect beacon block height %+v has epoch %+v but get %+v", beaconBlock.Header.Height, beaconBestState.Epoch+1, beaconBlock.Header.Epoch))
 	}
@@ -953,6 +963,10 @@ func (beaconBestState *BeaconBestState) processInstruction(instruction []string,
 			}
 		}
 	}
+	if instruction[0] == strconv.Itoa(metadata.EquivocationEvidenceMeta) {
+		beaconBestState.processEquivocationEvidenceInstruction(blockchain, instruction, committeeChange)
+		return nil, false, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}
+	}
 	if instruction[0] == SwapAction {
 		if common.IndexOfUint64(beaconBestState.BeaconHeight/blockchain.config.ChainParams.Epoch, blockchain.config.ChainParams.EpochBreakPointSwapNewKey) > -1 || len(instruction) == 7 {
 			err := beaconBestState.processSwapInstructionForKeyListV2(instruction, committeeChange)
@@ -1398,7 +1412,11 @@ func (blockchain *BlockChain) processStoreBeaconBlock(
 		return NewBlockChainError(ProcessBridgeInstructionError, err)
 	}
 	// execute, store PDE instruction
-	err = blockchain.processPDEInstructions(newBestState.featureStateDB, beaconBlock)
+	var pdeHistory *pdeHistoryRecorder
+	if blockchain.config.PDEHistory {
+		pdeHistory = newPDEHistoryRecorder(blockHeight)
+	}
+	err = blockchain.processPDEInstructions(newBestState.featureStateDB, beaconBlock, pdeHistory)
 	if err != nil {
 		return NewBlockChainError(ProcessPDEInstructionError, err)
 	}
@@ -1412,7 +1430,7 @@ func (blockchain *BlockChain) processStoreBeaconBlock(
 	//}
 
 	// execute, store Ralaying Instruction
-	err = blockchain.processRelayingInstructions(beaconBlock)
+	err = blockchain.processRelayingInstructions(newBestState.featureStateDB, beaconBlock)
 	if err != nil {
 		return NewBlockChainError(ProcessPortalRelayingError, err)
 	}
@@ -1478,6 +1496,11 @@ func (blockchain *BlockChain) processStoreBeaconBlock(
 	if err := rawdbv2.StoreBeaconBlock(batch, blockHeight, blockHash, beaconBlock); err != nil {
 		return NewBlockChainError(StoreBeaconBlockError, err)
 	}
+	if pdeHistory != nil && len(pdeHistory.events) > 0 {
+		if err := rawdbv2.StorePDEHistory(batch, blockHeight, blockHash, pdeHistory.events); err != nil {
+			return NewBlockChainError(PDEHistoryError, err)
+		}
+	}
 
 	//fmt.Printf("debug AddView %s %+v\n", newBestState.Hash().String(), newBestState.BestBlock)
 	finalView := blockchain.BeaconChain.GetFinalView()
@@ -1494,6 +1517,11 @@ func (blockchain *BlockChain) processStoreBeaconBlock(
 						//must not have error
 						panic(err)
 					}
+					if blockchain.config.PDEHistory {
+						if err := rawdbv2.DeletePDEHistory(blockchain.GetBeaconChainDatabase(), startView.GetHeight(), hash); err != nil {
+							panic(err)
+						}
+					}
 				}
 			}
 			startView = blockchain.BeaconChain.GetViewByHash(*startView.GetPreviousHash())
@@ -65,8 +65,11 @@ func (blockchain *BlockChain) NewBlockBeacon(curView *BeaconBestState, version i
 		return nil, err
 	}
 	//======Build Header Essential Data=======
-	beaconBlock.Header.Version = version
+	beaconBlock.Header.Version = blockchain.getBlockVersion(version, beaconBestState.BeaconHeight+1)
 	beaconBlock.Header.Height = beaconBestState.BeaconHeight + 1
+	if beaconBlock.Header.Version >= STATE_ROOT_BLOCK_VERSION {
+		curView.setHeaderStateRoots(&beaconBlock.Header)
+	}
 	if (beaconBestState.BeaconHeight+1)%blockchain.config.ChainParams.Epoch == 1 {
 		epoch = beaconBestState.Epoch + 1
 	} else {
@@ -400,6 +403,7 @@ func (blockchain *BlockChain) GetShardStateFromBlock(curView *BeaconBestState, n
 	swapInstructions := make(map[byte][][]string)
 	stopAutoStakingInstructions := [][]string{}
 	stopAutoStakingInstructionsFromBlock := [][]string{}
+	equivocationEvidenceActionsFromBlock := [][]string{}
 	stakeInstructionFromShardBlock := [][]string{}
 	swapInstructionFromShardBlock := [][]string{}
 	bridgeInstructions := [][]string{}
@@ -453,6 +457,12 @@ func (blockchain *BlockChain) GetShardStateFromBlock(curView *BeaconBestState, n
 				}
 				stopAutoStakingInstructionsFromBlock = append(stopAutoStakingInstructionsFromBlock, instruction)
 			}
+			if instruction[0] == strconv.Itoa(metadata.EquivocationEvidenceMeta) {
+				if len(instruction) != 2 {
+					continue
+				}
+				equivocationEvidenceActionsFromBlock = append(equivocationEvidenceActionsFromBlock, instruction)
+			}
 		}
 	}
 	if len(stakeInstructionFromShardBlock) != 0 {
@@ -556,6 +566,11 @@ func (blockchain *BlockChain) GetShardStateFromBlock(curView *BeaconBestState, n
 		BLogger.log.Infof("Beacon block %d found bridge swap confirm inst in shard block %d: %s", newBeaconHeight, shardBlock.Header.Height, confirmInsts)
 	}
 	bridgeInstructions = append(bridgeInstructions, bridgeInstructionForBlock...)
+	// Verify equivocation evidences reported in shard block
+	for _, action := range equivocationEvidenceActionsFromBlock {
+		equivocationEvidenceInsts := blockchain.buildInstructionsForEquivocationEvidence(curView, action[1], shardID, metadata.EquivocationEvidenceMeta)
+		bridgeInstructions = append(bridgeInstructions, equivocationEvidenceInsts...)
+	}
 
 	// Collect stateful actions
 	statefulActions := blockchain.collectStatefulActions(shardBlock.Instructions)
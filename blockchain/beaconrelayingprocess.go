@@ -7,13 +7,15 @@ import (
 	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btcutil"
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/metadata"
 	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
+	ethrelaying "github.com/incognitochain/incognito-chain/relaying/eth"
 	"github.com/tendermint/tendermint/types"
 	"strconv"
 )
 
-func (blockchain *BlockChain) processRelayingInstructions(block *BeaconBlock) error {
+func (blockchain *BlockChain) processRelayingInstructions(relayingStateDB *statedb.StateDB, block *BeaconBlock) error {
 	relayingState, err := blockchain.InitRelayingHeaderChainStateFromDB()
 	if err != nil {
 		Logger.log.Error(err)
@@ -31,6 +33,8 @@ func (blockchain *BlockChain) processRelayingInstructions(block *BeaconBlock) er
 			err = blockchain.processRelayingBNBHeaderInst(inst, relayingState)
 		case strconv.Itoa(metadata.RelayingBTCHeaderMeta):
 			err = blockchain.processRelayingBTCHeaderInst(inst, relayingState)
+		case strconv.Itoa(metadata.RelayingETHHeaderMeta):
+			err = blockchain.processRelayingETHHeaderInst(relayingStateDB, inst)
 		}
 		if err != nil {
 			Logger.log.Error(err)
@@ -84,6 +88,39 @@ func (blockchain *BlockChain) processRelayingBTCHeaderInst(
 	return nil
 }
 
+func (blockchain *BlockChain) processRelayingETHHeaderInst(
+	relayingStateDB *statedb.StateDB,
+	instruction []string,
+) error {
+	if len(instruction) != 4 {
+		return nil // skip the instruction
+	}
+	if instruction[2] != common.RelayingHeaderConsideringChainStatus {
+		return nil
+	}
+
+	var relayingHeaderContent metadata.RelayingHeaderContent
+	err := json.Unmarshal([]byte(instruction[3]), &relayingHeaderContent)
+	if err != nil {
+		return err
+	}
+	headers, err := ethrelaying.ParseHeadersFromB64EncodeStr(relayingHeaderContent.Header)
+	if err != nil {
+		return err
+	}
+	ethHeaderChain, err := blockchain.GetETHHeaderChain(relayingStateDB)
+	if err != nil {
+		return err
+	}
+	err = ethHeaderChain.InsertHeaders(headers)
+	if err != nil {
+		Logger.log.Errorf("[ETH Relaying] - InsertHeaders from %v fail with error: %v", relayingHeaderContent.BlockHeight, err)
+		return err
+	}
+	Logger.log.Infof("[ETH Relaying] - InsertHeaders from %v to %v success", headers[0].Number, headers[len(headers)-1].Number)
+	return nil
+}
+
 func (blockchain *BlockChain) processRelayingBNBHeaderInst(
 	instructions []string,
 	relayingState *RelayingHeaderChainState,
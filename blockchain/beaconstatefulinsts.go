@@ -37,6 +37,9 @@ func (blockchain *BlockChain) collectStatefulActions(
 			metadata.IssuingETHRequestMeta,
 			metadata.PDEContributionMeta,
 			metadata.PDETradeRequestMeta,
+			metadata.PDECrossPoolTradeRequestMeta,
+			metadata.PDELimitOrderRequestMeta,
+			metadata.PDECancelOrderRequestMeta,
 			metadata.PDEWithdrawalRequestMeta,
 			metadata.PortalCustodianDepositMeta,
 			metadata.PortalUserRegisterMeta,
@@ -44,6 +47,7 @@ func (blockchain *BlockChain) collectStatefulActions(
 			metadata.PortalExchangeRatesMeta,
 			metadata.RelayingBNBHeaderMeta,
 			metadata.RelayingBTCHeaderMeta,
+			metadata.RelayingETHHeaderMeta,
 			metadata.PortalCustodianWithdrawRequestMeta,
 			metadata.PortalRedeemRequestMeta,
 			metadata.PortalRequestUnlockCollateralMeta,
@@ -109,6 +113,9 @@ func (blockchain *BlockChain) buildStatefulInstructions(
 	// pde instructions
 	pdeContributionActionsByShardID := map[byte][][]string{}
 	pdeTradeActionsByShardID := map[byte][][]string{}
+	pdeCrossPoolTradeActionsByShardID := map[byte][][]string{}
+	pdeLimitOrderActionsByShardID := map[byte][][]string{}
+	pdeCancelOrderActionsByShardID := map[byte][][]string{}
 	pdeWithdrawalActionsByShardID := map[byte][][]string{}
 
 	// portal instructions
@@ -159,6 +166,24 @@ func (blockchain *BlockChain) buildStatefulInstructions(
 					action,
 					shardID,
 				)
+			case metadata.PDECrossPoolTradeRequestMeta:
+				pdeCrossPoolTradeActionsByShardID = groupPDEActionsByShardID(
+					pdeCrossPoolTradeActionsByShardID,
+					action,
+					shardID,
+				)
+			case metadata.PDELimitOrderRequestMeta:
+				pdeLimitOrderActionsByShardID = groupPDEActionsByShardID(
+					pdeLimitOrderActionsByShardID,
+					action,
+					shardID,
+				)
+			case metadata.PDECancelOrderRequestMeta:
+				pdeCancelOrderActionsByShardID = groupPDEActionsByShardID(
+					pdeCancelOrderActionsByShardID,
+					action,
+					shardID,
+				)
 			case metadata.PDEWithdrawalRequestMeta:
 				pdeWithdrawalActionsByShardID = groupPDEActionsByShardID(
 					pdeWithdrawalActionsByShardID,
@@ -245,6 +270,8 @@ func (blockchain *BlockChain) buildStatefulInstructions(
 				pm.relayingChains[metadata.RelayingBNBHeaderMeta].putAction(action)
 			case metadata.RelayingBTCHeaderMeta:
 				pm.relayingChains[metadata.RelayingBTCHeaderMeta].putAction(action)
+			case metadata.RelayingETHHeaderMeta:
+				pm.relayingChains[metadata.RelayingETHHeaderMeta].putAction(action)
 			default:
 				continue
 			}
@@ -262,6 +289,9 @@ func (blockchain *BlockChain) buildStatefulInstructions(
 		beaconHeight-1, currentPDEState,
 		pdeContributionActionsByShardID,
 		pdeTradeActionsByShardID,
+		pdeCrossPoolTradeActionsByShardID,
+		pdeLimitOrderActionsByShardID,
+		pdeCancelOrderActionsByShardID,
 		pdeWithdrawalActionsByShardID,
 	)
 
@@ -390,11 +420,61 @@ func sortPDETradeInstsByFee(
 	return append(sortedExistingPairTradeActions, notExistingPairTradeActions...)
 }
 
+func sortPDECrossPoolTradeInstsByFee(
+	pdeCrossPoolTradeActionsByShardID map[byte][][]string,
+) []metadata.PDECrossPoolTradeRequestAction {
+	tradeActions := []metadata.PDECrossPoolTradeRequestAction{}
+	var keys []int
+	for k := range pdeCrossPoolTradeActionsByShardID {
+		keys = append(keys, int(k))
+	}
+	sort.Ints(keys)
+	for _, value := range keys {
+		shardID := byte(value)
+		actions := pdeCrossPoolTradeActionsByShardID[shardID]
+		for _, action := range actions {
+			contentStr := action[1]
+			contentBytes, err := base64.StdEncoding.DecodeString(contentStr)
+			if err != nil {
+				Logger.log.Errorf("ERROR: an error occured while decoding content string of pde cross pool trade action: %+v", err)
+				continue
+			}
+			var pdeTradeReqAction metadata.PDECrossPoolTradeRequestAction
+			err = json.Unmarshal(contentBytes, &pdeTradeReqAction)
+			if err != nil {
+				Logger.log.Errorf("ERROR: an error occured while unmarshaling pde cross pool trade action: %+v", err)
+				continue
+			}
+			tradeActions = append(tradeActions, pdeTradeReqAction)
+		}
+	}
+
+	// routes can share pool pairs so cross pool trades are sorted all together by trading fee
+	sort.SliceStable(tradeActions, func(i, j int) bool {
+		// comparing a/b to c/d is equivalent with comparing a*d to c*b
+		firstItemProportion := big.NewInt(0)
+		firstItemProportion.Mul(
+			big.NewInt(int64(tradeActions[i].Meta.TradingFee)),
+			big.NewInt(int64(tradeActions[j].Meta.SellAmount)),
+		)
+		secondItemProportion := big.NewInt(0)
+		secondItemProportion.Mul(
+			big.NewInt(int64(tradeActions[j].Meta.TradingFee)),
+			big.NewInt(int64(tradeActions[i].Meta.SellAmount)),
+		)
+		return firstItemProportion.Cmp(secondItemProportion) == 1
+	})
+	return tradeActions
+}
+
 func (blockchain *BlockChain) handlePDEInsts(
 	beaconHeight uint64,
 	currentPDEState *CurrentPDEState,
 	pdeContributionActionsByShardID map[byte][][]string,
 	pdeTradeActionsByShardID map[byte][][]string,
+	pdeCrossPoolTradeActionsByShardID map[byte][][]string,
+	pdeLimitOrderActionsByShardID map[byte][][]string,
+	pdeCancelOrderActionsByShardID map[byte][][]string,
 	pdeWithdrawalActionsByShardID map[byte][][]string,
 ) ([][]string, error) {
 	instructions := [][]string{}
@@ -416,6 +496,65 @@ func (blockchain *BlockChain) handlePDEInsts(
 		}
 	}
 
+	// handle cross pool trades, after single pool ones so that they go through the updated pool pairs
+	sortedCrossPoolTradesActions := sortPDECrossPoolTradeInstsByFee(pdeCrossPoolTradeActionsByShardID)
+	for _, tradeAction := range sortedCrossPoolTradesActions {
+		actionContentBytes, _ := json.Marshal(tradeAction)
+		actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+		newInst, err := blockchain.buildInstructionsForPDECrossPoolTrade(actionContentBase64Str, tradeAction.ShardID, metadata.PDECrossPoolTradeRequestMeta, currentPDEState, beaconHeight)
+		if err != nil {
+			Logger.log.Error(err)
+			continue
+		}
+		if len(newInst) > 0 {
+			instructions = append(instructions, newInst...)
+		}
+	}
+
+	// handle new limit orders then cancellations, before matching the order book with the updated pool pairs
+	var loKeys []int
+	for k := range pdeLimitOrderActionsByShardID {
+		loKeys = append(loKeys, int(k))
+	}
+	sort.Ints(loKeys)
+	for _, value := range loKeys {
+		shardID := byte(value)
+		actions := pdeLimitOrderActionsByShardID[shardID]
+		for _, action := range actions {
+			contentStr := action[1]
+			newInst, err := blockchain.buildInstructionsForPDELimitOrder(contentStr, shardID, metadata.PDELimitOrderRequestMeta, currentPDEState, beaconHeight)
+			if err != nil {
+				Logger.log.Error(err)
+				continue
+			}
+			if len(newInst) > 0 {
+				instructions = append(instructions, newInst...)
+			}
+		}
+	}
+	var coKeys []int
+	for k := range pdeCancelOrderActionsByShardID {
+		coKeys = append(coKeys, int(k))
+	}
+	sort.Ints(coKeys)
+	for _, value := range coKeys {
+		shardID := byte(value)
+		actions := pdeCancelOrderActionsByShardID[shardID]
+		for _, action := range actions {
+			contentStr := action[1]
+			newInst, err := blockchain.buildInstructionsForPDECancelOrder(contentStr, shardID, metadata.PDECancelOrderRequestMeta, currentPDEState, beaconHeight)
+			if err != nil {
+				Logger.log.Error(err)
+				continue
+			}
+			if len(newInst) > 0 {
+				instructions = append(instructions, newInst...)
+			}
+		}
+	}
+	instructions = append(instructions, blockchain.buildInstructionsForPDELimitOrderMatching(currentPDEState, beaconHeight)...)
+	instructions = append(instructions, buildInstructionsForPDEExpiredOrders(currentPDEState, beaconHeight)...)
+
 	// handle withdrawal
 	var wrKeys []int
 	for k := range pdeWithdrawalActionsByShardID {
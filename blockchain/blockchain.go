@@ -6,7 +6,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"sort"
 
 	"github.com/incognitochain/incognito-chain/multiview"
 
@@ -18,10 +17,12 @@ import (
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/memcache"
 	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/portal"
 	"github.com/incognitochain/incognito-chain/privacy"
 	"github.com/incognitochain/incognito-chain/pubsub"
 	bnbrelaying "github.com/incognitochain/incognito-chain/relaying/bnb"
 	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
+	ethrelaying "github.com/incognitochain/incognito-chain/relaying/eth"
 	"github.com/incognitochain/incognito-chain/transaction"
 
 	"github.com/pkg/errors"
@@ -33,6 +34,10 @@ type BlockChain struct {
 	config      Config
 	cQuitSync   chan struct{}
 
+	outCoinIndexer *outCoinIndexer // nil if the output coin index is not enabled, see outcoinindex.go
+
+	portalExternalChains map[string]portal.ExternalChain // keyed by the token id of their ptoken, see portalutils.go
+
 	IsTest bool
 }
 
@@ -40,6 +45,7 @@ type BlockChain struct {
 type Config struct {
 	BTCChain          *btcrelaying.BlockChain
 	BNBChainState     *bnbrelaying.BNBChainState
+	ETHSealVerifier   ethrelaying.SealVerifier // checks proof of work of relayed eth headers
 	DataBase          map[int]incdb.Database
 	MemCache          *memcache.MemoryCache
 	Interrupt         <-chan struct{}
@@ -59,6 +65,10 @@ type Config struct {
 	Server            Server
 	ConsensusEngine   ConsensusEngine
 	Highway           Highway
+	StateDBMode       string // common.StateDBModeArchive or common.StateDBModePrune
+	PruneKeepViews    uint64
+	PDEHistory        bool // index pde instructions of beacon blocks, see pdehistory.go
+	OutCoinIndex      bool // index output coins of registered viewing keys, see outcoinindex.go
 }
 
 func NewBlockChain(config *Config, isTest bool) *BlockChain {
@@ -87,12 +97,18 @@ func (blockchain *BlockChain) Init(config *Config) error {
 	blockchain.config = *config
 	blockchain.config.IsBlockGenStarted = false
 	blockchain.IsTest = false
+	blockchain.portalExternalChains = blockchain.newPortalExternalChains()
 	// Initialize the chain state from the passed database.  When the db
 	// does not yet contain any chain state, both it and the chain state
 	// will be initialized to contain only the genesis block.
 	if err := blockchain.initChainState(); err != nil {
 		return err
 	}
+	if blockchain.config.OutCoinIndex {
+		if err := blockchain.initOutCoinIndexer(); err != nil {
+			return err
+		}
+	}
 	blockchain.cQuitSync = make(chan struct{})
 	return nil
 }
@@ -197,6 +213,7 @@ func (blockchain *BlockChain) initBeaconState() error {
 	if err != nil {
 		return err
 	}
+	initBeaconBestState.ConsensusStateDBRootHash = consensusRootHash
 	initBeaconBestState.consensusStateDB.ClearObjects()
 	if err := rawdbv2.StoreBeaconBlock(blockchain.GetBeaconChainDatabase(), initBlockHeight, initBlockHash, &initBeaconBestState.BestBlock); err != nil {
 		Logger.log.Error("Error store beacon block", initBeaconBestState.BestBlockHash, "in beacon chain")
@@ -498,32 +515,29 @@ func (blockchain *BlockChain) GetConfig() *Config {
 	return &blockchain.config
 }
 
-// GetPortalParams returns portal params in beaconheight
+// GetPortalParams returns the portal params activated at beaconHeight, see ScheduledParams
 func (blockchain *BlockChain) GetPortalParams(beaconHeight uint64) PortalParams {
 	portalParamMap := blockchain.GetConfig().ChainParams.PortalParams
-	// only has one value - default value
-	if len(portalParamMap) == 1 {
-		return portalParamMap[0]
-	}
-
 	bchs := []uint64{}
 	for bch := range portalParamMap {
 		bchs = append(bchs, bch)
 	}
-	sort.Slice(bchs, func(i, j int) bool {
-		return bchs[i] < bchs[j]
-	})
+	bchKey, _ := getActiveParamsHeight(bchs, beaconHeight)
+	return portalParamMap[bchKey]
+}
 
-	bchKey := bchs[len(bchs)-1]
-	for i := len(bchs) - 1; i >= 0; i-- {
-		if beaconHeight < bchs[i] {
-			continue
-		}
-		bchKey = bchs[i]
-		break
+// GetPDEParams returns the pde params activated at beaconHeight, false if pde params are not activated yet
+func (blockchain *BlockChain) GetPDEParams(beaconHeight uint64) (PDEParams, bool) {
+	pdeParamMap := blockchain.GetConfig().ChainParams.PDEParams
+	bchs := []uint64{}
+	for bch := range pdeParamMap {
+		bchs = append(bchs, bch)
 	}
-
-	return portalParamMap[bchKey]
+	bchKey, ok := getActiveParamsHeight(bchs, beaconHeight)
+	if !ok {
+		return PDEParams{}, false
+	}
+	return pdeParamMap[bchKey], true
 }
 
 func (blockchain *BlockChain) GetBeaconChainDatabase() incdb.Database {
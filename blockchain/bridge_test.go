@@ -8,10 +8,16 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/common/base58"
 	"github.com/pkg/errors"
 )
 
+var _ = func() (_ struct{}) {
+	BLogger.Init(common.NewBackend(nil).Logger("test", true))
+	return
+}()
+
 func TestParseAndConcatPubkeys(t *testing.T) {
 	testCases := []struct {
 		desc string
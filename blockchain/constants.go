@@ -3,6 +3,7 @@ package blockchain
 import (
 	"encoding/json"
 	"io/ioutil"
+	"math"
 	"os"
 	"strings"
 	"time"
@@ -14,6 +15,7 @@ const (
 	VERSION                       = 1
 	RANDOM_NUMBER                 = 3
 	SHARD_BLOCK_VERSION           = 1
+	STATE_ROOT_BLOCK_VERSION      = 3 // block of consensus v2 committing state roots of its previous block in header
 	DefaultMaxBlkReqPerPeer       = 900
 	DefaultMaxBlkReqPerTime       = 900
 	MinCommitteeSize              = 3                // min size to run bft
@@ -76,6 +78,7 @@ const (
 	// relaying header chain
 	MainnetBNBChainID = "Binance-Chain-Tigris"
 	MainnetBTCChainID = "Bitcoin-Mainnet"
+	MainnetETHChainID = "Ethereum-Mainnet"
 
 	// BNB fullnode
 	MainnetBNBFullNodeHost     = "dataseed1.ninicoin.io"
@@ -83,6 +86,12 @@ const (
 	MainnetBNBFullNodePort     = "443"
 
 	MainnetPortalFeeder = "12RwJVcDx4SM4PvjwwPrCRPZMMRT9g6QrnQUHD54EbtDb6AQbe26ciV6JXKyt4WRuFQVqLKqUUbb7VbWxR5V6KaG9HyFbKf6CrRxhSm"
+
+	// beacon heights activating consensus changes
+	MainnetBeaconHeightBreakPointStateRoot           = math.MaxUint64 // not activated yet
+	MainnetBeaconHeightBreakPointSlashStake          = 750000
+	MainnetBeaconHeightBreakPointExchangeRatesWindow = math.MaxUint64 // not activated yet
+	MainnetBeaconHeightBreakPointETHRelay            = math.MaxUint64 // not activated yet
 	// ------------- end Mainnet --------------------------------------
 )
 
@@ -137,12 +146,19 @@ const (
 	// relaying header chain
 	TestnetBNBChainID = "Binance-Chain-Nile"
 	TestnetBTCChainID = "Bitcoin-Testnet"
+	TestnetETHChainID = "Ethereum-Ropsten"
 
 	// BNB fullnode
 	TestnetBNBFullNodeHost     = "data-seed-pre-0-s1.binance.org"
 	TestnetBNBFullNodeProtocol = "https"
 	TestnetBNBFullNodePort     = "443"
 	TestnetPortalFeeder        = "12S2ciPBja9XCnEVEcsPvmCLeQH44vF8DMwSqgkH7wFETem5FiqiEpFfimETcNqDkARfht1Zpph9u5eQkjEnWsmZ5GB5vhc928EoNYH"
+
+	// beacon heights activating consensus changes
+	TestnetBeaconHeightBreakPointStateRoot           = math.MaxUint64 // not activated yet
+	TestnetBeaconHeightBreakPointSlashStake          = 1500000
+	TestnetBeaconHeightBreakPointExchangeRatesWindow = math.MaxUint64 // not activated yet
+	TestnetBeaconHeightBreakPointETHRelay            = math.MaxUint64 // not activated yet
 )
 
 // VARIABLE for testnet
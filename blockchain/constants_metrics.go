@@ -20,4 +20,7 @@ var (
 	beaconVerifyPostProcessingTimer         = metrics.NewRegisteredTimer("beacon/verify/postprocessing", nil)
 	beaconStoreBlockTimer                   = metrics.NewRegisteredTimer("beacon/storeblock", nil)
 	beaconUpdateBestStateTimer              = metrics.NewRegisteredTimer("beacon/updatebeststate", nil)
+
+	outCoinIndexHitMeter  = metrics.NewRegisteredMeter("outcoinindex/hit", nil)
+	outCoinIndexMissMeter = metrics.NewRegisteredMeter("outcoinindex/miss", nil)
 )
@@ -180,6 +180,13 @@ const (
 	InsertShardBlockError
 	GetShardBlockHeightByHashError
 	GetShardBlockByHashError
+	PruneStateDBError
+	StateRootHashError
+	GetStateProofError
+	VerifyStateProofError
+	FastSyncError
+	PDEHistoryError
+	OutCoinIndexError
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -343,6 +350,13 @@ var ErrCodeMessage = map[int]struct {
 	InsertShardBlockError:                             {-1154, "Insert Shard Block Error"},
 	GetShardBlockHeightByHashError:                    {-1155, "Get Shard Block Height By Hash Error"},
 	GetShardBlockByHashError:                          {-1156, "Get Shard Block By Hash Error"},
+	PruneStateDBError:                                 {-1157, "Prune State DB Error"},
+	StateRootHashError:                                {-1158, "State Root Hash Error"},
+	GetStateProofError:                                {-1159, "Get State Proof Error"},
+	VerifyStateProofError:                             {-1160, "Verify State Proof Error"},
+	FastSyncError:                                     {-1161, "Fast Sync Error"},
+	PDEHistoryError:                                   {-1162, "PDE History Error"},
+	OutCoinIndexError:                                 {-1163, "Output Coin Index Error"},
 	GetListOutputCoinsByKeysetError:                   {-2000, "Get List Output Coins By Keyset Error"},
 	GetTotalLockedCollateralError:                     {-3000, "Get Total Locked Collateral Error"},
 }
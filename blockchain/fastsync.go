@@ -0,0 +1,395 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/trie"
+)
+
+const (
+	// MaxStateNodesPerRequest is the max number of trie nodes served for one
+	// fast sync request
+	MaxStateNodesPerRequest = 384
+	fastSyncBloomSize       = 256 // in MB
+)
+
+var errShardFastSyncing = errors.New("shard is being fast synced")
+
+// ShardFastSync downloads the state of a finalized shard view from peers,
+// instead of replaying every shard block from genesis.
+//
+// The view is trusted through the beacon chain, which must be synced first:
+//   - the block after the view is confirmed by a finalized beacon block
+//   - this block commits the state roots of the view in its header (STATE_ROOT_BLOCK_VERSION)
+//   - committee, pending validators and staking txs of the view are checked
+//     against the roots in the header of the view block
+//
+// Every downloaded trie node is checked against its hash. NumOfBlocksByProducers
+// and BestCrossShard are not committed by any header, the values of the serving
+// peer are dropped and rebuilt from the blocks before the view (see AddPreviousBlock).
+type ShardFastSync struct {
+	blockchain *BlockChain
+	shardID    byte
+	view       *ShardBestState
+	sched      *trie.Sync
+	bloom      *trie.SyncBloom
+	requested  map[common.Hash]struct{} // nodes returned by Missing but not processed yet
+
+	// rebuild of NumOfBlocksByProducers and BestCrossShard, walking back from the view
+	previousHeight         uint64      // height of the next block to add, 0 once rebuilt
+	previousHash           common.Hash // hash of the next block to add
+	isProducersCounted     bool        // a swap block (start of the epoch) is reached
+	numOfBlocksByProducers map[string]uint64
+	bestCrossShard         map[byte]uint64
+}
+
+// GetShardFastSyncView returns the final view of shard, served to fast syncing peers
+func (blockchain *BlockChain) GetShardFastSyncView(shardID byte) ([]byte, error) {
+	if int(shardID) >= len(blockchain.ShardChain) {
+		return nil, NewBlockChainError(FastSyncError, fmt.Errorf("shard %+v not found", shardID))
+	}
+	view := blockchain.ShardChain[shardID].GetFinalView().(*ShardBestState)
+	if view.ShardHeight <= 1 {
+		return nil, NewBlockChainError(FastSyncError, fmt.Errorf("shard %+v is not synced", shardID))
+	}
+	return json.Marshal(view)
+}
+
+// GetShardStateNodes returns the encoded trie nodes of hashes in the shard
+// database, empty for the ones not found. Only the first
+// MaxStateNodesPerRequest hashes are served.
+func (blockchain *BlockChain) GetShardStateNodes(shardID byte, hashes []common.Hash) [][]byte {
+	if int(shardID) >= len(blockchain.ShardChain) {
+		return nil
+	}
+	if len(hashes) > MaxStateNodesPerRequest {
+		hashes = hashes[:MaxStateNodesPerRequest]
+	}
+	db := blockchain.GetShardChainDatabase(shardID)
+	res := make([][]byte, len(hashes))
+	for i, hash := range hashes {
+		if node, err := db.Get(hash[:]); err == nil {
+			res[i] = node
+		} else {
+			res[i] = []byte{}
+		}
+	}
+	return res
+}
+
+// NewShardFastSync decodes a view served by a peer and checks it is consistent
+// with its best block. The view must be higher than the final view of shard.
+func (blockchain *BlockChain) NewShardFastSync(shardID byte, viewData []byte) (*ShardFastSync, error) {
+	if int(shardID) >= len(blockchain.ShardChain) {
+		return nil, NewBlockChainError(FastSyncError, fmt.Errorf("shard %+v not found", shardID))
+	}
+	view := NewShardBestState()
+	if err := json.Unmarshal(viewData, view); err != nil {
+		return nil, NewBlockChainError(FastSyncError, err)
+	}
+	if view.BestBlock == nil {
+		return nil, NewBlockChainError(FastSyncError, errors.New("view has no best block"))
+	}
+	header := view.BestBlock.Header
+	if view.ShardID != shardID || header.ShardID != shardID {
+		return nil, NewBlockChainError(FastSyncError, fmt.Errorf("expect view of shard %+v but get %+v, block of shard %+v", shardID, view.ShardID, header.ShardID))
+	}
+	if view.BestBlockHash != *view.BestBlock.Hash() || view.ShardHeight != header.Height ||
+		view.BeaconHeight != header.BeaconHeight || view.BestBeaconHash != header.BeaconHash || view.Epoch != header.Epoch {
+		return nil, NewBlockChainError(FastSyncError, fmt.Errorf("view %+v is different from its best block %+v", view.BestBlockHash, view.BestBlock.Hash()))
+	}
+	if finalHeight := blockchain.ShardChain[shardID].GetFinalViewHeight(); view.ShardHeight <= finalHeight {
+		return nil, NewBlockChainError(FastSyncError, fmt.Errorf("view height %+v is not higher than final view height %+v", view.ShardHeight, finalHeight))
+	}
+	if err := view.verifyPostProcessingShardBlock(view.BestBlock, shardID); err != nil {
+		return nil, NewBlockChainError(FastSyncError, err)
+	}
+	fastSync := &ShardFastSync{
+		blockchain:             blockchain,
+		shardID:                shardID,
+		view:                   view,
+		requested:              make(map[common.Hash]struct{}),
+		previousHeight:         view.ShardHeight,
+		previousHash:           view.BestBlockHash,
+		numOfBlocksByProducers: make(map[string]uint64),
+		bestCrossShard:         make(map[byte]uint64),
+	}
+	if err := fastSync.AddPreviousBlock(view.BestBlock); err != nil {
+		return nil, err
+	}
+	return fastSync, nil
+}
+
+// Height returns the height of the view to be synced
+func (fastSync *ShardFastSync) Height() uint64 {
+	return fastSync.view.ShardHeight
+}
+
+// PreviousHeight returns the height of the block to be passed to AddPreviousBlock,
+// 0 once NumOfBlocksByProducers and BestCrossShard of the view are rebuilt
+func (fastSync *ShardFastSync) PreviousHeight() uint64 {
+	return fastSync.previousHeight
+}
+
+// AddPreviousBlock rebuilds NumOfBlocksByProducers and BestCrossShard of the view
+// from block, which must be the block of PreviousHeight, chained by hash to the view.
+// Its transactions, instructions and cross transactions are checked against the
+// roots of its header, as when the block is synced.
+// Blocks are added back to the start of the epoch for NumOfBlocksByProducers, and
+// back to the last cross shard block received from every other shard for
+// BestCrossShard (down to genesis for a shard which never sent one).
+func (fastSync *ShardFastSync) AddPreviousBlock(block *ShardBlock) error {
+	if fastSync.previousHeight == 0 {
+		return NewBlockChainError(FastSyncError, errors.New("view is already rebuilt"))
+	}
+	if block.Header.ShardID != fastSync.shardID || block.Header.Height != fastSync.previousHeight || *block.Hash() != fastSync.previousHash {
+		return NewBlockChainError(FastSyncError, fmt.Errorf("expect block %+v, %+v of shard %+v but get %+v, %+v of shard %+v", fastSync.previousHeight, fastSync.previousHash, fastSync.shardID, block.Header.Height, block.Hash(), block.Header.ShardID))
+	}
+	if err := fastSync.blockchain.verifyShardBlockBody(block); err != nil {
+		return NewBlockChainError(FastSyncError, err)
+	}
+	if !fastSync.isProducersCounted {
+		fastSync.numOfBlocksByProducers[block.GetProducerPubKeyStr()]++
+		for _, inst := range block.Body.Instructions {
+			if len(inst) > 0 && inst[0] == SwapAction {
+				fastSync.isProducersCounted = true
+				break
+			}
+		}
+	}
+	for shardID, crossTransactions := range block.Body.CrossTransactions {
+		if _, ok := fastSync.bestCrossShard[shardID]; !ok && len(crossTransactions) > 0 {
+			fastSync.bestCrossShard[shardID] = crossTransactions[len(crossTransactions)-1].BlockHeight
+		}
+	}
+	// the genesis block (height 1) is neither counted nor has cross transactions
+	if block.Header.Height <= 2 || (fastSync.isProducersCounted && len(fastSync.bestCrossShard) >= fastSync.blockchain.config.ChainParams.ActiveShards-1) {
+		fastSync.previousHeight = 0
+		return nil
+	}
+	fastSync.previousHeight--
+	fastSync.previousHash = block.Header.PreviousBlockHash
+	return nil
+}
+
+// Start verifies nextBlock, the block after the view, against the beacon chain
+// and the view, then schedules the download of the state tries of the view.
+// Start must be called before Missing and Process.
+func (fastSync *ShardFastSync) Start(nextBlock *ShardBlock) error {
+	view := fastSync.view
+	if nextBlock.Header.ShardID != fastSync.shardID || nextBlock.Header.Height != view.ShardHeight+1 || nextBlock.Header.PreviousBlockHash != view.BestBlockHash {
+		return NewBlockChainError(FastSyncError, fmt.Errorf("block %+v of shard %+v does not follow view %+v", nextBlock.Header.Height, nextBlock.Header.ShardID, view.BestBlockHash))
+	}
+	if nextBlock.Header.Version < STATE_ROOT_BLOCK_VERSION {
+		return NewBlockChainError(FastSyncError, fmt.Errorf("block %+v of version %+v does not commit state roots", nextBlock.Header.Height, nextBlock.Header.Version))
+	}
+	if err := fastSync.blockchain.verifyShardBlockConfirmed(nextBlock); err != nil {
+		return NewBlockChainError(FastSyncError, err)
+	}
+	if err := view.verifyHeaderStateRoots(&nextBlock.Header); err != nil {
+		return NewBlockChainError(FastSyncError, err)
+	}
+
+	chain := fastSync.blockchain.ShardChain[fastSync.shardID]
+	chain.insertLock.Lock()
+	defer chain.insertLock.Unlock()
+	if chain.fastSync != nil {
+		return NewBlockChainError(FastSyncError, errShardFastSyncing)
+	}
+	db := fastSync.blockchain.GetShardChainDatabase(fastSync.shardID)
+	fastSync.bloom = trie.NewSyncBloom(fastSyncBloomSize, db)
+	fastSync.sched = trie.NewSync(stateRoot(view.ConsensusStateDBRootHash), db, nil, fastSync.bloom)
+	for _, root := range []common.Hash{view.TransactionStateDBRootHash, view.FeatureStateDBRootHash, view.RewardStateDBRootHash, view.SlashStateDBRootHash} {
+		fastSync.sched.AddSubTrie(stateRoot(root), 0, common.Hash{}, nil)
+	}
+	chain.fastSync = fastSync
+	Logger.log.Infof("SHARD %+v | Start fast sync of view %+v, height %+v", fastSync.shardID, view.BestBlockHash, view.ShardHeight)
+	return nil
+}
+
+// Missing returns at most max hashes of trie nodes to be downloaded, the ones
+// requested before but not processed yet come first
+func (fastSync *ShardFastSync) Missing(max int) []common.Hash {
+	hashes := []common.Hash{}
+	for hash := range fastSync.requested {
+		hashes = append(hashes, hash)
+		if len(hashes) >= max {
+			return hashes
+		}
+	}
+	for _, hash := range fastSync.sched.Missing(max - len(hashes)) {
+		fastSync.requested[hash] = struct{}{}
+		hashes = append(hashes, hash)
+	}
+	return hashes
+}
+
+// Process checks the downloaded trie nodes against their hashes and stores them,
+// returns the number of processed nodes. Empty data are skipped, they are
+// returned by Missing again.
+func (fastSync *ShardFastSync) Process(hashes []common.Hash, data [][]byte) (int, error) {
+	results := []trie.SyncResult{}
+	for i, node := range data {
+		if i >= len(hashes) {
+			break
+		}
+		if len(node) == 0 {
+			continue
+		}
+		if _, ok := fastSync.requested[hashes[i]]; !ok {
+			continue
+		}
+		if hash := common.Keccak256Hash(node); hash != hashes[i] {
+			return 0, NewBlockChainError(FastSyncError, fmt.Errorf("expect state node %+v but get %+v", hashes[i], hash))
+		}
+		results = append(results, trie.SyncResult{Hash: hashes[i], Data: node})
+		delete(fastSync.requested, hashes[i])
+	}
+	if _, index, err := fastSync.sched.Process(results); err != nil {
+		return 0, NewBlockChainError(FastSyncError, fmt.Errorf("process state node %+v, error %+v", results[index].Hash, err))
+	}
+
+	chain := fastSync.blockchain.ShardChain[fastSync.shardID]
+	chain.insertLock.Lock()
+	defer chain.insertLock.Unlock()
+	batch := fastSync.blockchain.GetShardChainDatabase(fastSync.shardID).NewBatch()
+	if err := fastSync.sched.Commit(batch); err != nil {
+		return 0, NewBlockChainError(FastSyncError, err)
+	}
+	if err := batch.Write(); err != nil {
+		return 0, NewBlockChainError(FastSyncError, err)
+	}
+	return len(results), nil
+}
+
+// Pending returns the number of trie nodes which are not stored yet
+func (fastSync *ShardFastSync) Pending() int {
+	return fastSync.sched.Pending()
+}
+
+// Finish stores the view once all of its state is downloaded, and makes it
+// the only view of shard, from which blocks are synced as usual
+func (fastSync *ShardFastSync) Finish() error {
+	if fastSync.sched.Pending() > 0 {
+		return NewBlockChainError(FastSyncError, fmt.Errorf("%+v state nodes are still pending", fastSync.sched.Pending()))
+	}
+	if fastSync.previousHeight > 0 {
+		return NewBlockChainError(FastSyncError, fmt.Errorf("view is not rebuilt, block %+v is still missing", fastSync.previousHeight))
+	}
+	view := fastSync.view
+	view.NumOfBlocksByProducers = fastSync.numOfBlocksByProducers
+	view.BestCrossShard = fastSync.bestCrossShard
+	shardID := fastSync.shardID
+	db := fastSync.blockchain.GetShardChainDatabase(shardID)
+	if err := view.InitStateRootHash(db, fastSync.blockchain); err != nil {
+		return NewBlockChainError(FastSyncError, err)
+	}
+
+	chain := fastSync.blockchain.ShardChain[shardID]
+	chain.insertLock.Lock()
+	defer chain.insertLock.Unlock()
+	defer fastSync.close()
+	batch := db.NewBatch()
+	height := view.ShardHeight
+	if err := rawdbv2.StoreShardConsensusRootHash(batch, shardID, height, view.ConsensusStateDBRootHash); err != nil {
+		return NewBlockChainError(FastSyncError, err)
+	}
+	if err := rawdbv2.StoreShardTransactionRootHash(batch, shardID, height, view.TransactionStateDBRootHash); err != nil {
+		return NewBlockChainError(FastSyncError, err)
+	}
+	if err := rawdbv2.StoreShardFeatureRootHash(batch, shardID, height, view.FeatureStateDBRootHash); err != nil {
+		return NewBlockChainError(FastSyncError, err)
+	}
+	if err := rawdbv2.StoreShardCommitteeRewardRootHash(batch, shardID, height, view.RewardStateDBRootHash); err != nil {
+		return NewBlockChainError(FastSyncError, err)
+	}
+	if err := rawdbv2.StoreShardSlashRootHash(batch, shardID, height, view.SlashStateDBRootHash); err != nil {
+		return NewBlockChainError(FastSyncError, err)
+	}
+	if err := rawdbv2.StoreShardBlock(batch, shardID, height, view.BestBlockHash, view.BestBlock); err != nil {
+		return NewBlockChainError(FastSyncError, err)
+	}
+	if err := rawdbv2.StoreShardBlockIndex(batch, shardID, height, view.BestBlockHash); err != nil {
+		return NewBlockChainError(FastSyncError, err)
+	}
+	chain.multiView.Reset(view)
+	if err := fastSync.blockchain.BackupShardViews(batch, shardID); err != nil {
+		return NewBlockChainError(FastSyncError, err)
+	}
+	if err := batch.Write(); err != nil {
+		return NewBlockChainError(FastSyncError, err)
+	}
+	Logger.log.Infof("SHARD %+v | Finish fast sync of view %+v, height %+v", shardID, view.BestBlockHash, height)
+	return nil
+}
+
+// Abort stops a fast sync which is not finished. Downloaded trie nodes are kept
+// and reused by the next fast sync.
+func (fastSync *ShardFastSync) Abort() {
+	chain := fastSync.blockchain.ShardChain[fastSync.shardID]
+	chain.insertLock.Lock()
+	defer chain.insertLock.Unlock()
+	fastSync.close()
+}
+
+// close must be called with insertLock held
+func (fastSync *ShardFastSync) close() {
+	chain := fastSync.blockchain.ShardChain[fastSync.shardID]
+	if chain.fastSync == fastSync {
+		chain.fastSync = nil
+	}
+	if fastSync.bloom != nil {
+		fastSync.bloom.Close()
+	}
+}
+
+// verifyShardBlockBody checks the body of shardBlock against the roots of its header
+func (blockchain *BlockChain) verifyShardBlockBody(shardBlock *ShardBlock) error {
+	if err := verifyShardBlockTxRoot(shardBlock); err != nil {
+		return err
+	}
+	if !VerifyMerkleCrossTransaction(shardBlock.Body.CrossTransactions, shardBlock.Header.CrossTransactionRoot) {
+		return NewBlockChainError(CrossShardTransactionRootHashError, fmt.Errorf("Expect cross shard transaction root hash %+v", shardBlock.Header.CrossTransactionRoot))
+	}
+	txInstructions, err := CreateShardInstructionsFromTransactionAndInstruction(shardBlock.Body.Transactions, blockchain, shardBlock.Header.ShardID)
+	if err != nil {
+		return NewBlockChainError(ShardIntructionFromTransactionAndInstructionError, err)
+	}
+	totalInstructions := []string{}
+	for _, value := range txInstructions {
+		totalInstructions = append(totalInstructions, value...)
+	}
+	for _, value := range shardBlock.Body.Instructions {
+		totalInstructions = append(totalInstructions, value...)
+	}
+	if hash, ok := verifyHashFromStringArray(totalInstructions, shardBlock.Header.InstructionsRoot); !ok {
+		return NewBlockChainError(InstructionsHashError, fmt.Errorf("Expect instruction hash to be %+v but get %+v at block %+v", shardBlock.Header.InstructionsRoot, hash, shardBlock.Header.Height))
+	}
+	return nil
+}
+
+// verifyShardBlockConfirmed checks shardBlock is confirmed by a finalized beacon block
+func (blockchain *BlockChain) verifyShardBlockConfirmed(shardBlock *ShardBlock) error {
+	shardID := shardBlock.Header.ShardID
+	finalHeight := blockchain.BeaconChain.GetFinalView().GetHeight()
+	for height := shardBlock.Header.BeaconHeight + 1; height <= finalHeight; height++ {
+		beaconBlock, err := blockchain.GetBeaconBlockByHeightV1(height)
+		if err != nil {
+			return err
+		}
+		for _, shardState := range beaconBlock.Body.ShardState[shardID] {
+			if shardState.Height < shardBlock.Header.Height {
+				continue
+			}
+			if shardState.Height == shardBlock.Header.Height && shardState.Hash == *shardBlock.Hash() {
+				return nil
+			}
+			return fmt.Errorf("shard %+v block %+v, %+v is not the one confirmed by beacon block %+v", shardID, shardBlock.Header.Height, shardBlock.Hash(), height)
+		}
+	}
+	return fmt.Errorf("shard %+v block %+v is not confirmed by finalized beacon blocks up to %+v yet", shardID, shardBlock.Header.Height, finalHeight)
+}
@@ -0,0 +1,211 @@
+package blockchain
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+	"github.com/incognitochain/incognito-chain/multiview"
+	"github.com/incognitochain/incognito-chain/trie"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFastSyncTestChain returns a blockchain whose shard 0 database is a new leveldb
+func newFastSyncTestChain(t *testing.T) (*BlockChain, incdb.Database, func()) {
+	dir, err := ioutil.TempDir("", "fastsync")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := incdb.Open("leveldb", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blockchain := &BlockChain{
+		config:     Config{DataBase: map[int]incdb.Database{0: db}},
+		ShardChain: []*ShardChain{{multiView: multiview.NewMultiView()}},
+	}
+	return blockchain, db, func() {
+		db.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+// storeFastSyncTestState commits commitments of shard 0 to the state of db and returns its root
+func storeFastSyncTestState(t *testing.T, db incdb.Database, commitments [][]byte) common.Hash {
+	stateDB, err := statedb.NewWithPrefixTrie(common.EmptyRoot, statedb.NewDatabaseAccessWarper(db))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := statedb.StoreCommitments(stateDB, common.PRVCoinID, []byte("pubkey"), commitments, 0); err != nil {
+		t.Fatal(err)
+	}
+	root, err := stateDB.Commit(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stateDB.Database().TrieDB().Commit(root, false); err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+func newFastSyncTestCommitments(n int) [][]byte {
+	commitments := [][]byte{}
+	for i := 0; i < n; i++ {
+		commitments = append(commitments, common.HashB([]byte{byte(i >> 8), byte(i)}))
+	}
+	return commitments
+}
+
+// newTestShardFastSync schedules the download of the state of root into blockchain, as Start does once the view is verified
+func newTestShardFastSync(blockchain *BlockChain, root common.Hash) *ShardFastSync {
+	db := blockchain.GetShardChainDatabase(0)
+	bloom := trie.NewSyncBloom(1, db)
+	return &ShardFastSync{
+		blockchain: blockchain,
+		shardID:    0,
+		sched:      trie.NewSync(stateRoot(root), db, nil, bloom),
+		bloom:      bloom,
+		requested:  make(map[common.Hash]struct{}),
+	}
+}
+
+func TestShardFastSyncDownloadsState(t *testing.T) {
+	source, sourceDB, closeSource := newFastSyncTestChain(t)
+	defer closeSource()
+	target, targetDB, closeTarget := newFastSyncTestChain(t)
+	defer closeTarget()
+	commitments := newFastSyncTestCommitments(200)
+	root := storeFastSyncTestState(t, sourceDB, commitments)
+
+	fastSync := newTestShardFastSync(target, root)
+	defer fastSync.close()
+	for rounds := 0; fastSync.Pending() > 0; rounds++ {
+		if rounds > 100 {
+			t.Fatalf("state is not downloaded, %v nodes are pending", fastSync.Pending())
+		}
+		hashes := fastSync.Missing(16)
+		processed, err := fastSync.Process(hashes, source.GetShardStateNodes(0, hashes))
+		assert.Nil(t, err)
+		assert.Equal(t, len(hashes), processed)
+	}
+
+	stateDB, err := statedb.NewWithPrefixTrie(root, statedb.NewDatabaseAccessWarper(targetDB))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, commitment := range commitments {
+		ok, err := statedb.HasCommitment(stateDB, common.PRVCoinID, commitment, 0)
+		assert.Nil(t, err)
+		assert.True(t, ok)
+	}
+}
+
+func TestShardFastSyncProcessValidatesNodes(t *testing.T) {
+	source, sourceDB, closeSource := newFastSyncTestChain(t)
+	defer closeSource()
+	target, _, closeTarget := newFastSyncTestChain(t)
+	defer closeTarget()
+	root := storeFastSyncTestState(t, sourceDB, newFastSyncTestCommitments(200))
+
+	fastSync := newTestShardFastSync(target, root)
+	defer fastSync.close()
+	hashes := fastSync.Missing(16)
+	assert.Equal(t, []common.Hash{root}, hashes, "the root is downloaded first")
+	rootNode := source.GetShardStateNodes(0, hashes)[0]
+
+	// a node which does not match its hash is rejected
+	tampered := append([]byte{}, rootNode...)
+	tampered[len(tampered)-1] ^= 1
+	_, err := fastSync.Process(hashes, [][]byte{tampered})
+	assert.NotNil(t, err)
+	assert.Equal(t, hashes, fastSync.Missing(16), "rejected node is requested again")
+
+	// a node which is not requested is skipped
+	notRequested := common.Keccak256Hash(rootNode[1:])
+	processed, err := fastSync.Process([]common.Hash{notRequested}, [][]byte{rootNode[1:]})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, processed)
+
+	// a node the peer does not have is skipped and requested again
+	processed, err = fastSync.Process(hashes, [][]byte{{}})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, processed)
+	assert.Equal(t, hashes, fastSync.Missing(16))
+
+	processed, err = fastSync.Process(hashes, [][]byte{rootNode})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, processed)
+	assert.NotContains(t, fastSync.Missing(16), root, "children of the root are requested next")
+}
+
+func TestGetShardStateNodes(t *testing.T) {
+	blockchain, db, closeChain := newFastSyncTestChain(t)
+	defer closeChain()
+	root := storeFastSyncTestState(t, db, newFastSyncTestCommitments(10))
+
+	nodes := blockchain.GetShardStateNodes(0, []common.Hash{root, common.HashH([]byte("missing"))})
+	assert.Equal(t, root, common.Keccak256Hash(nodes[0]))
+	assert.Empty(t, nodes[1])
+
+	hashes := make([]common.Hash, MaxStateNodesPerRequest+1)
+	assert.Len(t, blockchain.GetShardStateNodes(0, hashes), MaxStateNodesPerRequest)
+	assert.Nil(t, blockchain.GetShardStateNodes(1, hashes), "shard is not found")
+}
+
+func TestShardFastSyncAddPreviousBlock(t *testing.T) {
+	blockchain := &BlockChain{config: Config{ChainParams: &Params{ActiveShards: 3}}}
+	blocks := map[uint64]*ShardBlock{}
+	previousHash := common.Hash{}
+	for height := uint64(1); height <= 10; height++ {
+		block := &ShardBlock{Header: ShardHeader{Height: height, PreviousBlockHash: previousHash, ProducerPubKeyStr: []string{"a", "b"}[height%2]}}
+		switch height {
+		case 3:
+			block.Body.Instructions = [][]string{{SwapAction}}
+		case 4:
+			block.Body.Instructions = [][]string{{SwapAction}}
+			block.Header.ProducerPubKeyStr = "c"
+		case 6:
+			block.Body.CrossTransactions = map[byte][]CrossTransaction{1: {{BlockHeight: 3}}, 2: {{BlockHeight: 8}}}
+		case 9:
+			block.Body.CrossTransactions = map[byte][]CrossTransaction{1: {{BlockHeight: 5}, {BlockHeight: 7}}}
+		}
+		instructions := []string{}
+		for _, inst := range block.Body.Instructions {
+			instructions = append(instructions, inst...)
+		}
+		block.Header.InstructionsRoot, _ = generateHashFromStringArray(instructions)
+		crossTransactionRoot, _ := CreateMerkleCrossTransaction(block.Body.CrossTransactions)
+		block.Header.CrossTransactionRoot = *crossTransactionRoot
+		blocks[height] = block
+		previousHash = *block.Hash()
+	}
+	fastSync := &ShardFastSync{
+		blockchain:             blockchain,
+		previousHeight:         10,
+		previousHash:           previousHash,
+		numOfBlocksByProducers: make(map[string]uint64),
+		bestCrossShard:         make(map[byte]uint64),
+	}
+
+	// a block which is not chained to the view is rejected
+	assert.NotNil(t, fastSync.AddPreviousBlock(blocks[9]))
+	tampered := *blocks[10]
+	tampered.Body.Instructions = [][]string{{SwapAction}}
+	assert.NotNil(t, fastSync.AddPreviousBlock(&tampered), "body does not match the roots of the header")
+	tampered.Body.Instructions = nil
+	tampered.Body.CrossTransactions = map[byte][]CrossTransaction{1: {{BlockHeight: 9}}}
+	assert.NotNil(t, fastSync.AddPreviousBlock(&tampered), "body does not match the roots of the header")
+	assert.Equal(t, uint64(10), fastSync.PreviousHeight())
+
+	for fastSync.PreviousHeight() > 0 {
+		assert.Nil(t, fastSync.AddPreviousBlock(blocks[fastSync.PreviousHeight()]))
+	}
+	assert.Equal(t, map[string]uint64{"a": 3, "b": 3, "c": 1}, fastSync.numOfBlocksByProducers, "blocks are counted back to the last swap block")
+	assert.Equal(t, map[byte]uint64{1: 7, 2: 8}, fastSync.bestCrossShard)
+	assert.NotNil(t, fastSync.AddPreviousBlock(blocks[3]), "view is already rebuilt")
+}
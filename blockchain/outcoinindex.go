@@ -0,0 +1,426 @@
+package blockchain
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/privacy/zeroknowledge"
+	"github.com/incognitochain/incognito-chain/transaction"
+)
+
+// outCoinIndexer keeps output coins of registered viewing keys decrypted in the database of their shard,
+// so listing output coins of a key does not scan and decrypt all output coins of its public key again.
+// Blocks are indexed right after they are stored. A key whose index is behind (just registered, rescanned
+// or the node ran without the index) is caught up in background by reading stored blocks height by height.
+// Coins are keyed by commitment so a block can be indexed more than once, and coins of blocks of discarded
+// forks are filtered out when they are read because their commitments are not in the transaction state.
+type outCoinIndexer struct {
+	mtx        sync.Mutex
+	keys       map[string]*rawdbv2.ViewingKeyIndex // [public key] -> index
+	catchingUp map[string]bool                     // [public key] -> key is being caught up
+}
+
+func newOutCoinIndexer() *outCoinIndexer {
+	return &outCoinIndexer{
+		keys:       make(map[string]*rawdbv2.ViewingKeyIndex),
+		catchingUp: make(map[string]bool),
+	}
+}
+
+// initOutCoinIndexer loads registered viewing keys of all shards and catches up those which are behind
+func (blockchain *BlockChain) initOutCoinIndexer() error {
+	blockchain.outCoinIndexer = newOutCoinIndexer()
+	indexer := blockchain.outCoinIndexer
+	indexer.mtx.Lock()
+	defer indexer.mtx.Unlock()
+	for _, shardID := range blockchain.GetShardIDs() {
+		indexes, err := rawdbv2.GetViewingKeyIndexes(blockchain.GetShardChainDatabase(byte(shardID)))
+		if err != nil {
+			return NewBlockChainError(OutCoinIndexError, err)
+		}
+		for i := range indexes {
+			index := indexes[i]
+			indexer.keys[string(index.PublicKey)] = &index
+			blockchain.startCatchUpViewingKey(string(index.PublicKey))
+		}
+	}
+	return nil
+}
+
+// RegisterViewingKey adds viewingKey to the output coin index, output coins of blocks from fromHeight are indexed
+func (blockchain *BlockChain) RegisterViewingKey(viewingKey privacy.ViewingKey, fromHeight uint64) error {
+	indexer := blockchain.outCoinIndexer
+	if indexer == nil {
+		return NewBlockChainError(OutCoinIndexError, errors.New("output coin index is not enabled"))
+	}
+	if len(viewingKey.Pk) == 0 || len(viewingKey.Rk) == 0 {
+		return NewBlockChainError(OutCoinIndexError, errors.New("viewing key is invalid"))
+	}
+	if fromHeight == 0 {
+		fromHeight = 1
+	}
+	shardID := common.GetShardIDFromLastByte(viewingKey.Pk[len(viewingKey.Pk)-1])
+	if err := blockchain.checkStoredShardBlock(shardID, fromHeight); err != nil {
+		return err
+	}
+	indexer.mtx.Lock()
+	defer indexer.mtx.Unlock()
+	if _, ok := indexer.keys[string(viewingKey.Pk)]; ok {
+		return NewBlockChainError(OutCoinIndexError, errors.New("viewing key is already registered"))
+	}
+	index := &rawdbv2.ViewingKeyIndex{
+		PublicKey:     viewingKey.Pk,
+		ReceivingKey:  viewingKey.Rk,
+		FromHeight:    fromHeight,
+		IndexedHeight: fromHeight - 1,
+	}
+	if err := rawdbv2.StoreViewingKeyIndex(blockchain.GetShardChainDatabase(shardID), *index); err != nil {
+		return NewBlockChainError(OutCoinIndexError, err)
+	}
+	indexer.keys[string(index.PublicKey)] = index
+	blockchain.startCatchUpViewingKey(string(index.PublicKey))
+	return nil
+}
+
+// UnregisterViewingKey removes the viewing key of publicKey and its indexed output coins
+func (blockchain *BlockChain) UnregisterViewingKey(publicKey []byte) error {
+	indexer := blockchain.outCoinIndexer
+	if indexer == nil {
+		return NewBlockChainError(OutCoinIndexError, errors.New("output coin index is not enabled"))
+	}
+	indexer.mtx.Lock()
+	defer indexer.mtx.Unlock()
+	if _, ok := indexer.keys[string(publicKey)]; !ok {
+		return NewBlockChainError(OutCoinIndexError, errors.New("viewing key is not registered"))
+	}
+	shardID := common.GetShardIDFromLastByte(publicKey[len(publicKey)-1])
+	if err := rawdbv2.DeleteViewingKeyIndex(blockchain.GetShardChainDatabase(shardID), publicKey); err != nil {
+		return NewBlockChainError(OutCoinIndexError, err)
+	}
+	delete(indexer.keys, string(publicKey))
+	return nil
+}
+
+// RescanViewingKey indexes again output coins of the viewing key of publicKey from blocks from fromHeight,
+// coins already indexed are kept
+func (blockchain *BlockChain) RescanViewingKey(publicKey []byte, fromHeight uint64) error {
+	indexer := blockchain.outCoinIndexer
+	if indexer == nil {
+		return NewBlockChainError(OutCoinIndexError, errors.New("output coin index is not enabled"))
+	}
+	if fromHeight == 0 {
+		fromHeight = 1
+	}
+	if len(publicKey) == 0 {
+		return NewBlockChainError(OutCoinIndexError, errors.New("viewing key is not registered"))
+	}
+	shardID := common.GetShardIDFromLastByte(publicKey[len(publicKey)-1])
+	if err := blockchain.checkStoredShardBlock(shardID, fromHeight); err != nil {
+		return err
+	}
+	indexer.mtx.Lock()
+	defer indexer.mtx.Unlock()
+	index, ok := indexer.keys[string(publicKey)]
+	if !ok {
+		return NewBlockChainError(OutCoinIndexError, errors.New("viewing key is not registered"))
+	}
+	updatedIndex := *index
+	if fromHeight < updatedIndex.FromHeight {
+		updatedIndex.FromHeight = fromHeight
+	}
+	if fromHeight-1 < updatedIndex.IndexedHeight {
+		updatedIndex.IndexedHeight = fromHeight - 1
+	}
+	if err := rawdbv2.StoreViewingKeyIndex(blockchain.GetShardChainDatabase(shardID), updatedIndex); err != nil {
+		return NewBlockChainError(OutCoinIndexError, err)
+	}
+	*index = updatedIndex
+	blockchain.startCatchUpViewingKey(string(publicKey))
+	return nil
+}
+
+// GetViewingKeyIndexes returns registered viewing keys of the output coin index and their indexing progress
+func (blockchain *BlockChain) GetViewingKeyIndexes() ([]rawdbv2.ViewingKeyIndex, error) {
+	indexer := blockchain.outCoinIndexer
+	if indexer == nil {
+		return nil, NewBlockChainError(OutCoinIndexError, errors.New("output coin index is not enabled"))
+	}
+	indexer.mtx.Lock()
+	defer indexer.mtx.Unlock()
+	result := []rawdbv2.ViewingKeyIndex{}
+	for _, index := range indexer.keys {
+		result = append(result, *index)
+	}
+	return result, nil
+}
+
+// checkStoredShardBlock returns an error if blocks of shardID are stored up to a height above height but block at height is not,
+// e.g the node was fast synced
+func (blockchain *BlockChain) checkStoredShardBlock(shardID byte, height uint64) error {
+	if int(shardID) >= len(blockchain.ShardChain) || height > blockchain.GetBestStateShard(shardID).ShardHeight {
+		return nil
+	}
+	blocks, err := blockchain.GetShardBlockHashByHeight(height, shardID)
+	if err != nil {
+		return NewBlockChainError(OutCoinIndexError, err)
+	}
+	if len(blocks) == 0 {
+		return NewBlockChainError(OutCoinIndexError, fmt.Errorf("shard %+v block at height %+v is not stored", shardID, height))
+	}
+	return nil
+}
+
+// indexOutputCoinsOfShardBlock indexes output coins of a stored shard block for the viewing keys of its shard,
+// keys which are behind are caught up instead
+func (blockchain *BlockChain) indexOutputCoinsOfShardBlock(shardBlock *ShardBlock) {
+	indexer := blockchain.outCoinIndexer
+	if indexer == nil {
+		return
+	}
+	indexer.mtx.Lock()
+	defer indexer.mtx.Unlock()
+	shardID := shardBlock.Header.ShardID
+	height := shardBlock.Header.Height
+	keys := []*rawdbv2.ViewingKeyIndex{}
+	for publicKey, index := range indexer.keys {
+		if common.GetShardIDFromLastByte(index.PublicKey[len(index.PublicKey)-1]) != shardID || height < index.FromHeight {
+			continue
+		}
+		if height > index.IndexedHeight+1 {
+			blockchain.startCatchUpViewingKey(publicKey)
+			continue
+		}
+		keys = append(keys, index)
+	}
+	if len(keys) == 0 {
+		return
+	}
+	db := blockchain.GetShardChainDatabase(shardID)
+	batch := db.NewBatch()
+	for _, index := range keys {
+		updatedIndex := *index
+		if err := storeOutputCoinsOfViewingKey(batch, &updatedIndex, shardBlock); err != nil {
+			Logger.log.Error(NewBlockChainError(OutCoinIndexError, err))
+			return
+		}
+		if height > updatedIndex.IndexedHeight {
+			updatedIndex.IndexedHeight = height
+			if err := rawdbv2.StoreViewingKeyIndex(batch, updatedIndex); err != nil {
+				Logger.log.Error(NewBlockChainError(OutCoinIndexError, err))
+				return
+			}
+		}
+	}
+	if err := batch.Write(); err != nil {
+		Logger.log.Error(NewBlockChainError(OutCoinIndexError, err))
+		return
+	}
+	for _, index := range keys {
+		if height > index.IndexedHeight {
+			index.IndexedHeight = height
+		}
+	}
+}
+
+// startCatchUpViewingKey starts catching up the viewing key of publicKey if it is not being caught up,
+// indexer.mtx must be held
+func (blockchain *BlockChain) startCatchUpViewingKey(publicKey string) {
+	indexer := blockchain.outCoinIndexer
+	if indexer.catchingUp[publicKey] {
+		return
+	}
+	indexer.catchingUp[publicKey] = true
+	go func() {
+		for blockchain.catchUpViewingKeyOneHeight(publicKey) {
+		}
+	}()
+}
+
+// catchUpViewingKeyOneHeight indexes all stored blocks at the height following the indexed height of the viewing key of publicKey,
+// it returns false when there is no more block to index.
+// Blocks of a shard are stored in order of height, so a block stored after a height is read here is indexed when it is stored.
+// Blocks are read and their coins decrypted without holding indexer.mtx, the index is only updated if the key was not
+// unregistered, rescanned or indexed by a new block meanwhile.
+func (blockchain *BlockChain) catchUpViewingKeyOneHeight(publicKey string) bool {
+	indexer := blockchain.outCoinIndexer
+	indexer.mtx.Lock()
+	index, ok := indexer.keys[publicKey]
+	if !ok {
+		delete(indexer.catchingUp, publicKey)
+		indexer.mtx.Unlock()
+		return false
+	}
+	updatedIndex := *index
+	indexer.mtx.Unlock()
+
+	stopCatchUp := func() bool {
+		indexer.mtx.Lock()
+		defer indexer.mtx.Unlock()
+		delete(indexer.catchingUp, publicKey)
+		return false
+	}
+	shardID := common.GetShardIDFromLastByte(updatedIndex.PublicKey[len(updatedIndex.PublicKey)-1])
+	height := updatedIndex.IndexedHeight + 1
+	var blocks map[common.Hash]*ShardBlock
+	var err error
+	if int(shardID) < len(blockchain.ShardChain) {
+		blocks, err = blockchain.GetShardBlockByHeight(height, shardID)
+	}
+	if err != nil {
+		Logger.log.Error(NewBlockChainError(OutCoinIndexError, err))
+	}
+	if len(blocks) == 0 {
+		Logger.log.Infof("Output coins of viewing key %+v are indexed up to shard %+v height %+v", base58.Base58Check{}.Encode(updatedIndex.PublicKey, common.ZeroByte), shardID, updatedIndex.IndexedHeight)
+		return stopCatchUp()
+	}
+	batch := blockchain.GetShardChainDatabase(shardID).NewBatch()
+	for _, shardBlock := range blocks {
+		if err := storeOutputCoinsOfViewingKey(batch, &updatedIndex, shardBlock); err != nil {
+			Logger.log.Error(NewBlockChainError(OutCoinIndexError, err))
+			return stopCatchUp()
+		}
+	}
+	updatedIndex.IndexedHeight = height
+	if err := rawdbv2.StoreViewingKeyIndex(batch, updatedIndex); err != nil {
+		Logger.log.Error(NewBlockChainError(OutCoinIndexError, err))
+		return stopCatchUp()
+	}
+
+	indexer.mtx.Lock()
+	defer indexer.mtx.Unlock()
+	index, ok = indexer.keys[publicKey]
+	if !ok {
+		delete(indexer.catchingUp, publicKey)
+		return false
+	}
+	if index.IndexedHeight != height-1 {
+		// read the index again
+		return true
+	}
+	if err := batch.Write(); err != nil {
+		Logger.log.Error(NewBlockChainError(OutCoinIndexError, err))
+		delete(indexer.catchingUp, publicKey)
+		return false
+	}
+	*index = updatedIndex
+	return true
+}
+
+// storeOutputCoinsOfViewingKey stores decrypted output coins of shardBlock received by the viewing key of index
+func storeOutputCoinsOfViewingKey(db incdb.KeyValueWriter, index *rawdbv2.ViewingKeyIndex, shardBlock *ShardBlock) error {
+	viewingKey := privacy.ViewingKey{Pk: index.PublicKey, Rk: index.ReceivingKey}
+	for tokenID, outputCoins := range outputCoinsOfShardBlock(shardBlock) {
+		for _, outputCoin := range outputCoins {
+			if !bytes.Equal(outputCoin.CoinDetails.GetPublicKey().ToBytesS(), index.PublicKey) {
+				continue
+			}
+			// decrypt a copy, coins of the block must not change
+			decryptedCoin := new(privacy.OutputCoin)
+			decryptedCoin.Init()
+			if err := decryptedCoin.SetBytes(outputCoin.Bytes()); err != nil {
+				return err
+			}
+			if decryptedCoin.CoinDetailsEncrypted != nil && !decryptedCoin.CoinDetailsEncrypted.IsNil() {
+				if err := decryptedCoin.Decrypt(viewingKey); err != nil {
+					continue
+				}
+			}
+			commitment := decryptedCoin.CoinDetails.GetCoinCommitment().ToBytesS()
+			if err := rawdbv2.StoreIndexedOutputCoin(db, index.PublicKey, tokenID, commitment, decryptedCoin.Bytes()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// outputCoinsOfShardBlock returns output coins created by txs and received from cross shard txs of shardBlock by token ID
+func outputCoinsOfShardBlock(shardBlock *ShardBlock) map[common.Hash][]*privacy.OutputCoin {
+	result := make(map[common.Hash][]*privacy.OutputCoin)
+	addProof := func(tokenID common.Hash, proof *zkp.PaymentProof) {
+		if proof != nil {
+			result[tokenID] = append(result[tokenID], proof.GetOutputCoins()...)
+		}
+	}
+	for _, tx := range shardBlock.Body.Transactions {
+		switch tx.GetType() {
+		case common.TxNormalType, common.TxRewardType, common.TxReturnStakingType:
+			if normalTx, ok := tx.(*transaction.Tx); ok {
+				addProof(common.PRVCoinID, normalTx.Proof)
+			}
+		case common.TxCustomTokenPrivacyType:
+			if tokenTx, ok := tx.(*transaction.TxCustomTokenPrivacy); ok {
+				addProof(common.PRVCoinID, tokenTx.Proof)
+				addProof(tokenTx.TxPrivacyTokenData.PropertyID, tokenTx.TxPrivacyTokenData.TxNormal.Proof)
+			}
+		}
+	}
+	for _, crossTransactions := range shardBlock.Body.CrossTransactions {
+		for _, crossTransaction := range crossTransactions {
+			for i := range crossTransaction.OutputCoin {
+				result[common.PRVCoinID] = append(result[common.PRVCoinID], &crossTransaction.OutputCoin[i])
+			}
+			for _, tokenPrivacyData := range crossTransaction.TokenPrivacyData {
+				for i := range tokenPrivacyData.OutputCoin {
+					result[tokenPrivacyData.PropertyID] = append(result[tokenPrivacyData.PropertyID], &tokenPrivacyData.OutputCoin[i])
+				}
+			}
+		}
+	}
+	return result
+}
+
+// getIndexedOutputCoins returns output coins of keyset like GetListOutputCoinsByKeyset from the output coin index,
+// it returns false if the viewing key of keyset is not registered or its index is behind the best state of shardID
+func (blockchain *BlockChain) getIndexedOutputCoins(keyset *incognitokey.KeySet, shardID byte, tokenID *common.Hash) ([]*privacy.OutputCoin, bool, error) {
+	indexer := blockchain.outCoinIndexer
+	if indexer == nil || len(keyset.ReadonlyKey.Rk) == 0 {
+		return nil, false, nil
+	}
+	bestState := blockchain.GetBestStateShard(shardID)
+	indexer.mtx.Lock()
+	index, ok := indexer.keys[string(keyset.PaymentAddress.Pk)]
+	ok = ok && bytes.Equal(index.ReceivingKey, keyset.ReadonlyKey.Rk) && index.IndexedHeight >= bestState.ShardHeight
+	indexer.mtx.Unlock()
+	if !ok {
+		outCoinIndexMissMeter.Mark(1)
+		return nil, false, nil
+	}
+	outCoinIndexHitMeter.Mark(1)
+	outCoinsInBytes, err := rawdbv2.GetIndexedOutputCoins(blockchain.GetShardChainDatabase(shardID), keyset.PaymentAddress.Pk, *tokenID)
+	if err != nil {
+		return nil, false, NewBlockChainError(OutCoinIndexError, err)
+	}
+	// coins are already decrypted, only spent coins are checked with private key
+	spentKeySet := &incognitokey.KeySet{PrivateKey: keyset.PrivateKey, PaymentAddress: keyset.PaymentAddress}
+	transactionStateDB := bestState.transactionStateDB
+	results := make([]*privacy.OutputCoin, 0)
+	for _, item := range outCoinsInBytes {
+		outCoin := &privacy.OutputCoin{}
+		outCoin.Init()
+		if err := outCoin.SetBytes(item); err != nil {
+			return nil, false, NewBlockChainError(OutCoinIndexError, err)
+		}
+		// skip coins of blocks of discarded forks
+		ok, err := statedb.HasCommitment(transactionStateDB, *tokenID, outCoin.CoinDetails.GetCoinCommitment().ToBytesS(), shardID)
+		if err != nil {
+			return nil, false, NewBlockChainError(OutCoinIndexError, err)
+		}
+		if !ok {
+			continue
+		}
+		if decryptedOut := DecryptOutputCoinByKey(transactionStateDB, outCoin, spentKeySet, tokenID, shardID); decryptedOut != nil {
+			results = append(results, decryptedOut)
+		}
+	}
+	return results, true, nil
+}
@@ -0,0 +1,322 @@
+package blockchain
+
+import (
+	"io/ioutil"
+	"os"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/multiview"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/stretchr/testify/assert"
+)
+
+type outCoinIndexTestChain struct {
+	blockchain *BlockChain
+	db         incdb.Database
+	stateDB    *statedb.StateDB
+	keySet     *incognitokey.KeySet
+	close      func()
+}
+
+// newOutCoinIndexTestChain returns a chain of shard 0 with a key set of shard 0, blocks are stored from height fromHeight
+func newOutCoinIndexTestChain(t *testing.T, fromHeight uint64) *outCoinIndexTestChain {
+	dir, err := ioutil.TempDir("", "outcoinindex")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := incdb.Open("leveldb", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stateDB, err := statedb.NewWithPrefixTrie(common.EmptyRoot, statedb.NewDatabaseAccessWarper(db))
+	if err != nil {
+		t.Fatal(err)
+	}
+	keySet := new(incognitokey.KeySet)
+	for seed := byte(0); ; seed++ {
+		keySet.GenerateKey([]byte{seed})
+		if common.GetShardIDFromLastByte(keySet.PaymentAddress.Pk[len(keySet.PaymentAddress.Pk)-1]) == 0 {
+			break
+		}
+	}
+	chain := &outCoinIndexTestChain{
+		blockchain: &BlockChain{
+			config:         Config{DataBase: map[int]incdb.Database{0: db}},
+			ShardChain:     []*ShardChain{{multiView: multiview.NewMultiView()}},
+			outCoinIndexer: newOutCoinIndexer(),
+		},
+		db:      db,
+		stateDB: stateDB,
+		keySet:  keySet,
+		close: func() {
+			db.Close()
+			os.RemoveAll(dir)
+		},
+	}
+	if fromHeight > 1 {
+		// blocks before fromHeight are not stored, e.g the node was fast synced
+		chain.setBestBlock(t, chain.newBlock(fromHeight-1, 1, nil))
+	}
+	return chain
+}
+
+// newBlock returns a shard block at height receiving coins of values for the key set from a cross shard tx
+func (chain *outCoinIndexTestChain) newBlock(height uint64, round int, values []uint64) *ShardBlock {
+	block := NewShardBlockWithHeader(ShardHeader{
+		Version:              SHARD_BLOCK_VERSION,
+		Height:               height,
+		Round:                round,
+		Epoch:                1,
+		Timestamp:            int64(height),
+		BeaconHeight:         1,
+		TotalTxsFee:          make(map[common.Hash]uint64),
+		CrossTransactionRoot: common.HashH([]byte("cross transactions")),
+	})
+	if height > 1 {
+		block.ValidationData = "{}"
+		block.Header.PreviousBlockHash = common.HashH(common.Uint64ToBytes(height - 1))
+		block.Header.CommitteeRoot = common.HashH([]byte("committee"))
+	}
+	outputCoins := []privacy.OutputCoin{}
+	for _, value := range values {
+		publicKey, _ := new(privacy.Point).FromBytesS(chain.keySet.PaymentAddress.Pk)
+		coin := new(privacy.Coin).Init()
+		coin.SetPublicKey(publicKey)
+		coin.SetValue(value)
+		coin.SetSNDerivator(privacy.RandomScalar())
+		coin.SetRandomness(privacy.RandomScalar())
+		coin.CommitAll()
+		outputCoins = append(outputCoins, privacy.OutputCoin{CoinDetails: coin})
+	}
+	// coin of another key
+	coin := new(privacy.Coin).Init()
+	coin.SetPublicKey(privacy.RandomPoint())
+	coin.SetValue(1)
+	coin.CommitAll()
+	outputCoins = append(outputCoins, privacy.OutputCoin{CoinDetails: coin})
+	block.Body.CrossTransactions[1] = []CrossTransaction{{BlockHeight: height, OutputCoin: outputCoins}}
+	return block
+}
+
+// storeBlock stores block, its coins are in the transaction state if inBestChain
+func (chain *outCoinIndexTestChain) storeBlock(t *testing.T, block *ShardBlock, inBestChain bool) {
+	if err := rawdbv2.StoreShardBlock(chain.db, 0, block.Header.Height, *block.Hash(), block); err != nil {
+		t.Fatal(err)
+	}
+	if !inBestChain {
+		return
+	}
+	for _, outputCoin := range block.Body.CrossTransactions[1][0].OutputCoin {
+		commitment := outputCoin.CoinDetails.GetCoinCommitment().ToBytesS()
+		if err := statedb.StoreCommitments(chain.stateDB, common.PRVCoinID, outputCoin.CoinDetails.GetPublicKey().ToBytesS(), [][]byte{commitment}, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+	chain.setBestBlock(t, block)
+}
+
+func (chain *outCoinIndexTestChain) setBestBlock(t *testing.T, block *ShardBlock) {
+	chain.blockchain.ShardChain[0].multiView.Reset(&ShardBestState{
+		BestBlock:          block,
+		ShardHeight:        block.Header.Height,
+		transactionStateDB: chain.stateDB,
+	})
+}
+
+// waitForCatchUp waits until the viewing key of the key set is not being caught up
+func (chain *outCoinIndexTestChain) waitForCatchUp(t *testing.T) {
+	indexer := chain.blockchain.outCoinIndexer
+	for start := time.Now(); time.Since(start) < 10*time.Second; time.Sleep(10 * time.Millisecond) {
+		indexer.mtx.Lock()
+		catchingUp := indexer.catchingUp[string(chain.keySet.PaymentAddress.Pk)]
+		indexer.mtx.Unlock()
+		if !catchingUp {
+			return
+		}
+	}
+	t.Fatal("viewing key is not caught up")
+}
+
+// indexedValues returns values of indexed output coins of the key set, sorted
+func (chain *outCoinIndexTestChain) indexedValues(t *testing.T) ([]uint64, bool) {
+	outputCoins, ok, err := chain.blockchain.getIndexedOutputCoins(chain.keySet, 0, &common.PRVCoinID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := []uint64{}
+	for _, outputCoin := range outputCoins {
+		values = append(values, outputCoin.CoinDetails.GetValue())
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	return values, ok
+}
+
+func (chain *outCoinIndexTestChain) indexedHeight(t *testing.T) uint64 {
+	indexes, err := chain.blockchain.GetViewingKeyIndexes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, index := range indexes {
+		if string(index.PublicKey) == string(chain.keySet.PaymentAddress.Pk) {
+			return index.IndexedHeight
+		}
+	}
+	t.Fatal("viewing key is not registered")
+	return 0
+}
+
+func TestOutCoinIndexCatchUp(t *testing.T) {
+	chain := newOutCoinIndexTestChain(t, 1)
+	defer chain.close()
+	chain.storeBlock(t, chain.newBlock(1, 1, []uint64{10}), true)
+	chain.storeBlock(t, chain.newBlock(2, 1, []uint64{20, 21}), true)
+	chain.storeBlock(t, chain.newBlock(3, 1, []uint64{30}), true)
+
+	_, ok := chain.indexedValues(t)
+	assert.False(t, ok, "viewing key is not registered")
+	assert.Nil(t, chain.blockchain.RegisterViewingKey(chain.keySet.ReadonlyKey, 0))
+	chain.waitForCatchUp(t)
+	assert.Equal(t, uint64(3), chain.indexedHeight(t))
+	values, ok := chain.indexedValues(t)
+	assert.True(t, ok)
+	assert.Equal(t, []uint64{10, 20, 21, 30}, values)
+
+	// new blocks are indexed when they are stored
+	block := chain.newBlock(4, 1, []uint64{40})
+	chain.storeBlock(t, block, true)
+	_, ok = chain.indexedValues(t)
+	assert.False(t, ok, "index is behind the best state")
+	chain.blockchain.indexOutputCoinsOfShardBlock(block)
+	assert.Equal(t, uint64(4), chain.indexedHeight(t))
+	values, ok = chain.indexedValues(t)
+	assert.True(t, ok)
+	assert.Equal(t, []uint64{10, 20, 21, 30, 40}, values)
+
+	// a block stored while the key is behind starts catching up the key
+	chain.storeBlock(t, chain.newBlock(5, 1, []uint64{50}), true)
+	block = chain.newBlock(6, 1, []uint64{60})
+	chain.storeBlock(t, block, true)
+	chain.blockchain.indexOutputCoinsOfShardBlock(block)
+	chain.waitForCatchUp(t)
+	assert.Equal(t, uint64(6), chain.indexedHeight(t))
+	values, _ = chain.indexedValues(t)
+	assert.Equal(t, []uint64{10, 20, 21, 30, 40, 50, 60}, values)
+}
+
+func TestOutCoinIndexFilterForkedCoins(t *testing.T) {
+	chain := newOutCoinIndexTestChain(t, 1)
+	defer chain.close()
+	chain.storeBlock(t, chain.newBlock(1, 1, []uint64{10}), true)
+	// two blocks at height 2, coins of the block of the discarded fork are not in the transaction state
+	chain.storeBlock(t, chain.newBlock(2, 1, []uint64{99}), false)
+	chain.storeBlock(t, chain.newBlock(2, 2, []uint64{20}), true)
+
+	assert.Nil(t, chain.blockchain.RegisterViewingKey(chain.keySet.ReadonlyKey, 1))
+	chain.waitForCatchUp(t)
+	assert.Equal(t, uint64(2), chain.indexedHeight(t))
+	outCoinsInBytes, err := rawdbv2.GetIndexedOutputCoins(chain.db, chain.keySet.PaymentAddress.Pk, common.PRVCoinID)
+	assert.Nil(t, err)
+	assert.Len(t, outCoinsInBytes, 3, "coins of both blocks are indexed")
+	values, ok := chain.indexedValues(t)
+	assert.True(t, ok)
+	assert.Equal(t, []uint64{10, 20}, values)
+
+	// index of another receiving key is not used
+	otherKeySet := *chain.keySet
+	otherKeySet.ReadonlyKey.Rk = privacy.RandomScalar().ToBytesS()
+	_, ok, err = chain.blockchain.getIndexedOutputCoins(&otherKeySet, 0, &common.PRVCoinID)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+}
+
+func TestOutCoinIndexRescan(t *testing.T) {
+	chain := newOutCoinIndexTestChain(t, 1)
+	defer chain.close()
+	chain.storeBlock(t, chain.newBlock(1, 1, []uint64{10}), true)
+	chain.storeBlock(t, chain.newBlock(2, 1, []uint64{20}), true)
+	chain.storeBlock(t, chain.newBlock(3, 1, []uint64{30}), true)
+
+	assert.Nil(t, chain.blockchain.RegisterViewingKey(chain.keySet.ReadonlyKey, 3))
+	chain.waitForCatchUp(t)
+	values, _ := chain.indexedValues(t)
+	assert.Equal(t, []uint64{30}, values)
+
+	assert.NotNil(t, chain.blockchain.RescanViewingKey([]byte{1, 2, 3}, 1), "viewing key is not registered")
+	assert.Nil(t, chain.blockchain.RescanViewingKey(chain.keySet.PaymentAddress.Pk, 2))
+	chain.waitForCatchUp(t)
+	indexes, err := chain.blockchain.GetViewingKeyIndexes()
+	assert.Nil(t, err)
+	assert.Len(t, indexes, 1)
+	assert.Equal(t, uint64(2), indexes[0].FromHeight)
+	assert.Equal(t, uint64(3), indexes[0].IndexedHeight)
+	values, ok := chain.indexedValues(t)
+	assert.True(t, ok)
+	assert.Equal(t, []uint64{20, 30}, values)
+
+	// rescan from a later height keeps the indexed range
+	assert.Nil(t, chain.blockchain.RescanViewingKey(chain.keySet.PaymentAddress.Pk, 3))
+	chain.waitForCatchUp(t)
+	indexes, _ = chain.blockchain.GetViewingKeyIndexes()
+	assert.Equal(t, uint64(2), indexes[0].FromHeight)
+	values, _ = chain.indexedValues(t)
+	assert.Equal(t, []uint64{20, 30}, values)
+
+	assert.Nil(t, chain.blockchain.RescanViewingKey(chain.keySet.PaymentAddress.Pk, 0))
+	chain.waitForCatchUp(t)
+	values, _ = chain.indexedValues(t)
+	assert.Equal(t, []uint64{10, 20, 30}, values)
+}
+
+func TestOutCoinIndexRegisterViewingKey(t *testing.T) {
+	chain := newOutCoinIndexTestChain(t, 3)
+	defer chain.close()
+	chain.storeBlock(t, chain.newBlock(3, 1, []uint64{30}), true)
+	chain.storeBlock(t, chain.newBlock(4, 1, []uint64{40}), true)
+
+	assert.NotNil(t, chain.blockchain.RegisterViewingKey(privacy.ViewingKey{Pk: chain.keySet.PaymentAddress.Pk}, 3), "viewing key is invalid")
+	assert.NotNil(t, chain.blockchain.RegisterViewingKey(chain.keySet.ReadonlyKey, 2), "block at height 2 is not stored")
+	assert.Nil(t, chain.blockchain.RegisterViewingKey(chain.keySet.ReadonlyKey, 3))
+	assert.NotNil(t, chain.blockchain.RegisterViewingKey(chain.keySet.ReadonlyKey, 3), "viewing key is already registered")
+	chain.waitForCatchUp(t)
+	assert.NotNil(t, chain.blockchain.RescanViewingKey(chain.keySet.PaymentAddress.Pk, 1), "block at height 1 is not stored")
+	values, _ := chain.indexedValues(t)
+	assert.Equal(t, []uint64{30, 40}, values)
+	indexes, err := rawdbv2.GetViewingKeyIndexes(chain.db)
+	assert.Nil(t, err)
+	assert.Len(t, indexes, 1)
+	assert.Equal(t, uint64(4), indexes[0].IndexedHeight)
+
+	assert.NotNil(t, chain.blockchain.UnregisterViewingKey([]byte{1, 2, 3}), "viewing key is not registered")
+	assert.Nil(t, chain.blockchain.UnregisterViewingKey(chain.keySet.PaymentAddress.Pk))
+	indexes, err = chain.blockchain.GetViewingKeyIndexes()
+	assert.Nil(t, err)
+	assert.Empty(t, indexes)
+	indexes, err = rawdbv2.GetViewingKeyIndexes(chain.db)
+	assert.Nil(t, err)
+	assert.Empty(t, indexes)
+	_, ok := chain.indexedValues(t)
+	assert.False(t, ok)
+
+	// the key can be registered again
+	assert.Nil(t, chain.blockchain.RegisterViewingKey(chain.keySet.ReadonlyKey, 4))
+	chain.waitForCatchUp(t)
+	assert.Equal(t, uint64(4), chain.indexedHeight(t))
+
+	// output coin index is not enabled
+	chain.blockchain.outCoinIndexer = nil
+	assert.NotNil(t, chain.blockchain.RegisterViewingKey(chain.keySet.ReadonlyKey, 1))
+	assert.NotNil(t, chain.blockchain.UnregisterViewingKey(chain.keySet.PaymentAddress.Pk))
+	assert.NotNil(t, chain.blockchain.RescanViewingKey(chain.keySet.PaymentAddress.Pk, 1))
+	_, err = chain.blockchain.GetViewingKeyIndexes()
+	assert.NotNil(t, err)
+	_, ok = chain.indexedValues(t)
+	assert.False(t, ok)
+}
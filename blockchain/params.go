@@ -23,6 +23,16 @@ type PortalParams struct {
 	TP130                                uint64
 	MinPercentPortingFee                 float64
 	MinPercentRedeemFee                  float64
+	// rates of all feeders are aggregated over a window of beacon blocks, the final rate of a token is their median,
+	// 0 keeps the median of the rates submitted in each beacon block
+	ExchangeRatesAggregationWindow uint64
+	// submissions deviating more than this percent from the previous final rate are rejected, 0 disables the check
+	MaxPercentExchangeRatesDeviation uint64
+}
+
+type PDEParams struct {
+	PoolFeeRate  uint64            // default fee of pde pools, in basis points of the sell amount
+	PoolFeeRates map[string]uint64 // fee of specific pde pools, keyed by token ids of the pair in ascending order joined by "-"
 }
 
 /*
@@ -51,6 +61,7 @@ type Params struct {
 	Epoch                            uint64
 	RandomTime                       uint64
 	SlashLevels                      []SlashLevel
+	EquivocationPunishedEpoches      uint8  // epochs in producers black list of a committee member proven to sign conflicting blocks
 	EthContractAddressStr            string // smart contract of ETH for bridge
 	Offset                           int    // default offset for swap policy, is used for cases that good producers length is less than max committee size
 	SwapOffset                       int    // is used for case that good producers length is equal to max committee size
@@ -60,14 +71,19 @@ type Params struct {
 	ChainVersion                     string
 	AssignOffset                     int
 	BeaconHeightBreakPointBurnAddr   uint64
+	BeaconHeightBreakPointStateRoot  uint64 // from this height, blocks of consensus v2 commit state roots in header
+	BeaconHeightBreakPointSlashStake uint64 // from this height, stake of equivocation offenders is not returned
+	BeaconHeightBreakPointETHRelay   uint64 // from this height, eth headers are relayed on-chain and eth deposits are verified against them
 	BNBRelayingHeaderChainID         string
 	BTCRelayingHeaderChainID         string
+	ETHRelayingHeaderChainID         string
 	BNBFullNodeProtocol              string
 	BNBFullNodeHost                  string
 	BNBFullNodePort                  string
 	PortalParams                     map[uint64]PortalParams
-	PortalFeederAddress              string
+	PortalFeederAddresses            []string
 	EpochBreakPointSwapNewKey        []uint64
+	PDEParams                        map[uint64]PDEParams // from the first of these heights, pde trades pay a fee to liquidity providers of the pools
 }
 
 type GenesisParams struct {
@@ -141,15 +157,17 @@ func init() {
 			SlashLevel{MinRange: 50, PunishedEpoches: 2},
 			SlashLevel{MinRange: 75, PunishedEpoches: 3},
 		},
+		EquivocationPunishedEpoches:    10,
 		CheckForce:                     false,
 		ChainVersion:                   "version-chain-test.json",
 		BeaconHeightBreakPointBurnAddr: 250000,
 		BNBRelayingHeaderChainID:       TestnetBNBChainID,
 		BTCRelayingHeaderChainID:       TestnetBTCChainID,
+		ETHRelayingHeaderChainID:       TestnetETHChainID,
 		BNBFullNodeProtocol:            TestnetBNBFullNodeProtocol,
 		BNBFullNodeHost:                TestnetBNBFullNodeHost,
 		BNBFullNodePort:                TestnetBNBFullNodePort,
-		PortalFeederAddress:            TestnetPortalFeeder,
+		PortalFeederAddresses:          []string{TestnetPortalFeeder},
 		PortalParams: map[uint64]PortalParams{
 			0: {
 				TimeOutCustodianReturnPubToken:       1 * time.Hour,
@@ -165,8 +183,29 @@ func init() {
 				MinPercentPortingFee:                 0.01,
 				MinPercentRedeemFee:                  0.01,
 			},
+			TestnetBeaconHeightBreakPointExchangeRatesWindow: {
+				TimeOutCustodianReturnPubToken:       1 * time.Hour,
+				TimeOutWaitingPortingRequest:         1 * time.Hour,
+				TimeOutWaitingRedeemRequest:          10 * time.Minute,
+				MaxPercentLiquidatedCollateralAmount: 105,
+				MaxPercentCustodianRewards:           10,
+				MinPercentCustodianRewards:           1,
+				MinLockCollateralAmountInEpoch:       5000 * 1e9, // 5000 prv
+				MinPercentLockedCollateral:           150,
+				TP120:                                120,
+				TP130:                                130,
+				MinPercentPortingFee:                 0.01,
+				MinPercentRedeemFee:                  0.01,
+				ExchangeRatesAggregationWindow:       1,
+				MaxPercentExchangeRatesDeviation:     50,
+			},
 		},
 		EpochBreakPointSwapNewKey: TestnetReplaceCommitteeEpoch,
+		PDEParams:                 map[uint64]PDEParams{}, // not activated yet
+
+		BeaconHeightBreakPointStateRoot:  TestnetBeaconHeightBreakPointStateRoot,
+		BeaconHeightBreakPointSlashStake: TestnetBeaconHeightBreakPointSlashStake,
+		BeaconHeightBreakPointETHRelay:   TestnetBeaconHeightBreakPointETHRelay,
 	}
 	// END TESTNET
 	// FOR MAINNET
@@ -214,15 +253,17 @@ func init() {
 			SlashLevel{MinRange: 50, PunishedEpoches: 2},
 			SlashLevel{MinRange: 75, PunishedEpoches: 3},
 		},
+		EquivocationPunishedEpoches:    10,
 		CheckForce:                     false,
 		ChainVersion:                   "version-chain-main.json",
 		BeaconHeightBreakPointBurnAddr: 150500,
 		BNBRelayingHeaderChainID:       MainnetBNBChainID,
 		BTCRelayingHeaderChainID:       MainnetBTCChainID,
+		ETHRelayingHeaderChainID:       MainnetETHChainID,
 		BNBFullNodeProtocol:            MainnetBNBFullNodeProtocol,
 		BNBFullNodeHost:                MainnetBNBFullNodeHost,
 		BNBFullNodePort:                MainnetBNBFullNodePort,
-		PortalFeederAddress:            MainnetPortalFeeder,
+		PortalFeederAddresses:          []string{MainnetPortalFeeder},
 		PortalParams: map[uint64]PortalParams{
 			0: {
 				TimeOutCustodianReturnPubToken:       24 * time.Hour,
@@ -238,8 +279,29 @@ func init() {
 				MinPercentPortingFee:                 0.01,
 				MinPercentRedeemFee:                  0.01,
 			},
+			MainnetBeaconHeightBreakPointExchangeRatesWindow: {
+				TimeOutCustodianReturnPubToken:       24 * time.Hour,
+				TimeOutWaitingPortingRequest:         24 * time.Hour,
+				TimeOutWaitingRedeemRequest:          15 * time.Minute,
+				MaxPercentLiquidatedCollateralAmount: 120,
+				MaxPercentCustodianRewards:           20,
+				MinPercentCustodianRewards:           1,
+				MinPercentLockedCollateral:           200,
+				MinLockCollateralAmountInEpoch:       17500 * 1e9, // 17500 prv
+				TP120:                                120,
+				TP130:                                130,
+				MinPercentPortingFee:                 0.01,
+				MinPercentRedeemFee:                  0.01,
+				ExchangeRatesAggregationWindow:       5,
+				MaxPercentExchangeRatesDeviation:     30,
+			},
 		},
 		EpochBreakPointSwapNewKey: MainnetReplaceCommitteeEpoch,
+		PDEParams:                 map[uint64]PDEParams{}, // not activated yet
+
+		BeaconHeightBreakPointStateRoot:  MainnetBeaconHeightBreakPointStateRoot,
+		BeaconHeightBreakPointSlashStake: MainnetBeaconHeightBreakPointSlashStake,
+		BeaconHeightBreakPointETHRelay:   MainnetBeaconHeightBreakPointETHRelay,
 	}
 	if IsTestNet {
 		GenesisParam = genesisParamsTestnetNew
@@ -0,0 +1,246 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ScheduledParams is a set of protocol parameters activated at a beacon height,
+// it applies until the next set of the same kind is activated. A set changes portal params, pde params or both.
+type ScheduledParams struct {
+	BeaconHeight uint64
+	PortalParams *PortalParams `json:",omitempty"`
+	PDEParams    *PDEParams    `json:",omitempty"`
+}
+
+// ParamsScheduleFile is the JSON document of the schedule of a network,
+// all nodes of a network must load the same schedule or they will not agree on beacon blocks
+type ParamsScheduleFile struct {
+	Network  string
+	Schedule []ScheduledParams
+}
+
+// networkParamsSchedules are the schedules of mainnet and testnet compiled into the binary,
+// so that every node of a network applies the same parameter sets
+var networkParamsSchedules = map[string]string{
+	MainetName: `{
+  "Network": "mainnet",
+  "Schedule": [
+    {
+      "BeaconHeight": 750000,
+      "PDEParams": {
+        "PoolFeeRate": 30,
+        "PoolFeeRates": {}
+      }
+    }
+  ]
+}`,
+	TestnetName: `{
+  "Network": "testnet",
+  "Schedule": [
+    {
+      "BeaconHeight": 1500000,
+      "PDEParams": {
+        "PoolFeeRate": 30,
+        "PoolFeeRates": {}
+      }
+    }
+  ]
+}`,
+}
+
+// LoadNetworkParamsSchedule adds the schedule compiled in for the network of params to its parameter sets,
+// it is always run at startup. Networks without a schedule only validate their compiled-in parameter sets
+func LoadNetworkParamsSchedule(params *Params) error {
+	data, ok := networkParamsSchedules[params.Name]
+	if !ok {
+		return params.ValidateParamsSchedule()
+	}
+	return loadParamsSchedule([]byte(data), params)
+}
+
+func loadParamsSchedule(data []byte, params *Params) error {
+	var scheduleFile ParamsScheduleFile
+	err := json.Unmarshal(data, &scheduleFile)
+	if err != nil {
+		return fmt.Errorf("can not parse params schedule: %v", err)
+	}
+	if scheduleFile.Network != params.Name {
+		return fmt.Errorf("params schedule is for network %v, node runs on %v", scheduleFile.Network, params.Name)
+	}
+	return params.ApplyParamsSchedule(scheduleFile.Schedule)
+}
+
+// ApplyParamsSchedule adds parameter sets to the compiled-in ones, a set replaces the compiled-in one at the same height
+func (params *Params) ApplyParamsSchedule(schedule []ScheduledParams) error {
+	for i, scheduledParams := range schedule {
+		if i > 0 && scheduledParams.BeaconHeight <= schedule[i-1].BeaconHeight {
+			return fmt.Errorf("params schedule must be ordered by beacon height, %v follows %v", scheduledParams.BeaconHeight, schedule[i-1].BeaconHeight)
+		}
+		if scheduledParams.PortalParams == nil && scheduledParams.PDEParams == nil {
+			return fmt.Errorf("params set at beacon height %v is empty", scheduledParams.BeaconHeight)
+		}
+		if scheduledParams.PortalParams != nil {
+			if params.PortalParams == nil {
+				params.PortalParams = make(map[uint64]PortalParams)
+			}
+			params.PortalParams[scheduledParams.BeaconHeight] = *scheduledParams.PortalParams
+		}
+		if scheduledParams.PDEParams != nil {
+			if params.PDEParams == nil {
+				params.PDEParams = make(map[uint64]PDEParams)
+			}
+			params.PDEParams[scheduledParams.BeaconHeight] = *scheduledParams.PDEParams
+		}
+	}
+	return params.ValidateParamsSchedule()
+}
+
+// ValidateParamsSchedule checks the parameter sets of every height, it is run at startup
+func (params *Params) ValidateParamsSchedule() error {
+	if _, ok := params.PortalParams[0]; !ok {
+		return errors.New("portal params must be set from beacon height 0")
+	}
+	for beaconHeight, portalParams := range params.PortalParams {
+		err := portalParams.validate()
+		if err != nil {
+			return fmt.Errorf("invalid portal params at beacon height %v: %v", beaconHeight, err)
+		}
+	}
+	for beaconHeight, pdeParams := range params.PDEParams {
+		err := pdeParams.validate()
+		if err != nil {
+			return fmt.Errorf("invalid pde params at beacon height %v: %v", beaconHeight, err)
+		}
+	}
+	return nil
+}
+
+// GetParamsSchedule lists the parameter sets by activation height
+func (params *Params) GetParamsSchedule() []ScheduledParams {
+	scheduleByHeight := make(map[uint64]*ScheduledParams)
+	getScheduledParams := func(beaconHeight uint64) *ScheduledParams {
+		if _, ok := scheduleByHeight[beaconHeight]; !ok {
+			scheduleByHeight[beaconHeight] = &ScheduledParams{BeaconHeight: beaconHeight}
+		}
+		return scheduleByHeight[beaconHeight]
+	}
+	for beaconHeight, portalParams := range params.PortalParams {
+		portalParams := portalParams
+		getScheduledParams(beaconHeight).PortalParams = &portalParams
+	}
+	for beaconHeight, pdeParams := range params.PDEParams {
+		pdeParams := pdeParams
+		getScheduledParams(beaconHeight).PDEParams = &pdeParams
+	}
+
+	schedule := make([]ScheduledParams, 0, len(scheduleByHeight))
+	for _, scheduledParams := range scheduleByHeight {
+		schedule = append(schedule, *scheduledParams)
+	}
+	sort.Slice(schedule, func(i, j int) bool {
+		return schedule[i].BeaconHeight < schedule[j].BeaconHeight
+	})
+	return schedule
+}
+
+// getActiveParamsHeight returns the activation height of the parameter set applying at beaconHeight,
+// false if no set is activated yet
+func getActiveParamsHeight(activationHeights []uint64, beaconHeight uint64) (uint64, bool) {
+	sort.Slice(activationHeights, func(i, j int) bool {
+		return activationHeights[i] < activationHeights[j]
+	})
+	for i := len(activationHeights) - 1; i >= 0; i-- {
+		if activationHeights[i] <= beaconHeight {
+			return activationHeights[i], true
+		}
+	}
+	return 0, false
+}
+
+func (portalParams PortalParams) validate() error {
+	if portalParams.TimeOutCustodianReturnPubToken <= 0 || portalParams.TimeOutWaitingPortingRequest <= 0 || portalParams.TimeOutWaitingRedeemRequest <= 0 {
+		return errors.New("timeouts must be positive")
+	}
+	if portalParams.TP120 <= 100 || portalParams.TP130 <= portalParams.TP120 {
+		return fmt.Errorf("TP120 %v and TP130 %v must be ascending and above 100", portalParams.TP120, portalParams.TP130)
+	}
+	if portalParams.MinPercentLockedCollateral <= portalParams.TP130 {
+		return fmt.Errorf("MinPercentLockedCollateral %v must be above TP130 %v", portalParams.MinPercentLockedCollateral, portalParams.TP130)
+	}
+	if portalParams.MaxPercentLiquidatedCollateralAmount < 100 {
+		return fmt.Errorf("MaxPercentLiquidatedCollateralAmount %v must be at least 100", portalParams.MaxPercentLiquidatedCollateralAmount)
+	}
+	if portalParams.MinPercentCustodianRewards > portalParams.MaxPercentCustodianRewards || portalParams.MaxPercentCustodianRewards > 100 {
+		return fmt.Errorf("custodian rewards from %v to %v percent are invalid", portalParams.MinPercentCustodianRewards, portalParams.MaxPercentCustodianRewards)
+	}
+	if portalParams.MinPercentPortingFee < 0 || portalParams.MinPercentRedeemFee < 0 {
+		return errors.New("fee percents must not be negative")
+	}
+	return nil
+}
+
+func (pdeParams PDEParams) validate() error {
+	if pdeParams.PoolFeeRate > 10000 {
+		return fmt.Errorf("pool fee rate %v is above 10000 basis points", pdeParams.PoolFeeRate)
+	}
+	for pairKey, feeRate := range pdeParams.PoolFeeRates {
+		tokenIDStrs := strings.Split(pairKey, "-")
+		if len(tokenIDStrs) != 2 || tokenIDStrs[0] >= tokenIDStrs[1] {
+			return fmt.Errorf("pool %v must be keyed by token ids in ascending order joined by \"-\"", pairKey)
+		}
+		if feeRate > 10000 {
+			return fmt.Errorf("fee rate %v of pool %v is above 10000 basis points", feeRate, pairKey)
+		}
+	}
+	return nil
+}
+
+type portalParamsAlias PortalParams
+
+// MarshalJSON writes timeouts of portal params as durations, e.g. "1h30m"
+func (portalParams PortalParams) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		portalParamsAlias
+		TimeOutCustodianReturnPubToken string
+		TimeOutWaitingPortingRequest   string
+		TimeOutWaitingRedeemRequest    string
+	}{
+		portalParamsAlias:              portalParamsAlias(portalParams),
+		TimeOutCustodianReturnPubToken: portalParams.TimeOutCustodianReturnPubToken.String(),
+		TimeOutWaitingPortingRequest:   portalParams.TimeOutWaitingPortingRequest.String(),
+		TimeOutWaitingRedeemRequest:    portalParams.TimeOutWaitingRedeemRequest.String(),
+	})
+}
+
+func (portalParams *PortalParams) UnmarshalJSON(data []byte) error {
+	temp := struct {
+		*portalParamsAlias
+		TimeOutCustodianReturnPubToken string
+		TimeOutWaitingPortingRequest   string
+		TimeOutWaitingRedeemRequest    string
+	}{
+		portalParamsAlias: (*portalParamsAlias)(portalParams),
+	}
+	err := json.Unmarshal(data, &temp)
+	if err != nil {
+		return err
+	}
+	portalParams.TimeOutCustodianReturnPubToken, err = time.ParseDuration(temp.TimeOutCustodianReturnPubToken)
+	if err != nil {
+		return fmt.Errorf("TimeOutCustodianReturnPubToken: %v", err)
+	}
+	portalParams.TimeOutWaitingPortingRequest, err = time.ParseDuration(temp.TimeOutWaitingPortingRequest)
+	if err != nil {
+		return fmt.Errorf("TimeOutWaitingPortingRequest: %v", err)
+	}
+	portalParams.TimeOutWaitingRedeemRequest, err = time.ParseDuration(temp.TimeOutWaitingRedeemRequest)
+	if err != nil {
+		return fmt.Errorf("TimeOutWaitingRedeemRequest: %v", err)
+	}
+	return nil
+}
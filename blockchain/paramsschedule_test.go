@@ -0,0 +1,130 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestScheduleParams() *Params {
+	return &Params{
+		Name: "testnet",
+		PortalParams: map[uint64]PortalParams{
+			0: {
+				TimeOutCustodianReturnPubToken:       1 * time.Hour,
+				TimeOutWaitingPortingRequest:         1 * time.Hour,
+				TimeOutWaitingRedeemRequest:          10 * time.Minute,
+				MaxPercentLiquidatedCollateralAmount: 105,
+				MaxPercentCustodianRewards:           10,
+				MinPercentCustodianRewards:           1,
+				MinPercentLockedCollateral:           150,
+				TP120:                                120,
+				TP130:                                130,
+			},
+		},
+		PDEParams: map[uint64]PDEParams{},
+	}
+}
+
+func TestLoadParamsSchedule(t *testing.T) {
+	scheduleJSON := `{
+		"Network": "testnet",
+		"Schedule": [
+			{"BeaconHeight": 100, "PDEParams": {"PoolFeeRate": 30, "PoolFeeRates": {"a-b": 10}}},
+			{"BeaconHeight": 200, "PortalParams": {
+				"TimeOutCustodianReturnPubToken": "2h", "TimeOutWaitingPortingRequest": "1h", "TimeOutWaitingRedeemRequest": "15m",
+				"MaxPercentLiquidatedCollateralAmount": 120, "MaxPercentCustodianRewards": 20, "MinPercentCustodianRewards": 1,
+				"MinPercentLockedCollateral": 200, "TP120": 120, "TP130": 130, "ExchangeRatesAggregationWindow": 5
+			}}
+		]
+	}`
+	params := newTestScheduleParams()
+	assert.Nil(t, loadParamsSchedule([]byte(scheduleJSON), params))
+	bc := &BlockChain{config: Config{ChainParams: params}}
+
+	assert.Equal(t, 1*time.Hour, bc.GetPortalParams(199).TimeOutCustodianReturnPubToken)
+	assert.Equal(t, 2*time.Hour, bc.GetPortalParams(200).TimeOutCustodianReturnPubToken)
+	assert.Equal(t, uint64(5), bc.GetPortalParams(1000).ExchangeRatesAggregationWindow)
+
+	assert.Equal(t, uint64(0), bc.getPDEPoolFeeRate(99, "a", "b"))
+	assert.Equal(t, uint64(10), bc.getPDEPoolFeeRate(100, "b", "a"))
+	assert.Equal(t, uint64(30), bc.getPDEPoolFeeRate(100, "a", "c"))
+
+	schedule := params.GetParamsSchedule()
+	assert.Equal(t, 3, len(schedule))
+	assert.Equal(t, uint64(0), schedule[0].BeaconHeight)
+	assert.Nil(t, schedule[1].PortalParams)
+	assert.Equal(t, uint64(200), schedule[2].BeaconHeight)
+
+	// schedule of another network
+	params = newTestScheduleParams()
+	params.Name = "mainnet"
+	assert.NotNil(t, loadParamsSchedule([]byte(scheduleJSON), params))
+}
+
+func TestApplyInvalidParamsSchedule(t *testing.T) {
+	validPortalParams := newTestScheduleParams().PortalParams[0]
+	invalidTP := validPortalParams
+	invalidTP.TP120 = 140
+	noTimeOut := validPortalParams
+	noTimeOut.TimeOutWaitingRedeemRequest = 0
+
+	tests := []struct {
+		name     string
+		schedule []ScheduledParams
+	}{
+		{name: "not ordered", schedule: []ScheduledParams{
+			{BeaconHeight: 200, PDEParams: &PDEParams{}},
+			{BeaconHeight: 100, PDEParams: &PDEParams{}},
+		}},
+		{name: "empty set", schedule: []ScheduledParams{{BeaconHeight: 100}}},
+		{name: "TP120 above TP130", schedule: []ScheduledParams{{BeaconHeight: 100, PortalParams: &invalidTP}}},
+		{name: "zero timeout", schedule: []ScheduledParams{{BeaconHeight: 100, PortalParams: &noTimeOut}}},
+		{name: "fee above 100%", schedule: []ScheduledParams{{BeaconHeight: 100, PDEParams: &PDEParams{PoolFeeRate: 10001}}}},
+		{name: "unordered pool key", schedule: []ScheduledParams{{BeaconHeight: 100, PDEParams: &PDEParams{PoolFeeRates: map[string]uint64{"b-a": 1}}}}},
+	}
+	for _, tc := range tests {
+		params := newTestScheduleParams()
+		assert.NotNil(t, params.ApplyParamsSchedule(tc.schedule), tc.name)
+	}
+
+	params := newTestScheduleParams()
+	delete(params.PortalParams, 0)
+	assert.NotNil(t, params.ValidateParamsSchedule())
+
+	assert.Nil(t, ChainTestParam.ValidateParamsSchedule())
+	assert.Nil(t, ChainMainParam.ValidateParamsSchedule())
+}
+
+// TestNetworkParamsSchedules loads the schedules of mainnet and testnet compiled into the binary
+func TestNetworkParamsSchedules(t *testing.T) {
+	for _, chainParams := range []Params{ChainMainParam, ChainTestParam} {
+		params := chainParams
+		params.PortalParams = make(map[uint64]PortalParams)
+		for beaconHeight, portalParams := range chainParams.PortalParams {
+			params.PortalParams[beaconHeight] = portalParams
+		}
+		params.PDEParams = make(map[uint64]PDEParams)
+		assert.Nil(t, LoadNetworkParamsSchedule(&params), params.Name)
+		assert.NotEmpty(t, params.PDEParams, params.Name)
+	}
+
+	// networks without a schedule only validate their params
+	params := newTestScheduleParams()
+	params.Name = "local"
+	assert.Nil(t, LoadNetworkParamsSchedule(params))
+	assert.Empty(t, params.PDEParams)
+}
+
+func TestPortalParamsJSON(t *testing.T) {
+	portalParams := newTestScheduleParams().PortalParams[0]
+	data, err := json.Marshal(portalParams)
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), `"TimeOutWaitingRedeemRequest":"10m0s"`)
+
+	var parsedPortalParams PortalParams
+	assert.Nil(t, json.Unmarshal(data, &parsedPortalParams))
+	assert.Equal(t, portalParams, parsedPortalParams)
+}
@@ -0,0 +1,430 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+// PDECandle is the OHLC price of Token1 in Token2 and the traded volume of a pair over a range of beacon heights
+type PDECandle struct {
+	FromHeight   uint64
+	ToHeight     uint64
+	Open         float64
+	High         float64
+	Low          float64
+	Close        float64
+	Token1Volume uint64
+	Token2Volume uint64
+	Trades       uint64
+}
+
+// PDEPairVolume is the traded volume of a pair over a range of beacon heights
+type PDEPairVolume struct {
+	Token1IDStr  string
+	Token2IDStr  string
+	Token1Volume uint64
+	Token2Volume uint64
+	Trades       uint64
+}
+
+// pdeHistoryRecorder collects history events of the pde instructions of a beacon block,
+// events are stored along with the block when the pde history index is enabled
+type pdeHistoryRecorder struct {
+	beaconHeight uint64
+	events       []rawdbv2.PDEHistoryEvent
+}
+
+func newPDEHistoryRecorder(beaconHeight uint64) *pdeHistoryRecorder {
+	return &pdeHistoryRecorder{beaconHeight: beaconHeight}
+}
+
+// begin builds the event of a pde instruction and its pool reserves before the instruction is processed,
+// beaconHeight is the height used to build pde state keys (i.e previous beacon height), return nil if instruction is not applied
+func (r *pdeHistoryRecorder) begin(inst []string, beaconHeight uint64, currentPDEState *CurrentPDEState) *rawdbv2.PDEHistoryEvent {
+	if len(inst) != 4 || currentPDEState == nil {
+		return nil
+	}
+	event := &rawdbv2.PDEHistoryEvent{
+		Status:       inst[2],
+		BeaconHeight: r.beaconHeight,
+	}
+	var err error
+	switch inst[0] {
+	case strconv.Itoa(metadata.PDETradeRequestMeta):
+		event.Type = rawdbv2.PDEHistoryTradeEvent
+		err = buildPDETradeHistoryEvent(event, inst[3])
+	case strconv.Itoa(metadata.PDECrossPoolTradeRequestMeta):
+		event.Type = rawdbv2.PDEHistoryTradeEvent
+		if event.Status == common.PDETradeAcceptedChainStatus {
+			// accepted cross pool trades are recorded as one trade event for each hop
+			r.recordPDECrossPoolTradeHops(event, inst[3], beaconHeight, currentPDEState)
+			return nil
+		}
+		err = buildPDECrossPoolTradeRefundHistoryEvent(event, inst[3])
+	case strconv.Itoa(metadata.PDELimitOrderRequestMeta):
+		if event.Status != common.PDELimitOrderFilledChainStatus {
+			return nil // orders only change pool pairs when they are filled
+		}
+		// a filled order is a trade accepted against the pool pair
+		event.Type = rawdbv2.PDEHistoryTradeEvent
+		event.Status = common.PDETradeAcceptedChainStatus
+		err = buildPDETradeHistoryEvent(event, inst[3])
+	case strconv.Itoa(metadata.PDEContributionMeta):
+		event.Type = rawdbv2.PDEHistoryContributionEvent
+		err = buildPDEContributionHistoryEvent(event, inst[3], beaconHeight, currentPDEState)
+	case strconv.Itoa(metadata.PDEWithdrawalRequestMeta):
+		event.Type = rawdbv2.PDEHistoryWithdrawalEvent
+		err = buildPDEWithdrawalHistoryEvent(event, inst[3])
+	default:
+		return nil
+	}
+	if err != nil {
+		Logger.log.Warnf("WARNING: could not build pde history event of instruction %+v: %+v", inst[0], err)
+		return nil
+	}
+	if event.Token1IDStr != "" {
+		pool, found := currentPDEState.PDEPoolPairs[string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, event.Token1IDStr, event.Token2IDStr))]
+		if found && pool != nil {
+			event.Token1PoolValueBefore = pool.Token1PoolValue
+			event.Token2PoolValueBefore = pool.Token2PoolValue
+		} else if event.Status == common.PDETradeAcceptedChainStatus || event.Status == common.PDEWithdrawalAcceptedChainStatus {
+			return nil // skipped by beacon as well
+		}
+	}
+	return event
+}
+
+// finish records the event with its pool reserves after the instruction is processed
+func (r *pdeHistoryRecorder) finish(event *rawdbv2.PDEHistoryEvent, beaconHeight uint64, currentPDEState *CurrentPDEState) {
+	if event.Token1IDStr != "" {
+		pool, found := currentPDEState.PDEPoolPairs[string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, event.Token1IDStr, event.Token2IDStr))]
+		if found && pool != nil {
+			event.Token1PoolValueAfter = pool.Token1PoolValue
+			event.Token2PoolValueAfter = pool.Token2PoolValue
+		}
+	}
+	r.events = append(r.events, *event)
+}
+
+// recordPDECrossPoolTradeHops records the hops of an accepted cross pool trade, pool reserves are simulated the same way
+// beacon applies the hops since a route can go through a pool pair more than once
+func (r *pdeHistoryRecorder) recordPDECrossPoolTradeHops(event *rawdbv2.PDEHistoryEvent, content string, beaconHeight uint64, currentPDEState *CurrentPDEState) {
+	var accepted metadata.PDECrossPoolTradeAcceptedContent
+	if err := json.Unmarshal([]byte(content), &accepted); err != nil {
+		Logger.log.Warnf("WARNING: could not build pde history events of cross pool trade: %+v", err)
+		return
+	}
+	pools := make(map[string]rawdbv2.PDEPoolForPair)
+	for _, hop := range accepted.Hops {
+		poolKey := string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, hop.Token1IDStr, hop.Token2IDStr))
+		pool, found := currentPDEState.PDEPoolPairs[poolKey]
+		if !found || pool == nil {
+			return // skipped by beacon as well
+		}
+		pools[poolKey] = *pool
+	}
+	for _, hop := range accepted.Hops {
+		hopEvent := *event
+		hopEvent.TxReqID = accepted.RequestedTxID
+		hopEvent.AddressStr = accepted.TraderAddressStr
+		poolKey := string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, hop.Token1IDStr, hop.Token2IDStr))
+		pool := pools[poolKey]
+		hopEvent.Token1PoolValueBefore, hopEvent.Token2PoolValueBefore = pool.Token1PoolValue, pool.Token2PoolValue
+		if hop.Token1PoolValueOperation.Operator == "+" {
+			hopEvent.TokenIDStr, hopEvent.Amount = hop.Token1IDStr, hop.Token1PoolValueOperation.Value
+			hopEvent.ReceiveTokenIDStr, hopEvent.ReceiveAmount = hop.Token2IDStr, hop.Token2PoolValueOperation.Value
+			pool.Token1PoolValue += hop.Token1PoolValueOperation.Value
+			pool.Token2PoolValue -= hop.Token2PoolValueOperation.Value
+		} else {
+			hopEvent.TokenIDStr, hopEvent.Amount = hop.Token2IDStr, hop.Token2PoolValueOperation.Value
+			hopEvent.ReceiveTokenIDStr, hopEvent.ReceiveAmount = hop.Token1IDStr, hop.Token1PoolValueOperation.Value
+			pool.Token1PoolValue -= hop.Token1PoolValueOperation.Value
+			pool.Token2PoolValue += hop.Token2PoolValueOperation.Value
+		}
+		pools[poolKey] = pool
+		hopEvent.Token1PoolValueAfter, hopEvent.Token2PoolValueAfter = pool.Token1PoolValue, pool.Token2PoolValue
+		setPDEHistoryEventPair(&hopEvent, hop.Token1IDStr, hop.Token2IDStr)
+		r.events = append(r.events, hopEvent)
+	}
+}
+
+func setPDEHistoryEventPair(event *rawdbv2.PDEHistoryEvent, token1IDStr string, token2IDStr string) {
+	if token1IDStr == "" || token2IDStr == "" || token1IDStr == token2IDStr {
+		return
+	}
+	tokenIDStrs := []string{token1IDStr, token2IDStr}
+	sort.Strings(tokenIDStrs)
+	event.Token1IDStr, event.Token2IDStr = tokenIDStrs[0], tokenIDStrs[1]
+}
+
+func buildPDETradeHistoryEvent(event *rawdbv2.PDEHistoryEvent, content string) error {
+	if event.Status == common.PDETradeRefundChainStatus {
+		contentBytes, err := base64.StdEncoding.DecodeString(content)
+		if err != nil {
+			return err
+		}
+		var action metadata.PDETradeRequestAction
+		if err := json.Unmarshal(contentBytes, &action); err != nil {
+			return err
+		}
+		event.TxReqID = action.TxReqID
+		event.AddressStr = action.Meta.TraderAddressStr
+		event.TokenIDStr = action.Meta.TokenIDToSellStr
+		event.Amount = action.Meta.SellAmount
+		event.ReceiveTokenIDStr = action.Meta.TokenIDToBuyStr
+		setPDEHistoryEventPair(event, action.Meta.TokenIDToSellStr, action.Meta.TokenIDToBuyStr)
+		return nil
+	}
+	var accepted metadata.PDETradeAcceptedContent
+	if err := json.Unmarshal([]byte(content), &accepted); err != nil {
+		return err
+	}
+	event.TxReqID = accepted.RequestedTxID
+	event.AddressStr = accepted.TraderAddressStr
+	event.ReceiveTokenIDStr = accepted.TokenIDToBuyStr
+	event.ReceiveAmount = accepted.ReceiveAmount
+	// sold token is the one added to the pool
+	if accepted.Token1PoolValueOperation.Operator == "+" {
+		event.TokenIDStr = accepted.Token1IDStr
+		event.Amount = accepted.Token1PoolValueOperation.Value
+	} else {
+		event.TokenIDStr = accepted.Token2IDStr
+		event.Amount = accepted.Token2PoolValueOperation.Value
+	}
+	setPDEHistoryEventPair(event, accepted.Token1IDStr, accepted.Token2IDStr)
+	return nil
+}
+
+func buildPDECrossPoolTradeRefundHistoryEvent(event *rawdbv2.PDEHistoryEvent, content string) error {
+	contentBytes, err := base64.StdEncoding.DecodeString(content)
+	if err != nil {
+		return err
+	}
+	var action metadata.PDECrossPoolTradeRequestAction
+	if err := json.Unmarshal(contentBytes, &action); err != nil {
+		return err
+	}
+	event.TxReqID = action.TxReqID
+	event.AddressStr = action.Meta.TraderAddressStr
+	event.TokenIDStr = action.Meta.TokenIDToSellStr
+	event.Amount = action.Meta.SellAmount
+	event.ReceiveTokenIDStr = action.Meta.TokenIDToBuyStr
+	setPDEHistoryEventPair(event, action.Meta.TokenIDToSellStr, action.Meta.TokenIDToBuyStr)
+	return nil
+}
+
+func buildPDEContributionHistoryEvent(event *rawdbv2.PDEHistoryEvent, content string, beaconHeight uint64, currentPDEState *CurrentPDEState) error {
+	var pairID string
+	switch event.Status {
+	case common.PDEContributionWaitingChainStatus:
+		var waiting metadata.PDEWaitingContribution
+		if err := json.Unmarshal([]byte(content), &waiting); err != nil {
+			return err
+		}
+		event.TxReqID, event.AddressStr = waiting.TxReqID, waiting.ContributorAddressStr
+		event.TokenIDStr, event.Amount = waiting.TokenIDStr, waiting.ContributedAmount
+		return nil // pair is not known until the contribution is matched
+	case common.PDEContributionRefundChainStatus:
+		var refund metadata.PDERefundContribution
+		if err := json.Unmarshal([]byte(content), &refund); err != nil {
+			return err
+		}
+		event.TxReqID, event.AddressStr = refund.TxReqID, refund.ContributorAddressStr
+		event.TokenIDStr, event.Amount = refund.TokenIDStr, refund.ContributedAmount
+		pairID = refund.PDEContributionPairID
+	case common.PDEContributionMatchedChainStatus:
+		var matched metadata.PDEMatchedContribution
+		if err := json.Unmarshal([]byte(content), &matched); err != nil {
+			return err
+		}
+		event.TxReqID, event.AddressStr = matched.TxReqID, matched.ContributorAddressStr
+		event.TokenIDStr, event.Amount = matched.TokenIDStr, matched.ContributedAmount
+		pairID = matched.PDEContributionPairID
+	case common.PDEContributionMatchedNReturnedChainStatus:
+		var matched metadata.PDEMatchedNReturnedContribution
+		if err := json.Unmarshal([]byte(content), &matched); err != nil {
+			return err
+		}
+		event.TxReqID, event.AddressStr = matched.TxReqID, matched.ContributorAddressStr
+		event.TokenIDStr, event.Amount = matched.TokenIDStr, matched.ActualContributedAmount
+		event.ReceiveTokenIDStr, event.ReceiveAmount = matched.TokenIDStr, matched.ReturnedContributedAmount
+		pairID = matched.PDEContributionPairID
+	default:
+		return errors.New("unknown contribution status")
+	}
+	// the other side of the pair is the waiting contribution, which may have been removed by the previous instruction of the pair
+	waitingKey := string(rawdbv2.BuildWaitingPDEContributionKey(beaconHeight, pairID))
+	waiting, found := currentPDEState.WaitingPDEContributions[waitingKey]
+	if !found || waiting == nil {
+		waiting, found = currentPDEState.DeletedWaitingPDEContributions[waitingKey]
+	}
+	if found && waiting != nil {
+		setPDEHistoryEventPair(event, waiting.TokenIDStr, event.TokenIDStr)
+	}
+	return nil
+}
+
+func buildPDEWithdrawalHistoryEvent(event *rawdbv2.PDEHistoryEvent, content string) error {
+	if event.Status == common.PDEWithdrawalRejectedChainStatus {
+		contentBytes, err := base64.StdEncoding.DecodeString(content)
+		if err != nil {
+			return err
+		}
+		var action metadata.PDEWithdrawalRequestAction
+		if err := json.Unmarshal(contentBytes, &action); err != nil {
+			return err
+		}
+		event.TxReqID = action.TxReqID
+		event.AddressStr = action.Meta.WithdrawerAddressStr
+		event.Shares = action.Meta.WithdrawalShareAmt
+		setPDEHistoryEventPair(event, action.Meta.WithdrawalToken1IDStr, action.Meta.WithdrawalToken2IDStr)
+		return nil
+	}
+	var accepted metadata.PDEWithdrawalAcceptedContent
+	if err := json.Unmarshal([]byte(content), &accepted); err != nil {
+		return err
+	}
+	event.TxReqID = accepted.TxReqID
+	event.AddressStr = accepted.WithdrawerAddressStr
+	event.TokenIDStr = accepted.WithdrawalTokenIDStr
+	event.Amount = accepted.DeductingPoolValue
+	event.Shares = accepted.DeductingShares
+	setPDEHistoryEventPair(event, accepted.PairToken1IDStr, accepted.PairToken2IDStr)
+	return nil
+}
+
+// GetPDEHistory returns indexed pde events from fromHeight to toHeight, only finalized beacon blocks are considered
+func (blockchain *BlockChain) GetPDEHistory(fromHeight uint64, toHeight uint64) ([]rawdbv2.PDEHistoryEvent, error) {
+	if !blockchain.config.PDEHistory {
+		return nil, NewBlockChainError(PDEHistoryError, errors.New("pde history index is not enabled, restart node with --pdehistory"))
+	}
+	if finalHeight := blockchain.BeaconChain.GetFinalViewHeight(); toHeight > finalHeight {
+		toHeight = finalHeight
+	}
+	if fromHeight > toHeight {
+		return []rawdbv2.PDEHistoryEvent{}, nil
+	}
+	events, err := rawdbv2.GetPDEHistory(blockchain.GetBeaconChainDatabase(), fromHeight, toHeight)
+	if err != nil {
+		return nil, NewBlockChainError(PDEHistoryError, err)
+	}
+	return events, nil
+}
+
+// GetPDEPoolHistory returns contributions, withdrawals and trades of a pair, with its reserves before and after each of them
+func (blockchain *BlockChain) GetPDEPoolHistory(token1IDStr string, token2IDStr string, fromHeight uint64, toHeight uint64) ([]rawdbv2.PDEHistoryEvent, error) {
+	events, err := blockchain.GetPDEHistory(fromHeight, toHeight)
+	if err != nil {
+		return nil, err
+	}
+	pair := &rawdbv2.PDEHistoryEvent{}
+	setPDEHistoryEventPair(pair, token1IDStr, token2IDStr)
+	result := []rawdbv2.PDEHistoryEvent{}
+	for _, event := range events {
+		if event.Token1IDStr == pair.Token1IDStr && event.Token2IDStr == pair.Token2IDStr {
+			result = append(result, event)
+		}
+	}
+	return result, nil
+}
+
+// GetPDETradeHistory returns accepted and refunded trades of a trader
+func (blockchain *BlockChain) GetPDETradeHistory(traderAddressStr string, fromHeight uint64, toHeight uint64) ([]rawdbv2.PDEHistoryEvent, error) {
+	events, err := blockchain.GetPDEHistory(fromHeight, toHeight)
+	if err != nil {
+		return nil, err
+	}
+	result := []rawdbv2.PDEHistoryEvent{}
+	for _, event := range events {
+		if event.Type == rawdbv2.PDEHistoryTradeEvent && event.AddressStr == traderAddressStr {
+			result = append(result, event)
+		}
+	}
+	return result, nil
+}
+
+// GetPDECandles returns OHLC candles of a pair, one for each interval of beacon heights with at least one accepted trade
+func (blockchain *BlockChain) GetPDECandles(token1IDStr string, token2IDStr string, fromHeight uint64, toHeight uint64, interval uint64) ([]PDECandle, error) {
+	if interval == 0 {
+		return nil, NewBlockChainError(PDEHistoryError, errors.New("candle interval must be positive"))
+	}
+	events, err := blockchain.GetPDEPoolHistory(token1IDStr, token2IDStr, fromHeight, toHeight)
+	if err != nil {
+		return nil, err
+	}
+	return buildPDECandles(events, interval), nil
+}
+
+// GetPDEPairVolume returns the volume of accepted trades of a pair
+func (blockchain *BlockChain) GetPDEPairVolume(token1IDStr string, token2IDStr string, fromHeight uint64, toHeight uint64) (*PDEPairVolume, error) {
+	events, err := blockchain.GetPDEPoolHistory(token1IDStr, token2IDStr, fromHeight, toHeight)
+	if err != nil {
+		return nil, err
+	}
+	volume := &PDEPairVolume{}
+	pair := &rawdbv2.PDEHistoryEvent{}
+	setPDEHistoryEventPair(pair, token1IDStr, token2IDStr)
+	volume.Token1IDStr, volume.Token2IDStr = pair.Token1IDStr, pair.Token2IDStr
+	for _, event := range events {
+		token1Amount, token2Amount, ok := pdeTradeAmounts(event)
+		if !ok {
+			continue
+		}
+		volume.Token1Volume += token1Amount
+		volume.Token2Volume += token2Amount
+		volume.Trades++
+	}
+	return volume, nil
+}
+
+// pdeTradeAmounts returns amounts of Token1 and Token2 exchanged by an accepted trade
+func pdeTradeAmounts(event rawdbv2.PDEHistoryEvent) (uint64, uint64, bool) {
+	if event.Type != rawdbv2.PDEHistoryTradeEvent || event.Status != common.PDETradeAcceptedChainStatus || event.Amount == 0 || event.ReceiveAmount == 0 {
+		return 0, 0, false
+	}
+	if event.TokenIDStr == event.Token1IDStr {
+		return event.Amount, event.ReceiveAmount, true
+	}
+	return event.ReceiveAmount, event.Amount, true
+}
+
+// buildPDECandles aggregates accepted trades of a pair, events must be in order of beacon height
+func buildPDECandles(events []rawdbv2.PDEHistoryEvent, interval uint64) []PDECandle {
+	candles := []PDECandle{}
+	for _, event := range events {
+		token1Amount, token2Amount, ok := pdeTradeAmounts(event)
+		if !ok {
+			continue
+		}
+		price := float64(token2Amount) / float64(token1Amount)
+		from := event.BeaconHeight - event.BeaconHeight%interval
+		if len(candles) == 0 || candles[len(candles)-1].FromHeight != from {
+			candles = append(candles, PDECandle{
+				FromHeight: from,
+				ToHeight:   from + interval - 1,
+				Open:       price,
+				High:       price,
+				Low:        price,
+			})
+		}
+		candle := &candles[len(candles)-1]
+		if price > candle.High {
+			candle.High = price
+		}
+		if price < candle.Low {
+			candle.Low = price
+		}
+		candle.Close = price
+		candle.Token1Volume += token1Amount
+		candle.Token2Volume += token2Amount
+		candle.Trades++
+	}
+	return candles
+}
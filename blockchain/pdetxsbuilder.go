@@ -42,22 +42,102 @@ func parseTradeAcceptedContent(
 	return &pdeTradeAcceptedContent, nil
 }
 
+// buildPDELimitOrderIssuanceTx pays the trader of a limit order which is filled, refunded, expired or cancelled,
+// orderStatus is the status of the response, instructions of accepted cancellations are paid as cancelled orders
+func (blockGenerator *BlockGenerator) buildPDELimitOrderIssuanceTx(
+	orderStatus string,
+	contentStr string,
+	producerPrivateKey *privacy.PrivateKey,
+	shardID byte,
+	shardView *ShardBestState,
+	beaconView *BeaconBestState,
+) (metadata.Transaction, error) {
+	Logger.log.Info("[PDE Limit Order] Starting...")
+	var traderAddressStr, tokenIDStr string
+	var receiveAmt uint64
+	var orderID common.Hash
+	var orderShardID byte
+	switch orderStatus {
+	case common.PDELimitOrderRefundChainStatus:
+		contentBytes, err := base64.StdEncoding.DecodeString(contentStr)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while decoding content string of pde limit order refund instruction: %+v", err)
+			return nil, nil
+		}
+		var pdeLimitOrderRequestAction metadata.PDELimitOrderRequestAction
+		err = json.Unmarshal(contentBytes, &pdeLimitOrderRequestAction)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while unmarshaling pde limit order refund content: %+v", err)
+			return nil, nil
+		}
+		traderAddressStr = pdeLimitOrderRequestAction.Meta.TraderAddressStr
+		receiveAmt = pdeLimitOrderRequestAction.Meta.SellAmount + pdeLimitOrderRequestAction.Meta.TradingFee
+		tokenIDStr = pdeLimitOrderRequestAction.Meta.TokenIDToSellStr
+		orderID = pdeLimitOrderRequestAction.TxReqID
+		orderShardID = pdeLimitOrderRequestAction.ShardID
+	case common.PDELimitOrderFilledChainStatus:
+		var filledContent metadata.PDETradeAcceptedContent
+		err := json.Unmarshal([]byte(contentStr), &filledContent)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while unmarshaling pde limit order filled content: %+v", err)
+			return nil, nil
+		}
+		traderAddressStr = filledContent.TraderAddressStr
+		receiveAmt = filledContent.ReceiveAmount
+		tokenIDStr = filledContent.TokenIDToBuyStr
+		orderID = filledContent.RequestedTxID
+		orderShardID = filledContent.ShardID
+	case common.PDELimitOrderExpiredChainStatus, common.PDELimitOrderCancelledChainStatus:
+		var refundContent metadata.PDELimitOrderRefundContent
+		err := json.Unmarshal([]byte(contentStr), &refundContent)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while unmarshaling pde limit order refund content: %+v", err)
+			return nil, nil
+		}
+		traderAddressStr = refundContent.TraderAddressStr
+		receiveAmt = refundContent.Amount
+		tokenIDStr = refundContent.TokenIDStr
+		orderID = refundContent.OrderID
+		orderShardID = refundContent.ShardID
+	default:
+		return nil, nil
+	}
+	if shardID != orderShardID {
+		return nil, nil
+	}
+	meta := metadata.NewPDELimitOrderResponse(
+		orderStatus,
+		orderID,
+		metadata.PDELimitOrderResponseMeta,
+	)
+	resTx, err := buildTradeResTx(
+		meta,
+		traderAddressStr,
+		receiveAmt,
+		tokenIDStr,
+		producerPrivateKey,
+		shardID,
+		shardView.GetCopiedTransactionStateDB(),
+		beaconView.GetBeaconFeatureStateDB(),
+	)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while initializing limit order response tx: %+v", err)
+		return nil, nil
+	}
+	Logger.log.Infof("[PDE Limit Order] Create %s tx ok.", orderStatus)
+	return resTx, nil
+}
+
 func buildTradeResTx(
-	instStatus string,
+	meta metadata.Metadata,
 	receiverAddressStr string,
 	receiveAmt uint64,
 	tokenIDStr string,
-	requestedTxID common.Hash,
 	producerPrivateKey *privacy.PrivateKey,
 	shardID byte,
 	transactionStateDB *statedb.StateDB,
 	bridgeStateDB *statedb.StateDB,
 ) (metadata.Transaction, error) {
-	meta := metadata.NewPDETradeResponse(
-		instStatus,
-		requestedTxID,
-		metadata.PDETradeResponseMeta,
-	)
 	tokenID, err := common.Hash{}.NewHashFromStr(tokenIDStr)
 	if err != nil {
 		Logger.log.Errorf("ERROR: an error occured while converting tokenid to hash: %+v", err)
@@ -141,12 +221,16 @@ func (blockGenerator *BlockGenerator) buildPDETradeRefundTx(
 	if shardID != pdeTradeRequestAction.ShardID {
 		return nil, nil
 	}
-	resTx, err := buildTradeResTx(
+	meta := metadata.NewPDETradeResponse(
 		instStatus,
+		pdeTradeRequestAction.TxReqID,
+		metadata.PDETradeResponseMeta,
+	)
+	resTx, err := buildTradeResTx(
+		meta,
 		pdeTradeRequestAction.Meta.TraderAddressStr,
 		pdeTradeRequestAction.Meta.SellAmount+pdeTradeRequestAction.Meta.TradingFee,
 		pdeTradeRequestAction.Meta.TokenIDToSellStr,
-		pdeTradeRequestAction.TxReqID,
 		producerPrivateKey,
 		shardID,
 		shardView.GetCopiedTransactionStateDB(),
@@ -175,12 +259,16 @@ func (blockGenerator *BlockGenerator) buildPDETradeAcceptedTx(
 	if shardID != pdeTradeAcceptedContent.ShardID {
 		return nil, nil
 	}
-	resTx, err := buildTradeResTx(
+	meta := metadata.NewPDETradeResponse(
 		instStatus,
+		pdeTradeAcceptedContent.RequestedTxID,
+		metadata.PDETradeResponseMeta,
+	)
+	resTx, err := buildTradeResTx(
+		meta,
 		pdeTradeAcceptedContent.TraderAddressStr,
 		pdeTradeAcceptedContent.ReceiveAmount,
 		pdeTradeAcceptedContent.TokenIDToBuyStr,
-		pdeTradeAcceptedContent.RequestedTxID,
 		producerPrivateKey,
 		shardID,
 		shardView.GetCopiedTransactionStateDB(),
@@ -223,6 +311,75 @@ func (blockGenerator *BlockGenerator) buildPDETradeIssuanceTx(
 	)
 }
 
+func (blockGenerator *BlockGenerator) buildPDECrossPoolTradeIssuanceTx(
+	instStatus string,
+	contentStr string,
+	producerPrivateKey *privacy.PrivateKey,
+	shardID byte,
+	shardView *ShardBestState,
+	beaconView *BeaconBestState,
+) (metadata.Transaction, error) {
+	Logger.log.Info("[PDE Cross Pool Trade] Starting...")
+	var traderAddressStr, tokenIDStr string
+	var receiveAmt uint64
+	var requestedTxID common.Hash
+	if instStatus == common.PDETradeRefundChainStatus {
+		contentBytes, err := base64.StdEncoding.DecodeString(contentStr)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while decoding content string of pde cross pool trade refund instruction: %+v", err)
+			return nil, nil
+		}
+		var pdeTradeRequestAction metadata.PDECrossPoolTradeRequestAction
+		err = json.Unmarshal(contentBytes, &pdeTradeRequestAction)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while unmarshaling pde cross pool trade refund content: %+v", err)
+			return nil, nil
+		}
+		if shardID != pdeTradeRequestAction.ShardID {
+			return nil, nil
+		}
+		traderAddressStr = pdeTradeRequestAction.Meta.TraderAddressStr
+		receiveAmt = pdeTradeRequestAction.Meta.SellAmount + pdeTradeRequestAction.Meta.TradingFee
+		tokenIDStr = pdeTradeRequestAction.Meta.TokenIDToSellStr
+		requestedTxID = pdeTradeRequestAction.TxReqID
+	} else {
+		var pdeTradeAcceptedContent metadata.PDECrossPoolTradeAcceptedContent
+		err := json.Unmarshal([]byte(contentStr), &pdeTradeAcceptedContent)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while unmarshaling pde cross pool trade accepted content: %+v", err)
+			return nil, nil
+		}
+		if shardID != pdeTradeAcceptedContent.ShardID {
+			return nil, nil
+		}
+		traderAddressStr = pdeTradeAcceptedContent.TraderAddressStr
+		receiveAmt = pdeTradeAcceptedContent.ReceiveAmount
+		tokenIDStr = pdeTradeAcceptedContent.TokenIDToBuyStr
+		requestedTxID = pdeTradeAcceptedContent.RequestedTxID
+	}
+	meta := metadata.NewPDECrossPoolTradeResponse(
+		instStatus,
+		requestedTxID,
+		metadata.PDECrossPoolTradeResponseMeta,
+	)
+	resTx, err := buildTradeResTx(
+		meta,
+		traderAddressStr,
+		receiveAmt,
+		tokenIDStr,
+		producerPrivateKey,
+		shardID,
+		shardView.GetCopiedTransactionStateDB(),
+		beaconView.GetBeaconFeatureStateDB(),
+	)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while initializing cross pool trading response tx: %+v", err)
+		return nil, nil
+	}
+	Logger.log.Infof("[PDE Cross Pool Trade] Create %s tx ok.", instStatus)
+	return resTx, nil
+}
+
 func (blockGenerator *BlockGenerator) buildPDEWithdrawalTx(
 	contentStr string,
 	producerPrivateKey *privacy.PrivateKey,
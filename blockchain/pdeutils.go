@@ -16,6 +16,9 @@ type CurrentPDEState struct {
 	DeletedWaitingPDEContributions map[string]*rawdbv2.PDEContribution
 	PDEPoolPairs                   map[string]*rawdbv2.PDEPoolForPair
 	PDEShares                      map[string]uint64
+	PDETradingFees                 map[string]map[string]uint64      // trading fees earned by shares, keyed by pde share key then by token id
+	PDELimitOrders                 map[string]*rawdbv2.PDELimitOrder // open orders of the order book, keyed by order id
+	DeletedPDELimitOrders          map[string]*rawdbv2.PDELimitOrder
 }
 
 func (s *CurrentPDEState) Copy() *CurrentPDEState {
@@ -56,11 +59,22 @@ func InitCurrentPDEStateFromDB(
 	if err != nil {
 		return nil, err
 	}
+	pdeTradingFees, err := statedb.GetPDETradingFees(stateDB, beaconHeight)
+	if err != nil {
+		return nil, err
+	}
+	pdeLimitOrders, err := statedb.GetPDELimitOrders(stateDB)
+	if err != nil {
+		return nil, err
+	}
 	return &CurrentPDEState{
 		WaitingPDEContributions:        waitingPDEContributions,
 		PDEPoolPairs:                   pdePoolPairs,
 		PDEShares:                      pdeShares,
+		PDETradingFees:                 pdeTradingFees,
+		PDELimitOrders:                 pdeLimitOrders,
 		DeletedWaitingPDEContributions: make(map[string]*rawdbv2.PDEContribution),
+		DeletedPDELimitOrders:          make(map[string]*rawdbv2.PDELimitOrder),
 	}, nil
 }
 
@@ -79,7 +93,12 @@ func storePDEStateToDB(
 	if err != nil {
 		return err
 	}
-	err = statedb.StorePDEShares(stateDB, beaconHeight, currentPDEState.PDEShares)
+	err = statedb.StorePDEShares(stateDB, beaconHeight, currentPDEState.PDEShares, currentPDEState.PDETradingFees)
+	if err != nil {
+		return err
+	}
+	statedb.DeletePDELimitOrders(stateDB, currentPDEState.DeletedPDELimitOrders)
+	err = statedb.StorePDELimitOrders(stateDB, currentPDEState.PDELimitOrders)
 	if err != nil {
 		return err
 	}
@@ -190,3 +209,76 @@ func updateWaitingContributionPairToPoolV2(
 		currentPDEState,
 	)
 }
+
+// getPDEPoolFeeRate returns the fee, in basis points of the sell amount, that liquidity providers of a pool pair take on trades
+func (blockchain *BlockChain) getPDEPoolFeeRate(beaconHeight uint64, token1IDStr string, token2IDStr string) uint64 {
+	if blockchain.config.ChainParams == nil {
+		return 0
+	}
+	pdeParams, ok := blockchain.GetPDEParams(beaconHeight)
+	if !ok {
+		return 0
+	}
+	tokenIDStrs := []string{token1IDStr, token2IDStr}
+	sort.Strings(tokenIDStrs)
+	if feeRate, found := pdeParams.PoolFeeRates[tokenIDStrs[0]+"-"+tokenIDStrs[1]]; found {
+		return feeRate
+	}
+	return pdeParams.PoolFeeRate
+}
+
+// computePDEPoolFee returns the part of the sell amount taken as pool fee
+func computePDEPoolFee(sellAmount uint64, feeRate uint64) uint64 {
+	if feeRate == 0 {
+		return 0
+	}
+	fee := big.NewInt(0)
+	fee.Mul(big.NewInt(int64(sellAmount)), big.NewInt(int64(feeRate)))
+	fee.Div(fee, big.NewInt(10000))
+	return fee.Uint64()
+}
+
+// addPDETradingFee credits the pool fee of a trade to the shares of the pool pair pro-rata,
+// the fee itself was already added to the pool reserves
+func addPDETradingFee(
+	beaconHeight uint64,
+	token1IDStr string,
+	token2IDStr string,
+	feeTokenIDStr string,
+	fee uint64,
+	currentPDEState *CurrentPDEState,
+) {
+	if fee == 0 {
+		return
+	}
+	pdeShareOnTokenPrefix := string(rawdbv2.BuildPDESharesKeyV2(beaconHeight, token1IDStr, token2IDStr, ""))
+	totalShares := uint64(0)
+	for key, value := range currentPDEState.PDEShares {
+		if strings.Contains(key, pdeShareOnTokenPrefix) {
+			totalShares += value
+		}
+	}
+	if totalShares == 0 {
+		return
+	}
+	if currentPDEState.PDETradingFees == nil {
+		currentPDEState.PDETradingFees = make(map[string]map[string]uint64)
+	}
+	for key, value := range currentPDEState.PDEShares {
+		if value == 0 || !strings.Contains(key, pdeShareOnTokenPrefix) {
+			continue
+		}
+		earnedFee := big.NewInt(0)
+		earnedFee.Mul(big.NewInt(int64(fee)), big.NewInt(int64(value)))
+		earnedFee.Div(earnedFee, big.NewInt(int64(totalShares)))
+		if earnedFee.Uint64() == 0 {
+			continue
+		}
+		tradingFees, found := currentPDEState.PDETradingFees[key]
+		if !found || tradingFees == nil {
+			tradingFees = make(map[string]uint64)
+			currentPDEState.PDETradingFees[key] = tradingFees
+		}
+		tradingFees[feeTokenIDStr] += earnedFee.Uint64()
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/metadata/rpccaller"
+	"github.com/incognitochain/incognito-chain/portal"
 	"github.com/pkg/errors"
 	"github.com/tendermint/tendermint/rpc/client"
 	"github.com/tendermint/tendermint/types"
@@ -26,6 +27,24 @@ type CurrentPortalState struct {
 	LockedCollateralForRewards *statedb.LockedCollateralState
 	//Store temporary exchange rates requests
 	ExchangeRatesRequests map[string]*metadata.ExchangeRatesRequestStatus // key : hash(beaconHeight | TxID)
+	// submission history of exchange rates feeders, their last rates are aggregated at the end of each window
+	ExchangeRatesFeeders map[string]*statedb.ExchangeRatesFeederState // key : feeder address
+}
+
+// getExchangeRatesFeeder returns the submission history of the feeder, a new one is created for its first submission
+func (currentPortalState *CurrentPortalState) getExchangeRatesFeeder(feederAddress string) *statedb.ExchangeRatesFeederState {
+	if currentPortalState.ExchangeRatesFeeders == nil {
+		currentPortalState.ExchangeRatesFeeders = make(map[string]*statedb.ExchangeRatesFeederState)
+	}
+	feeder, ok := currentPortalState.ExchangeRatesFeeders[feederAddress]
+	if !ok {
+		feeder = statedb.NewExchangeRatesFeederStateWithValue(feederAddress, make(map[string]*statedb.FeederExchangeRate), 0, 0, 0)
+		currentPortalState.ExchangeRatesFeeders[feederAddress] = feeder
+	}
+	if feeder.LastRates() == nil {
+		feeder.SetLastRates(make(map[string]*statedb.FeederExchangeRate))
+	}
+	return feeder
 }
 
 type CustodianStateSlice struct {
@@ -33,13 +52,57 @@ type CustodianStateSlice struct {
 	Value *statedb.CustodianState
 }
 
-type RedeemMemoBNB struct {
-	RedeemID                  string `json:"RedeemID"`
-	CustodianIncognitoAddress string `json:"CustodianIncognitoAddress"`
+// portalExternalChainBuilders registers the external chains supported by the Portal, keyed by the token id of their ptoken
+var portalExternalChainBuilders = map[string]func(blockchain *BlockChain) portal.ExternalChain{
+	common.PortalBTCIDStr: func(blockchain *BlockChain) portal.ExternalChain {
+		return portal.NewBTCChain(blockchain.GetBTCHeaderChain())
+	},
+	common.PortalBNBIDStr: func(blockchain *BlockChain) portal.ExternalChain {
+		return portal.NewBNBChain(blockchain, blockchain.GetBNBChainID())
+	},
+}
+
+// newPortalExternalChains builds the registered external chains, it is called once when the blockchain is initialized
+func (blockchain *BlockChain) newPortalExternalChains() map[string]portal.ExternalChain {
+	externalChains := make(map[string]portal.ExternalChain)
+	for tokenID, build := range portalExternalChainBuilders {
+		externalChains[tokenID] = build(blockchain)
+	}
+	return externalChains
+}
+
+// GetPortalExternalChain returns the external chain of the ptoken, nil if the token is not supported on the Portal
+func (blockchain *BlockChain) GetPortalExternalChain(tokenID string) portal.ExternalChain {
+	return blockchain.portalExternalChains[tokenID]
+}
+
+func (blockchain *BlockChain) IsPortalToken(tokenID string) bool {
+	return IsPortalToken(tokenID)
 }
 
-type PortingMemoBNB struct {
-	PortingID string `json:"PortingID"`
+func (blockchain *BlockChain) IsPortalExchangeRateToken(tokenID string) bool {
+	return IsPortalExchangeRateToken(tokenID)
+}
+
+// IsPortalToken returns true if an external chain of the ptoken is registered
+func IsPortalToken(tokenID string) bool {
+	_, ok := portalExternalChainBuilders[tokenID]
+	return ok
+}
+
+// IsPortalExchangeRateToken returns true if feeders submit exchange rates of the token, i.e. a ptoken of the Portal or PRV
+func IsPortalExchangeRateToken(tokenID string) bool {
+	return IsPortalToken(tokenID) || tokenID == common.PRVIDStr
+}
+
+// GetPortalTokenIDs returns the token ids of the ptokens supported on the Portal in ascending order
+func GetPortalTokenIDs() []string {
+	tokenIDs := make([]string, 0, len(portalExternalChainBuilders))
+	for tokenID := range portalExternalChainBuilders {
+		tokenIDs = append(tokenIDs, tokenID)
+	}
+	sort.Strings(tokenIDs)
+	return tokenIDs
 }
 
 func InitCurrentPortalStateFromDB(
@@ -73,6 +136,7 @@ func InitCurrentPortalStateFromDB(
 	if err != nil {
 		return nil, err
 	}
+	exchangeRatesFeeders := statedb.GetExchangeRatesFeeders(stateDB)
 
 	return &CurrentPortalState{
 		CustodianPoolState:         custodianPoolState,
@@ -83,6 +147,7 @@ func InitCurrentPortalStateFromDB(
 		ExchangeRatesRequests:      make(map[string]*metadata.ExchangeRatesRequestStatus),
 		LiquidationPool:            liquidateExchangeRatesPool,
 		LockedCollateralForRewards: lockedCollateralState,
+		ExchangeRatesFeeders:       exchangeRatesFeeders,
 	}, nil
 }
 
@@ -118,6 +183,10 @@ func storePortalStateToDB(
 	if err != nil {
 		return err
 	}
+	err = statedb.StoreExchangeRatesFeeders(stateDB, currentPortalState.ExchangeRatesFeeders)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -443,11 +512,6 @@ func pickupCustodianForRedeem(redeemAmount uint64, tokenID string, portalState *
 	return nil, errors.New("Not enough amount public token to return user")
 }
 
-// convertIncPBNBAmountToExternalBNBAmount converts amount in inc chain (decimal 9) to amount in bnb chain (decimal 8)
-func convertIncPBNBAmountToExternalBNBAmount(incPBNBAmount int64) int64 {
-	return incPBNBAmount / 10 // incPBNBAmount / 1^9 * 1^8
-}
-
 // updateCustodianStateAfterReqUnlockCollateral updates custodian state (amount collaterals) when custodian returns redeemAmount public token to user
 func updateCustodianStateAfterReqUnlockCollateral(custodianState *statedb.CustodianState, unlockedAmount uint64, tokenID string) error {
 	lockedAmount := custodianState.GetLockedAmountCollateral()
@@ -629,35 +693,24 @@ func NewConvertExchangeRatesObject(finalExchangeRates *statedb.FinalExchangeRate
 }
 
 func (c ConvertExchangeRatesObject) ExchangePToken2PRVByTokenId(pTokenId string, value uint64) (uint64, error) {
-	switch pTokenId {
-	case common.PortalBTCIDStr:
-		result, err := c.ExchangeBTC2PRV(value)
-		if err != nil {
-			return 0, err
-		}
-
-		return result, nil
-	case common.PortalBNBIDStr:
-		result, err := c.ExchangeBNB2PRV(value)
-		if err != nil {
-			return 0, err
-		}
-
-		return result, nil
+	if !IsPortalToken(pTokenId) {
+		return 0, errors.New("Ptoken is not support")
 	}
-
-	return 0, errors.New("Ptoken is not support")
+	//input : nano
+	//todo: check rates exist
+	pTokenRates := c.finalExchangeRates.Rates()[pTokenId].Amount     //return nano pUSDT
+	PRVRates := c.finalExchangeRates.Rates()[common.PRVIDStr].Amount //return nano pUSDT
+	return c.convert(value, pTokenRates, PRVRates)
 }
 
 func (c *ConvertExchangeRatesObject) ExchangePRV2PTokenByTokenId(pTokenId string, value uint64) (uint64, error) {
-	switch pTokenId {
-	case common.PortalBTCIDStr:
-		return c.ExchangePRV2BTC(value)
-	case common.PortalBNBIDStr:
-		return c.ExchangePRV2BNB(value)
+	if !IsPortalToken(pTokenId) {
+		return 0, errors.New("Ptoken is not support")
 	}
-
-	return 0, errors.New("Ptoken is not support")
+	//input nano
+	pTokenRates := c.finalExchangeRates.Rates()[pTokenId].Amount     //return nano pUSDT
+	PRVRates := c.finalExchangeRates.Rates()[common.PRVIDStr].Amount //return nano pUSDT
+	return c.convert(value, PRVRates, pTokenRates)
 }
 
 func (c *ConvertExchangeRatesObject) convert(value uint64, ratesFrom uint64, RatesTo uint64) (uint64, error) {
@@ -674,58 +727,6 @@ func (c *ConvertExchangeRatesObject) convert(value uint64, ratesFrom uint64, Rat
 
 }
 
-func (c *ConvertExchangeRatesObject) ExchangeBTC2PRV(value uint64) (uint64, error) {
-	//input : nano
-	//todo: check rates exist
-	BTCRates := c.finalExchangeRates.Rates()[common.PortalBTCIDStr].Amount //return nano pUSDT
-	PRVRates := c.finalExchangeRates.Rates()[common.PRVIDStr].Amount       //return nano pUSDT
-	valueExchange, err := c.convert(value, BTCRates, PRVRates)
-
-	if err != nil {
-		return 0, err
-	}
-	//nano
-	return valueExchange, nil
-}
-
-func (c *ConvertExchangeRatesObject) ExchangeBNB2PRV(value uint64) (uint64, error) {
-	BNBRates := c.finalExchangeRates.Rates()[common.PortalBNBIDStr].Amount
-	PRVRates := c.finalExchangeRates.Rates()[common.PRVIDStr].Amount
-
-	valueExchange, err := c.convert(value, BNBRates, PRVRates)
-
-	if err != nil {
-		return 0, err
-	}
-
-	return valueExchange, nil
-}
-
-func (c *ConvertExchangeRatesObject) ExchangePRV2BTC(value uint64) (uint64, error) {
-	//input nano
-	BTCRates := c.finalExchangeRates.Rates()[common.PortalBTCIDStr].Amount //return nano pUSDT
-	PRVRates := c.finalExchangeRates.Rates()[common.PRVIDStr].Amount       //return nano pUSDT
-
-	valueExchange, err := c.convert(value, PRVRates, BTCRates)
-
-	if err != nil {
-		return 0, err
-	}
-
-	return valueExchange, nil
-}
-
-func (c *ConvertExchangeRatesObject) ExchangePRV2BNB(value uint64) (uint64, error) {
-	BNBRates := c.finalExchangeRates.Rates()[common.PortalBNBIDStr].Amount
-	PRVRates := c.finalExchangeRates.Rates()[common.PRVIDStr].Amount
-
-	valueExchange, err := c.convert(value, PRVRates, BNBRates)
-	if err != nil {
-		return 0, err
-	}
-	return valueExchange, nil
-}
-
 func updateCurrentPortalStateOfLiquidationExchangeRates(
 	currentPortalState *CurrentPortalState,
 	custodianKey string,
@@ -844,12 +845,24 @@ func (blockchain *BlockChain) GetLatestBNBBlkHeight() (int64, error) {
 	return result.SyncInfo.LatestBlockHeight, nil
 }
 
-func calAndCheckTPRatio(
+// CustodianCollateralRatio is the collateral of a custodian for a ptoken against the public tokens it holds
+type CustodianCollateralRatio struct {
+	// LockedCollateral excludes the collaterals locked for waiting porting requests
+	LockedCollateral     uint64
+	HoldingPubToken      uint64
+	HoldingPubTokenInPRV uint64
+	// Percent is LockedCollateral * 100 / HoldingPubTokenInPRV
+	Percent uint64
+}
+
+// CalCustodianCollateralRatios returns the collateral ratios of a custodian at the exchange rates,
+// ptokens which the custodian has no collateral or public token of are skipped
+func CalCustodianCollateralRatios(
 	portalState *CurrentPortalState,
 	custodianState *statedb.CustodianState,
 	finalExchange *statedb.FinalExchangeRatesState,
-	portalParams PortalParams) (map[string]metadata.LiquidateTopPercentileExchangeRatesDetail, error) {
-	result := make(map[string]metadata.LiquidateTopPercentileExchangeRatesDetail)
+) (map[string]*CustodianCollateralRatio, error) {
+	result := make(map[string]*CustodianCollateralRatio)
 	convertExchangeRatesObj := NewConvertExchangeRatesObject(finalExchange)
 
 	lockedAmount := make(map[string]uint64)
@@ -898,18 +911,41 @@ func calAndCheckTPRatio(
 		tmp := new(big.Int).Mul(new(big.Int).SetUint64(amountPRV), big.NewInt(100))
 		percent := new(big.Int).Div(tmp, new(big.Int).SetUint64(amountPTokenInPRV)).Uint64()
 
-		if tp20, ok := checkTPRatio(percent, portalParams); ok {
+		result[tokenID] = &CustodianCollateralRatio{
+			LockedCollateral:     amountPRV,
+			HoldingPubToken:      amountPubToken,
+			HoldingPubTokenInPRV: amountPTokenInPRV,
+			Percent:              percent,
+		}
+	}
+
+	return result, nil
+}
+
+func calAndCheckTPRatio(
+	portalState *CurrentPortalState,
+	custodianState *statedb.CustodianState,
+	finalExchange *statedb.FinalExchangeRatesState,
+	portalParams PortalParams) (map[string]metadata.LiquidateTopPercentileExchangeRatesDetail, error) {
+	result := make(map[string]metadata.LiquidateTopPercentileExchangeRatesDetail)
+	collateralRatios, err := CalCustodianCollateralRatios(portalState, custodianState, finalExchange)
+	if err != nil {
+		return nil, err
+	}
+
+	for tokenID, ratio := range collateralRatios {
+		if tp20, ok := checkTPRatio(ratio.Percent, portalParams); ok {
 			if tp20 {
 				result[tokenID] = metadata.LiquidateTopPercentileExchangeRatesDetail{
 					TPKey:                    int(portalParams.TP120),
-					TPValue:                  percent,
-					HoldAmountFreeCollateral: lockedAmount[tokenID],
-					HoldAmountPubToken:       holdingPubToken[tokenID],
+					TPValue:                  ratio.Percent,
+					HoldAmountFreeCollateral: ratio.LockedCollateral,
+					HoldAmountPubToken:       ratio.HoldingPubToken,
 				}
 			} else {
 				result[tokenID] = metadata.LiquidateTopPercentileExchangeRatesDetail{
 					TPKey:                    int(portalParams.TP130),
-					TPValue:                  percent,
+					TPValue:                  ratio.Percent,
 					HoldAmountFreeCollateral: 0,
 					HoldAmountPubToken:       0,
 				}
@@ -1258,3 +1294,41 @@ func CalAmountTopUpWaitingPortings(
 
 	return result, nil
 }
+
+// findExchangeRatesOutlier returns the first token whose rate deviates more than maxPercentDeviation percent
+// from its previous final rate, tokens without a final rate yet are not checked and 0 disables the check
+func findExchangeRatesOutlier(rates []*metadata.ExchangeRateInfo, finalExchangeRates *statedb.FinalExchangeRatesState, maxPercentDeviation uint64) (string, bool) {
+	if maxPercentDeviation == 0 || finalExchangeRates == nil {
+		return "", false
+	}
+	for _, rate := range rates {
+		preRate, ok := finalExchangeRates.Rates()[rate.PTokenID]
+		if !ok || preRate.Amount == 0 {
+			continue
+		}
+		deviation := new(big.Int).Sub(new(big.Int).SetUint64(rate.Rate), new(big.Int).SetUint64(preRate.Amount))
+		deviation.Abs(deviation).Mul(deviation, big.NewInt(100))
+		maxDeviation := new(big.Int).Mul(new(big.Int).SetUint64(preRate.Amount), new(big.Int).SetUint64(maxPercentDeviation))
+		if deviation.Cmp(maxDeviation) > 0 {
+			return rate.PTokenID, true
+		}
+	}
+	return "", false
+}
+
+// isEndOfExchangeRatesWindow returns true if exchange rates are finalized at the beacon height,
+// a window of 0 is treated as 1, i.e. rates are finalized at every beacon block
+func isEndOfExchangeRatesWindow(beaconHeight uint64, window uint64) bool {
+	if window <= 1 {
+		return true
+	}
+	return beaconHeight%window == 0
+}
+
+// IsInExchangeRatesWindow returns true if a rate submitted at submittedHeight is aggregated by the window ending at beaconHeight
+func IsInExchangeRatesWindow(submittedHeight uint64, beaconHeight uint64, window uint64) bool {
+	if window == 0 {
+		window = 1
+	}
+	return submittedHeight <= beaconHeight && submittedHeight+window > beaconHeight
+}
@@ -1,12 +1,16 @@
 package blockchain
 
 import (
-	"github.com/stretchr/testify/assert"
 	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestCalculatePortingFees(t *testing.T) {
-	result := CalculatePortingFees(3106511852580)
+	result := CalculatePortingFees(3106511852580, 0.01)
 	assert.Equal(t, result, uint64(310651185))
 }
 
@@ -18,10 +22,7 @@ func TestCurrentPortalStateStruct(t *testing.T) {
 	assert.Equal(t, len(currentPortalState.ExchangeRatesRequests), 0)
 	assert.Equal(t, len(currentPortalState.WaitingPortingRequests), 0)
 	assert.Equal(t, len(currentPortalState.WaitingRedeemRequests), 0)
-	assert.Equal(t, len(currentPortalState.FinalExchangeRatesState), 0)
-
-	finalExchangeRates := currentPortalState.FinalExchangeRatesState["abc"]
-	assert.Equal(t, finalExchangeRates.Rates, nil)
+	assert.Nil(t, currentPortalState.FinalExchangeRatesState)
 
 	_, ok := currentPortalState.CustodianPoolState["abc"]
 	assert.Equal(t, ok, false)
@@ -31,3 +32,79 @@ func TestCurrentPortalStateStruct(t *testing.T) {
 		assert.NotNil(t, v)
 	}
 }
+
+func TestPickExchangesRatesFinalBeforeAndAfterWindowBreakPoint(t *testing.T) {
+	// the mainnet window is scheduled at a test break point
+	breakPoint := uint64(1000)
+	chainParams := ChainMainParam
+	chainParams.PortalParams = map[uint64]PortalParams{
+		0:          ChainMainParam.PortalParams[0],
+		breakPoint: ChainMainParam.PortalParams[MainnetBeaconHeightBreakPointExchangeRatesWindow],
+	}
+	bc := &BlockChain{config: Config{ChainParams: &chainParams}}
+	assert.Equal(t, uint64(0), bc.GetPortalParams(breakPoint-1).ExchangeRatesAggregationWindow)
+	assert.Equal(t, uint64(0), bc.GetPortalParams(breakPoint-1).MaxPercentExchangeRatesDeviation)
+	assert.Equal(t, uint64(5), bc.GetPortalParams(breakPoint).ExchangeRatesAggregationWindow)
+
+	newPortalState := func() *CurrentPortalState {
+		return &CurrentPortalState{
+			ExchangeRatesRequests: map[string]*metadata.ExchangeRatesRequestStatus{
+				"tx1": metadata.NewExchangeRatesRequestStatus(common.PortalExchangeRatesAcceptedStatus, "feeder1", []*metadata.ExchangeRateInfo{{PTokenID: common.PortalBTCIDStr, Rate: 100}}),
+				"tx2": metadata.NewExchangeRatesRequestStatus(common.PortalExchangeRatesAcceptedStatus, "feeder2", []*metadata.ExchangeRateInfo{{PTokenID: common.PortalBTCIDStr, Rate: 300}}),
+			},
+			ExchangeRatesFeeders: map[string]*statedb.ExchangeRatesFeederState{
+				"feeder3": statedb.NewExchangeRatesFeederStateWithValue("feeder3", map[string]*statedb.FeederExchangeRate{
+					common.PortalBTCIDStr: {Rate: 1000, BeaconHeight: breakPoint - 2},
+				}, breakPoint-2, 1, 0),
+			},
+		}
+	}
+
+	// below the break point, rates submitted in the beacon block are picked at every height
+	portalState := newPortalState()
+	bc.pickExchangesRatesFinal(portalState, breakPoint-1, bc.GetPortalParams(breakPoint-1))
+	assert.Equal(t, uint64(200), portalState.FinalExchangeRatesState.Rates()[common.PortalBTCIDStr].Amount)
+
+	// from the break point, last rates of feeders are picked at the end of each window
+	portalState = newPortalState()
+	bc.pickExchangesRatesFinal(portalState, breakPoint, bc.GetPortalParams(breakPoint))
+	assert.Equal(t, uint64(1000), portalState.FinalExchangeRatesState.Rates()[common.PortalBTCIDStr].Amount)
+
+	portalState = newPortalState()
+	bc.pickExchangesRatesFinal(portalState, breakPoint+1, bc.GetPortalParams(breakPoint+1))
+	assert.Nil(t, portalState.FinalExchangeRatesState)
+}
+
+func TestPortalExternalChainRegistry(t *testing.T) {
+	assert.Equal(t, []string{common.PortalBNBIDStr, common.PortalBTCIDStr}, GetPortalTokenIDs())
+	assert.True(t, IsPortalToken(common.PortalBTCIDStr))
+	assert.False(t, IsPortalToken(common.PRVIDStr))
+	assert.True(t, IsPortalExchangeRateToken(common.PRVIDStr))
+	assert.False(t, IsPortalExchangeRateToken(common.Hash{}.String()))
+
+	bc := &BlockChain{config: Config{ChainParams: &ChainTestParam}}
+	bc.portalExternalChains = bc.newPortalExternalChains()
+	for _, tokenID := range GetPortalTokenIDs() {
+		externalChain := bc.GetPortalExternalChain(tokenID)
+		if assert.NotNil(t, externalChain, tokenID) {
+			assert.Equal(t, uint64(10), externalChain.MinAmount())
+			assert.True(t, externalChain.MinConfirmations() > 0)
+		}
+	}
+	assert.Nil(t, bc.GetPortalExternalChain(common.PRVIDStr))
+
+	convertExchangeRatesObj := NewConvertExchangeRatesObject(statedb.NewFinalExchangeRatesStateWithValue(map[string]statedb.FinalExchangeRatesDetail{
+		common.PortalBTCIDStr: {Amount: 8000},
+		common.PRVIDStr:       {Amount: 2},
+	}))
+	prvAmount, err := convertExchangeRatesObj.ExchangePToken2PRVByTokenId(common.PortalBTCIDStr, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(40000), prvAmount)
+	pTokenAmount, err := convertExchangeRatesObj.ExchangePRV2PTokenByTokenId(common.PortalBTCIDStr, 40000)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(10), pTokenAmount)
+	_, err = convertExchangeRatesObj.ExchangePRV2PTokenByTokenId(common.PortalBNBIDStr, 40000)
+	assert.NotNil(t, err, "no exchange rate of the token")
+	_, err = convertExchangeRatesObj.ExchangePToken2PRVByTokenId(common.PRVIDStr, 10)
+	assert.NotNil(t, err, "prv is not a ptoken")
+}
@@ -0,0 +1,187 @@
+package blockchain
+
+import (
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/trie"
+)
+
+const (
+	// DefaultPruneKeepViews is the number of finalized heights whose state is
+	// kept when running in prune mode
+	DefaultPruneKeepViews = uint64(1000)
+	pruneStateInterval    = 10 * time.Minute
+)
+
+// StartStatePruning prunes state tries of all chains every pruneStateInterval
+// until cQuit is closed. It is only started when the node runs in
+// common.StateDBModePrune.
+func (blockchain *BlockChain) StartStatePruning(cQuit chan struct{}) {
+	ticker := time.NewTicker(pruneStateInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-cQuit:
+			return
+		case <-ticker.C:
+			if err := blockchain.PruneStateDB(blockchain.config.PruneKeepViews); err != nil {
+				Logger.log.Error(err)
+			}
+		}
+	}
+}
+
+// PruneStateDB deletes every trie node of beacon and shard databases which is
+// not reachable from the state of the current views or of the last keepViews
+// finalized heights. Tries are marked without blocking block insertion, which
+// is only blocked while each batch of nodes is being deleted.
+func (blockchain *BlockChain) PruneStateDB(keepViews uint64) error {
+	if keepViews == 0 {
+		keepViews = DefaultPruneKeepViews
+	}
+	for _, shardChain := range blockchain.ShardChain {
+		shardID := byte(shardChain.shardID)
+		// nodes downloaded by fast sync are not reachable from any view yet
+		if shardChain.isFastSyncing() {
+			Logger.log.Infof("Prune state of shard %+v, skip while fast syncing", shardID)
+			continue
+		}
+		start := time.Now()
+		pruner := statedb.NewPruner(blockchain.GetShardChainDatabase(shardID))
+		if err := markStateRootHashes(pruner, blockchain.shardStateRootHashes(shardID, keepViews)); err != nil {
+			return NewBlockChainError(PruneStateDBError, err)
+		}
+		// refresh is called with insertLock held before deleting nodes
+		refresh := func(pruner *statedb.Pruner) error {
+			if shardChain.fastSync != nil {
+				return errShardFastSyncing
+			}
+			return markStateRootHashes(pruner, blockchain.shardStateRootHashes(shardID, keepViews))
+		}
+		deleted, err := pruner.Sweep(&shardChain.insertLock, refresh)
+		if err == errShardFastSyncing {
+			Logger.log.Infof("Prune state of shard %+v, stop as fast sync is started", shardID)
+			continue
+		}
+		if err != nil {
+			return NewBlockChainError(PruneStateDBError, err)
+		}
+		Logger.log.Infof("Prune state of shard %+v, keep %+v trie nodes, delete %+v trie nodes, time %+v", shardID, pruner.Reachable(), deleted, time.Since(start))
+	}
+	start := time.Now()
+	pruner := statedb.NewPruner(blockchain.GetBeaconChainDatabase())
+	refresh := func(pruner *statedb.Pruner) error {
+		return markStateRootHashes(pruner, blockchain.beaconStateRootHashes(keepViews))
+	}
+	if err := refresh(pruner); err != nil {
+		return NewBlockChainError(PruneStateDBError, err)
+	}
+	deleted, err := pruner.Sweep(&blockchain.BeaconChain.insertLock, refresh)
+	if err != nil {
+		return NewBlockChainError(PruneStateDBError, err)
+	}
+	Logger.log.Infof("Prune state of beacon, keep %+v trie nodes, delete %+v trie nodes, time %+v", pruner.Reachable(), deleted, time.Since(start))
+	return nil
+}
+
+type stateRootHashes struct {
+	// views are roots of views in multiview, they must always be available
+	views []common.Hash
+	// heights are roots stored by height, some of them may already be pruned
+	// when keep views has been decreased between restarts
+	heights []common.Hash
+}
+
+func markStateRootHashes(pruner *statedb.Pruner, roots stateRootHashes) error {
+	for _, root := range roots.views {
+		if err := pruner.Mark(root); err != nil {
+			return err
+		}
+	}
+	for _, root := range roots.heights {
+		if err := pruner.Mark(root); err != nil {
+			if _, ok := err.(*trie.MissingNodeError); ok {
+				Logger.log.Warnf("Prune state, skip incomplete state root %+v, %+v", root, err)
+				continue
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// lowestKeptHeight returns the lowest height whose state must be kept
+func lowestKeptHeight(finalHeight uint64, keepViews uint64) uint64 {
+	if finalHeight <= keepViews {
+		return 1
+	}
+	return finalHeight - keepViews + 1
+}
+
+func (blockchain *BlockChain) beaconStateRootHashes(keepViews uint64) stateRootHashes {
+	roots := stateRootHashes{}
+	for _, v := range blockchain.BeaconChain.multiView.GetAllViewsWithBFS() {
+		view := v.(*BeaconBestState)
+		roots.views = append(roots.views, view.ConsensusStateDBRootHash, view.FeatureStateDBRootHash, view.RewardStateDBRootHash, view.SlashStateDBRootHash)
+	}
+	finalHeight := blockchain.BeaconChain.GetFinalView().GetHeight()
+	low := lowestKeptHeight(finalHeight, keepViews)
+	// shard blocks are processed with the beacon state at heights after their
+	// beacon height, so keep them for shards being synced
+	for _, shardChain := range blockchain.ShardChain {
+		shardView := shardChain.GetFinalView().(*ShardBestState)
+		if shardView.ShardHeight > 1 && shardView.BeaconHeight < low {
+			low = shardView.BeaconHeight
+		}
+	}
+	// shard reward requests read the beacon committee at the start of the previous epoch
+	if epoch := blockchain.config.ChainParams.Epoch; epoch > 0 && low/epoch > 1 {
+		low = (low/epoch - 1) * epoch
+	} else {
+		low = 1
+	}
+	db := blockchain.GetBeaconChainDatabase()
+	getters := []func(incdb.KeyValueReader, uint64) (common.Hash, error){
+		rawdbv2.GetBeaconConsensusStateRootHash,
+		rawdbv2.GetBeaconFeatureStateRootHash,
+		rawdbv2.GetBeaconRewardStateRootHash,
+		rawdbv2.GetBeaconSlashStateRootHash,
+	}
+	for height := low; height <= finalHeight; height++ {
+		for _, getter := range getters {
+			if root, err := getter(db, height); err == nil {
+				roots.heights = append(roots.heights, root)
+			}
+		}
+	}
+	return roots
+}
+
+func (blockchain *BlockChain) shardStateRootHashes(shardID byte, keepViews uint64) stateRootHashes {
+	roots := stateRootHashes{}
+	for _, v := range blockchain.ShardChain[shardID].multiView.GetAllViewsWithBFS() {
+		view := v.(*ShardBestState)
+		roots.views = append(roots.views, view.ConsensusStateDBRootHash, view.TransactionStateDBRootHash, view.FeatureStateDBRootHash, view.RewardStateDBRootHash, view.SlashStateDBRootHash)
+	}
+	finalHeight := blockchain.ShardChain[shardID].GetFinalView().GetHeight()
+	db := blockchain.GetShardChainDatabase(shardID)
+	getters := []func(incdb.KeyValueReader, byte, uint64) (common.Hash, error){
+		rawdbv2.GetShardConsensusRootHash,
+		rawdbv2.GetShardTransactionRootHash,
+		rawdbv2.GetShardFeatureRootHash,
+		rawdbv2.GetShardCommitteeRewardRootHash,
+		rawdbv2.GetShardSlashRootHash,
+	}
+	for height := lowestKeptHeight(finalHeight, keepViews); height <= finalHeight; height++ {
+		for _, getter := range getters {
+			if root, err := getter(db, shardID, height); err == nil {
+				roots.heights = append(roots.heights, root)
+			}
+		}
+	}
+	return roots
+}
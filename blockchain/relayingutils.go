@@ -4,9 +4,11 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/metadata"
 	bnbrelaying "github.com/incognitochain/incognito-chain/relaying/bnb"
 	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
+	ethrelaying "github.com/incognitochain/incognito-chain/relaying/eth"
 	"github.com/pkg/errors"
 	"github.com/tendermint/tendermint/types"
 	"strconv"
@@ -21,6 +23,9 @@ type relayingBNBChain struct {
 type relayingBTCChain struct {
 	*relayingChain
 }
+type relayingETHChain struct {
+	*relayingChain
+}
 type relayingProcessor interface {
 	getActions() [][]string
 	putAction(action []string)
@@ -157,6 +162,38 @@ func (rbtcChain *relayingBTCChain) buildRelayingInst(
 	return [][]string{inst}
 }
 
+func (rethChain *relayingETHChain) buildRelayingInst(
+	blockchain *BlockChain,
+	relayingHeaderAction metadata.RelayingHeaderAction,
+	relayingState *RelayingHeaderChainState,
+) [][]string {
+	meta := relayingHeaderAction.Meta
+	status := common.RelayingHeaderConsideringChainStatus
+	// headers are checked against the relayed chain when the instruction is processed,
+	// only the batch itself is checked here
+	headers, err := ethrelaying.ParseHeadersFromB64EncodeStr(meta.Header)
+	if err == nil {
+		err = ethrelaying.ValidateHeaderBatch(headers)
+	}
+	if err == nil && headers[0].Number.Uint64() != meta.BlockHeight {
+		err = errors.New("Block height in metadata is unmatched with number of the first header")
+	}
+	if err != nil {
+		Logger.log.Errorf("Error - [buildInstructionsForETHHeaderRelaying]: %v\n", err)
+		status = common.RelayingHeaderRejectedChainStatus
+	}
+	inst := rethChain.buildHeaderRelayingInst(
+		meta.IncogAddressStr,
+		meta.Header,
+		meta.BlockHeight,
+		meta.Type,
+		relayingHeaderAction.ShardID,
+		relayingHeaderAction.TxReqID,
+		status,
+	)
+	return [][]string{inst}
+}
+
 func NewPortalManager() *portalManager {
 	rbnbChain := &relayingBNBChain{
 		relayingChain: &relayingChain{
@@ -168,10 +205,16 @@ func NewPortalManager() *portalManager {
 			actions: [][]string{},
 		},
 	}
+	rethChain := &relayingETHChain{
+		relayingChain: &relayingChain{
+			actions: [][]string{},
+		},
+	}
 	return &portalManager{
 		relayingChains: map[int]relayingProcessor{
 			metadata.RelayingBNBHeaderMeta: rbnbChain,
 			metadata.RelayingBTCHeaderMeta: rbtcChain,
+			metadata.RelayingETHHeaderMeta: rethChain,
 		},
 	}
 }
@@ -210,3 +253,13 @@ func (bc *BlockChain) GetBNBBlockByHeight(blockHeight int64) (*types.Block, erro
 	bnbChainState := bc.GetBNBChainState()
 	return bnbChainState.GetBNBBlockByHeight(blockHeight)
 }
+
+// GetETHHeaderChain gets the eth header chain relayed in the feature statedb
+func (bc *BlockChain) GetETHHeaderChain(stateDB *statedb.StateDB) (*ethrelaying.HeaderChain, error) {
+	chainParams, err := ethrelaying.GetChainParams(bc.config.ChainParams.ETHRelayingHeaderChainID)
+	if err != nil {
+		return nil, err
+	}
+	store := ethrelaying.NewStateDBHeaderStore(stateDB)
+	return ethrelaying.NewHeaderChain(store, chainParams, bc.config.ETHSealVerifier), nil
+}
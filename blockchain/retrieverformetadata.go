@@ -36,3 +36,12 @@ func (blockchain *BlockChain) GetBurningAddress(beaconHeight uint64) string {
 
 	return burningAddress2
 }
+
+// IsETHRelayActivated returns true if eth deposits are verified against eth headers relayed on-chain at the beacon height,
+// the final beacon height is used if beaconHeight is 0
+func (blockchain *BlockChain) IsETHRelayActivated(beaconHeight uint64) bool {
+	if beaconHeight == 0 {
+		beaconHeight = blockchain.BeaconChain.GetFinalViewHeight()
+	}
+	return beaconHeight >= blockchain.config.ChainParams.BeaconHeightBreakPointETHRelay
+}
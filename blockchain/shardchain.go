@@ -21,6 +21,7 @@ type ShardChain struct {
 	Ready      bool
 
 	insertLock sync.Mutex
+	fastSync   *ShardFastSync // running fast sync, guarded by insertLock
 }
 
 func NewShardChain(shardID int, multiView *multiview.MultiView, blockGen *BlockGenerator, blockchain *BlockChain, chainName string) *ShardChain {
@@ -197,6 +198,18 @@ func (chain *ShardChain) InsertBlk(block common.BlockInterface, shouldValidate b
 	return err
 }
 
+// NewFastSync prepares to sync the state of a finalized view served by a peer,
+// see ShardFastSync
+func (chain *ShardChain) NewFastSync(viewData []byte) (*ShardFastSync, error) {
+	return chain.Blockchain.NewShardFastSync(byte(chain.shardID), viewData)
+}
+
+func (chain *ShardChain) isFastSyncing() bool {
+	chain.insertLock.Lock()
+	defer chain.insertLock.Unlock()
+	return chain.fastSync != nil
+}
+
 func (chain *ShardChain) InsertAndBroadcastBlock(block common.BlockInterface) error {
 	go chain.Blockchain.config.Server.PushBlockToAll(block, false)
 	err := chain.Blockchain.InsertShardBlock(block.(*ShardBlock), true)
@@ -40,6 +40,13 @@ type ShardHeader struct {
 	//for version 2
 	Proposer    string
 	ProposeTime int64
+
+	//for version 3, state roots of the previous block, used to verify statedb proofs
+	ConsensusStateRoot   common.Hash `json:"ConsensusStateRoot"`
+	TransactionStateRoot common.Hash `json:"TransactionStateRoot"`
+	FeatureStateRoot     common.Hash `json:"FeatureStateRoot"`
+	RewardStateRoot      common.Hash `json:"RewardStateRoot"`
+	SlashStateRoot       common.Hash `json:"SlashStateRoot"`
 }
 
 func (shardHeader *ShardHeader) String() string {
@@ -77,10 +84,17 @@ func (shardHeader *ShardHeader) String() string {
 		res += string(value)
 	}
 
-	if shardHeader.Version == 2 {
+	if shardHeader.Version >= 2 {
 		res += shardHeader.Proposer
 		res += fmt.Sprintf("%v", shardHeader.ProposeTime)
 	}
+	if shardHeader.Version >= STATE_ROOT_BLOCK_VERSION {
+		res += shardHeader.ConsensusStateRoot.String()
+		res += shardHeader.TransactionStateRoot.String()
+		res += shardHeader.FeatureStateRoot.String()
+		res += shardHeader.RewardStateRoot.String()
+		res += shardHeader.SlashStateRoot.String()
+	}
 	return res
 }
 
@@ -298,13 +298,8 @@ func (blockchain *BlockChain) verifyPreProcessingShardBlock(curView *ShardBestSt
 		return NewBlockChainError(WrongTimestampError, fmt.Errorf("Expect receive shardBlock has timestamp must be greater than %+v but get %+v", previousShardBlock.Header.Timestamp, shardBlock.Header.Timestamp))
 	}
 	// Verify transaction root
-	txMerkleTree := Merkle{}.BuildMerkleTreeStore(shardBlock.Body.Transactions)
-	txRoot := &common.Hash{}
-	if len(txMerkleTree) > 0 {
-		txRoot = txMerkleTree[len(txMerkleTree)-1]
-	}
-	if !bytes.Equal(shardBlock.Header.TxRoot.GetBytes(), txRoot.GetBytes()) && shardBlock.Header.Height != 487260 && shardBlock.Header.Height != 487261 {
-		return NewBlockChainError(TransactionRootHashError, fmt.Errorf("Expect transaction root hash %+v but get %+v", shardBlock.Header.TxRoot, txRoot))
+	if err := verifyShardBlockTxRoot(shardBlock); err != nil {
+		return err
 	}
 	// Verify ShardTx Root
 	_, shardTxMerkleData := CreateShardTxRoot(shardBlock.Body.Transactions)
@@ -549,6 +544,19 @@ func (blockchain *BlockChain) verifyPreProcessingShardBlockForSigning(curView *S
 	return nil
 }
 
+// verifyShardBlockTxRoot checks transactions of shardBlock against the transaction root of its header
+func verifyShardBlockTxRoot(shardBlock *ShardBlock) error {
+	txMerkleTree := Merkle{}.BuildMerkleTreeStore(shardBlock.Body.Transactions)
+	txRoot := &common.Hash{}
+	if len(txMerkleTree) > 0 {
+		txRoot = txMerkleTree[len(txMerkleTree)-1]
+	}
+	if !bytes.Equal(shardBlock.Header.TxRoot.GetBytes(), txRoot.GetBytes()) && shardBlock.Header.Height != 487260 && shardBlock.Header.Height != 487261 {
+		return NewBlockChainError(TransactionRootHashError, fmt.Errorf("Expect transaction root hash %+v but get %+v", shardBlock.Header.TxRoot, txRoot))
+	}
+	return nil
+}
+
 // verifyBestStateWithShardBlock will verify the validation of a block with some best state in cache or current best state
 // Get beacon state of this block
 // For example, new blockHeight is 91 then beacon state of this block must have height 90
@@ -556,6 +564,7 @@ func (blockchain *BlockChain) verifyPreProcessingShardBlockForSigning(curView *S
 //	- New Shard Block has parent (previous) hash is current shard state best block hash (compatible with current beststate)
 //	- New Shard Block Height must be compatible with best shard state
 //	- New Shard Block has beacon must higher or equal to beacon height of shard best state
+//	- New Shard Block version is compatible with its beacon height, state roots of version 3 are the ones of shard best state
 func (shardBestState *ShardBestState) verifyBestStateWithShardBlock(blockchain *BlockChain, shardBlock *ShardBlock, isVerifySig bool, shardID byte) error {
 	startTimeVerifyBestStateWithShardBlock := time.Now()
 	Logger.log.Debugf("SHARD %+v | Begin VerifyBestStateWithShardBlock Block with height %+v at hash %+v", shardBlock.Header.ShardID, shardBlock.Header.Height, shardBlock.Hash())
@@ -574,6 +583,14 @@ func (shardBestState *ShardBestState) verifyBestStateWithShardBlock(blockchain *
 	if shardBlock.Header.BeaconHeight < shardBestState.BeaconHeight {
 		return NewBlockChainError(ShardBestStateBeaconHeightNotCompatibleError, fmt.Errorf("Shard Block contain invalid beacon height, current beacon height %+v but get %+v ", shardBestState.BeaconHeight, shardBlock.Header.BeaconHeight))
 	}
+	if err := blockchain.verifyBlockVersion(shardBlock.Header.Version, shardBlock.Header.BeaconHeight); err != nil {
+		return err
+	}
+	if shardBlock.Header.Version >= STATE_ROOT_BLOCK_VERSION {
+		if err := shardBestState.verifyHeaderStateRoots(&shardBlock.Header); err != nil {
+			return err
+		}
+	}
 	shardVerifyWithBestStateTimer.UpdateSince(startTimeVerifyBestStateWithShardBlock)
 	Logger.log.Debugf("SHARD %+v | Finish VerifyBestStateWithShardBlock Block with height %+v at hash %+v", shardBlock.Header.ShardID, shardBlock.Header.Height, shardBlock.Hash())
 	return nil
@@ -635,6 +652,12 @@ func (oldBestState *ShardBestState) updateShardBestState(blockchain *BlockChain,
 	for stakePublicKey, txHash := range stakingTx {
 		shardBestState.StakingTx[stakePublicKey] = txHash
 	}
+	// stake of committee members proven to sign conflicting blocks is never returned
+	for _, beaconBlock := range beaconBlocks {
+		for offender := range blockchain.getSlashedEquivocationOffenders(beaconBlock.Header.Height, beaconBlock.Body.Instructions) {
+			delete(shardBestState.StakingTx, offender)
+		}
+	}
 	if common.IndexOfUint64(shardBlock.Header.BeaconHeight/blockchain.config.ChainParams.Epoch, blockchain.config.ChainParams.EpochBreakPointSwapNewKey) > -1 {
 		err = shardBestState.processShardBlockInstructionForKeyListV2(blockchain, shardBlock, committeeChange)
 	} else {
@@ -902,6 +925,8 @@ func (shardBestState *ShardBestState) verifyPostProcessingShardBlock(shardBlock
 //	4.1 Validate Init Custom Token
 //	5. Validate sanity data of tx
 //	6. Validate data in tx: privacy proof, metadata,...
+//	   skipped by TempTxPool for txs already verified against the transaction state of curView and the beacon feature state,
+//	   when they entered mempool or in a previous verification of the same block (see mempool.ProofCache)
 //	7. Validate tx with blockchain: douple spend, ...
 //	8. Check tx existed in block
 //	9. Not accept a salary tx
@@ -1095,6 +1120,7 @@ func (blockchain *BlockChain) processStoreShardBlock(newShardState *ShardBestSta
 	if err != nil {
 		return NewBlockChainError(StoreShardBlockError, err)
 	}
+	newShardState.SlashStateDBRootHash = slashRootHash
 	newShardState.consensusStateDB.ClearObjects()
 	newShardState.transactionStateDB.ClearObjects()
 	newShardState.featureStateDB.ClearObjects()
@@ -1156,6 +1182,7 @@ func (blockchain *BlockChain) processStoreShardBlock(newShardState *ShardBestSta
 	if err := batchData.Write(); err != nil {
 		return NewBlockChainError(StoreShardBlockError, err)
 	}
+	blockchain.indexOutputCoinsOfShardBlock(shardBlock)
 	shardStoreBlockTimer.UpdateSince(startTimeProcessStoreShardBlock)
 	Logger.log.Infof("SHARD %+v | 🔎 %d transactions in block height %+v \n", shardBlock.Header.ShardID, len(shardBlock.Body.Transactions), blockHeight)
 	return nil
@@ -165,7 +165,7 @@ func (blockchain *BlockChain) NewBlockShard(curView *ShardBestState, version int
 		Producer:          producerKey, //committeeMiningKeys[producerPosition],
 		ProducerPubKeyStr: producerPubKeyStr,
 		ShardID:           shardID,
-		Version:           version,
+		Version:           blockchain.getBlockVersion(version, beaconHeight),
 		PreviousBlockHash: shardBestState.BestBlockHash,
 		Height:            shardBestState.ShardHeight + 1,
 		Round:             round,
@@ -176,6 +176,9 @@ func (blockchain *BlockChain) NewBlockShard(curView *ShardBestState, version int
 		TotalTxsFee:       totalTxsFee,
 		ConsensusType:     curView.ConsensusAlgorithm,
 	}
+	if newShardBlock.Header.Version >= STATE_ROOT_BLOCK_VERSION {
+		curView.setHeaderStateRoots(&newShardBlock.Header)
+	}
 	//============Update Shard BestState=============
 	// startStep = time.Now()
 	newShardBestState, err := shardBestState.updateShardBestState(blockchain, newShardBlock, beaconBlocks, newCommitteeChange())
@@ -300,6 +303,12 @@ func (blockGenerator *BlockGenerator) buildResponseTxsFromBeaconInstructions(cur
 	errorInstructions := [][]string{}   // capture error instruction -> which instruction can not create tx
 	tempAutoStakingM := make(map[uint64]map[string]bool)
 	beaconView := blockGenerator.chain.BeaconChain.GetFinalView().(*BeaconBestState)
+	equivocationOffenders := make(map[string]uint8)
+	for _, beaconBlock := range beaconBlocks {
+		for offender, punishedEpoches := range blockGenerator.chain.getSlashedEquivocationOffenders(beaconBlock.Header.Height, beaconBlock.Body.Instructions) {
+			equivocationOffenders[offender] = punishedEpoches
+		}
+	}
 	for _, beaconBlock := range beaconBlocks {
 		autoStaking, ok := tempAutoStakingM[beaconBlock.Header.Height]
 		if !ok {
@@ -323,6 +332,10 @@ func (blockGenerator *BlockGenerator) buildResponseTxsFromBeaconInstructions(cur
 					if ok && res {
 						continue
 					}
+					// stake of equivocation offender is forfeited
+					if _, ok := equivocationOffenders[outPublicKeys]; ok {
+						continue
+					}
 					tx, err := blockGenerator.buildReturnStakingAmountTx(curView, outPublicKeys, producerPrivateKey, shardID)
 					if err != nil {
 						if strings.Index(err.Error(), "No staking tx in best state") == -1 {
@@ -366,6 +379,18 @@ func (blockGenerator *BlockGenerator) buildResponseTxsFromBeaconInstructions(cur
 				if len(l) >= 4 {
 					newTx, err = blockGenerator.buildPDETradeIssuanceTx(l[2], l[3], producerPrivateKey, shardID, curView, beaconView)
 				}
+			case metadata.PDECrossPoolTradeRequestMeta:
+				if len(l) >= 4 {
+					newTx, err = blockGenerator.buildPDECrossPoolTradeIssuanceTx(l[2], l[3], producerPrivateKey, shardID, curView, beaconView)
+				}
+			case metadata.PDELimitOrderRequestMeta:
+				if len(l) >= 4 && l[2] != common.PDELimitOrderOpenChainStatus {
+					newTx, err = blockGenerator.buildPDELimitOrderIssuanceTx(l[2], l[3], producerPrivateKey, shardID, curView, beaconView)
+				}
+			case metadata.PDECancelOrderRequestMeta:
+				if len(l) >= 4 && l[2] == common.PDECancelOrderAcceptedChainStatus {
+					newTx, err = blockGenerator.buildPDELimitOrderIssuanceTx(common.PDELimitOrderCancelledChainStatus, l[3], producerPrivateKey, shardID, curView, beaconView)
+				}
 			case metadata.PDEWithdrawalRequestMeta:
 				if len(l) >= 4 && l[2] == common.PDEWithdrawalAcceptedChainStatus {
 					newTx, err = blockGenerator.buildPDEWithdrawalTx(l[3], producerPrivateKey, shardID, curView, beaconView)
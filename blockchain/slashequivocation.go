@@ -0,0 +1,236 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/bridgesig"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+// signedBlockInfo - fields of a signed block header which are compared between two conflicting messages
+type signedBlockInfo struct {
+	hash            common.Hash
+	height          uint64
+	proposer        string
+	proposeTimeSlot int64
+	produceTimeSlot int64
+}
+
+func decodeSignedBlockHeader(chainID int, headerBytes []byte) (*signedBlockInfo, error) {
+	if chainID == -1 {
+		header := BeaconHeader{}
+		if err := json.Unmarshal(headerBytes, &header); err != nil {
+			return nil, err
+		}
+		return &signedBlockInfo{
+			hash:            header.Hash(),
+			height:          header.Height,
+			proposer:        header.Proposer,
+			proposeTimeSlot: common.CalculateTimeSlot(header.ProposeTime),
+			produceTimeSlot: common.CalculateTimeSlot(header.Timestamp),
+		}, nil
+	}
+	header := ShardHeader{}
+	if err := json.Unmarshal(headerBytes, &header); err != nil {
+		return nil, err
+	}
+	if int(header.ShardID) != chainID {
+		return nil, fmt.Errorf("expect header of shard %+v but get shard %+v", chainID, header.ShardID)
+	}
+	return &signedBlockInfo{
+		hash:            header.Hash(),
+		height:          header.Height,
+		proposer:        header.Proposer,
+		proposeTimeSlot: common.CalculateTimeSlot(header.ProposeTime),
+		produceTimeSlot: common.CalculateTimeSlot(header.Timestamp),
+	}, nil
+}
+
+// verifySignedConsensusMessage - check the message is signed by the bridge key of offender
+func verifySignedConsensusMessage(evidenceType string, block *signedBlockInfo, msg metadata.SignedConsensusMessage, offenderBriPk []byte) error {
+	var dataHash common.Hash
+	var sig []byte
+	if evidenceType == metadata.EquivocationProposeType {
+		dataHash = block.hash
+		sig = msg.ProducerSig
+	} else {
+		// same data as vote confirmation signed by blsbftv2 validator
+		data := []byte{}
+		data = append(data, block.hash.String()...)
+		data = append(data, msg.BLS...)
+		data = append(data, msg.BRI...)
+		dataHash = common.HashH(data)
+		sig = msg.Confirmation
+	}
+	result, err := bridgesig.Verify(offenderBriPk, dataHash.GetBytes(), sig)
+	if err != nil {
+		return err
+	}
+	if !result {
+		return errors.New("invalid signature of signed message")
+	}
+	return nil
+}
+
+// verifyEquivocationEvidence - return offender committee public key in base58 if both messages of evidence
+// are signed by the offender and conflict with each other.
+// Propose evidence: the same proposer proposes two different blocks at the same height in the same time slot.
+// Vote evidence: the same validator votes for two different blocks at the same height which were created and proposed in the same time slots.
+func verifyEquivocationEvidence(evidence metadata.EquivocationEvidence, activeShards int) (string, error) {
+	if evidence.ChainID < -1 || evidence.ChainID >= activeShards {
+		return "", fmt.Errorf("chain id %+v of evidence is invalid", evidence.ChainID)
+	}
+	offenderKey := incognitokey.CommitteePublicKey{}
+	if err := offenderKey.FromBase58(evidence.Offender); err != nil {
+		return "", err
+	}
+	offender, err := offenderKey.ToBase58()
+	if err != nil {
+		return "", err
+	}
+	offenderBriPk, ok := offenderKey.MiningPubKey[common.BridgeConsensus]
+	if !ok || len(offenderBriPk) == 0 {
+		return "", errors.New("offender has no bridge mining key")
+	}
+	blocks := [2]*signedBlockInfo{}
+	for i, msg := range evidence.Messages {
+		blocks[i], err = decodeSignedBlockHeader(evidence.ChainID, msg.BlockHeader)
+		if err != nil {
+			return "", err
+		}
+		if evidence.Type == metadata.EquivocationProposeType {
+			proposerKey := incognitokey.CommitteePublicKey{}
+			if err := proposerKey.FromBase58(blocks[i].proposer); err != nil {
+				return "", err
+			}
+			proposer, err := proposerKey.ToBase58()
+			if err != nil {
+				return "", err
+			}
+			if proposer != offender {
+				return "", errors.New("offender is not the proposer of signed block")
+			}
+		}
+		if err := verifySignedConsensusMessage(evidence.Type, blocks[i], msg, offenderBriPk); err != nil {
+			return "", err
+		}
+	}
+	if blocks[0].hash.IsEqual(&blocks[1].hash) {
+		return "", errors.New("signed messages are of the same block")
+	}
+	if blocks[0].height != blocks[1].height || blocks[0].proposeTimeSlot != blocks[1].proposeTimeSlot {
+		return "", errors.New("signed blocks are not of the same height and propose time slot")
+	}
+	switch evidence.Type {
+	case metadata.EquivocationProposeType:
+	case metadata.EquivocationVoteType:
+		// voting again for a block created in an earlier time slot is allowed
+		if blocks[0].produceTimeSlot != blocks[1].produceTimeSlot {
+			return "", errors.New("voted blocks are not created in the same time slot")
+		}
+	default:
+		return "", fmt.Errorf("evidence type %+v is invalid", evidence.Type)
+	}
+	return offender, nil
+}
+
+func (blockchain *BlockChain) buildInstructionsForEquivocationEvidence(
+	curView *BeaconBestState,
+	contentStr string,
+	shardID byte,
+	metaType int,
+) [][]string {
+	contentBytes, err := base64.StdEncoding.DecodeString(contentStr)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while decoding content string of equivocation evidence action: %+v", err)
+		return [][]string{}
+	}
+	var equivocationEvidenceAction metadata.EquivocationEvidenceRequestAction
+	err = json.Unmarshal(contentBytes, &equivocationEvidenceAction)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while unmarshaling equivocation evidence action: %+v", err)
+		return [][]string{}
+	}
+	rejectedInst := []string{
+		strconv.Itoa(metaType),
+		strconv.Itoa(int(shardID)),
+		common.EquivocationEvidenceRejectedChainStatus,
+		contentStr,
+	}
+	evidence := equivocationEvidenceAction.Meta.Evidence
+	offender, err := verifyEquivocationEvidence(evidence, blockchain.config.ChainParams.ActiveShards)
+	if err != nil {
+		Logger.log.Warnf("WARN: equivocation evidence of tx %+v is rejected: %+v", equivocationEvidenceAction.TxReqID.String(), err)
+		return [][]string{rejectedInst}
+	}
+	// only punish committee members, validators and candidates who are still staking
+	if common.IndexOfStr(offender, curView.getAllCommitteeValidatorCandidateFlattenList()) == -1 {
+		Logger.log.Warnf("WARN: equivocation evidence of tx %+v is rejected: offender %+v is not staking", equivocationEvidenceAction.TxReqID.String(), offender)
+		return [][]string{rejectedInst}
+	}
+	acceptedContent := metadata.EquivocationEvidenceAcceptedContent{
+		Offender:        offender,
+		ChainID:         evidence.ChainID,
+		Type:            evidence.Type,
+		PunishedEpoches: blockchain.config.ChainParams.EquivocationPunishedEpoches,
+		TxReqID:         equivocationEvidenceAction.TxReqID,
+		ShardID:         shardID,
+	}
+	acceptedContentBytes, err := json.Marshal(acceptedContent)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while marshaling equivocation evidence accepted content: %+v", err)
+		return [][]string{}
+	}
+	acceptedInst := []string{
+		strconv.Itoa(metaType),
+		strconv.Itoa(int(shardID)),
+		common.EquivocationEvidenceAcceptedChainStatus,
+		string(acceptedContentBytes),
+	}
+	return [][]string{acceptedInst}
+}
+
+// getEquivocationOffenders - return offenders of accepted equivocation evidences in beacon instructions with their punished epochs
+func getEquivocationOffenders(instructions [][]string) map[string]uint8 {
+	offenders := make(map[string]uint8)
+	for _, inst := range instructions {
+		if len(inst) != 4 || inst[0] != strconv.Itoa(metadata.EquivocationEvidenceMeta) || inst[2] != common.EquivocationEvidenceAcceptedChainStatus {
+			continue
+		}
+		var acceptedContent metadata.EquivocationEvidenceAcceptedContent
+		if err := json.Unmarshal([]byte(inst[3]), &acceptedContent); err != nil {
+			Logger.log.Errorf("ERROR: an error occured while unmarshaling equivocation evidence accepted content: %+v", err)
+			continue
+		}
+		if epoches, ok := offenders[acceptedContent.Offender]; !ok || epoches < acceptedContent.PunishedEpoches {
+			offenders[acceptedContent.Offender] = acceptedContent.PunishedEpoches
+		}
+	}
+	return offenders
+}
+
+// getSlashedEquivocationOffenders - return offenders of accepted equivocation evidences in instructions of the beacon block at beaconHeight,
+// they are slashed only from BeaconHeightBreakPointSlashStake
+func (blockchain *BlockChain) getSlashedEquivocationOffenders(beaconHeight uint64, instructions [][]string) map[string]uint8 {
+	if beaconHeight < blockchain.config.ChainParams.BeaconHeightBreakPointSlashStake {
+		return map[string]uint8{}
+	}
+	return getEquivocationOffenders(instructions)
+}
+
+// processEquivocationEvidenceInstruction - turn off auto staking of the offender,
+// its stake is not returned when it is swapped out of committee
+func (beaconBestState *BeaconBestState) processEquivocationEvidenceInstruction(blockchain *BlockChain, instruction []string, committeeChange *committeeChange) {
+	for offender := range blockchain.getSlashedEquivocationOffenders(beaconBestState.BeaconHeight, [][]string{instruction}) {
+		if autoStaking, ok := beaconBestState.AutoStaking[offender]; ok && autoStaking {
+			beaconBestState.AutoStaking[offender] = false
+			committeeChange.stopAutoStaking = append(committeeChange.stopAutoStaking, offender)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/bridgesig"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+type equivocationTestSigner struct {
+	committeeKey string
+	briPriKey    []byte
+}
+
+func newEquivocationTestSigner(t *testing.T, seed string) equivocationTestSigner {
+	committeePublicKey, err := incognitokey.NewCommitteeKeyFromSeed([]byte(seed), []byte(seed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	committeeKey, err := committeePublicKey.ToBase58()
+	if err != nil {
+		t.Fatal(err)
+	}
+	briPriKey, _ := bridgesig.KeyGen([]byte(seed))
+	return equivocationTestSigner{
+		committeeKey: committeeKey,
+		briPriKey:    bridgesig.SKBytes(&briPriKey),
+	}
+}
+
+func buildEquivocationTestHeader(t *testing.T, proposer string, height uint64, proposeTime int64, produceTime int64, round int) (ShardHeader, json.RawMessage) {
+	header := ShardHeader{
+		ShardID:     1,
+		Height:      height,
+		Proposer:    proposer,
+		Producer:    proposer,
+		ProposeTime: proposeTime,
+		Timestamp:   produceTime,
+		Round:       round,
+	}
+	headerBytes, err := json.Marshal(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return header, headerBytes
+}
+
+func signEquivocationTestProposal(t *testing.T, signer equivocationTestSigner, header ShardHeader, headerBytes json.RawMessage) metadata.SignedConsensusMessage {
+	hash := header.Hash()
+	sig, err := bridgesig.Sign(signer.briPriKey, hash.GetBytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return metadata.SignedConsensusMessage{BlockHeader: headerBytes, ProducerSig: sig}
+}
+
+func signEquivocationTestVote(t *testing.T, signer equivocationTestSigner, header ShardHeader, headerBytes json.RawMessage) metadata.SignedConsensusMessage {
+	hash := header.Hash()
+	msg := metadata.SignedConsensusMessage{BlockHeader: headerBytes, BLS: []byte{1, 2, 3}}
+	data := []byte{}
+	data = append(data, hash.String()...)
+	data = append(data, msg.BLS...)
+	data = append(data, msg.BRI...)
+	confirmation, err := bridgesig.Sign(signer.briPriKey, common.HashB(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg.Confirmation = confirmation
+	return msg
+}
+
+func TestVerifyProposeEquivocationEvidence(t *testing.T) {
+	proposer := newEquivocationTestSigner(t, "equivocation proposer seed")
+	other := newEquivocationTestSigner(t, "equivocation other seed")
+	header1, headerBytes1 := buildEquivocationTestHeader(t, proposer.committeeKey, 100, 1000, 1000, 1)
+	header2, headerBytes2 := buildEquivocationTestHeader(t, proposer.committeeKey, 100, 1000, 1000, 2)
+	evidence := metadata.EquivocationEvidence{
+		Type:     metadata.EquivocationProposeType,
+		ChainID:  1,
+		Offender: proposer.committeeKey,
+		Messages: [2]metadata.SignedConsensusMessage{
+			signEquivocationTestProposal(t, proposer, header1, headerBytes1),
+			signEquivocationTestProposal(t, proposer, header2, headerBytes2),
+		},
+	}
+	offender, err := verifyEquivocationEvidence(evidence, 8)
+	if err != nil {
+		t.Fatalf("expect evidence to be accepted but get %v", err)
+	}
+	if offender != proposer.committeeKey {
+		t.Fatalf("expect offender %v but get %v", proposer.committeeKey, offender)
+	}
+
+	// the same block twice is not an equivocation
+	sameBlockEvidence := evidence
+	sameBlockEvidence.Messages[1] = sameBlockEvidence.Messages[0]
+	if _, err := verifyEquivocationEvidence(sameBlockEvidence, 8); err == nil {
+		t.Fatal("expect evidence of the same block to be rejected")
+	}
+
+	// blocks proposed in different time slots
+	header3, headerBytes3 := buildEquivocationTestHeader(t, proposer.committeeKey, 100, 1000+common.TIMESLOT, 1000, 1)
+	differentSlotEvidence := evidence
+	differentSlotEvidence.Messages[1] = signEquivocationTestProposal(t, proposer, header3, headerBytes3)
+	if _, err := verifyEquivocationEvidence(differentSlotEvidence, 8); err == nil {
+		t.Fatal("expect evidence of different time slots to be rejected")
+	}
+
+	// signed by another key
+	forgedEvidence := evidence
+	forgedEvidence.Messages[1] = signEquivocationTestProposal(t, other, header2, headerBytes2)
+	if _, err := verifyEquivocationEvidence(forgedEvidence, 8); err == nil {
+		t.Fatal("expect evidence with forged signature to be rejected")
+	}
+
+	// wrong chain id
+	wrongChainEvidence := evidence
+	wrongChainEvidence.ChainID = 2
+	if _, err := verifyEquivocationEvidence(wrongChainEvidence, 8); err == nil {
+		t.Fatal("expect evidence of another chain to be rejected")
+	}
+}
+
+func TestVerifyVoteEquivocationEvidence(t *testing.T) {
+	proposer := newEquivocationTestSigner(t, "equivocation proposer seed")
+	validator := newEquivocationTestSigner(t, "equivocation validator seed")
+	header1, headerBytes1 := buildEquivocationTestHeader(t, proposer.committeeKey, 100, 1000, 1000, 1)
+	header2, headerBytes2 := buildEquivocationTestHeader(t, proposer.committeeKey, 100, 1000, 1000, 2)
+	evidence := metadata.EquivocationEvidence{
+		Type:     metadata.EquivocationVoteType,
+		ChainID:  1,
+		Offender: validator.committeeKey,
+		Messages: [2]metadata.SignedConsensusMessage{
+			signEquivocationTestVote(t, validator, header1, headerBytes1),
+			signEquivocationTestVote(t, validator, header2, headerBytes2),
+		},
+	}
+	offender, err := verifyEquivocationEvidence(evidence, 8)
+	if err != nil {
+		t.Fatalf("expect evidence to be accepted but get %v", err)
+	}
+	if offender != validator.committeeKey {
+		t.Fatalf("expect offender %v but get %v", validator.committeeKey, offender)
+	}
+
+	// voting again for a block created in an earlier time slot is allowed
+	header3, headerBytes3 := buildEquivocationTestHeader(t, proposer.committeeKey, 100, 1000, 1000-common.TIMESLOT, 1)
+	reVoteEvidence := evidence
+	reVoteEvidence.Messages[1] = signEquivocationTestVote(t, validator, header3, headerBytes3)
+	if _, err := verifyEquivocationEvidence(reVoteEvidence, 8); err == nil {
+		t.Fatal("expect evidence of blocks created in different time slots to be rejected")
+	}
+}
+
+func TestGetEquivocationOffenders(t *testing.T) {
+	metaType := strconv.Itoa(metadata.EquivocationEvidenceMeta)
+	buildInst := func(offender string, punishedEpoches uint8, status string) []string {
+		content, _ := json.Marshal(metadata.EquivocationEvidenceAcceptedContent{
+			Offender:        offender,
+			PunishedEpoches: punishedEpoches,
+		})
+		return []string{metaType, "0", status, string(content)}
+	}
+	insts := [][]string{
+		buildInst("offender1", 5, common.EquivocationEvidenceAcceptedChainStatus),
+		buildInst("offender1", 10, common.EquivocationEvidenceAcceptedChainStatus),
+		buildInst("offender2", 10, common.EquivocationEvidenceRejectedChainStatus),
+		{SwapAction, "", "", "shard", "0"},
+	}
+	offenders := getEquivocationOffenders(insts)
+	if len(offenders) != 1 || offenders["offender1"] != 10 {
+		t.Fatalf("expect only offender1 with 10 punished epoches but get %v", offenders)
+	}
+
+	// offenders are slashed only from the break point
+	blockchain := &BlockChain{config: Config{ChainParams: &Params{BeaconHeightBreakPointSlashStake: 100}}}
+	if offenders := blockchain.getSlashedEquivocationOffenders(99, insts); len(offenders) != 0 {
+		t.Fatalf("expect no offender before the break point but get %v", offenders)
+	}
+	if offenders := blockchain.getSlashedEquivocationOffenders(100, insts); len(offenders) != 1 {
+		t.Fatalf("expect offender1 at the break point but get %v", offenders)
+	}
+}
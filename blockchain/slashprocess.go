@@ -155,6 +155,13 @@ func (blockchain *BlockChain) processForSlashing(slashStateDB *statedb.StateDB,
 			}
 		}
 	}
+	// committee members proven to sign conflicting blocks
+	for producer, punishedEpoches := range blockchain.getSlashedEquivocationOffenders(beaconHeight, beaconBlock.GetInstructions()) {
+		epoches, found := producersBlackList[producer]
+		if !found || epoches < punishedEpoches {
+			producersBlackList[producer] = punishedEpoches
+		}
+	}
 	for _, punishedProducerFinished := range punishedProducersFinished {
 		flag := false
 		for producerBlaskList, _ := range producersBlackList {
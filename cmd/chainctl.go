@@ -16,22 +16,23 @@ import (
 	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/badgerdb"
 	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
 	"github.com/incognitochain/incognito-chain/mempool"
 	"github.com/incognitochain/incognito-chain/pubsub"
 )
 
-func makeBlockChain(databaseDir string, testNet bool) (*blockchain.BlockChain, error) {
+func makeBlockChain(dbDriver string, databaseDir string, testNet bool) (*blockchain.BlockChain, error) {
 	blockchain.Logger.Init(common.NewBackend(nil).Logger("ChainCMD", true))
 	blockchain.BLogger.Init(common.NewBackend(nil).Logger("ChainCMD", true))
 	mempool.Logger.Init(common.NewBackend(nil).Logger("ChainCMD", true))
 	dataaccessobject.Logger.Init(common.NewBackend(nil).Logger("ChainCMD", true))
 	trie.Logger.Init(common.NewBackend(nil).Logger("ChainCMD", true))
-	db, err := incdb.Open("leveldb", filepath.Join(databaseDir))
+	db, err := incdb.OpenMultipleDB(dbDriver, filepath.Join(databaseDir))
 	if err != nil {
 		return nil, err
 	}
-	log.Printf("Open leveldb at %+v successfully", filepath.Join(databaseDir))
+	log.Printf("Open %s at %+v successfully", dbDriver, filepath.Join(databaseDir))
 	bc := blockchain.NewBlockChain(&blockchain.Config{}, false)
 	var bcParams *blockchain.Params
 	if testNet {
@@ -15,6 +15,7 @@ const (
 	defaultConfigFilename = "component.conf"
 	defaultDataDirname    = "data"
 	defaultLogDirname     = "logs"
+	defaultDBDriver       = "leveldb"
 )
 
 var (
@@ -39,6 +40,9 @@ type params struct {
 	ChainDataDir string `long:"chaindatadir" description:"Directory of Stored Blockchain Database"`
 	OutDataDir   string `long:"outdatadir" description:"Directory of Export Blockchain Data"`
 	FileName     string `long:"filename" description:"Filename of Backup Blockchin Data"`
+	DBDriver     string `long:"dbdriver" description:"Database driver of chaindatadir {leveldb, badgerdb}"`
+	OutDBDriver  string `long:"outdbdriver" description:"Database driver of outdatadir when migrating database {leveldb, badgerdb}"`
+	KeepViews    uint64 `long:"keepviews" description:"Number of finalized heights whose state is kept when pruning state database"`
 	// wallet
 	WalletName        string `long:"wallet" description:"Wallet Database Name file, default is 'wallet'"`
 	WalletPassphrase  string `long:"walletpassphrase" description:"Wallet passphrase"`
@@ -58,8 +62,10 @@ func newConfigParser(cfg *params, options flags.Options) *flags.Parser {
 
 func loadParams() (*params, error) {
 	cfg := params{
-		DataDir: defaultDataDir,
-		TestNet: false,
+		DataDir:   defaultDataDir,
+		TestNet:   false,
+		DBDriver:  defaultDBDriver,
+		KeepViews: blockchain.DefaultPruneKeepViews,
 	}
 
 	preParser := newConfigParser(&cfg, flags.HelpFlag)
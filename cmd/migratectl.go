@@ -0,0 +1,339 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"syscall"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incdb"
+)
+
+const migrationProgressFilename = "migration-progress.json"
+
+var errMigrationInterrupted = errors.New("migration interrupted, run the same command again to resume")
+
+// migrationProgress records how far the copy of each database returned by
+// incdb.OpenMultipleDB has gone. It is stored next to the destination databases
+// so an interrupted migration resumes after the last written batch.
+type migrationProgress struct {
+	// LastKey is the hex encoded last key written into each database
+	LastKey map[int]string `json:"LastKey"`
+	// Done marks databases which have been fully copied
+	Done map[int]bool `json:"Done"`
+	file string
+}
+
+func loadMigrationProgress(outDatadir string) (*migrationProgress, error) {
+	progress := &migrationProgress{
+		LastKey: make(map[int]string),
+		Done:    make(map[int]bool),
+		file:    filepath.Join(outDatadir, migrationProgressFilename),
+	}
+	data, err := ioutil.ReadFile(progress.file)
+	if os.IsNotExist(err) {
+		return progress, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, progress); err != nil {
+		return nil, err
+	}
+	return progress, nil
+}
+
+func (progress *migrationProgress) save() error {
+	data, err := json.Marshal(progress)
+	if err != nil {
+		return err
+	}
+	tmpFile := progress.file + ".tmp"
+	if err := ioutil.WriteFile(tmpFile, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile, progress.file)
+}
+
+// migrateChainDatabase copies every key/value pair of the beacon and shard
+// databases stored with srcDriver in chainDatadir into new databases stored with
+// dstDriver in outDatadir, then verifies the copy.
+func migrateChainDatabase(srcDriver string, chainDatadir string, dstDriver string, outDatadir string) error {
+	if srcDriver == dstDriver && filepath.Clean(chainDatadir) == filepath.Clean(outDatadir) {
+		return errors.New("source and destination database are the same")
+	}
+	if err := os.MkdirAll(outDatadir, os.ModePerm); err != nil {
+		return err
+	}
+	progress, err := loadMigrationProgress(outDatadir)
+	if err != nil {
+		return err
+	}
+	srcDBs, err := incdb.OpenMultipleDB(srcDriver, chainDatadir)
+	if err != nil {
+		return err
+	}
+	defer closeDatabases(srcDBs)
+	dstDBs, err := incdb.OpenMultipleDB(dstDriver, outDatadir)
+	if err != nil {
+		return err
+	}
+	defer closeDatabases(dstDBs)
+
+	// Watch for Ctrl-C while the migration is running.
+	// If a signal is received, the migration will stop after the current batch.
+	interrupt := make(chan os.Signal, 1)
+	stop := make(chan struct{})
+	signal.Notify(interrupt, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(interrupt)
+	defer close(interrupt)
+	go func() {
+		if _, ok := <-interrupt; ok {
+			log.Println("Interrupted during migration, stopping at next batch")
+		}
+		close(stop)
+	}()
+	checkInterrupt := func() bool {
+		select {
+		case <-stop:
+			return true
+		default:
+			return false
+		}
+	}
+
+	dbIDs := []int{}
+	for dbID := range srcDBs {
+		dbIDs = append(dbIDs, dbID)
+	}
+	sort.Ints(dbIDs)
+	for _, dbID := range dbIDs {
+		if progress.Done[dbID] {
+			log.Printf("Database %+v already migrated, skip", dbID)
+			continue
+		}
+		if err := copyDatabase(dbID, srcDBs[dbID], dstDBs[dbID], progress, checkInterrupt); err != nil {
+			return err
+		}
+	}
+	for _, dbID := range dbIDs {
+		count, err := compareDatabase(srcDBs[dbID], dstDBs[dbID])
+		if err != nil {
+			return fmt.Errorf("verify database %+v failed, %+v", dbID, err)
+		}
+		if err := verifyStateRootHashes(dbID, srcDBs[dbID], dstDBs[dbID]); err != nil {
+			return fmt.Errorf("verify state root hash of database %+v failed, %+v", dbID, err)
+		}
+		log.Printf("Verify database %+v successfully, %+v keys", dbID, count)
+	}
+	if err := os.Remove(progress.file); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	log.Printf("Migrate database from %+v (%+v) to %+v (%+v) successfully", chainDatadir, srcDriver, outDatadir, dstDriver)
+	return nil
+}
+
+// copyDatabase copies src into dst in batches of about incdb.IdealBatchSize
+// bytes, recording the last copied key after each batch so the copy can resume
+// from there.
+func copyDatabase(dbID int, src incdb.Database, dst incdb.Database, progress *migrationProgress, checkInterrupt func() bool) error {
+	var resumeKey []byte
+	var it incdb.Iterator
+	if progress.LastKey[dbID] != "" {
+		var err error
+		resumeKey, err = hex.DecodeString(progress.LastKey[dbID])
+		if err != nil {
+			return err
+		}
+		log.Printf("Resume database %+v from key %+v", dbID, progress.LastKey[dbID])
+		it = src.NewIteratorWithStart(resumeKey)
+	} else {
+		it = src.NewIterator()
+	}
+	defer it.Release()
+
+	batch := dst.NewBatch()
+	var lastKey []byte
+	count, batches := 0, 0
+	flush := func() error {
+		if err := batch.Write(); err != nil {
+			return err
+		}
+		batch.Reset()
+		progress.LastKey[dbID] = hex.EncodeToString(lastKey)
+		return progress.save()
+	}
+	for it.Next() {
+		if resumeKey != nil && bytes.Equal(it.Key(), resumeKey) {
+			continue
+		}
+		if err := batch.Put(it.Key(), it.Value()); err != nil {
+			return err
+		}
+		lastKey = append(lastKey[:0], it.Key()...)
+		count++
+		if batch.ValueSize() >= incdb.IdealBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+			if batches++; batches%100 == 0 {
+				log.Printf("Migrate database %+v, copied %+v keys", dbID, count)
+			}
+			if checkInterrupt() {
+				return errMigrationInterrupted
+			}
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	if lastKey != nil {
+		if err := flush(); err != nil {
+			return err
+		}
+	}
+	progress.Done[dbID] = true
+	if err := progress.save(); err != nil {
+		return err
+	}
+	log.Printf("Migrate database %+v successfully, copied %+v keys", dbID, count)
+	return nil
+}
+
+// compareDatabase walks src and dst side by side and returns the number of keys
+// if both contain exactly the same key/value pairs.
+func compareDatabase(src incdb.Database, dst incdb.Database) (int, error) {
+	srcIt := src.NewIterator()
+	defer srcIt.Release()
+	dstIt := dst.NewIterator()
+	defer dstIt.Release()
+	count := 0
+	for {
+		srcNext, dstNext := srcIt.Next(), dstIt.Next()
+		if !srcNext || !dstNext {
+			if srcNext != dstNext {
+				return count, fmt.Errorf("number of keys mismatch after %+v keys", count)
+			}
+			break
+		}
+		if !bytes.Equal(srcIt.Key(), dstIt.Key()) {
+			return count, fmt.Errorf("key mismatch at %+v, expect %x, got %x", count, srcIt.Key(), dstIt.Key())
+		}
+		if !bytes.Equal(srcIt.Value(), dstIt.Value()) {
+			return count, fmt.Errorf("value mismatch at key %x", srcIt.Key())
+		}
+		count++
+	}
+	if err := srcIt.Error(); err != nil {
+		return count, err
+	}
+	return count, dstIt.Error()
+}
+
+// verifyStateRootHashes checks that every view of the stored best state has the
+// same state root hashes in src and dst, and that dst can open those tries.
+func verifyStateRootHashes(dbID int, src incdb.Database, dst incdb.Database) error {
+	type rootHashGetter struct {
+		name   string
+		root   common.Hash
+		getter func(db incdb.KeyValueReader) (common.Hash, error)
+	}
+	var getters []rootHashGetter
+	if dbID == common.BeaconChainDataBaseID {
+		b, err := rawdbv2.GetBeaconBestState(dst)
+		if err != nil {
+			log.Printf("Database %+v has no beacon best state, skip state root verification", dbID)
+			return nil
+		}
+		allViews := []*blockchain.BeaconBestState{}
+		if err := json.Unmarshal(b, &allViews); err != nil {
+			return err
+		}
+		for _, v := range allViews {
+			height := v.BeaconHeight
+			getters = append(getters,
+				rootHashGetter{"consensus", v.ConsensusStateDBRootHash, func(db incdb.KeyValueReader) (common.Hash, error) {
+					return rawdbv2.GetBeaconConsensusStateRootHash(db, height)
+				}},
+				rootHashGetter{"feature", v.FeatureStateDBRootHash, func(db incdb.KeyValueReader) (common.Hash, error) {
+					return rawdbv2.GetBeaconFeatureStateRootHash(db, height)
+				}},
+				rootHashGetter{"reward", v.RewardStateDBRootHash, func(db incdb.KeyValueReader) (common.Hash, error) {
+					return rawdbv2.GetBeaconRewardStateRootHash(db, height)
+				}},
+				rootHashGetter{"slash", v.SlashStateDBRootHash, func(db incdb.KeyValueReader) (common.Hash, error) {
+					return rawdbv2.GetBeaconSlashStateRootHash(db, height)
+				}},
+			)
+		}
+	} else {
+		shardID := byte(dbID)
+		b, err := rawdbv2.GetShardBestState(dst, shardID)
+		if err != nil {
+			log.Printf("Database %+v has no shard best state, skip state root verification", dbID)
+			return nil
+		}
+		allViews := []*blockchain.ShardBestState{}
+		if err := json.Unmarshal(b, &allViews); err != nil {
+			return err
+		}
+		for _, v := range allViews {
+			height := v.ShardHeight
+			getters = append(getters,
+				rootHashGetter{"consensus", v.ConsensusStateDBRootHash, func(db incdb.KeyValueReader) (common.Hash, error) {
+					return rawdbv2.GetShardConsensusRootHash(db, shardID, height)
+				}},
+				rootHashGetter{"transaction", v.TransactionStateDBRootHash, func(db incdb.KeyValueReader) (common.Hash, error) {
+					return rawdbv2.GetShardTransactionRootHash(db, shardID, height)
+				}},
+				rootHashGetter{"feature", v.FeatureStateDBRootHash, func(db incdb.KeyValueReader) (common.Hash, error) {
+					return rawdbv2.GetShardFeatureRootHash(db, shardID, height)
+				}},
+				rootHashGetter{"reward", v.RewardStateDBRootHash, func(db incdb.KeyValueReader) (common.Hash, error) {
+					return rawdbv2.GetShardCommitteeRewardRootHash(db, shardID, height)
+				}},
+				rootHashGetter{"slash", v.SlashStateDBRootHash, func(db incdb.KeyValueReader) (common.Hash, error) {
+					return rawdbv2.GetShardSlashRootHash(db, shardID, height)
+				}},
+			)
+		}
+	}
+	dbAccessWarper := statedb.NewDatabaseAccessWarper(dst)
+	for _, g := range getters {
+		srcRoot, err := g.getter(src)
+		if err != nil {
+			return err
+		}
+		dstRoot, err := g.getter(dst)
+		if err != nil {
+			return err
+		}
+		if !srcRoot.IsEqual(&dstRoot) || !dstRoot.IsEqual(&g.root) {
+			return fmt.Errorf("%+v state root hash mismatch, view %+v, source %+v, destination %+v", g.name, g.root, srcRoot, dstRoot)
+		}
+		if _, err := statedb.NewWithPrefixTrie(dstRoot, dbAccessWarper); err != nil {
+			return fmt.Errorf("open %+v state root hash %+v failed, %+v", g.name, dstRoot, err)
+		}
+	}
+	return nil
+}
+
+func closeDatabases(dbs map[int]incdb.Database) {
+	for dbID, db := range dbs {
+		if err := db.Close(); err != nil {
+			log.Printf("Close database %+v failed, err %+v", dbID, err)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func fillDatabases(t *testing.T, driver string, dir string, numberOfKeys int) {
+	dbs, err := incdb.OpenMultipleDB(driver, dir)
+	assert.Equal(t, nil, err)
+	defer closeDatabases(dbs)
+	for dbID, db := range dbs {
+		for i := 0; i < numberOfKeys; i++ {
+			key := []byte(fmt.Sprintf("key-%d-%06d", dbID, i))
+			assert.Equal(t, nil, db.Put(key, make([]byte, 1024)))
+		}
+	}
+}
+
+func TestMigrateChainDatabase(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "test_migrate_")
+	assert.Equal(t, nil, err)
+	defer os.RemoveAll(dir)
+	srcDir, dstDir := filepath.Join(dir, "src"), filepath.Join(dir, "dst")
+	numberOfKeys := 300
+	fillDatabases(t, "leveldb", srcDir, numberOfKeys)
+
+	// stop right after the first batch to simulate an interruption
+	progress, err := loadMigrationProgress(dstDir)
+	assert.Equal(t, nil, err)
+	srcDBs, err := incdb.OpenMultipleDB("leveldb", srcDir)
+	assert.Equal(t, nil, err)
+	dstDBs, err := incdb.OpenMultipleDB("badgerdb", dstDir)
+	assert.Equal(t, nil, err)
+	err = copyDatabase(common.BeaconChainDataBaseID, srcDBs[common.BeaconChainDataBaseID], dstDBs[common.BeaconChainDataBaseID], progress, func() bool { return true })
+	assert.Equal(t, errMigrationInterrupted, err)
+	assert.NotEqual(t, "", progress.LastKey[common.BeaconChainDataBaseID])
+	assert.Equal(t, false, progress.Done[common.BeaconChainDataBaseID])
+	closeDatabases(srcDBs)
+	closeDatabases(dstDBs)
+
+	err = migrateChainDatabase("leveldb", srcDir, "badgerdb", dstDir)
+	assert.Equal(t, nil, err)
+	_, err = os.Stat(filepath.Join(dstDir, migrationProgressFilename))
+	assert.Equal(t, true, os.IsNotExist(err))
+
+	dstDBs, err = incdb.OpenMultipleDB("badgerdb", dstDir)
+	assert.Equal(t, nil, err)
+	defer closeDatabases(dstDBs)
+	for dbID, db := range dstDBs {
+		it := db.NewIterator()
+		count := 0
+		for it.Next() {
+			count++
+		}
+		it.Release()
+		assert.Equal(t, numberOfKeys, count, "database %d", dbID)
+	}
+}
+
+func TestCompareDatabase(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "test_migrate_")
+	assert.Equal(t, nil, err)
+	defer os.RemoveAll(dir)
+	src, err := incdb.Open("leveldb", filepath.Join(dir, "src"))
+	assert.Equal(t, nil, err)
+	defer src.Close()
+	dst, err := incdb.Open("badgerdb", filepath.Join(dir, "dst"))
+	assert.Equal(t, nil, err)
+	defer dst.Close()
+
+	assert.Equal(t, nil, src.Put([]byte("a"), []byte("1")))
+	assert.Equal(t, nil, src.Put([]byte("b"), []byte("2")))
+	assert.Equal(t, nil, dst.Put([]byte("a"), []byte("1")))
+	_, err = compareDatabase(src, dst)
+	assert.NotEqual(t, nil, err)
+
+	assert.Equal(t, nil, dst.Put([]byte("b"), []byte("3")))
+	_, err = compareDatabase(src, dst)
+	assert.NotEqual(t, nil, err)
+
+	assert.Equal(t, nil, dst.Put([]byte("b"), []byte("2")))
+	count, err := compareDatabase(src, dst)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, count)
+}
@@ -130,7 +130,7 @@ func processCmd() {
 				log.Println("No Expected Params")
 				return
 			}
-			bc, err := makeBlockChain(cfg.ChainDataDir, cfg.TestNet)
+			bc, err := makeBlockChain(cfg.DBDriver, cfg.ChainDataDir, cfg.TestNet)
 			if err != nil {
 				log.Println("Error create blockchain variable ", err)
 				return
@@ -193,7 +193,7 @@ func processCmd() {
 				log.Println("No Backup File to Process")
 				return
 			}
-			bc, err := makeBlockChain(cfg.ChainDataDir, cfg.TestNet)
+			bc, err := makeBlockChain(cfg.DBDriver, cfg.ChainDataDir, cfg.TestNet)
 			if err != nil {
 				log.Println("Error create blockchain variable ", err)
 				return
@@ -213,5 +213,33 @@ func processCmd() {
 				}
 			}
 		}
+	case migrateDatabase:
+		{
+			if cfg.ChainDataDir == "" || cfg.OutDataDir == "" || cfg.OutDBDriver == "" {
+				log.Println("Wrong param")
+				return
+			}
+			err := migrateChainDatabase(cfg.DBDriver, cfg.ChainDataDir, cfg.OutDBDriver, cfg.OutDataDir)
+			if err != nil {
+				log.Printf("Migrate database failed, err %+v", err)
+			}
+		}
+	case pruneStateDatabase:
+		{
+			if cfg.ChainDataDir == "" {
+				log.Println("Wrong param")
+				return
+			}
+			bc, err := makeBlockChain(cfg.DBDriver, cfg.ChainDataDir, cfg.TestNet)
+			if err != nil {
+				log.Println("Error create blockchain variable ", err)
+				return
+			}
+			if err := bc.PruneStateDB(cfg.KeepViews); err != nil {
+				log.Printf("Prune state database failed, err %+v", err)
+				return
+			}
+			log.Printf("Prune state database successfully, keep state of last %+v finalized heights", cfg.KeepViews)
+		}
 	}
 }
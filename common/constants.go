@@ -69,6 +69,9 @@ const (
 	NodeModeAuto   = "auto"
 	NodeModeBeacon = "beacon"
 
+	StateDBModeArchive = "archive" // keep state of every height
+	StateDBModePrune   = "prune"   // only keep state of recent heights
+
 	BeaconRole     = "beacon"
 	ShardRole      = "shard"
 	CommitteeRole  = "committee"
@@ -123,6 +126,12 @@ const (
 	PDEWithdrawalAcceptedStatus = 1
 	PDEWithdrawalRejectedStatus = 2
 
+	PDELimitOrderOpenStatus      = 1
+	PDELimitOrderFilledStatus    = 2
+	PDELimitOrderRefundStatus    = 3
+	PDELimitOrderExpiredStatus   = 4
+	PDELimitOrderCancelledStatus = 5
+
 	MinTxFeesOnTokenRequirement                             = 10000000000000 // 10000 prv, this requirement is applied from beacon height 87301 mainnet
 	BeaconBlockHeighMilestoneForMinTxFeesOnTokenRequirement = 87301          // milestone of beacon height, when apply min fee on token requirement
 
@@ -196,6 +205,21 @@ const (
 
 	PDEWithdrawalAcceptedChainStatus = "accepted"
 	PDEWithdrawalRejectedChainStatus = "rejected"
+
+	PDELimitOrderOpenChainStatus      = "open"
+	PDELimitOrderFilledChainStatus    = "filled"
+	PDELimitOrderRefundChainStatus    = "refund"
+	PDELimitOrderExpiredChainStatus   = "expired"
+	PDELimitOrderCancelledChainStatus = "cancelled"
+
+	PDECancelOrderAcceptedChainStatus = "accepted"
+	PDECancelOrderRejectedChainStatus = "rejected"
+)
+
+// Equivocation evidence statuses for chain
+const (
+	EquivocationEvidenceAcceptedChainStatus = "accepted"
+	EquivocationEvidenceRejectedChainStatus = "rejected"
 )
 
 // Portal status for chain
@@ -260,18 +284,6 @@ const PortalBTCIDStr = "ef5947f70ead81a76a53c7c8b7317dd5245510c665d3a13921dc9a58
 const PortalBNBIDStr = "6abd698ea7ddd1f98b1ecaaddab5db0453b8363ff092f0d8d7d4c6b1155fb693"
 const PRVIDStr = "0000000000000000000000000000000000000000000000000000000000000004"
 
-var PortalSupportedIncTokenIDs = []string{
-	PortalBTCIDStr, // pBTC
-	PortalBNBIDStr, // pBNB
-}
-
-// set MinAmountPortalPToken to avoid attacking with amount is less than smallest unit of cryptocurrency
-// such as satoshi in BTC
-var MinAmountPortalPToken = map[string]uint64{
-	PortalBTCIDStr: 10,
-	PortalBNBIDStr: 10,
-}
-
 const (
 	HexEmptyRoot = "56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421"
 )
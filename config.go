@@ -15,7 +15,9 @@ import (
 	"strings"
 
 	"github.com/davecgh/go-spew/spew"
+	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/wire"
 	"github.com/jessevdk/go-flags"
 )
 
@@ -25,6 +27,9 @@ const (
 	DefaultDataDirname                 = "data"
 	DefaultDatabaseDirname             = "block"
 	DefaultDatabaseMempoolDirname      = "mempool"
+	DefaultDatabaseDriver              = "leveldb"
+	DefaultStateDBMode                 = common.StateDBModeArchive
+	DefaultPruneKeepViews              = blockchain.DefaultPruneKeepViews
 	DefaultLogLevel                    = "info"
 	DefaultLogDirname                  = "logs"
 	DefaultLogFilename                 = "log.log"
@@ -43,9 +48,14 @@ const (
 	DefaultDisableRpcTLS               = true
 	DefaultFastStartup                 = true
 	DefaultNodeMode                    = common.NodeModeRelay
+	DefaultWireCodec                   = wire.WireCodecLegacy
+	DefaultNumHighways                 = 2
 	DefaultEnableMining                = true
 	DefaultTxPoolTTL                   = uint(15 * 60) // 15 minutes
 	DefaultTxPoolMaxTx                 = uint64(100000)
+	DefaultTxPoolMaxTxPerSender        = uint64(1000)
+	DefaultTxPoolMaxTxPerMetaType      = uint64(10000)
+	DefaultTxPoolProofCacheSize        = 20000
 	DefaultLimitFee                    = uint64(1) // 1 nano PRV = 10^-9 PRV
 	//DefaultLimitFee = uint64(100000) // 100000 nano PRV = 100000 * 10^-9 PRV
 	// For wallet
@@ -76,6 +86,12 @@ type config struct {
 	DataDir            string `short:"D" long:"datadir" description:"Directory to store data"`
 	DatabaseDir        string `short:"d" long:"datapre" description:"Database dir"`
 	DatabaseMempoolDir string `short:"m" long:"datamempool" description:"Mempool Database Dir"`
+	DatabaseDriver     string `long:"dbdriver" description:"Database driver used to store chain data {leveldb, badgerdb}"`
+	StateDBMode        string `long:"statedbmode" description:"State database mode {archive, prune} -- archive keeps state of every height, prune only keeps state of the last prunekeepviews finalized heights"`
+	PruneKeepViews     uint64 `long:"prunekeepviews" description:"Number of finalized heights whose state is kept in prune mode"`
+	FastSync           bool   `long:"fastsync" description:"Download the state of a recent finalized shard view from peers instead of replaying shard blocks from genesis -- NOTE: beacon is still fully synced, and history before the downloaded view is not available -- disabled by default, until highways forward fast sync requests"`
+	PDEHistory         bool   `long:"pdehistory" description:"Index pde trades, contributions and withdrawals of beacon blocks to serve pde history RPCs -- NOTE: only blocks inserted while the index is enabled are indexed"`
+	OutCoinIndex       bool   `long:"outcoinindex" description:"Index output coins of viewing keys registered through RPC as shard blocks are stored, so their output coins and balances are served without scanning -- NOTE: blocks stored before a key is registered are rescanned only if they are kept in the database"`
 	LogDir             string `short:"l" long:"logdir" description:"Directory to log output."`
 	LogLevel           string `long:"loglevel" description:"Logging level for all subsystems {trace, debug, info, warn, error, critical} -- You may also specify <subsystem>=<level>,<subsystem2>=<level>,... to set the log level for individual subsystems -- Use show to list available subsystems"`
 
@@ -132,9 +148,13 @@ type config struct {
 
 	FastStartup bool `long:"faststartup" description:"Load existed shard/chain dependencies instead of rebuild from block data"`
 
-	TxPoolTTL   uint   `long:"txpoolttl" description:"Set Time To Live (TTL) Value for transaction that enter pool"`
-	TxPoolMaxTx uint64 `long:"txpoolmaxtx" description:"Set Maximum number of transaction in pool"`
-	LimitFee    uint64 `long:"limitfee" description:"Limited fee for tx(per Kb data), default is 0.00 PRV"`
+	TxPoolTTL              uint   `long:"txpoolttl" description:"Set Time To Live (TTL) Value for transaction that enter pool"`
+	TxPoolMaxTx            uint64 `long:"txpoolmaxtx" description:"Set Maximum number of transaction in pool"`
+	TxPoolMaxTxPerSender   uint64 `long:"txpoolmaxtxpersender" description:"Set Maximum number of transaction of a sender in pool, 0 is unlimited -- NOTE: a sender is identified by the signing public key of its txs, which is one-time for privacy txs, so this only limits senders of non-privacy txs"`
+	TxPoolMaxTxPerMetaType uint64 `long:"txpoolmaxtxpermetatype" description:"Set Maximum number of transaction of each metadata type in pool, 0 is unlimited"`
+	TxPoolProofWorkers     int    `long:"txpoolproofworkers" description:"Set Maximum number of transaction proofs verified at the same time, 0 is the number of CPUs"`
+	TxPoolProofCacheSize   int    `long:"txpoolproofcachesize" description:"Set number of verified transaction proofs kept so they are not verified again in new blocks"`
+	LimitFee               uint64 `long:"limitfee" description:"Limited fee for tx(per Kb data), default is 0.00 PRV"`
 
 	LoadMempool       bool   `long:"loadmempool" description:"Load transactions from Mempool database"`
 	PersistMempool    bool   `long:"persistmempool" description:"Persistence transaction in memepool database"`
@@ -147,10 +167,13 @@ type config struct {
 	EnableMining      bool   `long:"mining" description:"enable mining"`
 	MiningKeys        string `long:"miningkeys" description:"keys used for different consensus algorigthm"`
 	PrivateKey        string `long:"privatekey" description:"your wallet privatekey"`
+	RemoteSigner      string `long:"remotesigner" description:"unix socket of the signer process keeping your mining key, used instead of miningkeys and privatekey"`
 	Accelerator       bool   `long:"accelerator" description:"Relay Node Configuration For Consensus"`
 
 	// Highway
 	Libp2pPrivateKey string `long:"libp2pprivatekey" description:"Private key used to create node's PeerID, empty to generate random key each run"`
+	WireCodec        string `long:"wirecodec" description:"Encoding of messages published to highway topics {legacy, dual, binary} -- dual also publishes binary messages to binary topics, binary only publishes to binary topics and needs highways relaying them"`
+	NumHighways      int    `long:"numhighways" description:"Number of highways to stay connected to, messages are published through all of them and a backup replaces the current highway when it fails"`
 }
 
 func (cfg config) IsTestnet() bool {
@@ -316,6 +339,9 @@ func loadConfig() (*config, []string, error) {
 		DataDir:                     defaultDataDir,
 		DatabaseDir:                 DefaultDatabaseDirname,
 		DatabaseMempoolDir:          DefaultDatabaseMempoolDirname,
+		DatabaseDriver:              DefaultDatabaseDriver,
+		StateDBMode:                 DefaultStateDBMode,
+		PruneKeepViews:              DefaultPruneKeepViews,
 		LogDir:                      defaultLogDir,
 		RPCKey:                      defaultRPCKeyFile,
 		RPCCert:                     defaultRPCCertFile,
@@ -328,11 +354,16 @@ func loadConfig() (*config, []string, error) {
 		TestNet:                     "true",
 		DiscoverPeersAddress:        "127.0.0.1:9330", //"35.230.8.182:9339",
 		NodeMode:                    DefaultNodeMode,
+		WireCodec:                   DefaultWireCodec,
+		NumHighways:                 DefaultNumHighways,
 		MiningKeys:                  common.EmptyString,
 		PrivateKey:                  common.EmptyString,
 		FastStartup:                 DefaultFastStartup,
 		TxPoolTTL:                   DefaultTxPoolTTL,
 		TxPoolMaxTx:                 DefaultTxPoolMaxTx,
+		TxPoolMaxTxPerSender:        DefaultTxPoolMaxTxPerSender,
+		TxPoolMaxTxPerMetaType:      DefaultTxPoolMaxTxPerMetaType,
+		TxPoolProofCacheSize:        DefaultTxPoolProofCacheSize,
 		PersistMempool:              DefaultPersistMempool,
 		LimitFee:                    DefaultLimitFee,
 		MetricUrl:                   DefaultMetricUrl,
@@ -637,6 +668,26 @@ func loadConfig() (*config, []string, error) {
 		}
 	}
 
+	if cfg.StateDBMode != common.StateDBModeArchive && cfg.StateDBMode != common.StateDBModePrune {
+		return nil, nil, fmt.Errorf("invalid statedbmode %+v, must be %+v or %+v", cfg.StateDBMode, common.StateDBModeArchive, common.StateDBModePrune)
+	}
+
+	if cfg.WireCodec != wire.WireCodecLegacy && cfg.WireCodec != wire.WireCodecDual && cfg.WireCodec != wire.WireCodecBinary {
+		return nil, nil, fmt.Errorf("invalid wirecodec %+v, must be %+v, %+v or %+v", cfg.WireCodec, wire.WireCodecLegacy, wire.WireCodecDual, wire.WireCodecBinary)
+	}
+
+	if cfg.NumHighways < 1 {
+		return nil, nil, fmt.Errorf("invalid numhighways %+v, must be at least 1", cfg.NumHighways)
+	}
+
+	if cfg.TxPoolProofWorkers < 0 {
+		return nil, nil, fmt.Errorf("invalid txpoolproofworkers %+v, must not be negative", cfg.TxPoolProofWorkers)
+	}
+
+	if cfg.TxPoolProofCacheSize < 1 {
+		return nil, nil, fmt.Errorf("invalid txpoolproofcachesize %+v, must be at least 1", cfg.TxPoolProofCacheSize)
+	}
+
 	if cfg.MiningKeys == "" && cfg.PrivateKey == "" && cfg.NodeMode != common.NodeModeRelay {
 		return nil, nil, errors.New("MiningKeys can't be empty if nodemode isn't relay")
 	}
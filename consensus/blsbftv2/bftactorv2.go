@@ -11,6 +11,8 @@ import (
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/blsmultisig"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/incdb"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/wire"
@@ -19,11 +21,12 @@ import (
 type BLSBFT_V2 struct {
 	Chain    ChainInterface
 	Node     NodeInterface
+	DB       incdb.Database // store vote journal, nil to keep it in memory only
 	ChainKey string
 	ChainID  int
 	PeerID   string
 
-	UserKeySet   *MiningKey
+	UserKeySet   Signer
 	BFTMessageCh chan wire.MessageBFT
 	isStarted    bool
 	StopCh       chan struct{}
@@ -38,6 +41,8 @@ type BLSBFT_V2 struct {
 	receiveBlockByHeight map[uint64][]*ProposeBlockInfo   //blockHeight -> blockInfo
 	receiveBlockByHash   map[string]*ProposeBlockInfo     //blockHash -> blockInfo
 	voteHistory          map[uint64]common.BlockInterface // bestview height (previsous height )-> block
+	journal              *voteJournal
+	evidences            *evidencePool
 }
 
 func (e BLSBFT_V2) GetChainKey() string {
@@ -81,6 +86,14 @@ func (e *BLSBFT_V2) Start() error {
 	if e.isStarted {
 		return NewConsensusError(ConsensusAlreadyStartedError, errors.New(e.ChainKey))
 	}
+	journal, err := loadVoteJournal(e.DB, e.ChainKey)
+	if err != nil {
+		return NewConsensusError(JournalError, err)
+	}
+	e.journal = journal
+	if e.evidences == nil {
+		e.evidences = newEvidencePool(e.ChainID)
+	}
 
 	e.isStarted = true
 	e.StopCh = make(chan struct{})
@@ -89,7 +102,6 @@ func (e *BLSBFT_V2) Start() error {
 	e.receiveBlockByHash = make(map[string]*ProposeBlockInfo)
 	e.receiveBlockByHeight = make(map[uint64][]*ProposeBlockInfo)
 	e.voteHistory = make(map[uint64]common.BlockInterface)
-	var err error
 	e.proposeHistory, err = lru.New(1000)
 	if err != nil {
 		panic(err)
@@ -126,6 +138,11 @@ func (e *BLSBFT_V2) Start() error {
 				} else {
 					e.receiveBlockByHash[blkHash].block = block
 				}
+				// look for proposer and validators who signed another block in the same time slot
+				e.collectProposeEvidence(block)
+				for _, vote := range e.receiveBlockByHash[blkHash].votes {
+					e.collectVoteEvidence(block, vote)
+				}
 
 				e.Logger.Info("[Monitor] Receive block ", block.Hash().String(), "height", block.GetHeight(), ",block timeslot ", common.CalculateTimeSlot(block.GetProposeTime()))
 				if block.GetHeight() <= e.Chain.GetBestViewHeight() {
@@ -146,6 +163,9 @@ func (e *BLSBFT_V2) Start() error {
 						b.votes[voteMsg.Validator] = voteMsg // store it
 						e.Logger.Infof("[Monitor] receive vote for block %s (%d) from %v", voteMsg.BlockHash, len(e.receiveBlockByHash[voteMsg.BlockHash].votes), voteMsg.Validator)
 						b.hasNewVote = true
+						if b.block != nil {
+							e.collectVoteEvidence(b.block, voteMsg)
+						}
 					}
 				} else {
 					e.receiveBlockByHash[voteMsg.BlockHash] = &ProposeBlockInfo{
@@ -167,6 +187,8 @@ func (e *BLSBFT_V2) Start() error {
 				e.currentTime = time.Now().Unix()
 				e.currentTimeSlot = common.CalculateTimeSlot(e.currentTime)
 				bestView := e.Chain.GetBestView()
+				e.journal.prune(e.Chain.GetFinalView().GetHeight())
+				e.evidences.prune(e.Chain.GetFinalView().GetHeight())
 
 				/*
 					Check for whether we should propose block
@@ -176,7 +198,7 @@ func (e *BLSBFT_V2) Start() error {
 
 				if proposerPk.GetMiningKeyBase58(common.BlsConsensus) == userPk && common.CalculateTimeSlot(bestView.GetBlock().GetProduceTime()) != e.currentTimeSlot { // current timeslot is not add to view, and this user is proposer of this timeslot
 					//using block hash as key of best view -> check if this best view we propose or not
-					if _, ok := e.proposeHistory.Get(fmt.Sprintf("%s%d", e.currentTimeSlot)); !ok {
+					if _, ok := e.proposeHistory.Get(fmt.Sprintf("%s%d", e.currentTimeSlot)); !ok && !e.journal.hasProposed(e.currentTimeSlot) {
 
 						e.proposeHistory.Add(fmt.Sprintf("%s%d", e.currentTimeSlot), 1)
 						//Proposer Rule: check propose block connected to bestview(longest chain rule 1) and re-propose valid block with smallest timestamp (including already propose in the past) (rule 2)
@@ -254,9 +276,10 @@ func (e *BLSBFT_V2) Start() error {
 	return nil
 }
 
-func NewInstance(chain ChainInterface, chainKey string, chainID int, node NodeInterface, logger common.Logger) *BLSBFT_V2 {
+func NewInstance(chain ChainInterface, db incdb.Database, chainKey string, chainID int, node NodeInterface, logger common.Logger) *BLSBFT_V2 {
 	var newInstance = new(BLSBFT_V2)
 	newInstance.Chain = chain
+	newInstance.DB = db
 	newInstance.ChainKey = chainKey
 	newInstance.ChainID = chainID
 	newInstance.Node = node
@@ -370,6 +393,19 @@ func (e *BLSBFT_V2) validateAndVote(v *ProposeBlockInfo) error {
 		return err
 	}
 
+	// never sign a vote conflicting with the one signed before, even before restart
+	consensusVote := newConsensusVote(v.block)
+	if err := e.journal.checkVote(v.block.GetHeight(), consensusVote); err != nil {
+		e.Logger.Error(err)
+		return err
+	}
+
+	// the signer may keep its own record and refuse the vote
+	if err := e.guardSign(SignGuardVote, v.block); err != nil {
+		e.Logger.Error(err)
+		return err
+	}
+
 	//if valid then vote
 	var Vote = new(BFTVote)
 	bytelist := []blsmultisig.PublicKey{}
@@ -414,6 +450,10 @@ func (e *BLSBFT_V2) validateAndVote(v *ProposeBlockInfo) error {
 		return NewConsensusError(UnExpectedError, err)
 	}
 
+	if err := e.journal.recordVote(v.block.GetHeight(), consensusVote); err != nil {
+		e.Logger.Error(err)
+		return err
+	}
 	v.isValid = true
 	e.voteHistory[v.block.GetHeight()] = v.block
 	e.Logger.Info("sending vote...")
@@ -452,9 +492,19 @@ func (e *BLSBFT_V2) proposeBlock(proposerPk incognitokey.CommitteePublicKey, blo
 		return nil, NewConsensusError(BlockCreationError, errors.New("block is nil"))
 	}
 
+	if err := e.guardSign(SignGuardPropose, block); err != nil {
+		return nil, err
+	}
 	validationData := e.CreateValidationData(block)
+	if len(validationData.ProducerBLSSig) == 0 {
+		return nil, NewConsensusError(SignDataError, errors.New("producer signature is empty"))
+	}
 	validationDataString, _ := EncodeValidationData(validationData)
 	block.(blockValidation).AddValidationField(validationDataString)
+	proposal := rawdbv2.ConsensusProposal{BlockHash: *block.Hash(), Height: block.GetHeight()}
+	if err := e.journal.recordProposal(e.currentTimeSlot, proposal); err != nil {
+		return nil, err
+	}
 	blockData, _ := json.Marshal(block)
 	var proposeCtn = new(BFTPropose)
 	proposeCtn.Block = blockData
@@ -500,7 +550,7 @@ func (e *BLSBFT_V2) preValidateVote(blockHash []byte, Vote *BFTVote, candidate [
 	return err
 }
 
-func (s *BFTVote) signVote(key *MiningKey) error {
+func (s *BFTVote) signVote(key Signer) error {
 	data := []byte{}
 	data = append(data, s.BlockHash...)
 	data = append(data, s.BLS...)
@@ -19,6 +19,10 @@ const (
 	DecodeValidationDataError
 	EncodeValidationDataError
 	BlockCreationError
+	ConflictVoteError
+	ConflictProposalError
+	JournalError
+	RemoteSignerError
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -37,6 +41,10 @@ var ErrCodeMessage = map[int]struct {
 	DecodeValidationDataError:    {-1009, "Decode Validation Data error"},
 	EncodeValidationDataError:    {-1010, "Encode Validation Data Error"},
 	BlockCreationError:           {-1011, "Block Creation Error"},
+	ConflictVoteError:            {-1012, "Conflict Vote Error"},
+	ConflictProposalError:        {-1013, "Conflict Proposal Error"},
+	JournalError:                 {-1014, "Vote Journal Error"},
+	RemoteSignerError:            {-1015, "Remote Signer Error"},
 }
 
 type ConsensusError struct {
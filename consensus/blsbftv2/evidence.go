@@ -0,0 +1,193 @@
+package blsbftv2
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+// signedMessage - the first message seen of a signer at a height and time slot
+type signedMessage struct {
+	height    uint64
+	blockHash string
+	msg       metadata.SignedConsensusMessage
+}
+
+// evidencePool - collect conflicting proposals and votes signed by committee members,
+// found evidences are kept until the node operator reports them in an equivocation evidence tx
+type evidencePool struct {
+	lock      sync.RWMutex
+	chainID   int
+	proposals map[string]signedMessage // proposer-height-propose time slot -> first proposal
+	votes     map[string]signedMessage // validator-height-propose time slot-produce time slot -> first vote
+	evidences map[string]metadata.EquivocationEvidence
+}
+
+func newEvidencePool(chainID int) *evidencePool {
+	return &evidencePool{
+		chainID:   chainID,
+		proposals: make(map[string]signedMessage),
+		votes:     make(map[string]signedMessage),
+		evidences: make(map[string]metadata.EquivocationEvidence),
+	}
+}
+
+// getBlockHeader - extract header of block to be attached into signed message
+func getBlockHeader(block common.BlockInterface) (json.RawMessage, error) {
+	blockBytes, err := json.Marshal(block)
+	if err != nil {
+		return nil, err
+	}
+	blockWithHeader := struct {
+		Header json.RawMessage
+	}{}
+	if err := json.Unmarshal(blockBytes, &blockWithHeader); err != nil {
+		return nil, err
+	}
+	return blockWithHeader.Header, nil
+}
+
+// addProposal - the proposal must be signed by the block proposer
+func (p *evidencePool) addProposal(block common.BlockInterface, producerSig []byte) (*metadata.EquivocationEvidence, error) {
+	key := fmt.Sprintf("%v-%v-%v", block.GetProposer(), block.GetHeight(), common.CalculateTimeSlot(block.GetProposeTime()))
+	header, err := getBlockHeader(block)
+	if err != nil {
+		return nil, err
+	}
+	msg := signedMessage{
+		height:    block.GetHeight(),
+		blockHash: block.Hash().String(),
+		msg: metadata.SignedConsensusMessage{
+			BlockHeader: header,
+			ProducerSig: producerSig,
+		},
+	}
+	return p.add(p.proposals, key, msg, metadata.EquivocationProposeType, block.GetProposer()), nil
+}
+
+// addVote - the vote must be signed by the offender, which is the committee public key in base58 of the validator
+func (p *evidencePool) addVote(block common.BlockInterface, vote BFTVote, offender string) (*metadata.EquivocationEvidence, error) {
+	key := fmt.Sprintf("%v-%v-%v-%v", vote.Validator, block.GetHeight(), common.CalculateTimeSlot(block.GetProposeTime()), common.CalculateTimeSlot(block.GetProduceTime()))
+	header, err := getBlockHeader(block)
+	if err != nil {
+		return nil, err
+	}
+	msg := signedMessage{
+		height:    block.GetHeight(),
+		blockHash: vote.BlockHash,
+		msg: metadata.SignedConsensusMessage{
+			BlockHeader:  header,
+			BLS:          vote.BLS,
+			BRI:          vote.BRI,
+			Confirmation: vote.Confirmation,
+		},
+	}
+	return p.add(p.votes, key, msg, metadata.EquivocationVoteType, offender), nil
+}
+
+func (p *evidencePool) add(firstMsgs map[string]signedMessage, key string, msg signedMessage, evidenceType string, offender string) *metadata.EquivocationEvidence {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	firstMsg, ok := firstMsgs[key]
+	if !ok {
+		firstMsgs[key] = msg
+		return nil
+	}
+	if firstMsg.blockHash == msg.blockHash {
+		return nil
+	}
+	if _, ok := p.evidences[key]; ok {
+		return nil
+	}
+	evidence := metadata.EquivocationEvidence{
+		Type:     evidenceType,
+		ChainID:  p.chainID,
+		Offender: offender,
+		Messages: [2]metadata.SignedConsensusMessage{firstMsg.msg, msg.msg},
+	}
+	p.evidences[key] = evidence
+	return &evidence
+}
+
+// prune - remove messages of finalized heights, found evidences are kept
+func (p *evidencePool) prune(finalHeight uint64) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for _, firstMsgs := range []map[string]signedMessage{p.proposals, p.votes} {
+		for key, msg := range firstMsgs {
+			if msg.height < finalHeight {
+				delete(firstMsgs, key)
+			}
+		}
+	}
+}
+
+func (p *evidencePool) getEvidences() []metadata.EquivocationEvidence {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	evidences := []metadata.EquivocationEvidence{}
+	for _, evidence := range p.evidences {
+		evidences = append(evidences, evidence)
+	}
+	return evidences
+}
+
+// GetEquivocationEvidences - return evidences of committee members who signed conflicting blocks
+func (e *BLSBFT_V2) GetEquivocationEvidences() []metadata.EquivocationEvidence {
+	if e.evidences == nil {
+		return []metadata.EquivocationEvidence{}
+	}
+	return e.evidences.getEvidences()
+}
+
+// collectProposeEvidence - check the proposal against proposals of the same proposer
+func (e *BLSBFT_V2) collectProposeEvidence(block common.BlockInterface) {
+	if err := ValidateProducerSig(block); err != nil {
+		return
+	}
+	valData, err := DecodeValidationData(block.GetValidationField())
+	if err != nil {
+		return
+	}
+	evidence, err := e.evidences.addProposal(block, valData.ProducerBLSSig)
+	if err != nil {
+		e.Logger.Error(err)
+		return
+	}
+	if evidence != nil {
+		e.Logger.Criticalf("[Monitor] found equivocation evidence: %v proposes two different blocks at height %v", evidence.Offender, block.GetHeight())
+	}
+}
+
+// collectVoteEvidence - check the vote against votes of the same validator, block of the vote must be received
+func (e *BLSBFT_V2) collectVoteEvidence(block common.BlockInterface, vote BFTVote) {
+	view := e.Chain.GetViewByHash(block.GetPrevHash())
+	if view == nil {
+		return
+	}
+	offender := ""
+	for _, c := range view.GetCommittee() {
+		if vote.Validator != c.GetMiningKeyBase58(common.BlsConsensus) {
+			continue
+		}
+		if err := vote.validateVoteOwner(c.MiningPubKey[common.BridgeConsensus]); err != nil {
+			return
+		}
+		offender, _ = c.ToBase58()
+		break
+	}
+	if offender == "" {
+		return
+	}
+	evidence, err := e.evidences.addVote(block, vote, offender)
+	if err != nil {
+		e.Logger.Error(err)
+		return
+	}
+	if evidence != nil {
+		e.Logger.Criticalf("[Monitor] found equivocation evidence: %v votes for two different blocks at height %v", vote.Validator, block.GetHeight())
+	}
+}
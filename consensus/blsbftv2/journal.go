@@ -0,0 +1,126 @@
+package blsbftv2
+
+import (
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/incdb"
+)
+
+// voteJournal is a write-ahead journal of proposals and votes signed by this node.
+// Every proposal and vote is recorded before it is broadcast and the journal is reloaded on Start,
+// so the actor never signs anything conflicting with what it signed before a restart.
+// Without database (e.g simulation), the journal is only kept in memory.
+type voteJournal struct {
+	db        incdb.Database
+	chainKey  string
+	votes     map[uint64]rawdbv2.ConsensusVote    // block height -> latest vote
+	proposals map[int64]rawdbv2.ConsensusProposal // time slot -> proposed block
+}
+
+func loadVoteJournal(db incdb.Database, chainKey string) (*voteJournal, error) {
+	j := &voteJournal{
+		db:        db,
+		chainKey:  chainKey,
+		votes:     make(map[uint64]rawdbv2.ConsensusVote),
+		proposals: make(map[int64]rawdbv2.ConsensusProposal),
+	}
+	if db == nil {
+		return j, nil
+	}
+	var err error
+	j.votes, err = rawdbv2.GetConsensusVotes(db, chainKey)
+	if err != nil {
+		return nil, err
+	}
+	j.proposals, err = rawdbv2.GetConsensusProposals(db, chainKey)
+	if err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+// checkVote - apply voting rule 1 on the latest vote at this height:
+// vote again only for a block created in an earlier time slot, or the same block re-proposed in a later time slot
+func (j *voteJournal) checkVote(height uint64, vote rawdbv2.ConsensusVote) error {
+	lastVote, ok := j.votes[height]
+	if !ok || lastVote.BlockHash == vote.BlockHash {
+		return nil
+	}
+	if vote.ProduceTimeSlot < lastVote.ProduceTimeSlot {
+		return nil
+	}
+	if vote.ProduceTimeSlot == lastVote.ProduceTimeSlot && vote.ProposeTimeSlot > lastVote.ProposeTimeSlot {
+		return nil
+	}
+	return NewConsensusError(ConflictVoteError, fmt.Errorf("already voted for block %v (produce time slot %v, propose time slot %v) at height %v",
+		lastVote.BlockHash.String(), lastVote.ProduceTimeSlot, lastVote.ProposeTimeSlot, height))
+}
+
+func (j *voteJournal) recordVote(height uint64, vote rawdbv2.ConsensusVote) error {
+	if err := j.checkVote(height, vote); err != nil {
+		return err
+	}
+	if j.db != nil {
+		if err := rawdbv2.StoreConsensusVote(j.db, j.chainKey, height, vote); err != nil {
+			return NewConsensusError(JournalError, err)
+		}
+	}
+	j.votes[height] = vote
+	return nil
+}
+
+func (j *voteJournal) hasProposed(timeSlot int64) bool {
+	_, ok := j.proposals[timeSlot]
+	return ok
+}
+
+// recordProposal - only one block can be proposed in a time slot
+func (j *voteJournal) recordProposal(timeSlot int64, proposal rawdbv2.ConsensusProposal) error {
+	if lastProposal, ok := j.proposals[timeSlot]; ok && lastProposal.BlockHash != proposal.BlockHash {
+		return NewConsensusError(ConflictProposalError, fmt.Errorf("already proposed block %v at height %v in time slot %v",
+			lastProposal.BlockHash.String(), lastProposal.Height, timeSlot))
+	}
+	if j.db != nil {
+		if err := rawdbv2.StoreConsensusProposal(j.db, j.chainKey, timeSlot, proposal); err != nil {
+			return NewConsensusError(JournalError, err)
+		}
+	}
+	j.proposals[timeSlot] = proposal
+	return nil
+}
+
+// prune - remove votes and proposals of heights which are already finalized
+func (j *voteJournal) prune(finalHeight uint64) {
+	for height := range j.votes {
+		if height > finalHeight {
+			continue
+		}
+		if j.db != nil {
+			if err := rawdbv2.DeleteConsensusVote(j.db, j.chainKey, height); err != nil {
+				continue
+			}
+		}
+		delete(j.votes, height)
+	}
+	for timeSlot, proposal := range j.proposals {
+		if proposal.Height > finalHeight {
+			continue
+		}
+		if j.db != nil {
+			if err := rawdbv2.DeleteConsensusProposal(j.db, j.chainKey, timeSlot); err != nil {
+				continue
+			}
+		}
+		delete(j.proposals, timeSlot)
+	}
+}
+
+func newConsensusVote(block common.BlockInterface) rawdbv2.ConsensusVote {
+	return rawdbv2.ConsensusVote{
+		BlockHash:       *block.Hash(),
+		ProposeTimeSlot: common.CalculateTimeSlot(block.GetProposeTime()),
+		ProduceTimeSlot: common.CalculateTimeSlot(block.GetProduceTime()),
+	}
+}
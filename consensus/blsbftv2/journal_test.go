@@ -0,0 +1,150 @@
+package blsbftv2
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestJournalDB(t *testing.T) (incdb.Database, func()) {
+	dir, err := ioutil.TempDir("", "journal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := incdb.Open("leveldb", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, func() {
+		db.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestVoteJournalCheckVote(t *testing.T) {
+	journal, err := loadVoteJournal(nil, "shard-0")
+	assert.Nil(t, err)
+	block1 := common.HashH([]byte("block1"))
+	block2 := common.HashH([]byte("block2"))
+
+	vote := rawdbv2.ConsensusVote{BlockHash: block1, ProposeTimeSlot: 10, ProduceTimeSlot: 10}
+	assert.Nil(t, journal.checkVote(100, vote), "first vote at a height")
+	assert.Nil(t, journal.recordVote(100, vote))
+	assert.Nil(t, journal.checkVote(100, vote), "vote again for the same block")
+	assert.Nil(t, journal.checkVote(101, rawdbv2.ConsensusVote{BlockHash: block2, ProposeTimeSlot: 10, ProduceTimeSlot: 10}), "vote at another height")
+
+	tests := []struct {
+		name    string
+		vote    rawdbv2.ConsensusVote
+		wantErr bool
+	}{
+		{
+			name:    "another block in the same time slots",
+			vote:    rawdbv2.ConsensusVote{BlockHash: block2, ProposeTimeSlot: 10, ProduceTimeSlot: 10},
+			wantErr: true,
+		},
+		{
+			name:    "another block created later",
+			vote:    rawdbv2.ConsensusVote{BlockHash: block2, ProposeTimeSlot: 11, ProduceTimeSlot: 11},
+			wantErr: true,
+		},
+		{
+			name:    "another block created earlier",
+			vote:    rawdbv2.ConsensusVote{BlockHash: block2, ProposeTimeSlot: 11, ProduceTimeSlot: 9},
+			wantErr: false,
+		},
+		{
+			name:    "block of the same time slot re-proposed later",
+			vote:    rawdbv2.ConsensusVote{BlockHash: block2, ProposeTimeSlot: 11, ProduceTimeSlot: 10},
+			wantErr: false,
+		},
+		{
+			name:    "block of the same time slot re-proposed earlier",
+			vote:    rawdbv2.ConsensusVote{BlockHash: block2, ProposeTimeSlot: 9, ProduceTimeSlot: 10},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := journal.checkVote(100, tt.vote)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkVote() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				assert.Equal(t, ErrCodeMessage[ConflictVoteError].Code, err.(*ConsensusError).Code)
+			}
+		})
+	}
+
+	// a refused vote is not recorded
+	assert.NotNil(t, journal.recordVote(100, rawdbv2.ConsensusVote{BlockHash: block2, ProposeTimeSlot: 10, ProduceTimeSlot: 10}))
+	assert.Equal(t, vote, journal.votes[100])
+}
+
+func TestVoteJournalRecordProposal(t *testing.T) {
+	db, closeDB := newTestJournalDB(t)
+	defer closeDB()
+	journal, err := loadVoteJournal(db, "beacon")
+	assert.Nil(t, err)
+	proposal := rawdbv2.ConsensusProposal{BlockHash: common.HashH([]byte("block1")), Height: 100}
+
+	assert.False(t, journal.hasProposed(10))
+	assert.Nil(t, journal.recordProposal(10, proposal))
+	assert.True(t, journal.hasProposed(10))
+	assert.Nil(t, journal.recordProposal(10, proposal), "propose the same block again")
+	err = journal.recordProposal(10, rawdbv2.ConsensusProposal{BlockHash: common.HashH([]byte("block2")), Height: 100})
+	assert.NotNil(t, err, "propose another block in the same time slot")
+	assert.Equal(t, ErrCodeMessage[ConflictProposalError].Code, err.(*ConsensusError).Code)
+	assert.Nil(t, journal.recordProposal(11, rawdbv2.ConsensusProposal{BlockHash: common.HashH([]byte("block2")), Height: 100}))
+
+	vote := rawdbv2.ConsensusVote{BlockHash: proposal.BlockHash, ProposeTimeSlot: 10, ProduceTimeSlot: 10}
+	assert.Nil(t, journal.recordVote(100, vote))
+
+	// proposals and votes survive a restart
+	reloaded, err := loadVoteJournal(db, "beacon")
+	assert.Nil(t, err)
+	assert.True(t, reloaded.hasProposed(10))
+	assert.True(t, reloaded.hasProposed(11))
+	assert.NotNil(t, reloaded.recordProposal(10, rawdbv2.ConsensusProposal{BlockHash: common.HashH([]byte("block2")), Height: 100}))
+	assert.NotNil(t, reloaded.checkVote(100, rawdbv2.ConsensusVote{BlockHash: common.HashH([]byte("block2")), ProposeTimeSlot: 10, ProduceTimeSlot: 10}))
+
+	// journals of chains are separated
+	other, err := loadVoteJournal(db, "shard-0")
+	assert.Nil(t, err)
+	assert.False(t, other.hasProposed(10))
+	assert.Empty(t, other.votes)
+}
+
+func TestVoteJournalPrune(t *testing.T) {
+	db, closeDB := newTestJournalDB(t)
+	defer closeDB()
+	journal, err := loadVoteJournal(db, "shard-1")
+	assert.Nil(t, err)
+	for height := uint64(100); height < 105; height++ {
+		blockHash := common.HashH([]byte{byte(height)})
+		timeSlot := int64(height) * 10
+		assert.Nil(t, journal.recordProposal(timeSlot, rawdbv2.ConsensusProposal{BlockHash: blockHash, Height: height}))
+		assert.Nil(t, journal.recordVote(height, rawdbv2.ConsensusVote{BlockHash: blockHash, ProposeTimeSlot: timeSlot, ProduceTimeSlot: timeSlot}))
+	}
+
+	journal.prune(102)
+	assert.Len(t, journal.votes, 2)
+	assert.Len(t, journal.proposals, 2)
+	assert.False(t, journal.hasProposed(1020))
+	assert.True(t, journal.hasProposed(1030))
+	_, ok := journal.votes[102]
+	assert.False(t, ok)
+
+	// pruned records are deleted from database too
+	reloaded, err := loadVoteJournal(db, "shard-1")
+	assert.Nil(t, err)
+	assert.Len(t, reloaded.votes, 2)
+	assert.Len(t, reloaded.proposals, 2)
+	assert.Nil(t, reloaded.checkVote(102, rawdbv2.ConsensusVote{BlockHash: common.HashH([]byte("another block")), ProposeTimeSlot: 1020, ProduceTimeSlot: 1020}))
+}
@@ -0,0 +1,159 @@
+package blsbftv2
+
+import (
+	"errors"
+	"net/rpc"
+	"sync"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/blsmultisig"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+)
+
+// Signer - sign consensus messages with the mining key of this node.
+// The key is either loaded into the node process (MiningKey)
+// or kept by a separate signer process on a hardened host (RemoteSigner).
+type Signer interface {
+	GetPublicKey() incognitokey.CommitteePublicKey
+	GetPublicKeyBase58() string
+	BLSSignData(data []byte, selfIdx int, committee []blsmultisig.PublicKey) ([]byte, error)
+	BriSignData(data []byte) ([]byte, error)
+}
+
+// types of sign guard
+const (
+	SignGuardPropose = "propose"
+	SignGuardVote    = "vote"
+)
+
+// SignGuard - the block about to be proposed or voted,
+// it is sent to the signer before the block hash is signed
+type SignGuard struct {
+	ChainKey string
+	Type     string
+	Height   uint64
+	Block    rawdbv2.ConsensusVote // hash and time slots of the block
+}
+
+// SignGuarder - implemented by signers which keep their own anti double sign record,
+// a guard conflicting with the record is refused and the block hash is never signed
+type SignGuarder interface {
+	GuardSign(guard SignGuard) error
+}
+
+// guardSign - let the signer check the block against its own record before signing it
+func (e *BLSBFT_V2) guardSign(guardType string, block common.BlockInterface) error {
+	guarder, ok := e.UserKeySet.(SignGuarder)
+	if !ok {
+		return nil
+	}
+	return guarder.GuardSign(SignGuard{
+		ChainKey: e.ChainKey,
+		Type:     guardType,
+		Height:   block.GetHeight(),
+		Block:    newConsensusVote(block),
+	})
+}
+
+// LoadRemoteSigner - sign with the mining key kept by the signer process listening on the unix socket
+func (e *BLSBFT_V2) LoadRemoteSigner(socketPath string) error {
+	signer, err := NewRemoteSigner(socketPath)
+	if err != nil {
+		return err
+	}
+	e.UserKeySet = signer
+	return nil
+}
+
+type BLSSignArgs struct {
+	Data      []byte
+	SelfIdx   int
+	Committee []blsmultisig.PublicKey
+}
+
+// RemoteSigner - client of the signer process, see SignerServer
+type RemoteSigner struct {
+	lock       sync.Mutex
+	socketPath string
+	client     *rpc.Client
+	publicKey  incognitokey.CommitteePublicKey
+}
+
+func NewRemoteSigner(socketPath string) (*RemoteSigner, error) {
+	signer := &RemoteSigner{
+		socketPath: socketPath,
+	}
+	var publicKey string
+	if err := signer.call("Signer.GetPublicKey", "", &publicKey); err != nil {
+		return nil, NewConsensusError(LoadKeyError, err)
+	}
+	if err := signer.publicKey.FromBase58(publicKey); err != nil {
+		return nil, NewConsensusError(LoadKeyError, err)
+	}
+	return signer, nil
+}
+
+// call - dial the signer again if the connection is closed, e.g the signer process is restarted
+func (s *RemoteSigner) call(method string, args interface{}, reply interface{}) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for retry := 0; retry < 2; retry++ {
+		if s.client == nil {
+			client, err := rpc.Dial("unix", s.socketPath)
+			if err != nil {
+				return NewConsensusError(RemoteSignerError, err)
+			}
+			s.client = client
+		}
+		err := s.client.Call(method, args, reply)
+		if err == rpc.ErrShutdown {
+			s.client.Close()
+			s.client = nil
+			continue
+		}
+		if err != nil {
+			return NewConsensusError(RemoteSignerError, err)
+		}
+		return nil
+	}
+	return NewConsensusError(RemoteSignerError, errors.New("connection to signer is shut down"))
+}
+
+func (s *RemoteSigner) GetPublicKey() incognitokey.CommitteePublicKey {
+	return s.publicKey
+}
+
+func (s *RemoteSigner) GetPublicKeyBase58() string {
+	key, err := s.publicKey.ToBase58()
+	if err != nil {
+		return ""
+	}
+	return key
+}
+
+func (s *RemoteSigner) GuardSign(guard SignGuard) error {
+	var ok bool
+	return s.call("Signer.GuardSign", guard, &ok)
+}
+
+func (s *RemoteSigner) BLSSignData(data []byte, selfIdx int, committee []blsmultisig.PublicKey) ([]byte, error) {
+	var sig []byte
+	args := BLSSignArgs{
+		Data:      data,
+		SelfIdx:   selfIdx,
+		Committee: committee,
+	}
+	if err := s.call("Signer.BLSSignData", args, &sig); err != nil {
+		return nil, NewConsensusError(SignDataError, err)
+	}
+	return sig, nil
+}
+
+func (s *RemoteSigner) BriSignData(data []byte) ([]byte, error) {
+	var sig []byte
+	if err := s.call("Signer.BriSignData", data, &sig); err != nil {
+		return nil, NewConsensusError(SignDataError, err)
+	}
+	return sig, nil
+}
@@ -0,0 +1,117 @@
+package blsbftv2
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/blsmultisig"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestRemoteSigner starts a signer server on a unix socket and connects a remote signer to it
+func newTestRemoteSigner(t *testing.T) (*MiningKey, *RemoteSigner, func()) {
+	key, err := newMiningKey("1Md5Jd3syKLygiphTyXZGLQFswsbgPpVfchYfiVrHX86A6Zsyn")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, closeDB := newTestJournalDB(t)
+	server, err := NewSignerServer(key, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, err := ioutil.TempDir("", "signer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	socketPath := filepath.Join(dir, "signer.sock")
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go server.Serve(listener)
+	signer, err := NewRemoteSigner(socketPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, signer, func() {
+		listener.Close()
+		closeDB()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestRemoteSignerPublicKey(t *testing.T) {
+	key, signer, closeSigner := newTestRemoteSigner(t)
+	defer closeSigner()
+	assert.Equal(t, key.GetPublicKey(), signer.GetPublicKey())
+	assert.Equal(t, key.GetPublicKeyBase58(), signer.GetPublicKeyBase58())
+
+	_, err := NewRemoteSigner(filepath.Join(os.TempDir(), "no-signer.sock"))
+	assert.NotNil(t, err, "no signer listening")
+}
+
+func TestRemoteSignerGuardsBlockHashes(t *testing.T) {
+	key, signer, closeSigner := newTestRemoteSigner(t)
+	defer closeSigner()
+	publicKey := signer.GetPublicKey()
+	blsKey, _ := publicKey.GetMiningKey(common.BlsConsensus)
+	committee := []blsmultisig.PublicKey{blsKey}
+	block1 := rawdbv2.ConsensusVote{BlockHash: common.HashH([]byte("block1")), ProposeTimeSlot: 10, ProduceTimeSlot: 10}
+	block2 := rawdbv2.ConsensusVote{BlockHash: common.HashH([]byte("block2")), ProposeTimeSlot: 10, ProduceTimeSlot: 10}
+
+	// data which is not a hash, e.g peer address, is signed
+	data := []byte("peer address")
+	sig, err := signer.BriSignData(data)
+	assert.Nil(t, err)
+	expectedSig, _ := key.BriSignData(data)
+	assert.Equal(t, expectedSig, sig)
+
+	// hash of a block which is not guarded is refused
+	_, err = signer.BLSSignData(block1.BlockHash.GetBytes(), 0, committee)
+	assert.NotNil(t, err)
+	_, err = signer.BriSignData(block1.BlockHash.GetBytes())
+	assert.NotNil(t, err)
+
+	// vote for a guarded block
+	assert.Nil(t, signer.GuardSign(SignGuard{ChainKey: "shard-0", Type: SignGuardVote, Height: 10, Block: block1}))
+	bls, err := signer.BLSSignData(block1.BlockHash.GetBytes(), 0, committee)
+	assert.Nil(t, err)
+	expectedBLS, _ := key.BLSSignData(block1.BlockHash.GetBytes(), 0, committee)
+	assert.Equal(t, expectedBLS, bls)
+	// confirmation of the vote
+	confirmation := voteConfirmationHash(block1.BlockHash, bls, nil)
+	_, err = signer.BriSignData(confirmation.GetBytes())
+	assert.Nil(t, err)
+	otherConfirmation := voteConfirmationHash(block1.BlockHash, []byte{1, 2, 3}, nil)
+	_, err = signer.BriSignData(otherConfirmation.GetBytes())
+	assert.NotNil(t, err, "confirmation of a vote with another bls signature")
+
+	// conflicting vote at the same height is refused, the block hash is never signed
+	assert.NotNil(t, signer.GuardSign(SignGuard{ChainKey: "shard-0", Type: SignGuardVote, Height: 10, Block: block2}))
+	_, err = signer.BLSSignData(block2.BlockHash.GetBytes(), 0, committee)
+	assert.NotNil(t, err)
+	// the same block can be voted on another chain
+	assert.Nil(t, signer.GuardSign(SignGuard{ChainKey: "shard-1", Type: SignGuardVote, Height: 10, Block: block2}))
+
+	// only one block is proposed in a time slot
+	assert.Nil(t, signer.GuardSign(SignGuard{ChainKey: "beacon", Type: SignGuardPropose, Height: 20, Block: block1}))
+	_, err = signer.BriSignData(block1.BlockHash.GetBytes())
+	assert.Nil(t, err)
+	assert.NotNil(t, signer.GuardSign(SignGuard{ChainKey: "beacon", Type: SignGuardPropose, Height: 20, Block: block2}))
+
+	assert.NotNil(t, signer.GuardSign(SignGuard{ChainKey: "beacon", Type: "unknown", Height: 20, Block: block1}))
+}
+
+func TestRemoteSignerReconnect(t *testing.T) {
+	_, signer, closeSigner := newTestRemoteSigner(t)
+	defer closeSigner()
+	signer.client.Close()
+	sig, err := signer.BriSignData([]byte("peer address"))
+	assert.Nil(t, err, "signer is dialed again after the connection is shut down")
+	assert.NotEmpty(t, sig)
+}
@@ -0,0 +1,186 @@
+package blsbftv2
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/rpc"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/incdb"
+)
+
+const (
+	signerKeepHeights   = 100  // heights of votes and proposals kept in record of signer
+	signerAllowedHashes = 1000 // hashes of guarded blocks and vote confirmations allowed to be signed
+)
+
+// SignerServer - keep the mining key in a separate process and sign for the node over a unix socket.
+// Every block is guarded against the anti double sign record of the signer before its hash is signed,
+// data of hash size which is neither a guarded block hash nor the confirmation of a vote is refused,
+// so a compromised node can not make the signer sign conflicting blocks.
+type SignerServer struct {
+	lock     sync.Mutex
+	key      *MiningKey
+	db       incdb.Database
+	journals map[string]*voteJournal // chain key -> record of votes and proposals
+	allowed  *lru.Cache              // hash allowed to be signed -> guarded block hash
+	blsSigs  *lru.Cache              // guarded block hash -> bls signature of vote
+}
+
+func NewSignerServer(key *MiningKey, db incdb.Database) (*SignerServer, error) {
+	allowed, err := lru.New(signerAllowedHashes)
+	if err != nil {
+		return nil, err
+	}
+	blsSigs, err := lru.New(signerAllowedHashes)
+	if err != nil {
+		return nil, err
+	}
+	return &SignerServer{
+		key:      key,
+		db:       db,
+		journals: make(map[string]*voteJournal),
+		allowed:  allowed,
+		blsSigs:  blsSigs,
+	}, nil
+}
+
+// Serve - accept connections of the node on listener until it is closed
+func (s *SignerServer) Serve(listener net.Listener) error {
+	server := rpc.NewServer()
+	if err := server.RegisterName("Signer", &signerHandler{s}); err != nil {
+		return err
+	}
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+		go server.ServeConn(conn)
+	}
+}
+
+func (s *SignerServer) getJournal(chainKey string) (*voteJournal, error) {
+	if journal, ok := s.journals[chainKey]; ok {
+		return journal, nil
+	}
+	journal, err := loadVoteJournal(s.db, chainKey)
+	if err != nil {
+		return nil, NewConsensusError(JournalError, err)
+	}
+	s.journals[chainKey] = journal
+	return journal, nil
+}
+
+func (s *SignerServer) guardSign(guard SignGuard) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	journal, err := s.getJournal(guard.ChainKey)
+	if err != nil {
+		return err
+	}
+	switch guard.Type {
+	case SignGuardPropose:
+		proposal := rawdbv2.ConsensusProposal{BlockHash: guard.Block.BlockHash, Height: guard.Height}
+		err = journal.recordProposal(guard.Block.ProposeTimeSlot, proposal)
+	case SignGuardVote:
+		err = journal.recordVote(guard.Height, guard.Block)
+	default:
+		err = NewConsensusError(RemoteSignerError, fmt.Errorf("sign guard type %v is invalid", guard.Type))
+	}
+	if err != nil {
+		return err
+	}
+	if guard.Height > signerKeepHeights {
+		journal.prune(guard.Height - signerKeepHeights)
+	}
+	s.allowed.Add(guard.Block.BlockHash, guard.Block.BlockHash)
+	return nil
+}
+
+// voteConfirmationHash - hash of data signed by BFTVote.signVote
+func voteConfirmationHash(blockHash common.Hash, bls []byte, bri []byte) common.Hash {
+	data := []byte{}
+	data = append(data, blockHash.String()...)
+	data = append(data, bls...)
+	data = append(data, bri...)
+	return common.HashH(data)
+}
+
+func (s *SignerServer) blsSignData(args BLSSignArgs) ([]byte, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	blockHash, err := common.Hash{}.NewHash(args.Data)
+	if err != nil || !s.allowed.Contains(*blockHash) {
+		return nil, NewConsensusError(RemoteSignerError, errors.New("bls signature is only signed on hash of guarded block"))
+	}
+	sig, err := s.key.BLSSignData(args.Data, args.SelfIdx, args.Committee)
+	if err != nil {
+		return nil, err
+	}
+	s.blsSigs.Add(*blockHash, sig)
+	// the vote has no bridge signature if block has no bridge instruction
+	s.allowed.Add(voteConfirmationHash(*blockHash, sig, nil), *blockHash)
+	return sig, nil
+}
+
+func (s *SignerServer) briSignData(data []byte) ([]byte, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if len(data) != common.HashSize {
+		// not a consensus message, e.g peer address
+		return s.key.BriSignData(data)
+	}
+	hash, _ := common.Hash{}.NewHash(data)
+	if !s.allowed.Contains(*hash) {
+		return nil, NewConsensusError(RemoteSignerError, errors.New("hash is neither a guarded block nor a vote confirmation"))
+	}
+	sig, err := s.key.BriSignData(data)
+	if err != nil {
+		return nil, err
+	}
+	if bls, ok := s.blsSigs.Get(*hash); ok {
+		s.allowed.Add(voteConfirmationHash(*hash, bls.([]byte), sig), *hash)
+	}
+	return sig, nil
+}
+
+// signerHandler - methods of SignerServer called by RemoteSigner
+type signerHandler struct {
+	server *SignerServer
+}
+
+func (h *signerHandler) GetPublicKey(args string, reply *string) error {
+	*reply = h.server.key.GetPublicKeyBase58()
+	return nil
+}
+
+func (h *signerHandler) GuardSign(args SignGuard, reply *bool) error {
+	if err := h.server.guardSign(args); err != nil {
+		return err
+	}
+	*reply = true
+	return nil
+}
+
+func (h *signerHandler) BLSSignData(args BLSSignArgs, reply *[]byte) error {
+	sig, err := h.server.blsSignData(args)
+	if err != nil {
+		return err
+	}
+	*reply = sig
+	return nil
+}
+
+func (h *signerHandler) BriSignData(args []byte, reply *[]byte) error {
+	sig, err := h.server.briSignData(args)
+	if err != nil {
+		return err
+	}
+	*reply = sig
+	return nil
+}
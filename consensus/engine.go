@@ -17,6 +17,7 @@ type Engine struct {
 	BFTProcess           map[int]ConsensusInterface //chainID -> consensus
 	userMiningPublicKeys map[string]*incognitokey.CommitteePublicKey
 	userKeyListString    string
+	remoteSigner         *blsbft2.RemoteSigner
 	consensusName        string
 	currentMiningProcess ConsensusInterface
 	config               *EngineConfig
@@ -95,7 +96,10 @@ func (s *Engine) WatchCommitteeChange() {
 	}
 
 	var miningProcess ConsensusInterface = nil
-	if role == "committee" {
+	if role == "committee" && s.version == 1 && s.config.Node.GetRemoteSigner() != "" {
+		// consensus v1 signs with a key loaded into the node only
+		Logger.Log.Warnf("CONSENSUS: remote signer is not supported by consensus v%v, node does not take part in consensus", s.version)
+	} else if role == "committee" {
 		chainName := "beacon"
 
 		if chainID >= 0 {
@@ -114,9 +118,9 @@ func (s *Engine) WatchCommitteeChange() {
 				}
 			} else {
 				if chainID == -1 {
-					s.BFTProcess[chainID] = blsbft2.NewInstance(s.config.Blockchain.BeaconChain, chainName, chainID, s.config.Node, Logger.Log)
+					s.BFTProcess[chainID] = blsbft2.NewInstance(s.config.Blockchain.BeaconChain, s.config.Blockchain.GetBeaconChainDatabase(), chainName, chainID, s.config.Node, Logger.Log)
 				} else {
-					s.BFTProcess[chainID] = blsbft2.NewInstance(s.config.Blockchain.ShardChain[chainID], chainName, chainID, s.config.Node, Logger.Log)
+					s.BFTProcess[chainID] = blsbft2.NewInstance(s.config.Blockchain.ShardChain[chainID], s.config.Blockchain.GetShardChainDatabase(byte(chainID)), chainName, chainID, s.config.Node, Logger.Log)
 				}
 			}
 
@@ -11,9 +11,13 @@ import (
 	"github.com/incognitochain/incognito-chain/consensus/blsbft"
 	"github.com/incognitochain/incognito-chain/consensus/blsbftv2"
 	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/metadata"
 )
 
 func (engine *Engine) LoadMiningKeys(keysString string) error {
+	if engine.config != nil && engine.config.Node.GetRemoteSigner() != "" {
+		return engine.loadRemoteSigner(engine.config.Node.GetRemoteSigner())
+	}
 	if len(keysString) > 0 {
 		keys := strings.Split(keysString, "|")
 		if len(keys) > 0 {
@@ -49,6 +53,24 @@ func (engine *Engine) LoadMiningKeys(keysString string) error {
 	return nil
 }
 
+// loadRemoteSigner - mining key is kept by the signer process, only consensus v2 signs with it,
+// no consensus v1 process is started for a remote signer (see WatchCommitteeChange)
+func (engine *Engine) loadRemoteSigner(socketPath string) error {
+	if engine.remoteSigner == nil {
+		signer, err := blsbftv2.NewRemoteSigner(socketPath)
+		if err != nil {
+			return err
+		}
+		engine.remoteSigner = signer
+	}
+	if bftv2, ok := engine.currentMiningProcess.(*blsbftv2.BLSBFT_V2); ok {
+		bftv2.UserKeySet = engine.remoteSigner
+	}
+	publicKey := engine.remoteSigner.GetPublicKey()
+	engine.SetMiningPublicKeys(common.BlsConsensus, &publicKey)
+	return nil
+}
+
 func (engine *Engine) GetCurrentMiningPublicKey() (publickey string, keyType string) {
 	if engine != nil && engine.GetMiningPublicKeys() != nil {
 		name := engine.consensusName
@@ -177,7 +199,7 @@ func (engine *Engine) ValidateProducerPosition(blk common.BlockInterface, lastPr
 func (engine *Engine) ValidateProducerSig(block common.BlockInterface, consensusType string) error {
 	if block.GetVersion() == 1 {
 		return blsbft.ValidateProducerSig(block)
-	} else if block.GetVersion() == 2 {
+	} else if block.GetVersion() >= 2 {
 		return blsbftv2.ValidateProducerSig(block)
 	}
 	return fmt.Errorf("Wrong block version: %v", block.GetVersion())
@@ -187,7 +209,7 @@ func (engine *Engine) ValidateBlockCommitteSig(block common.BlockInterface, comm
 	//fmt.Println("Xxx ValidateBlockCommitteSig", len(committee))
 	if block.GetVersion() == 1 {
 		return blsbft.ValidateCommitteeSig(block, committee)
-	} else if block.GetVersion() == 2 {
+	} else if block.GetVersion() >= 2 {
 		return blsbftv2.ValidateCommitteeSig(block, committee)
 	}
 	return fmt.Errorf("Wrong block version: %v", block.GetVersion())
@@ -212,12 +234,23 @@ func (engine *Engine) GenMiningKeyFromPrivateKey(privateKey string) (string, err
 func (engine *Engine) ExtractBridgeValidationData(block common.BlockInterface) ([][]byte, []int, error) {
 	if block.GetVersion() == 1 {
 		return blsbft.ExtractBridgeValidationData(block)
-	} else if block.GetVersion() == 2 {
+	} else if block.GetVersion() >= 2 {
 		return blsbftv2.ExtractBridgeValidationData(block)
 	}
 	return nil, nil, blsbft.NewConsensusError(blsbft.ConsensusTypeNotExistError, errors.New(block.GetConsensusType()))
 }
 
+// GetEquivocationEvidences - return evidences of committee members signing conflicting blocks, collected by consensus of all chains
+func (engine *Engine) GetEquivocationEvidences() []metadata.EquivocationEvidence {
+	evidences := []metadata.EquivocationEvidence{}
+	for _, BFTProcess := range engine.BFTProcess {
+		if bftv2, ok := BFTProcess.(*blsbftv2.BLSBFT_V2); ok {
+			evidences = append(evidences, bftv2.GetEquivocationEvidences()...)
+		}
+	}
+	return evidences
+}
+
 func (engine *Engine) GetCurrentConsensusVersion() int {
 	return engine.version
 }
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+)
+
+type finalizedBlock struct {
+	hash     string
+	node     string
+	timeSlot uint64
+}
+
+// FinalityTracker - record blocks finalized by every node to assert safety and liveness of a scenario
+type FinalityTracker struct {
+	lock              sync.Mutex
+	finalized         map[uint64]finalizedBlock // height -> first finalized block
+	violations        []string
+	lastHeight        uint64
+	finalizeTimeSlots []uint64 // time slots in which the finalized height increases
+}
+
+func NewFinalityTracker() *FinalityTracker {
+	return &FinalityTracker{
+		finalized: make(map[uint64]finalizedBlock),
+	}
+}
+
+// RecordFinalized - node sees block hash finalized at height in timeSlot
+func (t *FinalityTracker) RecordFinalized(node string, height uint64, hash string, timeSlot uint64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	block, ok := t.finalized[height]
+	if !ok {
+		t.finalized[height] = finalizedBlock{hash: hash, node: node, timeSlot: timeSlot}
+		if height > t.lastHeight {
+			t.lastHeight = height
+			t.finalizeTimeSlots = append(t.finalizeTimeSlots, timeSlot)
+		}
+		return
+	}
+	if block.hash != hash {
+		t.violations = append(t.violations, fmt.Sprintf("height %d: node %s finalized %s in time slot %d, node %s finalized %s in time slot %d",
+			height, block.node, block.hash, block.timeSlot, node, hash, timeSlot))
+	}
+}
+
+// CheckSafety - no two different blocks are finalized at one height
+func (t *FinalityTracker) CheckSafety() error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if len(t.violations) > 0 {
+		return fmt.Errorf("safety is violated, %d conflicting finalized blocks, first one at %s", len(t.violations), t.violations[0])
+	}
+	return nil
+}
+
+// CheckLiveness - after stabilizationTimeSlot, a new block is finalized within every maxTimeSlots time slots until currentTimeSlot
+func (t *FinalityTracker) CheckLiveness(stabilizationTimeSlot uint64, currentTimeSlot uint64, maxTimeSlots uint64) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	last := stabilizationTimeSlot
+	for _, timeSlot := range t.finalizeTimeSlots {
+		if timeSlot < stabilizationTimeSlot {
+			continue
+		}
+		if timeSlot-last > maxTimeSlots {
+			return fmt.Errorf("liveness is violated, no block is finalized from time slot %d to %d", last, timeSlot)
+		}
+		last = timeSlot
+	}
+	if currentTimeSlot > last && currentTimeSlot-last > maxTimeSlots {
+		return fmt.Errorf("liveness is violated, no block is finalized from time slot %d to %d", last, currentTimeSlot)
+	}
+	return nil
+}
+
+func (t *FinalityTracker) GetFinalizedHeight() uint64 {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.lastHeight
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFinalityTrackerCheckSafety(t *testing.T) {
+	tracker := NewFinalityTracker()
+	tracker.RecordFinalized("0", 1, "genesis", 1)
+	tracker.RecordFinalized("1", 1, "genesis", 1)
+	tracker.RecordFinalized("0", 2, "block2", 3)
+	tracker.RecordFinalized("1", 2, "block2", 4)
+	assert.Nil(t, tracker.CheckSafety())
+	assert.Equal(t, uint64(2), tracker.GetFinalizedHeight())
+
+	tracker.RecordFinalized("1_twin", 2, "conflict", 5)
+	err := tracker.CheckSafety()
+	assert.NotNil(t, err, "two blocks are finalized at height 2")
+	assert.Contains(t, err.Error(), "node 0 finalized block2")
+	assert.Contains(t, err.Error(), "node 1_twin finalized conflict")
+	assert.Equal(t, uint64(2), tracker.GetFinalizedHeight())
+}
+
+func TestFinalityTrackerCheckLiveness(t *testing.T) {
+	tracker := NewFinalityTracker()
+	// finalized in time slots 3, 4 (before stabilization), 12, 15, 20
+	for height, timeSlot := range map[uint64]uint64{1: 3, 2: 4, 3: 12, 4: 15, 5: 20} {
+		tracker.RecordFinalized("0", height, "block", timeSlot)
+	}
+	// the same height seen finalized later by another node does not count
+	tracker.RecordFinalized("1", 3, "block", 30)
+
+	tests := []struct {
+		name                  string
+		stabilizationTimeSlot uint64
+		currentTimeSlot       uint64
+		maxTimeSlots          uint64
+		wantErr               bool
+	}{
+		{name: "blocks are finalized in time", stabilizationTimeSlot: 10, currentTimeSlot: 22, maxTimeSlots: 5},
+		{name: "first block after stabilization is late", stabilizationTimeSlot: 5, currentTimeSlot: 22, maxTimeSlots: 5, wantErr: true},
+		{name: "gap between blocks is too long", stabilizationTimeSlot: 10, currentTimeSlot: 22, maxTimeSlots: 4, wantErr: true},
+		{name: "no block is finalized at the end", stabilizationTimeSlot: 10, currentTimeSlot: 30, maxTimeSlots: 5, wantErr: true},
+		{name: "no block is finalized after stabilization", stabilizationTimeSlot: 25, currentTimeSlot: 28, maxTimeSlots: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tracker.CheckLiveness(tt.stabilizationTimeSlot, tt.currentTimeSlot, tt.maxTimeSlots)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckLiveness() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,265 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus/blsbftv2"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/multiview"
+)
+
+// SimView - view of the simulated chain, committee never changes
+type SimView struct {
+	block     *blockchain.ShardBlock
+	committee []incognitokey.CommitteePublicKey
+}
+
+func (v *SimView) GetHash() *common.Hash {
+	return v.block.Hash()
+}
+
+func (v *SimView) GetPreviousHash() *common.Hash {
+	return &v.block.Header.PreviousBlockHash
+}
+
+func (v *SimView) GetHeight() uint64 {
+	return v.block.Header.Height
+}
+
+func (v *SimView) GetCommittee() []incognitokey.CommitteePublicKey {
+	return v.committee
+}
+
+func (v *SimView) GetProposerByTimeSlot(ts int64, version int) incognitokey.CommitteePublicKey {
+	return v.committee[blockchain.GetProposerByTimeSlot(ts, len(v.committee))]
+}
+
+func (v *SimView) GetBlock() common.BlockInterface {
+	return v.block
+}
+
+// SimChain - in memory chain of a simulated node, blocks are empty shard blocks,
+// only their signatures are validated before they are inserted
+type SimChain struct {
+	name      string
+	chainID   int
+	multiView *multiview.MultiView
+	committee []incognitokey.CommitteePublicKey
+	ready     bool
+}
+
+func NewSimChain(name string, chainID int, committee []incognitokey.CommitteePublicKey) *SimChain {
+	chain := &SimChain{
+		name:      name,
+		chainID:   chainID,
+		multiView: multiview.NewMultiView(),
+		committee: committee,
+		ready:     true,
+	}
+	chain.multiView.AddView(&SimView{block: blockchain.ChainTestParam.GenesisShardBlock, committee: committee})
+	return chain
+}
+
+func (chain *SimChain) GetFinalView() multiview.View {
+	return chain.multiView.GetFinalView()
+}
+
+func (chain *SimChain) GetBestView() multiview.View {
+	return chain.multiView.GetBestView()
+}
+
+func (chain *SimChain) GetViewByHash(hash common.Hash) multiview.View {
+	return chain.multiView.GetViewByHash(hash)
+}
+
+func (chain *SimChain) GetEpoch() uint64 {
+	return 1
+}
+
+func (chain *SimChain) GetChainName() string {
+	return chain.name
+}
+
+func (chain *SimChain) GetConsensusType() string {
+	return common.BlsConsensus
+}
+
+func (chain *SimChain) GetLastBlockTimeStamp() int64 {
+	return chain.GetBestView().GetBlock().GetProduceTime()
+}
+
+func (chain *SimChain) GetMinBlkInterval() time.Duration {
+	return common.TIMESLOT * time.Second
+}
+
+func (chain *SimChain) GetMaxBlkCreateTime() time.Duration {
+	return common.TIMESLOT * time.Second / 2
+}
+
+func (chain *SimChain) IsReady() bool {
+	return chain.ready
+}
+
+func (chain *SimChain) SetReady(ready bool) {
+	chain.ready = ready
+}
+
+func (chain *SimChain) GetActiveShardNumber() int {
+	return 1
+}
+
+func (chain *SimChain) CurrentHeight() uint64 {
+	return chain.GetBestView().GetHeight()
+}
+
+func (chain *SimChain) GetCommitteeSize() int {
+	return len(chain.committee)
+}
+
+func (chain *SimChain) GetCommittee() []incognitokey.CommitteePublicKey {
+	return append([]incognitokey.CommitteePublicKey{}, chain.committee...)
+}
+
+func (chain *SimChain) GetPendingCommittee() []incognitokey.CommitteePublicKey {
+	return []incognitokey.CommitteePublicKey{}
+}
+
+func (chain *SimChain) GetPubKeyCommitteeIndex(pubkey string) int {
+	for index, key := range chain.committee {
+		if key.GetMiningKeyBase58(common.BlsConsensus) == pubkey {
+			return index
+		}
+	}
+	return -1
+}
+
+func (chain *SimChain) GetLastProposerIndex() int {
+	return -1
+}
+
+func (chain *SimChain) UnmarshalBlock(blockString []byte) (common.BlockInterface, error) {
+	var shardBlk blockchain.ShardBlock
+	err := json.Unmarshal(blockString, &shardBlk)
+	if err != nil {
+		return nil, err
+	}
+	return &shardBlk, nil
+}
+
+func (chain *SimChain) CreateNewBlock(version int, proposer string, round int, startTime int64) (common.BlockInterface, error) {
+	bestView := chain.GetBestView()
+	return blockchain.NewShardBlockWithHeader(blockchain.ShardHeader{
+		Version:           version,
+		Height:            bestView.GetHeight() + 1,
+		PreviousBlockHash: *bestView.GetHash(),
+		Round:             round,
+		Epoch:             1,
+		Timestamp:         startTime,
+		BeaconHeight:      1,
+		Producer:          proposer,
+		Proposer:          proposer,
+		ProposeTime:       startTime,
+		CommitteeRoot:     common.HashH([]byte(chain.name)),
+		TotalTxsFee:       make(map[common.Hash]uint64),
+	}), nil
+}
+
+func (chain *SimChain) CreateNewBlockFromOldBlock(oldBlock common.BlockInterface, proposer string, startTime int64) (common.BlockInterface, error) {
+	b, _ := json.Marshal(oldBlock)
+	newBlock := new(blockchain.ShardBlock)
+	if err := json.Unmarshal(b, newBlock); err != nil {
+		return nil, err
+	}
+	newBlock.Header.Proposer = proposer
+	newBlock.Header.ProposeTime = startTime
+	return newBlock, nil
+}
+
+// fullnode - chain which only receives blocks inserted by committee nodes
+var fullnode *SimChain
+
+func (chain *SimChain) InsertAndBroadcastBlock(block common.BlockInterface) error {
+	if err := chain.insertBlock(block); err != nil {
+		return err
+	}
+	if fullnode != nil && fullnode != chain {
+		// fullnode syncs blocks it missed
+		for _, b := range chain.getBlocksTo(*block.Hash()) {
+			fullnode.insertBlock(b)
+		}
+	}
+	return nil
+}
+
+// insertBlock - add view of a block signed by enough validators of its previous view
+func (chain *SimChain) insertBlock(block common.BlockInterface) error {
+	if chain.GetViewByHash(*block.Hash()) != nil {
+		return nil
+	}
+	prevView := chain.GetViewByHash(block.GetPrevHash())
+	if prevView == nil {
+		return fmt.Errorf("previous view %v of block %v is not found", block.GetPrevHash().String(), block.Hash().String())
+	}
+	if err := chain.ValidateBlockSignatures(block, prevView.GetCommittee()); err != nil {
+		return err
+	}
+	chain.multiView.AddView(&SimView{block: block.(*blockchain.ShardBlock), committee: chain.committee})
+	return nil
+}
+
+func (chain *SimChain) ValidateBlockSignatures(block common.BlockInterface, committee []incognitokey.CommitteePublicKey) error {
+	if err := blsbftv2.ValidateProducerSig(block); err != nil {
+		return err
+	}
+	return blsbftv2.ValidateCommitteeSig(block, committee)
+}
+
+// ValidatePreSignBlock - block must be proposed by the proposer of its time slot on top of a known view
+func (chain *SimChain) ValidatePreSignBlock(block common.BlockInterface) error {
+	prevView := chain.GetViewByHash(block.GetPrevHash())
+	if prevView == nil {
+		return fmt.Errorf("previous view %v of block %v is not found", block.GetPrevHash().String(), block.Hash().String())
+	}
+	if block.GetHeight() != prevView.GetHeight()+1 {
+		return fmt.Errorf("expect block height %v but get %v", prevView.GetHeight()+1, block.GetHeight())
+	}
+	proposer := prevView.GetProposerByTimeSlot(common.CalculateTimeSlot(block.GetProposeTime()), 2)
+	if proposerBase58, _ := proposer.ToBase58(); proposerBase58 != block.GetProposer() {
+		return errors.New("block is not proposed by the proposer of its time slot")
+	}
+	return blsbftv2.ValidateProducerSig(block)
+}
+
+func (chain *SimChain) GetShardID() int {
+	return chain.chainID
+}
+
+func (chain *SimChain) GetBestViewHeight() uint64 {
+	return chain.CurrentHeight()
+}
+
+func (chain *SimChain) GetFinalViewHeight() uint64 {
+	return chain.GetFinalView().GetHeight()
+}
+
+func (chain *SimChain) GetBestViewHash() string {
+	return chain.GetBestView().GetHash().String()
+}
+
+func (chain *SimChain) GetFinalViewHash() string {
+	return chain.GetFinalView().GetHash().String()
+}
+
+// getBlocksTo - blocks from the final view to the view of hash, in order of height
+func (chain *SimChain) getBlocksTo(hash common.Hash) []common.BlockInterface {
+	blocks := []common.BlockInterface{}
+	for view := chain.GetViewByHash(hash); view != nil; view = chain.GetViewByHash(*view.GetPreviousHash()) {
+		blocks = append([]common.BlockInterface{view.GetBlock()}, blocks...)
+	}
+	return blocks
+}
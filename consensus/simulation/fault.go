@@ -0,0 +1,228 @@
+package main
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+const ANY_NODE = -1
+
+// faults are active from StartTimeSlot to EndTimeSlot (inclusive), the network heals after EndTimeSlot
+type slotRange struct {
+	StartTimeSlot uint64
+	EndTimeSlot   uint64
+}
+
+func (r slotRange) isActive(timeSlot uint64) bool {
+	return timeSlot >= r.StartTimeSlot && timeSlot <= r.EndTimeSlot
+}
+
+// linkFault - delay or drop messages sent from a node to another, ANY_NODE matches all nodes
+type linkFault struct {
+	slotRange
+	From     int
+	To       int
+	Delay    time.Duration
+	DropRate float64
+}
+
+// partition - messages between nodes of different groups are dropped, nodes not in any group are isolated
+type partition struct {
+	slotRange
+	Groups [][]int
+}
+
+func (p partition) isSeparated(from int, to int) bool {
+	for _, group := range p.Groups {
+		hasFrom, hasTo := false, false
+		for _, node := range group {
+			hasFrom = hasFrom || node == from
+			hasTo = hasTo || node == to
+		}
+		if hasFrom || hasTo {
+			return !(hasFrom && hasTo)
+		}
+	}
+	return true
+}
+
+// crash - node is stopped at StartTimeSlot and restarted after EndTimeSlot
+type crash struct {
+	slotRange
+	Node int
+}
+
+// byzantine - node equivocates (a twin with the same key talks to TwinGroup while the node talks to the others)
+// or sends invalid blocks
+type byzantine struct {
+	slotRange
+	Node      int
+	TwinGroup []int
+	Invalid   bool
+}
+
+// FaultScenario - scriptable fault model of the simulation network.
+// Every message is checked against the scenario before it is delivered,
+// the last time slot of all faults is the global stabilization time used by liveness assertion.
+type FaultScenario struct {
+	lock       sync.Mutex
+	rand       *rand.Rand
+	links      []linkFault
+	partitions []partition
+	crashes    []crash
+	byzantines []byzantine
+	clockSkews map[int]time.Duration
+}
+
+func NewFaultScenario(seed int64) *FaultScenario {
+	return &FaultScenario{
+		rand:       rand.New(rand.NewSource(seed)),
+		clockSkews: make(map[int]time.Duration),
+	}
+}
+
+func (s *FaultScenario) DelayLink(from int, to int, startTimeSlot uint64, endTimeSlot uint64, delay time.Duration) *FaultScenario {
+	s.links = append(s.links, linkFault{slotRange: slotRange{startTimeSlot, endTimeSlot}, From: from, To: to, Delay: delay})
+	return s
+}
+
+// DropLink - drop messages of the link with probability dropRate, 1 to drop all
+func (s *FaultScenario) DropLink(from int, to int, startTimeSlot uint64, endTimeSlot uint64, dropRate float64) *FaultScenario {
+	s.links = append(s.links, linkFault{slotRange: slotRange{startTimeSlot, endTimeSlot}, From: from, To: to, DropRate: dropRate})
+	return s
+}
+
+func (s *FaultScenario) Partition(startTimeSlot uint64, endTimeSlot uint64, groups ...[]int) *FaultScenario {
+	s.partitions = append(s.partitions, partition{slotRange: slotRange{startTimeSlot, endTimeSlot}, Groups: groups})
+	return s
+}
+
+func (s *FaultScenario) Crash(node int, startTimeSlot uint64, endTimeSlot uint64) *FaultScenario {
+	s.crashes = append(s.crashes, crash{slotRange: slotRange{startTimeSlot, endTimeSlot}, Node: node})
+	return s
+}
+
+// Equivocate - run a twin of node with the same mining key, the twin only talks to twinGroup
+// and the node only talks to the others, so both propose and vote on conflicting blocks
+func (s *FaultScenario) Equivocate(node int, twinGroup []int, startTimeSlot uint64, endTimeSlot uint64) *FaultScenario {
+	s.byzantines = append(s.byzantines, byzantine{slotRange: slotRange{startTimeSlot, endTimeSlot}, Node: node, TwinGroup: twinGroup})
+	return s
+}
+
+// SendInvalidBlock - blocks proposed by node are tampered after they are signed
+func (s *FaultScenario) SendInvalidBlock(node int, startTimeSlot uint64, endTimeSlot uint64) *FaultScenario {
+	s.byzantines = append(s.byzantines, byzantine{slotRange: slotRange{startTimeSlot, endTimeSlot}, Node: node, Invalid: true})
+	return s
+}
+
+// SkewClock - a node with clock behind (negative skew) sends its messages late,
+// a node with clock ahead receives messages of the others late
+func (s *FaultScenario) SkewClock(node int, skew time.Duration) *FaultScenario {
+	s.clockSkews[node] = skew
+	return s
+}
+
+// Deliver - return whether a message sent in timeSlot reaches the receiver and how long it is delayed,
+// fromTwin and toTwin tell the message is sent by or to the twin of an equivocating node
+func (s *FaultScenario) Deliver(timeSlot uint64, from int, to int, fromTwin bool, toTwin bool) (bool, time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.IsCrashed(from, timeSlot) || s.IsCrashed(to, timeSlot) {
+		return false, 0
+	}
+	for _, p := range s.partitions {
+		if p.isActive(timeSlot) && p.isSeparated(from, to) {
+			return false, 0
+		}
+	}
+	for _, b := range s.byzantines {
+		if b.Invalid || !b.isActive(timeSlot) {
+			continue
+		}
+		if from == b.Node && !b.isTwinPeer(to, fromTwin) {
+			return false, 0
+		}
+		if to == b.Node && !b.isTwinPeer(from, toTwin) {
+			return false, 0
+		}
+	}
+	delay := time.Duration(0)
+	for _, l := range s.links {
+		if !l.isActive(timeSlot) || (l.From != ANY_NODE && l.From != from) || (l.To != ANY_NODE && l.To != to) {
+			continue
+		}
+		if l.DropRate > 0 && s.rand.Float64() < l.DropRate {
+			return false, 0
+		}
+		delay += l.Delay
+	}
+	if skew := s.clockSkews[from]; skew < 0 {
+		delay += -skew
+	}
+	if skew := s.clockSkews[to]; skew > 0 {
+		delay += skew
+	}
+	return true, delay
+}
+
+// isTwinPeer - the twin only talks to its group, the node only talks to the others
+func (b byzantine) isTwinPeer(peer int, isTwin bool) bool {
+	inTwinGroup := false
+	for _, node := range b.TwinGroup {
+		inTwinGroup = inTwinGroup || node == peer
+	}
+	return inTwinGroup == isTwin
+}
+
+func (s *FaultScenario) IsCrashed(node int, timeSlot uint64) bool {
+	for _, c := range s.crashes {
+		if c.Node == node && c.isActive(timeSlot) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsEquivocating - the twin of node is running in timeSlot
+func (s *FaultScenario) IsEquivocating(node int, timeSlot uint64) bool {
+	for _, b := range s.byzantines {
+		if b.Node == node && !b.Invalid && b.isActive(timeSlot) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *FaultScenario) IsSendingInvalidBlock(node int, timeSlot uint64) bool {
+	for _, b := range s.byzantines {
+		if b.Node == node && b.Invalid && b.isActive(timeSlot) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetStabilizationTimeSlot - the first time slot after all faults are healed
+func (s *FaultScenario) GetStabilizationTimeSlot() uint64 {
+	last := uint64(0)
+	ranges := []slotRange{}
+	for _, l := range s.links {
+		ranges = append(ranges, l.slotRange)
+	}
+	for _, p := range s.partitions {
+		ranges = append(ranges, p.slotRange)
+	}
+	for _, c := range s.crashes {
+		ranges = append(ranges, c.slotRange)
+	}
+	for _, b := range s.byzantines {
+		ranges = append(ranges, b.slotRange)
+	}
+	for _, r := range ranges {
+		if r.EndTimeSlot > last {
+			last = r.EndTimeSlot
+		}
+	}
+	return last + 1
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFaultScenarioDeliver(t *testing.T) {
+	faults := NewFaultScenario(1)
+	faults.Partition(10, 12, []int{0, 1}, []int{2})
+	faults.Crash(3, 20, 21)
+	faults.DelayLink(ANY_NODE, 0, 30, 31, time.Second)
+	faults.DelayLink(1, ANY_NODE, 30, 30, 2*time.Second)
+	faults.DropLink(2, 1, 30, 31, 1)
+
+	tests := []struct {
+		name      string
+		timeSlot  uint64
+		from      int
+		to        int
+		wantOK    bool
+		wantDelay time.Duration
+	}{
+		{name: "no fault", timeSlot: 1, from: 0, to: 3, wantOK: true},
+		{name: "same group of partition", timeSlot: 10, from: 0, to: 1, wantOK: true},
+		{name: "different groups of partition", timeSlot: 11, from: 1, to: 2, wantOK: false},
+		{name: "node not in any group is isolated", timeSlot: 12, from: 3, to: 0, wantOK: false},
+		{name: "partition is healed", timeSlot: 13, from: 1, to: 2, wantOK: true},
+		{name: "sender is crashed", timeSlot: 20, from: 3, to: 0, wantOK: false},
+		{name: "receiver is crashed", timeSlot: 21, from: 0, to: 3, wantOK: false},
+		{name: "crashed node is restarted", timeSlot: 22, from: 3, to: 0, wantOK: true},
+		{name: "delay to node 0", timeSlot: 31, from: 3, to: 0, wantOK: true, wantDelay: time.Second},
+		{name: "delays of links are added", timeSlot: 30, from: 1, to: 0, wantOK: true, wantDelay: 3 * time.Second},
+		{name: "link is dropped", timeSlot: 30, from: 2, to: 1, wantOK: false},
+		{name: "reverse link is not dropped", timeSlot: 30, from: 1, to: 2, wantOK: true, wantDelay: 2 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, delay := faults.Deliver(tt.timeSlot, tt.from, tt.to, false, false)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantDelay, delay)
+		})
+	}
+}
+
+func TestFaultScenarioDropRate(t *testing.T) {
+	faults := NewFaultScenario(1)
+	faults.DropLink(0, 1, 1, 1, 0.5)
+	delivered := 0
+	for i := 0; i < 1000; i++ {
+		if ok, _ := faults.Deliver(1, 0, 1, false, false); ok {
+			delivered++
+		}
+	}
+	assert.True(t, delivered > 400 && delivered < 600, "about half of messages are delivered, got %d", delivered)
+
+	// the same seed drops the same messages
+	for i := 0; i < 100; i++ {
+		ok1, _ := NewFaultScenario(int64(i)).DropLink(0, 1, 1, 1, 0.5).Deliver(1, 0, 1, false, false)
+		ok2, _ := NewFaultScenario(int64(i)).DropLink(0, 1, 1, 1, 0.5).Deliver(1, 0, 1, false, false)
+		assert.Equal(t, ok1, ok2)
+	}
+}
+
+func TestFaultScenarioEquivocate(t *testing.T) {
+	faults := NewFaultScenario(1)
+	faults.Equivocate(1, []int{2}, 10, 11)
+	assert.False(t, faults.IsEquivocating(1, 9))
+	assert.True(t, faults.IsEquivocating(1, 10))
+	assert.False(t, faults.IsEquivocating(2, 10))
+	assert.False(t, faults.IsSendingInvalidBlock(1, 10))
+
+	tests := []struct {
+		name     string
+		from     int
+		to       int
+		fromTwin bool
+		toTwin   bool
+		wantOK   bool
+	}{
+		{name: "twin to its group", from: 1, to: 2, fromTwin: true, wantOK: true},
+		{name: "twin to others", from: 1, to: 0, fromTwin: true, wantOK: false},
+		{name: "node to twin group", from: 1, to: 2, wantOK: false},
+		{name: "node to others", from: 1, to: 3, wantOK: true},
+		{name: "twin group to twin", from: 2, to: 1, toTwin: true, wantOK: true},
+		{name: "twin group to node", from: 2, to: 1, wantOK: false},
+		{name: "others to twin", from: 0, to: 1, toTwin: true, wantOK: false},
+		{name: "others to node", from: 0, to: 1, wantOK: true},
+		{name: "between other nodes", from: 0, to: 2, wantOK: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, _ := faults.Deliver(10, tt.from, tt.to, tt.fromTwin, tt.toTwin)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+	ok, _ := faults.Deliver(12, 1, 0, true, false)
+	assert.True(t, ok, "equivocation is over")
+}
+
+func TestFaultScenarioInvalidBlockAndClockSkew(t *testing.T) {
+	faults := NewFaultScenario(1)
+	faults.SendInvalidBlock(2, 5, 6)
+	faults.SkewClock(1, -500*time.Millisecond)
+	faults.SkewClock(3, 300*time.Millisecond)
+	assert.True(t, faults.IsSendingInvalidBlock(2, 5))
+	assert.False(t, faults.IsSendingInvalidBlock(2, 7))
+	assert.False(t, faults.IsEquivocating(2, 5))
+	ok, _ := faults.Deliver(5, 2, 0, false, false)
+	assert.True(t, ok, "invalid blocks are delivered")
+
+	// node with clock behind sends late, node with clock ahead receives late
+	_, delay := faults.Deliver(1, 1, 0, false, false)
+	assert.Equal(t, 500*time.Millisecond, delay)
+	_, delay = faults.Deliver(1, 0, 3, false, false)
+	assert.Equal(t, 300*time.Millisecond, delay)
+	_, delay = faults.Deliver(1, 1, 3, false, false)
+	assert.Equal(t, 800*time.Millisecond, delay)
+	_, delay = faults.Deliver(1, 3, 1, false, false)
+	assert.Equal(t, time.Duration(0), delay)
+}
+
+func TestFaultScenarioGetStabilizationTimeSlot(t *testing.T) {
+	faults := NewFaultScenario(1)
+	assert.Equal(t, uint64(1), faults.GetStabilizationTimeSlot())
+	faults.Partition(2, 5, []int{0, 1}, []int{2, 3})
+	assert.Equal(t, uint64(6), faults.GetStabilizationTimeSlot())
+	faults.Crash(3, 8, 10).Equivocate(1, []int{2}, 12, 14).SendInvalidBlock(2, 16, 17)
+	assert.Equal(t, uint64(18), faults.GetStabilizationTimeSlot())
+	faults.DelayLink(ANY_NODE, 0, 18, 20, time.Second)
+	assert.Equal(t, uint64(21), faults.GetStabilizationTimeSlot())
+	// clock skew is permanent, it does not delay stabilization
+	faults.SkewClock(1, time.Second)
+	assert.Equal(t, uint64(21), faults.GetStabilizationTimeSlot())
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"github.com/incognitochain/incognito-chain/consensus/blsbftv2"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"testing"
+	"time"
+)
+
+func Test_Main4Committee_ByzantineScenario(t *testing.T) {
+	skipSimulation(t)
+	committee := []string{
+		"112t8rnXB47RhSdyVRU41TEf78nxbtWGtmjutwSp9YqsNaCpFxQGXcnwcXTtBkCGDk1KLBRBeWMvb2aXG5SeDUJRHtFV8jTB3weHEkbMJ1AL",
+		"112t8rnXVdfBqBMigSs5fm9NSS8rgsVVURUxArpv6DxYmPZujKqomqUa2H9wh1zkkmDGtDn2woK4NuRDYnYRtVkUhK34TMfbUF4MShSkrCw5",
+		"112t8rnXi8eKJ5RYJjyQYcFMThfbXHgaL6pq5AF5bWsDXwfsw8pqQUreDv6qgWyiABoDdphvqE7NFr9K92aomX7Gi5Nm1e4tEoV3qRLVdfSR",
+		"112t8rnY42xRqJghQX3zvhgEa2ZJBwSzJ46SXyVQEam1yNpN4bfAqJwh1SsobjHAz8wwRvwnqJBfxrbwUuTxqgEbuEE8yMu6F14QmwtwyM43",
+	}
+	committeePkStruct := []incognitokey.CommitteePublicKey{}
+	for _, v := range committee {
+		p, _ := blsbftv2.LoadUserKeyFromIncPrivateKey(v)
+		m, _ := blsbftv2.GetMiningKeyFromPrivateSeed(p)
+		committeePkStruct = append(committeePkStruct, m.GetPublicKey())
+	}
+	nodeList := []*Node{}
+	for {
+		if int(currentTimeSlot())%len(committee) == len(committee)-1 {
+			break
+		} else {
+			time.Sleep(1 * time.Millisecond)
+		}
+	}
+
+	for i, _ := range committee {
+		ni := NewNode(committeePkStruct, committee, i)
+		nodeList = append(nodeList, ni)
+	}
+	var startNode = func() {
+		for _, v := range nodeList {
+			v.nodeList = nodeList
+			go v.Start()
+		}
+	}
+	GetSimulation().nodeList = nodeList
+	//simulation
+	rootTimeSlot := currentTimeSlot()
+	startTimeSlot := rootTimeSlot + 1
+	fmt.Println("root Time slot", rootTimeSlot)
+	GetSimulation().setStartTimeSlot(startTimeSlot)
+	var setTimeSlot = func(s int) uint64 {
+		return startTimeSlot + uint64(s) - 1
+	}
+
+	for _, v := range nodeList {
+		v.consensusEngine.Logger.Info("\n\n")
+		v.consensusEngine.Logger.Info("===============================")
+		v.consensusEngine.Logger.Info("\n\n")
+		fmt.Printf("Node %s log is %s\n", v.id, fmt.Sprintf("log%s.log", v.id))
+	}
+
+	/*
+		START YOUR SIMULATION HERE
+	*/
+	faults := NewFaultScenario(time.Now().UnixNano())
+	// partition which heals, no side has enough votes to finalize
+	faults.Partition(setTimeSlot(2), setTimeSlot(5), []int{0, 1}, []int{2, 3})
+	// node 3 crashes and restarts
+	faults.Crash(3, setTimeSlot(8), setTimeSlot(10))
+	// node 1 and its twin propose and vote on conflicting blocks
+	faults.Equivocate(1, []int{2}, setTimeSlot(12), setTimeSlot(14))
+	// node 2 sends invalid blocks
+	faults.SendInvalidBlock(2, setTimeSlot(16), setTimeSlot(17))
+	// slow and lossy links
+	faults.DelayLink(ANY_NODE, 0, setTimeSlot(18), setTimeSlot(20), 2*time.Second)
+	faults.DropLink(3, ANY_NODE, setTimeSlot(18), setTimeSlot(20), 0.5)
+	// node 1 clock is behind
+	faults.SkewClock(1, -500*time.Millisecond)
+	twins := []*Node{NewTwinNode(committeePkStruct, committee, 1)}
+	GetSimulation().setFaultScenario(faults, twins, 8)
+
+	timeslot := setTimeSlot(30)
+	/*
+		END YOUR SIMULATION HERE
+	*/
+	GetSimulation().setMaxTimeSlot(timeslot)
+	startNode()
+	go func() {
+		lastTimeSlot := uint64(0)
+		for {
+			curTimeSlot := (currentTimeSlot() - startTimeSlot) + 1
+			if lastTimeSlot != curTimeSlot {
+				GetSimulation().onTimeSlot(startTimeSlot + curTimeSlot - 1)
+			}
+			for _, v := range nodeList {
+				if lastTimeSlot != curTimeSlot && curTimeSlot <= GetSimulation().maxTimeSlot {
+					v.consensusEngine.Logger.Info("========================================")
+					v.consensusEngine.Logger.Info("SIMULATION NODE", v.id, "TIMESLOT", curTimeSlot)
+					v.consensusEngine.Logger.Info("========================================")
+				}
+			}
+			lastTimeSlot = curTimeSlot
+			time.Sleep(1 * time.Millisecond)
+		}
+	}()
+	select {}
+}
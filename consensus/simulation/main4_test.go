@@ -2,17 +2,23 @@ package main
 
 import (
 	"fmt"
-	"github.com/incognitochain/incognito-chain/blockchain"
-	"github.com/incognitochain/incognito-chain/blockchain/v2/shard"
-	"github.com/incognitochain/incognito-chain/common"
-	"github.com/incognitochain/incognito-chain/consensus_v2/blsbftv2"
+	"github.com/incognitochain/incognito-chain/consensus/blsbftv2"
 	"github.com/incognitochain/incognito-chain/incognitokey"
+	"os"
 	"testing"
 	"time"
 )
 
-func Test_Main4Committee(t *testing.T) {
+// skipSimulation - scenarios run in real time until their last time slot and exit the test binary,
+// they only run with SIMULATION=1
+func skipSimulation(t *testing.T) {
+	if os.Getenv("SIMULATION") == "" {
+		t.Skip("set SIMULATION=1 to run consensus simulation")
+	}
+}
 
+func Test_Main4Committee(t *testing.T) {
+	skipSimulation(t)
 	committee := []string{
 		"112t8rnXB47RhSdyVRU41TEf78nxbtWGtmjutwSp9YqsNaCpFxQGXcnwcXTtBkCGDk1KLBRBeWMvb2aXG5SeDUJRHtFV8jTB3weHEkbMJ1AL",
 		"112t8rnXVdfBqBMigSs5fm9NSS8rgsVVURUxArpv6DxYmPZujKqomqUa2H9wh1zkkmDGtDn2woK4NuRDYnYRtVkUhK34TMfbUF4MShSkrCw5",
@@ -26,9 +32,8 @@ func Test_Main4Committee(t *testing.T) {
 		committeePkStruct = append(committeePkStruct, m.GetPublicKey())
 	}
 	nodeList := []*Node{}
-	genesisTime, _ := time.Parse(shard.GENESIS_TIMESTAMP, blockchain.TestnetGenesisBlockTime)
 	for {
-		if int(common.GetTimeSlot(genesisTime.Unix(), time.Now().Unix(), blsbftv2.TIMESLOT))%len(committee) == len(committee)-1 {
+		if int(currentTimeSlot())%len(committee) == len(committee)-1 {
 			break
 		} else {
 			time.Sleep(1 * time.Millisecond)
@@ -47,7 +52,7 @@ func Test_Main4Committee(t *testing.T) {
 	}
 	GetSimulation().nodeList = nodeList
 	//simulation
-	rootTimeSlot := nodeList[0].chain.GetBestView().GetRootTimeSlot()
+	rootTimeSlot := currentTimeSlot()
 	startTimeSlot := rootTimeSlot + 1
 	fmt.Println("root Time slot", rootTimeSlot)
 	GetSimulation().setStartTimeSlot(startTimeSlot)
@@ -104,7 +109,7 @@ func Test_Main4Committee(t *testing.T) {
 	go func() {
 		lastTimeSlot := uint64(0)
 		for {
-			curTimeSlot := (common.GetTimeSlot(genesisTime.Unix(), time.Now().Unix(), blsbftv2.TIMESLOT) - startTimeSlot) + 1
+			curTimeSlot := (currentTimeSlot() - startTimeSlot) + 1
 			for _, v := range nodeList {
 				if lastTimeSlot != curTimeSlot && curTimeSlot <= GetSimulation().maxTimeSlot {
 					v.consensusEngine.Logger.Info("========================================")
@@ -122,6 +127,7 @@ func Test_Main4Committee(t *testing.T) {
 }
 
 func Test_Main4Committee_ScenarioA(t *testing.T) {
+	skipSimulation(t)
 	committee := []string{
 		"112t8rnXB47RhSdyVRU41TEf78nxbtWGtmjutwSp9YqsNaCpFxQGXcnwcXTtBkCGDk1KLBRBeWMvb2aXG5SeDUJRHtFV8jTB3weHEkbMJ1AL",
 		"112t8rnXVdfBqBMigSs5fm9NSS8rgsVVURUxArpv6DxYmPZujKqomqUa2H9wh1zkkmDGtDn2woK4NuRDYnYRtVkUhK34TMfbUF4MShSkrCw5",
@@ -135,9 +141,8 @@ func Test_Main4Committee_ScenarioA(t *testing.T) {
 		committeePkStruct = append(committeePkStruct, m.GetPublicKey())
 	}
 	nodeList := []*Node{}
-	genesisTime, _ := time.Parse(shard.GENESIS_TIMESTAMP, blockchain.TestnetGenesisBlockTime)
 	for {
-		if int(common.GetTimeSlot(genesisTime.Unix(), time.Now().Unix(), blsbftv2.TIMESLOT))%len(committee) == len(committee)-1 {
+		if int(currentTimeSlot())%len(committee) == len(committee)-1 {
 			break
 		} else {
 			time.Sleep(1 * time.Millisecond)
@@ -156,7 +161,7 @@ func Test_Main4Committee_ScenarioA(t *testing.T) {
 	}
 	GetSimulation().nodeList = nodeList
 	//simulation
-	rootTimeSlot := nodeList[0].chain.GetBestView().GetRootTimeSlot()
+	rootTimeSlot := currentTimeSlot()
 	startTimeSlot := rootTimeSlot + 1
 	fmt.Println("root Time slot", rootTimeSlot)
 	GetSimulation().setStartTimeSlot(startTimeSlot)
@@ -240,7 +245,7 @@ func Test_Main4Committee_ScenarioA(t *testing.T) {
 	go func() {
 		lastTimeSlot := uint64(0)
 		for {
-			curTimeSlot := (common.GetTimeSlot(genesisTime.Unix(), time.Now().Unix(), blsbftv2.TIMESLOT) - startTimeSlot) + 1
+			curTimeSlot := (currentTimeSlot() - startTimeSlot) + 1
 			for _, v := range nodeList {
 				if lastTimeSlot != curTimeSlot && curTimeSlot <= GetSimulation().maxTimeSlot {
 					v.consensusEngine.Logger.Info("========================================")
@@ -257,6 +262,7 @@ func Test_Main4Committee_ScenarioA(t *testing.T) {
 }
 
 func Test_Main4Committee_ScenarioB(t *testing.T) {
+	skipSimulation(t)
 	committee := []string{
 		"112t8rnXB47RhSdyVRU41TEf78nxbtWGtmjutwSp9YqsNaCpFxQGXcnwcXTtBkCGDk1KLBRBeWMvb2aXG5SeDUJRHtFV8jTB3weHEkbMJ1AL",
 		"112t8rnXVdfBqBMigSs5fm9NSS8rgsVVURUxArpv6DxYmPZujKqomqUa2H9wh1zkkmDGtDn2woK4NuRDYnYRtVkUhK34TMfbUF4MShSkrCw5",
@@ -270,9 +276,8 @@ func Test_Main4Committee_ScenarioB(t *testing.T) {
 		committeePkStruct = append(committeePkStruct, m.GetPublicKey())
 	}
 	nodeList := []*Node{}
-	genesisTime, _ := time.Parse(shard.GENESIS_TIMESTAMP, blockchain.TestnetGenesisBlockTime)
 	for {
-		if int(common.GetTimeSlot(genesisTime.Unix(), time.Now().Unix(), blsbftv2.TIMESLOT))%len(committee) == len(committee)-1 {
+		if int(currentTimeSlot())%len(committee) == len(committee)-1 {
 			break
 		} else {
 			time.Sleep(1 * time.Millisecond)
@@ -291,7 +296,7 @@ func Test_Main4Committee_ScenarioB(t *testing.T) {
 	}
 	GetSimulation().nodeList = nodeList
 	//simulation
-	rootTimeSlot := nodeList[0].chain.GetBestView().GetRootTimeSlot()
+	rootTimeSlot := currentTimeSlot()
 	startTimeSlot := rootTimeSlot + 1
 	fmt.Println("root Time slot", rootTimeSlot)
 	GetSimulation().setStartTimeSlot(startTimeSlot)
@@ -374,7 +379,7 @@ func Test_Main4Committee_ScenarioB(t *testing.T) {
 	go func() {
 		lastTimeSlot := uint64(0)
 		for {
-			curTimeSlot := (common.GetTimeSlot(genesisTime.Unix(), time.Now().Unix(), blsbftv2.TIMESLOT) - startTimeSlot) + 1
+			curTimeSlot := (currentTimeSlot() - startTimeSlot) + 1
 			if lastTimeSlot != curTimeSlot {
 				time.AfterFunc(time.Millisecond*500, func() {
 					fmt.Printf("Best view height: %d. Final view height: %d\n", fullnode.GetBestView().GetHeight(), fullnode.GetFinalView().GetHeight())
@@ -395,6 +400,7 @@ func Test_Main4Committee_ScenarioB(t *testing.T) {
 }
 
 func Test_Main4Committee_ScenarioC(t *testing.T) {
+	skipSimulation(t)
 	committee := []string{
 		"112t8rnXB47RhSdyVRU41TEf78nxbtWGtmjutwSp9YqsNaCpFxQGXcnwcXTtBkCGDk1KLBRBeWMvb2aXG5SeDUJRHtFV8jTB3weHEkbMJ1AL",
 		"112t8rnXVdfBqBMigSs5fm9NSS8rgsVVURUxArpv6DxYmPZujKqomqUa2H9wh1zkkmDGtDn2woK4NuRDYnYRtVkUhK34TMfbUF4MShSkrCw5",
@@ -408,9 +414,8 @@ func Test_Main4Committee_ScenarioC(t *testing.T) {
 		committeePkStruct = append(committeePkStruct, m.GetPublicKey())
 	}
 	nodeList := []*Node{}
-	genesisTime, _ := time.Parse(shard.GENESIS_TIMESTAMP, blockchain.TestnetGenesisBlockTime)
 	for {
-		if int(common.GetTimeSlot(genesisTime.Unix(), time.Now().Unix(), blsbftv2.TIMESLOT))%len(committee) == len(committee)-1 {
+		if int(currentTimeSlot())%len(committee) == len(committee)-1 {
 			break
 		} else {
 			time.Sleep(1 * time.Millisecond)
@@ -429,7 +434,7 @@ func Test_Main4Committee_ScenarioC(t *testing.T) {
 	}
 	GetSimulation().nodeList = nodeList
 	//simulation
-	rootTimeSlot := nodeList[0].chain.GetBestView().GetRootTimeSlot()
+	rootTimeSlot := currentTimeSlot()
 	startTimeSlot := rootTimeSlot + 1
 	fmt.Println("root Time slot", rootTimeSlot)
 	GetSimulation().setStartTimeSlot(startTimeSlot)
@@ -475,7 +480,7 @@ func Test_Main4Committee_ScenarioC(t *testing.T) {
 	go func() {
 		lastTimeSlot := uint64(0)
 		for {
-			curTimeSlot := (common.GetTimeSlot(genesisTime.Unix(), time.Now().Unix(), blsbftv2.TIMESLOT) - startTimeSlot) + 1
+			curTimeSlot := (currentTimeSlot() - startTimeSlot) + 1
 			if lastTimeSlot != curTimeSlot {
 				time.AfterFunc(time.Millisecond*500, func() {
 					fmt.Printf("Best view height: %d. Final view height: %d\n", fullnode.GetBestView().GetHeight(), fullnode.GetFinalView().GetHeight())
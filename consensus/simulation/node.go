@@ -1,30 +1,27 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
-	"github.com/incognitochain/incognito-chain/blockchain"
+	"os"
 	"strconv"
 	"time"
 
-	"os"
-
-	blockchainv2 "github.com/incognitochain/incognito-chain/blockchain/v2"
-	shardv2 "github.com/incognitochain/incognito-chain/blockchain/v2/shard"
-	"github.com/incognitochain/incognito-chain/consensus_v2"
-
 	"github.com/incognitochain/incognito-chain/common"
-	"github.com/incognitochain/incognito-chain/consensus_v2/blsbftv2"
+	"github.com/incognitochain/incognito-chain/consensus/blsbftv2"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/wire"
-	libp2p "github.com/libp2p/go-libp2p-peer"
 )
 
 type Node struct {
 	id              string
-	consensusEngine *blsbftv2.BLSBFT
-	chain           consensus.ChainViewManagerInterface
+	consensusEngine *blsbftv2.BLSBFT_V2
+	chain           *SimChain
 	nodeList        []*Node
 	startSimulation bool
+	isStarted       bool
+	isCrashed       bool // stopped by fault scenario
+	isTwin          bool // run with the same key of node id to equivocate
 }
 
 type logWriter struct {
@@ -37,10 +34,23 @@ func (s logWriter) Write(p []byte) (n int, err error) {
 	return len(p), nil
 }
 
-var fullnode *blockchainv2.ChainViewManager
-
 func NewNode(committeePkStruct []incognitokey.CommitteePublicKey, committee []string, index int) *Node {
-	name := fmt.Sprintf("log%d", index)
+	return newNode(committeePkStruct, committee, index, fmt.Sprintf("log%d", index), "shard", 0)
+}
+
+// NewNodeBeacon - create a node of the beacon committee
+func NewNodeBeacon(committeePkStruct []incognitokey.CommitteePublicKey, committee []string, index int) *Node {
+	return newNode(committeePkStruct, committee, index, fmt.Sprintf("log%d", index), "beacon", -1)
+}
+
+// NewTwinNode - create a twin of node index, which signs with the same mining key
+func NewTwinNode(committeePkStruct []incognitokey.CommitteePublicKey, committee []string, index int) *Node {
+	twin := newNode(committeePkStruct, committee, index, fmt.Sprintf("log%d_twin", index), "shard", 0)
+	twin.isTwin = true
+	return twin
+}
+
+func newNode(committeePkStruct []incognitokey.CommitteePublicKey, committee []string, index int, name string, chainKey string, chainID int) *Node {
 	fd, err := os.OpenFile(fmt.Sprintf("%s.log", name), os.O_CREATE|os.O_WRONLY, 0666)
 	if err != nil {
 		panic(err)
@@ -52,43 +62,15 @@ func NewNode(committeePkStruct []incognitokey.CommitteePublicKey, committee []st
 	})
 	consensusLogger := backendLog.Logger("Consensus", false)
 	consensusLogger.SetLevel(1)
-	chainViewLogger := backendLog.Logger("ShardView", false)
-	chainViewLogger.SetLevel(1)
 
 	node := Node{id: fmt.Sprintf("%d", index)}
-	db := &FakeDB{}
-	db.genesisBlock = shardv2.CreateShardGenesisBlock(1, blockchain.Testnet, blockchain.TestnetGenesisBlockTime, blockchain.TestnetInitPRV)
-	node.chain = blockchainv2.InitNewChainViewManager(fmt.Sprintf("shard0_%d", index), &shardv2.ShardView{
-		BC:             &shardv2.FakeBC{},
-		Block:          db.genesisBlock.(*shardv2.ShardBlock),
-		ShardCommittee: committeePkStruct,
-		DB:             db,
-		Logger:         chainViewLogger,
-	})
-
 	if fullnode == nil {
-		db := &FakeDB{}
-		db.genesisBlock = shardv2.CreateShardGenesisBlock(1, blockchain.Testnet, blockchain.TestnetGenesisBlockTime, blockchain.TestnetInitPRV)
-		backendLog := common.NewBackend(nil).Logger("Fullnode", false)
-		fullnode = blockchainv2.InitNewChainViewManager("fullnode", &shardv2.ShardView{
-			ShardID:        0,
-			Block:          db.genesisBlock.(*shardv2.ShardBlock),
-			ShardCommittee: committeePkStruct,
-			DB:             db,
-			Logger:         backendLog,
-		})
-	}
-
-	//node.chain.UserPubKey = committeePkStruct[index]
-	node.chain.GetBestView()
-
-	node.consensusEngine = &blsbftv2.BLSBFT{
-		Chain:    node.chain,
-		Node:     &node,
-		ChainKey: "shard",
-		PeerID:   name,
-		Logger:   consensusLogger,
+		fullnode = NewSimChain("fullnode", chainID, committeePkStruct)
 	}
+	node.chain = NewSimChain(fmt.Sprintf("%s_%d", chainKey, index), chainID, committeePkStruct)
+	// vote journal is kept in memory, a restarted node forgets its votes like a node which lost its database
+	node.consensusEngine = blsbftv2.NewInstance(node.chain, nil, chainKey, chainID, &node, consensusLogger)
+	node.consensusEngine.PeerID = name
 
 	prvSeed, err := blsbftv2.LoadUserKeyFromIncPrivateKey(committee[index])
 	failOnError(err)
@@ -97,48 +79,53 @@ func NewNode(committeePkStruct []incognitokey.CommitteePublicKey, committee []st
 }
 
 func (s *Node) Start() {
+	s.isStarted = true
 	s.consensusEngine.Start()
 }
 
+func (s *Node) Stop() {
+	s.isStarted = false
+	s.consensusEngine.Stop()
+}
+
 func (s *Node) GetID() string {
 	return s.id
 }
 
-func (s *Node) RequestSyncBlock(nodeID string, fromView string, toView string) {
-	s.consensusEngine.Logger.Debug("Sync block from node", nodeID)
-	time.AfterFunc(time.Millisecond*100, func() {
-		nodeIDNumber, _ := strconv.Atoi(nodeID)
-		views := GetSimulation().nodeList[nodeIDNumber].chain.GetViewByRange(fromView, toView)
-		for _, v := range views {
-			s.chain.ConnectBlockAndAddView(v.GetBlock())
-		}
-		s.consensusEngine.Logger.Debug("Sync block ", len(views))
-	})
-
+func (s *Node) GetName() string {
+	if s.isTwin {
+		return s.id + "_twin"
+	}
+	return s.id
 }
 
-func (s *Node) NotifyOutdatedView(nodeID string, latestView string) {
+// RequestMissingViewViaStream - insert blocks of the peer up to the missing views
+func (s *Node) RequestMissingViewViaStream(peerID string, hashes [][]byte, fromCID int, chainName string) error {
+	peer := GetSimulation().getNode(peerID)
+	if peer == nil {
+		return fmt.Errorf("peer %v is not found", peerID)
+	}
+	s.consensusEngine.Logger.Debug("Sync block from node", peerID)
 	time.AfterFunc(time.Millisecond*100, func() {
-		nodeIDNumber, _ := strconv.Atoi(nodeID)
-		views := s.chain.GetViewByRange("", latestView)
-		for _, v := range views {
-			GetSimulation().nodeList[nodeIDNumber].chain.ConnectBlockAndAddView(v.GetBlock())
-			GetSimulation().nodeList[nodeIDNumber].consensusEngine.Logger.Debug("Sync from notify outdated view, block height", v.GetHeight())
+		for _, hash := range hashes {
+			blocks := peer.chain.getBlocksTo(common.BytesToHash(hash))
+			for _, block := range blocks {
+				if err := s.chain.insertBlock(block); err != nil {
+					s.consensusEngine.Logger.Debug("Sync block", block.GetHeight(), err)
+				}
+			}
+			s.consensusEngine.Logger.Debug("Sync block ", len(blocks))
 		}
 	})
-
-}
-
-func (s *Node) BroadCastBlock(block consensus.BlockInterface) {
-	fullnode.ConnectBlockAndAddView(block)
+	return nil
 }
 
-func (s *Node) PushMessageToChain(msg interface{}, chain consensus.ChainViewManagerInterface) error {
+func (s *Node) PushMessageToChain(msg wire.Message, chain common.ChainInterface) error {
 	time.AfterFunc(time.Millisecond*100, func() {
 		if msg.(*wire.MessageBFT).Type == "propose" {
-			timeSlot := msg.(*wire.MessageBFT).TimeSlot
+			timeSlot := uint64(msg.(*wire.MessageBFT).TimeSlot)
 			if timeSlot > GetSimulation().maxTimeSlot {
-				os.Exit(0)
+				GetSimulation().exit()
 			}
 			pComm := GetSimulation().scenario.proposeComm
 			if comm, ok := pComm[timeSlot]; ok {
@@ -149,11 +136,11 @@ func (s *Node) PushMessageToChain(msg interface{}, chain consensus.ChainViewMana
 					if senderComm, ok := comm[s.id]; ok {
 						if senderComm[i] == 1 {
 							s.consensusEngine.Logger.Debug("Send propose to ", c.id)
-							c.consensusEngine.ProcessBFTMsg(msg.(*wire.MessageBFT), s)
+							s.deliverBFTMsg(msg.(*wire.MessageBFT), c)
 						}
 					} else {
 						s.consensusEngine.Logger.Debug("Send propose to ", c.id)
-						c.consensusEngine.ProcessBFTMsg(msg.(*wire.MessageBFT), s)
+						s.deliverBFTMsg(msg.(*wire.MessageBFT), c)
 					}
 
 				}
@@ -163,17 +150,18 @@ func (s *Node) PushMessageToChain(msg interface{}, chain consensus.ChainViewMana
 						continue
 					}
 					s.consensusEngine.Logger.Debug("Send propose to ", c.id)
-					c.consensusEngine.ProcessBFTMsg(msg.(*wire.MessageBFT), s)
+					s.deliverBFTMsg(msg.(*wire.MessageBFT), c)
 				}
 			}
+			s.deliverBFTMsgToTwins(msg.(*wire.MessageBFT))
 			return
 		}
 
 		if msg.(*wire.MessageBFT).Type == "vote" {
 			vComm := GetSimulation().scenario.voteComm
-			timeSlot := msg.(*wire.MessageBFT).TimeSlot
+			timeSlot := uint64(msg.(*wire.MessageBFT).TimeSlot)
 			if timeSlot > GetSimulation().maxTimeSlot {
-				os.Exit(0)
+				GetSimulation().exit()
 			}
 			if comm, ok := vComm[timeSlot]; ok {
 				for i, c := range s.nodeList {
@@ -183,11 +171,11 @@ func (s *Node) PushMessageToChain(msg interface{}, chain consensus.ChainViewMana
 					if senderComm, ok := comm[s.id]; ok {
 						if senderComm[i] == 1 {
 							s.consensusEngine.Logger.Debug("Send vote to ", c.id)
-							c.consensusEngine.ProcessBFTMsg(msg.(*wire.MessageBFT), s)
+							s.deliverBFTMsg(msg.(*wire.MessageBFT), c)
 						}
 					} else {
 						s.consensusEngine.Logger.Debug("Send vote to ", c.id)
-						c.consensusEngine.ProcessBFTMsg(msg.(*wire.MessageBFT), s)
+						s.deliverBFTMsg(msg.(*wire.MessageBFT), c)
 					}
 
 				}
@@ -197,9 +185,10 @@ func (s *Node) PushMessageToChain(msg interface{}, chain consensus.ChainViewMana
 						continue
 					}
 					s.consensusEngine.Logger.Debug("Send vote to ", c.id)
-					c.consensusEngine.ProcessBFTMsg(msg.(*wire.MessageBFT), s)
+					s.deliverBFTMsg(msg.(*wire.MessageBFT), c)
 				}
 			}
+			s.deliverBFTMsgToTwins(msg.(*wire.MessageBFT))
 			return
 		}
 	})
@@ -207,9 +196,80 @@ func (s *Node) PushMessageToChain(msg interface{}, chain consensus.ChainViewMana
 	return nil
 }
 
-func (Node) UpdateConsensusState(role string, userPbk string, currentShard *byte, beaconCommittee []string, shardCommittee map[byte][]string) {
-	//not use in bft
-	return
+// deliverBFTMsg - deliver the message to receiver unless it is dropped by the fault scenario
+func (s *Node) deliverBFTMsg(msg *wire.MessageBFT, receiver *Node) {
+	faults := GetSimulation().faults
+	// receiver syncs missing blocks from the sender
+	msgFromSender := *msg
+	msgFromSender.PeerID = s.GetName()
+	msg = &msgFromSender
+	if faults == nil {
+		receiver.consensusEngine.ProcessBFTMsg(msg)
+		return
+	}
+	from, _ := strconv.Atoi(s.id)
+	to, _ := strconv.Atoi(receiver.id)
+	ok, delay := faults.Deliver(uint64(msg.TimeSlot), from, to, s.isTwin, receiver.isTwin)
+	if !ok {
+		s.consensusEngine.Logger.Debug("Drop", msg.Type, "to", receiver.GetName())
+		return
+	}
+	if msg.Type == "propose" && faults.IsSendingInvalidBlock(from, uint64(msg.TimeSlot)) {
+		invalidMsg, err := tamperProposeMsg(msg)
+		if err != nil {
+			s.consensusEngine.Logger.Error(err)
+			return
+		}
+		msg = invalidMsg
+	}
+	time.AfterFunc(delay, func() {
+		receiver.consensusEngine.ProcessBFTMsg(msg)
+	})
+}
+
+func (s *Node) deliverBFTMsgToTwins(msg *wire.MessageBFT) {
+	for _, twin := range GetSimulation().twins {
+		if twin == s || !twin.isStarted {
+			continue
+		}
+		s.consensusEngine.Logger.Debug("Send", msg.Type, "to", twin.GetName())
+		s.deliverBFTMsg(msg, twin)
+	}
+}
+
+// tamperProposeMsg - change the timestamp of the proposed block, so its hash does not match the producer signature
+func tamperProposeMsg(msg *wire.MessageBFT) (*wire.MessageBFT, error) {
+	content := map[string]json.RawMessage{}
+	if err := json.Unmarshal(msg.Content, &content); err != nil {
+		return nil, err
+	}
+	block := map[string]json.RawMessage{}
+	if err := json.Unmarshal(content["Block"], &block); err != nil {
+		return nil, err
+	}
+	header := map[string]interface{}{}
+	if err := json.Unmarshal(block["Header"], &header); err != nil {
+		return nil, err
+	}
+	timestamp, _ := header["Timestamp"].(float64)
+	header["Timestamp"] = timestamp + 1
+	headerBytes, err := json.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+	block["Header"] = headerBytes
+	blockBytes, err := json.Marshal(block)
+	if err != nil {
+		return nil, err
+	}
+	content["Block"] = blockBytes
+	contentBytes, err := json.Marshal(content)
+	if err != nil {
+		return nil, err
+	}
+	invalidMsg := *msg
+	invalidMsg.Content = contentBytes
+	return &invalidMsg, nil
 }
 
 func (Node) IsEnableMining() bool {
@@ -227,13 +287,8 @@ func (Node) GetPrivateKey() string {
 	panic("implement me")
 }
 
-func (Node) DropAllConnections() {
-	//not use in bft
-	return
-}
-
-func (Node) PushMessageToPeer(msg interface{}, peerId libp2p.ID) error {
-	return nil
+func (s *Node) GetUserMiningState() (role string, chainID int) {
+	return "committee", s.chain.GetShardID()
 }
 
 func main() {
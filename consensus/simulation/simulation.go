@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"strconv"
 )
 
 type Simulation struct {
@@ -12,6 +13,11 @@ type Simulation struct {
 	startTimeSlot uint64
 	scenario      Scenario
 	expected      Expected
+
+	faults           *FaultScenario
+	twins            map[string]*Node // node id -> twin of equivocating node
+	finality         *FinalityTracker
+	livenessTimeSlot uint64 // a block must be finalized within this number of time slots after faults are healed
 }
 
 type Scenario struct {
@@ -37,6 +43,8 @@ func GetSimulation() *Simulation {
 			voteComm:    make(map[uint64]map[string][]int),
 			sync:        make(map[string][]string),
 		}
+		simulation.twins = make(map[string]*Node)
+		simulation.finality = NewFinalityTracker()
 	}
 	return simulation
 }
@@ -54,3 +62,84 @@ func (s *Simulation) setLogfile(fd os.File) {
 	s.fd = fd
 
 }
+
+// setFaultScenario - run the simulation with faults, twins are created for nodes which equivocate
+func (s *Simulation) setFaultScenario(faults *FaultScenario, twins []*Node, livenessTimeSlot uint64) {
+	s.faults = faults
+	for _, twin := range twins {
+		s.twins[twin.id] = twin
+	}
+	s.livenessTimeSlot = livenessTimeSlot
+}
+
+// onTimeSlot - crash and restart nodes, start and stop twins, record finalized blocks
+func (s *Simulation) onTimeSlot(timeSlot uint64) {
+	if s.faults == nil {
+		return
+	}
+	for _, node := range s.nodeList {
+		nodeIdx, _ := strconv.Atoi(node.id)
+		crashed := s.faults.IsCrashed(nodeIdx, timeSlot)
+		if crashed && !node.isCrashed {
+			node.consensusEngine.Logger.Info("SIMULATION NODE", node.id, "CRASH")
+			node.isCrashed = true
+			node.Stop()
+		} else if !crashed && node.isCrashed {
+			node.consensusEngine.Logger.Info("SIMULATION NODE", node.id, "RESTART")
+			node.isCrashed = false
+			go node.Start()
+		}
+		if twin, ok := s.twins[node.id]; ok {
+			equivocating := s.faults.IsEquivocating(nodeIdx, timeSlot)
+			if equivocating && !twin.isStarted {
+				twin.nodeList = s.nodeList
+				twin.isStarted = true
+				go twin.Start()
+			} else if !equivocating && twin.isStarted {
+				twin.Stop()
+			}
+		}
+	}
+	for _, node := range s.getAllNodes() {
+		if node.isTwin && !node.isStarted {
+			continue
+		}
+		view := node.chain.GetFinalView()
+		s.finality.RecordFinalized(node.GetName(), view.GetHeight(), view.GetBlock().Hash().String(), timeSlot)
+	}
+}
+
+func (s *Simulation) getAllNodes() []*Node {
+	nodes := append([]*Node{}, s.nodeList...)
+	for _, twin := range s.twins {
+		nodes = append(nodes, twin)
+	}
+	return nodes
+}
+
+// getNode - node or twin of name
+func (s *Simulation) getNode(name string) *Node {
+	for _, node := range s.getAllNodes() {
+		if node.GetName() == name {
+			return node
+		}
+	}
+	return nil
+}
+
+// exit - assert safety and liveness of the fault scenario when simulation reaches max time slot
+func (s *Simulation) exit() {
+	if s.faults == nil {
+		os.Exit(0)
+	}
+	if err := s.finality.CheckSafety(); err != nil {
+		fmt.Println("SIMULATION FAILED:", err)
+		os.Exit(1)
+	}
+	if err := s.finality.CheckLiveness(s.faults.GetStabilizationTimeSlot(), s.maxTimeSlot, s.livenessTimeSlot); err != nil {
+		fmt.Println("SIMULATION FAILED:", err)
+		os.Exit(1)
+	}
+	fmt.Println("SIMULATION PASSED: final height", s.finality.GetFinalizedHeight())
+	os.Exit(0)
+}
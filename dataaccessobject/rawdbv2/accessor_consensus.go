@@ -0,0 +1,106 @@
+package rawdbv2
+
+import (
+	"encoding/binary"
+	"encoding/json"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incdb"
+)
+
+// ConsensusVote is the latest vote signed by this node at a block height
+type ConsensusVote struct {
+	BlockHash       common.Hash
+	ProposeTimeSlot int64
+	ProduceTimeSlot int64
+}
+
+// ConsensusProposal is the block proposed by this node in a time slot
+type ConsensusProposal struct {
+	BlockHash common.Hash
+	Height    uint64
+}
+
+// StoreConsensusVote - store the latest vote of a chain at a block height, it replaces the previous vote at this height
+func StoreConsensusVote(db incdb.KeyValueWriter, chainKey string, height uint64, vote ConsensusVote) error {
+	val, err := json.Marshal(vote)
+	if err != nil {
+		return NewRawdbError(StoreConsensusJournalError, err)
+	}
+	if err := db.Put(GetConsensusVoteKey(chainKey, height), val); err != nil {
+		return NewRawdbError(StoreConsensusJournalError, err)
+	}
+	return nil
+}
+
+// GetConsensusVotes - get all stored votes of a chain by block height
+func GetConsensusVotes(db incdb.Database, chainKey string) (map[uint64]ConsensusVote, error) {
+	prefix := GetConsensusVotePrefix(chainKey)
+	iterator := db.NewIteratorWithPrefix(prefix)
+	defer iterator.Release()
+	result := make(map[uint64]ConsensusVote)
+	for iterator.Next() {
+		key := iterator.Key()
+		if len(key) != len(prefix)+8 {
+			continue
+		}
+		vote := ConsensusVote{}
+		if err := json.Unmarshal(iterator.Value(), &vote); err != nil {
+			return nil, NewRawdbError(GetConsensusJournalError, err)
+		}
+		result[binary.BigEndian.Uint64(key[len(prefix):])] = vote
+	}
+	if err := iterator.Error(); err != nil {
+		return nil, NewRawdbError(GetConsensusJournalError, err)
+	}
+	return result, nil
+}
+
+func DeleteConsensusVote(db incdb.KeyValueWriter, chainKey string, height uint64) error {
+	if err := db.Delete(GetConsensusVoteKey(chainKey, height)); err != nil {
+		return NewRawdbError(DeleteConsensusJournalError, err)
+	}
+	return nil
+}
+
+// StoreConsensusProposal - store the block proposed by a chain in a time slot
+func StoreConsensusProposal(db incdb.KeyValueWriter, chainKey string, timeSlot int64, proposal ConsensusProposal) error {
+	val, err := json.Marshal(proposal)
+	if err != nil {
+		return NewRawdbError(StoreConsensusJournalError, err)
+	}
+	if err := db.Put(GetConsensusProposalKey(chainKey, timeSlot), val); err != nil {
+		return NewRawdbError(StoreConsensusJournalError, err)
+	}
+	return nil
+}
+
+// GetConsensusProposals - get all stored proposals of a chain by time slot
+func GetConsensusProposals(db incdb.Database, chainKey string) (map[int64]ConsensusProposal, error) {
+	prefix := GetConsensusProposalPrefix(chainKey)
+	iterator := db.NewIteratorWithPrefix(prefix)
+	defer iterator.Release()
+	result := make(map[int64]ConsensusProposal)
+	for iterator.Next() {
+		key := iterator.Key()
+		if len(key) != len(prefix)+8 {
+			continue
+		}
+		proposal := ConsensusProposal{}
+		if err := json.Unmarshal(iterator.Value(), &proposal); err != nil {
+			return nil, NewRawdbError(GetConsensusJournalError, err)
+		}
+		result[int64(binary.BigEndian.Uint64(key[len(prefix):]))] = proposal
+	}
+	if err := iterator.Error(); err != nil {
+		return nil, NewRawdbError(GetConsensusJournalError, err)
+	}
+	return result, nil
+}
+
+func DeleteConsensusProposal(db incdb.KeyValueWriter, chainKey string, timeSlot int64) error {
+	if err := db.Delete(GetConsensusProposalKey(chainKey, timeSlot)); err != nil {
+		return NewRawdbError(DeleteConsensusJournalError, err)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package rawdbv2_test
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+)
+
+func TestConsensusJournal(t *testing.T) {
+	resetDatabaseTx()
+	hashes := generateTxHash(3)
+	vote := rawdbv2.ConsensusVote{BlockHash: hashes[0], ProposeTimeSlot: 10, ProduceTimeSlot: 10}
+	if err := rawdbv2.StoreConsensusVote(dbTx, "shard-1", 300, vote); err != nil {
+		t.Fatal(err)
+	}
+	// a later vote at the same height replaces the previous one
+	vote = rawdbv2.ConsensusVote{BlockHash: hashes[1], ProposeTimeSlot: 11, ProduceTimeSlot: 10}
+	if err := rawdbv2.StoreConsensusVote(dbTx, "shard-1", 300, vote); err != nil {
+		t.Fatal(err)
+	}
+	if err := rawdbv2.StoreConsensusVote(dbTx, "shard-10", 301, vote); err != nil {
+		t.Fatal(err)
+	}
+	if err := rawdbv2.StoreConsensusProposal(dbTx, "shard-1", 12, rawdbv2.ConsensusProposal{BlockHash: hashes[2], Height: 301}); err != nil {
+		t.Fatal(err)
+	}
+	votes, err := rawdbv2.GetConsensusVotes(dbTx, "shard-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(votes) != 1 || votes[300] != vote {
+		t.Fatalf("want vote %+v at height 300 but got %+v", vote, votes)
+	}
+	proposals, err := rawdbv2.GetConsensusProposals(dbTx, "shard-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(proposals) != 1 || proposals[12].BlockHash != hashes[2] || proposals[12].Height != 301 {
+		t.Fatalf("want proposal of block %+v in time slot 12 but got %+v", hashes[2], proposals)
+	}
+	if err := rawdbv2.DeleteConsensusVote(dbTx, "shard-1", 300); err != nil {
+		t.Fatal(err)
+	}
+	if err := rawdbv2.DeleteConsensusProposal(dbTx, "shard-1", 12); err != nil {
+		t.Fatal(err)
+	}
+	votes, _ = rawdbv2.GetConsensusVotes(dbTx, "shard-1")
+	proposals, _ = rawdbv2.GetConsensusProposals(dbTx, "shard-1")
+	if len(votes) != 0 || len(proposals) != 0 {
+		t.Fatalf("want empty journal but got votes %+v proposals %+v", votes, proposals)
+	}
+	votes, _ = rawdbv2.GetConsensusVotes(dbTx, "shard-10")
+	if len(votes) != 1 {
+		t.Fatalf("want 1 vote of shard-10 but got %+v", votes)
+	}
+}
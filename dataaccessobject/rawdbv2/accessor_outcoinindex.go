@@ -0,0 +1,95 @@
+package rawdbv2
+
+import (
+	"encoding/json"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incdb"
+)
+
+// ViewingKeyIndex is a viewing key registered to the output coin index of its shard,
+// output coins of all blocks stored from FromHeight to IndexedHeight are indexed
+type ViewingKeyIndex struct {
+	PublicKey     []byte
+	ReceivingKey  []byte
+	FromHeight    uint64
+	IndexedHeight uint64
+}
+
+// StoreViewingKeyIndex - store a registered viewing key and its indexing progress
+func StoreViewingKeyIndex(db incdb.KeyValueWriter, index ViewingKeyIndex) error {
+	val, err := json.Marshal(index)
+	if err != nil {
+		return NewRawdbError(StoreOutputCoinIndexError, err)
+	}
+	if err := db.Put(GetViewingKeyIndexKey(index.PublicKey), val); err != nil {
+		return NewRawdbError(StoreOutputCoinIndexError, err)
+	}
+	return nil
+}
+
+// GetViewingKeyIndexes - get all registered viewing keys
+func GetViewingKeyIndexes(db incdb.Database) ([]ViewingKeyIndex, error) {
+	iterator := db.NewIteratorWithPrefix(GetViewingKeyIndexPrefix())
+	defer iterator.Release()
+	result := []ViewingKeyIndex{}
+	for iterator.Next() {
+		index := ViewingKeyIndex{}
+		if err := json.Unmarshal(iterator.Value(), &index); err != nil {
+			return nil, NewRawdbError(GetOutputCoinIndexError, err)
+		}
+		result = append(result, index)
+	}
+	if err := iterator.Error(); err != nil {
+		return nil, NewRawdbError(GetOutputCoinIndexError, err)
+	}
+	return result, nil
+}
+
+// DeleteViewingKeyIndex - delete a registered viewing key along with all its indexed output coins
+func DeleteViewingKeyIndex(db incdb.Database, publicKey []byte) error {
+	batch := db.NewBatch()
+	if err := batch.Delete(GetViewingKeyIndexKey(publicKey)); err != nil {
+		return NewRawdbError(DeleteOutputCoinIndexError, err)
+	}
+	iterator := db.NewIteratorWithPrefix(GetIndexedOutputCoinsOfKeyPrefix(publicKey))
+	defer iterator.Release()
+	for iterator.Next() {
+		key := make([]byte, len(iterator.Key()))
+		copy(key, iterator.Key())
+		if err := batch.Delete(key); err != nil {
+			return NewRawdbError(DeleteOutputCoinIndexError, err)
+		}
+	}
+	if err := iterator.Error(); err != nil {
+		return NewRawdbError(DeleteOutputCoinIndexError, err)
+	}
+	if err := batch.Write(); err != nil {
+		return NewRawdbError(DeleteOutputCoinIndexError, err)
+	}
+	return nil
+}
+
+// StoreIndexedOutputCoin - store a decrypted output coin of a registered viewing key, keyed by its commitment
+func StoreIndexedOutputCoin(db incdb.KeyValueWriter, publicKey []byte, tokenID common.Hash, commitment []byte, outputCoin []byte) error {
+	if err := db.Put(GetIndexedOutputCoinKey(publicKey, tokenID, commitment), outputCoin); err != nil {
+		return NewRawdbError(StoreOutputCoinIndexError, err)
+	}
+	return nil
+}
+
+// GetIndexedOutputCoins - get all indexed output coins of a registered viewing key in a token
+func GetIndexedOutputCoins(db incdb.Database, publicKey []byte, tokenID common.Hash) ([][]byte, error) {
+	iterator := db.NewIteratorWithPrefix(GetIndexedOutputCoinPrefix(publicKey, tokenID))
+	defer iterator.Release()
+	result := [][]byte{}
+	for iterator.Next() {
+		outputCoin := make([]byte, len(iterator.Value()))
+		copy(outputCoin, iterator.Value())
+		result = append(result, outputCoin)
+	}
+	if err := iterator.Error(); err != nil {
+		return nil, NewRawdbError(GetOutputCoinIndexError, err)
+	}
+	return result, nil
+}
@@ -0,0 +1,87 @@
+package rawdbv2_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+)
+
+func TestOutputCoinIndex(t *testing.T) {
+	resetDatabaseTx()
+	publicKey1 := bytes.Repeat([]byte{1}, 32)
+	publicKey2 := bytes.Repeat([]byte{2}, 32)
+	tokenID := common.HashH([]byte("token"))
+	for _, publicKey := range [][]byte{publicKey1, publicKey2} {
+		index := rawdbv2.ViewingKeyIndex{PublicKey: publicKey, ReceivingKey: publicKey, FromHeight: 1, IndexedHeight: 10}
+		if err := rawdbv2.StoreViewingKeyIndex(dbTx, index); err != nil {
+			t.Fatal(err)
+		}
+		for i := byte(0); i < 3; i++ {
+			commitment := append([]byte{i}, publicKey...)
+			if err := rawdbv2.StoreIndexedOutputCoin(dbTx, publicKey, common.PRVCoinID, commitment, commitment); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := rawdbv2.StoreIndexedOutputCoin(dbTx, publicKey, tokenID, publicKey, publicKey); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// storing a coin twice keeps one coin
+	if err := rawdbv2.StoreIndexedOutputCoin(dbTx, publicKey1, tokenID, publicKey1, publicKey1); err != nil {
+		t.Fatal(err)
+	}
+
+	indexes, err := rawdbv2.GetViewingKeyIndexes(dbTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(indexes) != 2 || indexes[0].IndexedHeight != 10 {
+		t.Fatalf("want 2 viewing keys indexed up to height 10 but got %+v", indexes)
+	}
+	coins, err := rawdbv2.GetIndexedOutputCoins(dbTx, publicKey1, common.PRVCoinID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(coins) != 3 {
+		t.Fatalf("want 3 prv coins but got %+v", len(coins))
+	}
+	for _, coin := range coins {
+		if !bytes.Equal(coin[1:], publicKey1) {
+			t.Fatalf("unexpected coin %+v", coin)
+		}
+	}
+	coins, err = rawdbv2.GetIndexedOutputCoins(dbTx, publicKey1, tokenID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(coins) != 1 {
+		t.Fatalf("want 1 token coin but got %+v", len(coins))
+	}
+
+	if err := rawdbv2.DeleteViewingKeyIndex(dbTx, publicKey1); err != nil {
+		t.Fatal(err)
+	}
+	indexes, err = rawdbv2.GetViewingKeyIndexes(dbTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(indexes) != 1 || !bytes.Equal(indexes[0].PublicKey, publicKey2) {
+		t.Fatalf("want only second viewing key but got %+v", indexes)
+	}
+	coins, err = rawdbv2.GetIndexedOutputCoins(dbTx, publicKey1, common.PRVCoinID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(coins) != 0 {
+		t.Fatalf("want no coin of deleted key but got %+v", len(coins))
+	}
+	coins, err = rawdbv2.GetIndexedOutputCoins(dbTx, publicKey2, common.PRVCoinID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(coins) != 3 {
+		t.Fatalf("want 3 prv coins of second key but got %+v", len(coins))
+	}
+}
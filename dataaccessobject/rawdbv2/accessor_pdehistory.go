@@ -0,0 +1,83 @@
+package rawdbv2
+
+import (
+	"encoding/binary"
+	"encoding/json"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incdb"
+)
+
+const (
+	PDEHistoryTradeEvent        = "trade"
+	PDEHistoryContributionEvent = "contribution"
+	PDEHistoryWithdrawalEvent   = "withdrawal"
+)
+
+// PDEHistoryEvent is a pde instruction processed by beacon, along with the reserves of its pool pair
+// Token1IDStr and Token2IDStr are ordered as in the pool pair, they are empty if the pair is unknown (e.g waiting contribution)
+type PDEHistoryEvent struct {
+	Type                  string
+	Status                string // chain status of the instruction
+	BeaconHeight          uint64
+	TxReqID               common.Hash
+	AddressStr            string
+	Token1IDStr           string
+	Token2IDStr           string
+	TokenIDStr            string // sold, contributed or withdrawn token
+	Amount                uint64 // sold, contributed or withdrawn amount
+	ReceiveTokenIDStr     string // bought or returned token
+	ReceiveAmount         uint64 // bought or returned amount
+	Shares                uint64 // withdrawn shares
+	Token1PoolValueBefore uint64
+	Token2PoolValueBefore uint64
+	Token1PoolValueAfter  uint64
+	Token2PoolValueAfter  uint64
+}
+
+// StorePDEHistory - store pde history events of a beacon block
+func StorePDEHistory(db incdb.KeyValueWriter, height uint64, hash common.Hash, events []PDEHistoryEvent) error {
+	val, err := json.Marshal(events)
+	if err != nil {
+		return NewRawdbError(StorePDEHistoryError, err)
+	}
+	if err := db.Put(GetPDEHistoryKey(height, hash), val); err != nil {
+		return NewRawdbError(StorePDEHistoryError, err)
+	}
+	return nil
+}
+
+// DeletePDEHistory - delete pde history events of a beacon block, e.g block of a discarded fork
+func DeletePDEHistory(db incdb.KeyValueWriter, height uint64, hash common.Hash) error {
+	if err := db.Delete(GetPDEHistoryKey(height, hash)); err != nil {
+		return NewRawdbError(DeletePDEHistoryError, err)
+	}
+	return nil
+}
+
+// GetPDEHistory - get pde history events of all stored beacon blocks from fromHeight to toHeight (inclusive), in order of height
+func GetPDEHistory(db incdb.Database, fromHeight uint64, toHeight uint64) ([]PDEHistoryEvent, error) {
+	prefix := GetPDEHistoryPrefix()
+	iterator := db.NewIteratorWithStart(GetPDEHistoryKey(fromHeight, common.Hash{}))
+	defer iterator.Release()
+	result := []PDEHistoryEvent{}
+	for iterator.Next() {
+		key := iterator.Key()
+		if len(key) != len(prefix)+8+common.HashSize || string(key[:len(prefix)]) != string(prefix) {
+			break
+		}
+		height := binary.BigEndian.Uint64(key[len(prefix) : len(prefix)+8])
+		if height > toHeight {
+			break
+		}
+		events := []PDEHistoryEvent{}
+		if err := json.Unmarshal(iterator.Value(), &events); err != nil {
+			return nil, NewRawdbError(GetPDEHistoryError, err)
+		}
+		result = append(result, events...)
+	}
+	if err := iterator.Error(); err != nil {
+		return nil, NewRawdbError(GetPDEHistoryError, err)
+	}
+	return result, nil
+}
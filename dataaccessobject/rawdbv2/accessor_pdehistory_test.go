@@ -0,0 +1,50 @@
+package rawdbv2_test
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+)
+
+func TestGetPDEHistory(t *testing.T) {
+	resetDatabaseTx()
+	hashes := generateTxHash(300)
+	// heights cross the byte boundary to check that events are iterated in order of height
+	for i, hash := range hashes {
+		height := uint64(i + 1)
+		events := []rawdbv2.PDEHistoryEvent{{Type: rawdbv2.PDEHistoryTradeEvent, BeaconHeight: height, TxReqID: hash}}
+		if err := rawdbv2.StorePDEHistory(dbTx, height, hash, events); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := rawdbv2.DeletePDEHistory(dbTx, 260, hashes[259]); err != nil {
+		t.Fatal(err)
+	}
+	got, err := rawdbv2.GetPDEHistory(dbTx, 250, 270)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 20 {
+		t.Fatalf("want 20 events but got %+v", len(got))
+	}
+	prevHeight := uint64(0)
+	for _, event := range got {
+		if event.BeaconHeight < 250 || event.BeaconHeight > 270 || event.BeaconHeight == 260 {
+			t.Fatalf("unexpected event at height %+v", event.BeaconHeight)
+		}
+		if event.BeaconHeight <= prevHeight {
+			t.Fatalf("want events in order of height but got %+v after %+v", event.BeaconHeight, prevHeight)
+		}
+		if event.TxReqID != hashes[event.BeaconHeight-1] {
+			t.Fatalf("want tx %+v but got %+v", hashes[event.BeaconHeight-1], event.TxReqID)
+		}
+		prevHeight = event.BeaconHeight
+	}
+	got, err = rawdbv2.GetPDEHistory(dbTx, 301, 400)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("want no event but got %+v", len(got))
+	}
+}
@@ -83,6 +83,21 @@ const (
 	StoreRelayingBNBHeaderError
 	GetRelayingBNBHeaderError
 	GetBNBDataHashError
+
+	// pde history
+	StorePDEHistoryError
+	GetPDEHistoryError
+	DeletePDEHistoryError
+
+	// Consensus journal
+	StoreConsensusJournalError
+	GetConsensusJournalError
+	DeleteConsensusJournalError
+
+	// output coin index
+	StoreOutputCoinIndexError
+	GetOutputCoinIndexError
+	DeleteOutputCoinIndexError
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -164,6 +179,21 @@ var ErrCodeMessage = map[int]struct {
 	StoreRelayingBNBHeaderError: {-5001, "Store relaying header bnb error"},
 	GetRelayingBNBHeaderError:   {-5002, "Get relaying header bnb error"},
 	GetBNBDataHashError:         {-5003, "Get bnb data hash by block height error"},
+
+	// pde history
+	StorePDEHistoryError:  {-6001, "Store pde history error"},
+	GetPDEHistoryError:    {-6002, "Get pde history error"},
+	DeletePDEHistoryError: {-6003, "Delete pde history error"},
+
+	// consensus journal
+	StoreConsensusJournalError:  {-7001, "Store consensus journal error"},
+	GetConsensusJournalError:    {-7002, "Get consensus journal error"},
+	DeleteConsensusJournalError: {-7003, "Delete consensus journal error"},
+
+	// output coin index
+	StoreOutputCoinIndexError:  {-8001, "Store output coin index error"},
+	GetOutputCoinIndexError:    {-8002, "Get output coin index error"},
+	DeleteOutputCoinIndexError: {-8003, "Delete output coin index error"},
 }
 
 type RawdbError struct {
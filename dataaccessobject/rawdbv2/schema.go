@@ -1,6 +1,8 @@
 package rawdbv2
 
 import (
+	"encoding/binary"
+
 	"github.com/incognitochain/incognito-chain/common"
 )
 
@@ -33,6 +35,11 @@ var (
 	shardSlashRootHashPrefix           = []byte("s-sl" + string(splitter))
 	shardFeatureRootHashPrefix         = []byte("s-fe" + string(splitter))
 	previousBestStatePrefix            = []byte("previous-best-state" + string(splitter))
+	pdeHistoryPrefix                   = []byte("pde-h" + string(splitter))
+	consensusVotePrefix                = []byte("c-v" + string(splitter))
+	consensusProposalPrefix            = []byte("c-p" + string(splitter))
+	viewingKeyIndexPrefix              = []byte("vk-i" + string(splitter))
+	indexedOutputCoinPrefix            = []byte("vk-c" + string(splitter))
 	splitter                           = []byte("-[-]-")
 )
 
@@ -173,6 +180,76 @@ func GetStoreTxByPublicPrefix(publicKey []byte) []byte {
 	return append(temp, publicKey...)
 }
 
+// ============================= PDE History =======================================
+// height is big endian encoded so that keys are iterated in order of beacon height
+func GetPDEHistoryKey(height uint64, hash common.Hash) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, height)
+	temp := make([]byte, 0, len(pdeHistoryPrefix))
+	temp = append(temp, pdeHistoryPrefix...)
+	key := append(temp, buf...)
+	return append(key, hash[:]...)
+}
+
+func GetPDEHistoryPrefix() []byte {
+	temp := make([]byte, 0, len(pdeHistoryPrefix))
+	temp = append(temp, pdeHistoryPrefix...)
+	return temp
+}
+
+// ============================= Consensus Journal =======================================
+func GetConsensusVotePrefix(chainKey string) []byte {
+	temp := make([]byte, 0, len(consensusVotePrefix))
+	temp = append(temp, consensusVotePrefix...)
+	temp = append(temp, []byte(chainKey)...)
+	return append(temp, splitter...)
+}
+
+func GetConsensusVoteKey(chainKey string, height uint64) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, height)
+	return append(GetConsensusVotePrefix(chainKey), buf...)
+}
+
+func GetConsensusProposalPrefix(chainKey string) []byte {
+	temp := make([]byte, 0, len(consensusProposalPrefix))
+	temp = append(temp, consensusProposalPrefix...)
+	temp = append(temp, []byte(chainKey)...)
+	return append(temp, splitter...)
+}
+
+func GetConsensusProposalKey(chainKey string, timeSlot int64) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, uint64(timeSlot))
+	return append(GetConsensusProposalPrefix(chainKey), buf...)
+}
+
+// ============================= Output Coin Index =======================================
+func GetViewingKeyIndexPrefix() []byte {
+	temp := make([]byte, 0, len(viewingKeyIndexPrefix))
+	temp = append(temp, viewingKeyIndexPrefix...)
+	return temp
+}
+
+func GetViewingKeyIndexKey(publicKey []byte) []byte {
+	return append(GetViewingKeyIndexPrefix(), publicKey...)
+}
+
+func GetIndexedOutputCoinsOfKeyPrefix(publicKey []byte) []byte {
+	temp := make([]byte, 0, len(indexedOutputCoinPrefix))
+	temp = append(temp, indexedOutputCoinPrefix...)
+	temp = append(temp, publicKey...)
+	return append(temp, splitter...)
+}
+
+func GetIndexedOutputCoinPrefix(publicKey []byte, tokenID common.Hash) []byte {
+	return append(GetIndexedOutputCoinsOfKeyPrefix(publicKey), tokenID[:]...)
+}
+
+func GetIndexedOutputCoinKey(publicKey []byte, tokenID common.Hash, commitment []byte) []byte {
+	return append(GetIndexedOutputCoinPrefix(publicKey, tokenID), commitment...)
+}
+
 // ============================= Cross Shard =======================================
 func GetCrossShardNextHeightKey(fromShard byte, toShard byte, height uint64) []byte {
 	buf := common.Uint64ToBytes(height)
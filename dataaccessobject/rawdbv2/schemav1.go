@@ -16,6 +16,7 @@ var (
 	PDEContributionStatusPrefix  = []byte("pdecontributionstatus-")
 	PDETradeStatusPrefix         = []byte("pdetradestatus-")
 	PDEWithdrawalStatusPrefix    = []byte("pdewithdrawalstatus-")
+	PDELimitOrderStatusPrefix    = []byte("pdelimitorderstatus-")
 )
 
 // TODO - change json to CamelCase
@@ -53,6 +54,23 @@ func NewPDEPoolForPair(token1IDStr string, token1PoolValue uint64, token2IDStr s
 	return &PDEPoolForPair{Token1IDStr: token1IDStr, Token1PoolValue: token1PoolValue, Token2IDStr: token2IDStr, Token2PoolValue: token2PoolValue}
 }
 
+// PDELimitOrder - an open limit order of the pde order book, it is identified by the id of the request tx
+type PDELimitOrder struct {
+	OrderID             common.Hash
+	TraderAddressStr    string
+	TokenIDToBuyStr     string
+	TokenIDToSellStr    string
+	SellAmount          uint64
+	MinAcceptableAmount uint64
+	TradingFee          uint64
+	ShardID             byte
+	ExpiryHeight        uint64 // the order is expired at the beacon block of this height if it has not been filled
+}
+
+func NewPDELimitOrder(orderID common.Hash, traderAddressStr string, tokenIDToBuyStr string, tokenIDToSellStr string, sellAmount uint64, minAcceptableAmount uint64, tradingFee uint64, shardID byte, expiryHeight uint64) *PDELimitOrder {
+	return &PDELimitOrder{OrderID: orderID, TraderAddressStr: traderAddressStr, TokenIDToBuyStr: tokenIDToBuyStr, TokenIDToSellStr: tokenIDToSellStr, SellAmount: sellAmount, MinAcceptableAmount: minAcceptableAmount, TradingFee: tradingFee, ShardID: shardID, ExpiryHeight: expiryHeight}
+}
+
 func BuildPDESharesKey(
 	beaconHeight uint64,
 	token1IDStr string,
@@ -65,7 +65,8 @@ func GetPDEPoolPair(stateDB *StateDB, beaconHeight uint64) (map[string]*rawdbv2.
 	return pdePoolPairs, nil
 }
 
-func StorePDEShares(stateDB *StateDB, beaconHeight uint64, pdeShares map[string]uint64) error {
+// StorePDEShares stores shares of contributors along with the trading fees they have earned, both maps are keyed by pde share key
+func StorePDEShares(stateDB *StateDB, beaconHeight uint64, pdeShares map[string]uint64, pdeTradingFees map[string]map[string]uint64) error {
 	for tempKey, shareAmount := range pdeShares {
 		strs := strings.Split(tempKey, "-")
 		token1ID := strs[2]
@@ -73,6 +74,9 @@ func StorePDEShares(stateDB *StateDB, beaconHeight uint64, pdeShares map[string]
 		contributorAddress := strs[4]
 		key := GeneratePDEShareObjectKey(token1ID, token2ID, contributorAddress)
 		value := NewPDEShareStateWithValue(token1ID, token2ID, contributorAddress, shareAmount)
+		if tradingFees, ok := pdeTradingFees[tempKey]; ok && len(tradingFees) > 0 {
+			value.SetTradingFees(tradingFees)
+		}
 		err := stateDB.SetStateObject(PDEShareObjectType, key, value)
 		if err != nil {
 			return NewStatedbError(StorePDEShareError, err)
@@ -92,6 +96,50 @@ func GetPDEShares(stateDB *StateDB, beaconHeight uint64) (map[string]uint64, err
 	return pdeShares, nil
 }
 
+// GetPDETradingFees returns trading fees earned by contributors, keyed by pde share key then by token id
+func GetPDETradingFees(stateDB *StateDB, beaconHeight uint64) (map[string]map[string]uint64, error) {
+	pdeTradingFees := make(map[string]map[string]uint64)
+	pdeShareStates := stateDB.getAllPDEShareState()
+	for _, sState := range pdeShareStates {
+		if len(sState.TradingFees()) == 0 {
+			continue
+		}
+		key := string(GetPDEShareKey(beaconHeight, sState.Token1ID(), sState.Token2ID(), sState.ContributorAddress()))
+		pdeTradingFees[key] = sState.TradingFees()
+	}
+	return pdeTradingFees, nil
+}
+
+// StorePDELimitOrders stores open limit orders of the order book, keyed by order id
+func StorePDELimitOrders(stateDB *StateDB, pdeLimitOrders map[string]*rawdbv2.PDELimitOrder) error {
+	for _, order := range pdeLimitOrders {
+		key := GeneratePDELimitOrderObjectKey(order.OrderID)
+		value := NewPDELimitOrderStateWithValue(order.OrderID, order.TraderAddressStr, order.TokenIDToBuyStr, order.TokenIDToSellStr, order.SellAmount, order.MinAcceptableAmount, order.TradingFee, order.ShardID, order.ExpiryHeight)
+		err := stateDB.SetStateObject(PDELimitOrderObjectType, key, value)
+		if err != nil {
+			return NewStatedbError(StorePDELimitOrderError, err)
+		}
+	}
+	return nil
+}
+
+func GetPDELimitOrders(stateDB *StateDB) (map[string]*rawdbv2.PDELimitOrder, error) {
+	pdeLimitOrders := make(map[string]*rawdbv2.PDELimitOrder)
+	pdeLimitOrderStates := stateDB.getAllPDELimitOrderState()
+	for _, loState := range pdeLimitOrderStates {
+		value := rawdbv2.NewPDELimitOrder(loState.OrderID(), loState.TraderAddress(), loState.TokenIDToBuy(), loState.TokenIDToSell(), loState.SellAmount(), loState.MinAcceptableAmount(), loState.TradingFee(), loState.ShardID(), loState.ExpiryHeight())
+		pdeLimitOrders[loState.OrderID().String()] = value
+	}
+	return pdeLimitOrders, nil
+}
+
+func DeletePDELimitOrders(stateDB *StateDB, deletedPDELimitOrders map[string]*rawdbv2.PDELimitOrder) {
+	for _, order := range deletedPDELimitOrders {
+		key := GeneratePDELimitOrderObjectKey(order.OrderID)
+		stateDB.MarkDeleteStateObject(PDELimitOrderObjectType, key)
+	}
+}
+
 func GetPDEPoolForPair(stateDB *StateDB, beaconHeight uint64, tokenIDToBuy string, tokenIDToSell string) ([]byte, error) {
 	tokenIDs := []string{tokenIDToBuy, tokenIDToSell}
 	sort.Strings(tokenIDs)
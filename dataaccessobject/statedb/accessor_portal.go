@@ -186,6 +186,23 @@ func DeleteCustodianState(stateDB *StateDB, deletedCustodianStates map[string]*C
 	}
 }
 
+//======================  Exchange rates feeders  ======================
+// GetExchangeRatesFeeders returns the submission history of exchange rates feeders by feeder address
+func GetExchangeRatesFeeders(stateDB *StateDB) map[string]*ExchangeRatesFeederState {
+	return stateDB.getAllExchangeRatesFeederState()
+}
+
+func StoreExchangeRatesFeeders(stateDB *StateDB, feeders map[string]*ExchangeRatesFeederState) error {
+	for _, feeder := range feeders {
+		key := GenerateExchangeRatesFeederObjectKey(feeder.feederAddress)
+		err := stateDB.SetStateObject(PortalExchangeRatesFeederObjectType, key, feeder)
+		if err != nil {
+			return NewStatedbError(StoreExchangeRatesFeederStateError, err)
+		}
+	}
+	return nil
+}
+
 func StoreCustodianDepositStatus(stateDB *StateDB, txID string, statusContent []byte) error {
 	statusType := PortalCustodianDepositStatusPrefix()
 	statusSuffix := []byte(txID)
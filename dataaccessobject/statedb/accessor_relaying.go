@@ -0,0 +1,79 @@
+package statedb
+
+import (
+	"bytes"
+	"math/big"
+)
+
+// StoreRelayingETHHeader stores a relayed ethereum header keyed by its block hash
+func StoreRelayingETHHeader(stateDB *StateDB, blockHash []byte, blockNumber uint64, header []byte, totalDifficulty *big.Int) error {
+	key := GenerateRelayingETHHeaderObjectKey(blockHash)
+	value := NewRelayingETHHeaderStateWithValue(blockHash, blockNumber, header, totalDifficulty)
+	err := stateDB.SetStateObject(RelayingETHHeaderObjectType, key, value)
+	if err != nil {
+		return NewStatedbError(StoreRelayingETHHeaderError, err)
+	}
+	return nil
+}
+
+// GetRelayingETHHeader returns the relayed ethereum header with the block hash, has is false if it was not relayed
+func GetRelayingETHHeader(stateDB *StateDB, blockHash []byte) (*RelayingETHHeaderState, bool, error) {
+	key := GenerateRelayingETHHeaderObjectKey(blockHash)
+	headerState, has, err := stateDB.getRelayingETHHeaderState(key)
+	if err != nil {
+		return nil, false, NewStatedbError(GetRelayingETHHeaderError, err)
+	}
+	if !has {
+		return nil, false, nil
+	}
+	if !bytes.Equal(headerState.BlockHash(), blockHash) {
+		panic("same key wrong value")
+	}
+	return headerState, true, nil
+}
+
+// StoreRelayingETHCanonicalHash indexes the block hash of the relayed canonical chain at the block number
+func StoreRelayingETHCanonicalHash(stateDB *StateDB, blockNumber uint64, blockHash []byte) error {
+	key := GenerateRelayingETHHeaderIndexObjectKey(blockNumber)
+	value := NewRelayingETHHeaderIndexStateWithValue(blockNumber, blockHash)
+	err := stateDB.SetStateObject(RelayingETHHeaderIndexObjectType, key, value)
+	if err != nil {
+		return NewStatedbError(StoreRelayingETHHeaderError, err)
+	}
+	return nil
+}
+
+func GetRelayingETHCanonicalHash(stateDB *StateDB, blockNumber uint64) ([]byte, bool, error) {
+	key := GenerateRelayingETHHeaderIndexObjectKey(blockNumber)
+	indexState, has, err := stateDB.getRelayingETHHeaderIndexState(key)
+	if err != nil {
+		return nil, false, NewStatedbError(GetRelayingETHHeaderError, err)
+	}
+	if !has {
+		return nil, false, nil
+	}
+	return indexState.BlockHash(), true, nil
+}
+
+// StoreRelayingETHBestHeader points the head of the relayed canonical chain to the block hash
+func StoreRelayingETHBestHeader(stateDB *StateDB, blockNumber uint64, blockHash []byte) error {
+	key := GenerateRelayingETHBestHeaderObjectKey()
+	value := NewRelayingETHHeaderIndexStateWithValue(blockNumber, blockHash)
+	err := stateDB.SetStateObject(RelayingETHHeaderIndexObjectType, key, value)
+	if err != nil {
+		return NewStatedbError(StoreRelayingETHHeaderError, err)
+	}
+	return nil
+}
+
+func GetRelayingETHBestHeader(stateDB *StateDB) (uint64, []byte, bool, error) {
+	key := GenerateRelayingETHBestHeaderObjectKey()
+	indexState, has, err := stateDB.getRelayingETHHeaderIndexState(key)
+	if err != nil {
+		return 0, nil, false, NewStatedbError(GetRelayingETHHeaderError, err)
+	}
+	if !has {
+		return 0, nil, false, nil
+	}
+	return indexState.BlockNumber(), indexState.BlockHash(), true, nil
+}
@@ -44,6 +44,14 @@ const (
 	PortalRewardInfoObjectType
 	LockedCollateralStateObjectType
 	RewardFeatureStateObjectType
+	PDELimitOrderObjectType
+
+	// relaying
+	RelayingETHHeaderObjectType
+	RelayingETHHeaderIndexObjectType
+
+	// portal exchange rates feeders
+	PortalExchangeRatesFeederObjectType
 )
 
 // Prefix length
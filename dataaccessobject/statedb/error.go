@@ -25,6 +25,10 @@ const (
 	ErrInvalidPDEPoolPairStateType            = "invalid pde pool pair state type"
 	ErrInvalidPDEShareStateType               = "invalid pde shard state type"
 	ErrInvalidPDEStatusStateType              = "invalid pde status state type"
+	ErrInvalidPDELimitOrderStateType          = "invalid pde limit order state type"
+	ErrInvalidRelayingETHHeaderStateType      = "invalid relaying eth header state type"
+	ErrInvalidRelayingETHHeaderIndexStateType = "invalid relaying eth header index state type"
+	ErrInvalidExchangeRatesFeederStateType    = "invalid exchange rates feeder state type"
 	ErrInvalidBridgeEthTxStateType            = "invalid bridge eth tx state type"
 	ErrInvalidBridgeTokenInfoStateType        = "invalid bridge token info state type"
 	ErrInvalidBridgeStatusStateType           = "invalid bridge status state type"
@@ -110,6 +114,7 @@ const (
 	GetPDEPoolForPairError
 	TrackPDEStatusError
 	GetPDEStatusError
+	StorePDELimitOrderError
 	// bridge error
 	BridgeInsertETHTxHashIssuedError
 	IsETHTxHashIssuedError
@@ -168,6 +173,7 @@ const (
 	GetPortalReqMatchingRedeemByTxIDStatusError
 	GetPortalTopupWaitingPortingStatusError
 	GetPortalRedeemRequestFromLiquidationByTxIDStatusError
+	StoreExchangeRatesFeederStateError
 
 	//porting request
 	GetPortingRequestTxStatusError
@@ -200,6 +206,14 @@ const (
 	GetAllRewardFeatureError
 	ResetAllFeatureRewardByTokenIDError
 	GetRewardFeatureAmountByTokenIDError
+
+	// relaying
+	StoreRelayingETHHeaderError
+	GetRelayingETHHeaderError
+
+	// state proof
+	GetStateObjectProofError
+	VerifyStateObjectProofError
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -263,6 +277,7 @@ var ErrCodeMessage = map[int]struct {
 	GetPDEPoolForPairError:           {-4003, "Get PDEX Pool Pair Error"},
 	TrackPDEStatusError:              {-4004, "Track PDEX Status Error"},
 	GetPDEStatusError:                {-4005, "Get PDEX Status Error"},
+	StorePDELimitOrderError:          {-4006, "Store PDEX Limit Order Error"},
 	// -5xxx: bridge error
 	BridgeInsertETHTxHashIssuedError: {-5000, "Bridge Insert ETH Tx Hash Issued Error"},
 	IsETHTxHashIssuedError:           {-5001, "Is ETH Tx Hash Issued Error"},
@@ -321,11 +336,18 @@ var ErrCodeMessage = map[int]struct {
 	GetPortalReqMatchingRedeemByTxIDStatusError:            {-14041, "Get req matching redeem request error"},
 	GetPortalTopupWaitingPortingStatusError:                {-14042, "Get custodian top up for waiting porting error"},
 	GetPortalRedeemRequestFromLiquidationByTxIDStatusError: {-14043, "Get portal redeem req from liquidation pool status error"},
+	StoreExchangeRatesFeederStateError:                     {-14044, "Store exchange rates feeder state error"},
 
 	StoreRewardFeatureError:              {-15000, "Store reward feature state error"},
 	GetRewardFeatureError:                {-15001, "Get reward feature state error"},
 	GetAllRewardFeatureError:             {-15002, "Get all reward feature state error"},
 	GetRewardFeatureAmountByTokenIDError: {-15003, "Get reward feature amount by tokenID error"},
+
+	StoreRelayingETHHeaderError: {-15100, "Store relaying eth header error"},
+	GetRelayingETHHeaderError:   {-15101, "Get relaying eth header error"},
+
+	GetStateObjectProofError:    {-16000, "Get state object proof error"},
+	VerifyStateObjectProofError: {-16001, "Verify state object proof error"},
 }
 
 type StatedbError struct {
@@ -0,0 +1,52 @@
+package statedb
+
+import (
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incdb/memorydb"
+	"github.com/incognitochain/incognito-chain/trie"
+)
+
+// GetStateObjectProof returns the value stored for a state object key in the
+// state trie of root, along with the encoded trie nodes on the path to that key.
+// If the key does not exist, value is nil and the nodes prove its absence.
+func GetStateObjectProof(db DatabaseAccessWarper, root common.Hash, key common.Hash) ([]byte, [][]byte, error) {
+	tr, err := db.OpenPrefixTrie(root)
+	if err != nil {
+		return nil, nil, NewStatedbError(GetStateObjectProofError, err)
+	}
+	value, err := tr.TryGet(key[:])
+	if err != nil {
+		return nil, nil, NewStatedbError(GetStateObjectProofError, err)
+	}
+	proofDb := memorydb.New()
+	if err := tr.Prove(key[:], 0, proofDb); err != nil {
+		return nil, nil, NewStatedbError(GetStateObjectProofError, err)
+	}
+	proof := [][]byte{}
+	it := proofDb.NewIterator()
+	for it.Next() {
+		proof = append(proof, common.CopyBytes(it.Value()))
+	}
+	it.Release()
+	return value, proof, nil
+}
+
+// VerifyStateObjectProof checks proof against the state root and returns the
+// value it proves for key, or nil if it proves that key does not exist.
+func VerifyStateObjectProof(root common.Hash, key common.Hash, proof [][]byte) ([]byte, error) {
+	if root == common.EmptyRoot {
+		return nil, nil
+	}
+	proofDb := memorydb.New()
+	for _, node := range proof {
+		hash := common.Keccak256Hash(node)
+		if err := proofDb.Put(hash[:], node); err != nil {
+			return nil, NewStatedbError(VerifyStateObjectProofError, err)
+		}
+	}
+	value, _, err := trie.VerifyProof(root, key[:], proofDb)
+	if err != nil {
+		return nil, NewStatedbError(VerifyStateObjectProofError, err)
+	}
+	return value, nil
+}
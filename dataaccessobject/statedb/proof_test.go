@@ -0,0 +1,86 @@
+package statedb
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incdb"
+)
+
+func TestStateDB_GetAndVerifyStateObjectProof(t *testing.T) {
+	dbPath, err := ioutil.TempDir(os.TempDir(), "test_proof_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dbPath)
+	db, err := incdb.Open("leveldb", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	warper := NewDatabaseAccessWarper(db)
+
+	keys, values := generateKeyValuePairWithPrefix(limit100, []byte("abc"))
+	stateDB, err := NewWithPrefixTrie(emptyRoot, warper)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootHash := storeTestObjects(t, stateDB, keys[:50], values[:50])
+
+	for i := 0; i < 50; i++ {
+		value, proof, err := GetStateObjectProof(warper, rootHash, keys[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(value, values[i]) {
+			t.Fatalf("want value %+v, got %+v", values[i], value)
+		}
+		got, err := VerifyStateObjectProof(rootHash, keys[i], proof)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, values[i]) {
+			t.Fatalf("want proven value %+v, got %+v", values[i], got)
+		}
+		// proof of one key must not prove a wrong root
+		if _, err := VerifyStateObjectProof(common.HashH(rootHash[:]), keys[i], proof); err == nil {
+			t.Fatal("want error verifying proof against wrong root")
+		}
+	}
+
+	// absent keys are proven with a nil value
+	for i := 50; i < 60; i++ {
+		value, proof, err := GetStateObjectProof(warper, rootHash, keys[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if value != nil {
+			t.Fatalf("want nil value for absent key, got %+v", value)
+		}
+		got, err := VerifyStateObjectProof(rootHash, keys[i], proof)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != nil {
+			t.Fatalf("want nil proven value for absent key, got %+v", got)
+		}
+	}
+
+	// tampered proof is rejected
+	_, proof, err := GetStateObjectProof(warper, rootHash, keys[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range proof {
+		tampered := make([][]byte, len(proof))
+		copy(tampered, proof)
+		tampered[i] = common.CopyBytes(proof[i])
+		tampered[i][len(tampered[i])-1] ^= 0xff
+		if got, err := VerifyStateObjectProof(rootHash, keys[0], tampered); err == nil && reflect.DeepEqual(got, values[0]) {
+			t.Fatalf("want tampered node %+v to be rejected", i)
+		}
+	}
+}
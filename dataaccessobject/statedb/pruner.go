@@ -0,0 +1,141 @@
+package statedb
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/trie"
+)
+
+// Pruner removes trie nodes which can not be reached from any retained state
+// root hash. Trie nodes are stored in the same database as other chain data,
+// with their 32 bytes hash as key, so every other key of the database is left
+// untouched.
+//
+// Pruning is done in two steps:
+// - Mark: walk every trie that must be kept and remember its nodes
+// - Sweep: delete every stored trie node which has not been marked
+type Pruner struct {
+	db        incdb.Database
+	reachable map[common.Hash]struct{}
+}
+
+func NewPruner(db incdb.Database) *Pruner {
+	return &Pruner{
+		db:        db,
+		reachable: make(map[common.Hash]struct{}),
+	}
+}
+
+// Mark walks the trie of root and marks all of its nodes as reachable.
+// Sub tries which have already been marked are not walked again, so marking
+// many roots sharing most of their nodes is cheap.
+func (pruner *Pruner) Mark(root common.Hash) error {
+	if root == (common.Hash{}) || root == common.EmptyRoot {
+		return nil
+	}
+	if _, ok := pruner.reachable[root]; ok {
+		return nil
+	}
+	t, err := trie.New(root, trie.NewIntermediateWriter(pruner.db))
+	if err != nil {
+		return err
+	}
+	it := t.NodeIterator(nil)
+	descend := true
+	for it.Next(descend) {
+		descend = true
+		hash := it.Hash()
+		// embedded nodes and leaf values has no hash
+		if hash == (common.Hash{}) {
+			continue
+		}
+		if _, ok := pruner.reachable[hash]; ok {
+			descend = false
+			continue
+		}
+		pruner.reachable[hash] = struct{}{}
+	}
+	return it.Error()
+}
+
+// Reachable return number of marked trie nodes
+func (pruner *Pruner) Reachable() int {
+	return len(pruner.reachable)
+}
+
+// Sweep deletes all stored trie nodes which have not been marked, and returns
+// the number of deleted nodes.
+//
+// The database is scanned without any lock. Candidates are then deleted in
+// batches, each batch holding lock. Before deleting a batch, refresh is called
+// so the caller can mark roots committed while scanning (e.g new blocks),
+// that way no node reused by a newer trie is deleted. Offline pruning may pass a
+// nil lock and refresh.
+func (pruner *Pruner) Sweep(lock sync.Locker, refresh func(*Pruner) error) (int, error) {
+	candidates := [][]byte{}
+	it := pruner.db.NewIterator()
+	for it.Next() {
+		key := it.Key()
+		if len(key) != common.HashSize {
+			continue
+		}
+		if _, ok := pruner.reachable[common.BytesToHash(key)]; ok {
+			continue
+		}
+		// only trie nodes are keyed by the hash of their value
+		hash := common.Keccak256Hash(it.Value())
+		if !bytes.Equal(hash[:], key) {
+			continue
+		}
+		candidates = append(candidates, common.CopyBytes(key))
+	}
+	it.Release()
+	if err := it.Error(); err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	batchSize := incdb.IdealBatchSize / common.HashSize
+	for start := 0; start < len(candidates); start += batchSize {
+		end := start + batchSize
+		if end > len(candidates) {
+			end = len(candidates)
+		}
+		n, err := pruner.sweepBatch(candidates[start:end], lock, refresh)
+		deleted += n
+		if err != nil {
+			return deleted, err
+		}
+	}
+	return deleted, nil
+}
+
+func (pruner *Pruner) sweepBatch(keys [][]byte, lock sync.Locker, refresh func(*Pruner) error) (int, error) {
+	if lock != nil {
+		lock.Lock()
+		defer lock.Unlock()
+	}
+	if refresh != nil {
+		if err := refresh(pruner); err != nil {
+			return 0, err
+		}
+	}
+	batch := pruner.db.NewBatch()
+	deleted := 0
+	for _, key := range keys {
+		if _, ok := pruner.reachable[common.BytesToHash(key)]; ok {
+			continue
+		}
+		if err := batch.Delete(key); err != nil {
+			return 0, err
+		}
+		deleted++
+	}
+	if err := batch.Write(); err != nil {
+		return 0, err
+	}
+	return deleted, nil
+}
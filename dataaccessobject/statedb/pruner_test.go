@@ -0,0 +1,106 @@
+package statedb
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/trie"
+)
+
+func storeTestObjects(t *testing.T, stateDB *StateDB, keys []common.Hash, values [][]byte) common.Hash {
+	for i := range keys {
+		if err := stateDB.SetStateObject(TestObjectType, keys[i], values[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rootHash, err := stateDB.Commit(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stateDB.Database().TrieDB().Commit(rootHash, false); err != nil {
+		t.Fatal(err)
+	}
+	return rootHash
+}
+
+func TestPruner_MarkAndSweep(t *testing.T) {
+	dbPath, err := ioutil.TempDir(os.TempDir(), "test_pruner_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dbPath)
+	db, err := incdb.Open("leveldb", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	// non trie data must survive pruning
+	otherKey := common.HashH([]byte("other"))
+	if err := db.Put(otherKey[:], []byte("other")); err != nil {
+		t.Fatal(err)
+	}
+
+	keys, values := generateKeyValuePairWithPrefix(limit100, []byte("abc"))
+	stateDB, err := NewWithPrefixTrie(emptyRoot, NewDatabaseAccessWarper(db))
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldRoot := storeTestObjects(t, stateDB, keys[:50], values[:50])
+	newRoot := storeTestObjects(t, stateDB, keys[50:], values[50:])
+
+	pruner := NewPruner(db)
+	if err := pruner.Mark(newRoot); err != nil {
+		t.Fatal(err)
+	}
+	refreshed := false
+	deleted, err := pruner.Sweep(nil, func(p *Pruner) error {
+		refreshed = true
+		return p.Mark(newRoot)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !refreshed {
+		t.Fatal("want refresh to be called before deleting")
+	}
+	if deleted == 0 {
+		t.Fatal("want some trie nodes of old root to be deleted")
+	}
+
+	if _, err := trie.New(oldRoot, trie.NewIntermediateWriter(db)); err == nil {
+		t.Fatalf("want old root %+v to be pruned", oldRoot)
+	}
+	newStateDB, err := NewWithPrefixTrie(newRoot, NewDatabaseAccessWarper(db))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range keys {
+		v, err := newStateDB.getTestObject(keys[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(v, values[i]) {
+			t.Fatalf("want value %+v, got %+v", values[i], v)
+		}
+	}
+	if v, err := db.Get(otherKey[:]); err != nil || string(v) != "other" {
+		t.Fatalf("want non trie data untouched, got %+v, %+v", v, err)
+	}
+
+	// pruning again has nothing to delete
+	pruner = NewPruner(db)
+	if err := pruner.Mark(newRoot); err != nil {
+		t.Fatal(err)
+	}
+	deleted, err = pruner.Sweep(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deleted != 0 {
+		t.Fatalf("want 0 deleted nodes, got %+v", deleted)
+	}
+}
@@ -34,6 +34,7 @@ var (
 	pdeTradeStatusPrefix               = []byte("pdetradestatus-")
 	pdeWithdrawalStatusPrefix          = []byte("pdewithdrawalstatus-")
 	pdeStatusPrefix                    = []byte("pdestatus-")
+	pdeLimitOrderPrefix                = []byte("pdelimitorder-")
 	bridgeEthTxPrefix                  = []byte("bri-eth-tx-")
 	bridgeCentralizedTokenInfoPrefix   = []byte("bri-cen-token-info-")
 	bridgeDecentralizedTokenInfoPrefix = []byte("bri-de-token-info-")
@@ -55,6 +56,7 @@ var (
 	portalCustodianStatePrefix                    = []byte("portalcustodian-")
 	portalWaitingRedeemRequestsPrefix             = []byte("portalwaitingredeemrequest-")
 	portalMatchedRedeemRequestsPrefix             = []byte("portalmatchedredeemrequest-")
+	portalExchangeRatesFeederStatePrefix          = []byte("portalexchangeratesfeeder-")
 
 	portalStatusPrefix                           = []byte("portalstatus-")
 	portalCustodianDepositStatusPrefix           = []byte("custodiandeposit-")
@@ -73,6 +75,11 @@ var (
 	portalRewardInfoStatePrefix       = []byte("portalreward-")
 	portalLockedCollateralStatePrefix = []byte("portallockedcollateral-")
 
+	// relaying
+	relayingETHHeaderPrefix      = []byte("relayingethheader-")
+	relayingETHHeaderIndexPrefix = []byte("relayingethheaderindex-")
+	relayingETHBestHeaderPrefix  = []byte("relayingethbestheader-")
+
 	// reward for features in network (such as portal, pdex, etc)
 	rewardFeatureStatePrefix = []byte("rewardfeaturestate-")
 	// feature names
@@ -186,6 +193,11 @@ func GetPDEStatusPrefix() []byte {
 	return h[:][:prefixHashKeyLength]
 }
 
+func GetPDELimitOrderPrefix() []byte {
+	h := common.HashH(pdeLimitOrderPrefix)
+	return h[:][:prefixHashKeyLength]
+}
+
 func GetBridgeEthTxPrefix() []byte {
 	h := common.HashH(bridgeEthTxPrefix)
 	return h[:][:prefixHashKeyLength]
@@ -306,6 +318,11 @@ func GetPortalLiquidationPoolPrefix() []byte {
 	return h[:][:prefixHashKeyLength]
 }
 
+func GetPortalExchangeRatesFeederStatePrefix() []byte {
+	h := common.HashH(portalExchangeRatesFeederStatePrefix)
+	return h[:][:prefixHashKeyLength]
+}
+
 func GetPortalCustodianStatePrefix() []byte {
 	h := common.HashH(portalCustodianStatePrefix)
 	return h[:][:prefixHashKeyLength]
@@ -341,6 +358,21 @@ func GetRewardFeatureStatePrefix(epoch uint64) []byte {
 	return h[:][:prefixHashKeyLength]
 }
 
+func GetRelayingETHHeaderPrefix() []byte {
+	h := common.HashH(relayingETHHeaderPrefix)
+	return h[:][:prefixHashKeyLength]
+}
+
+func GetRelayingETHHeaderIndexPrefix() []byte {
+	h := common.HashH(relayingETHHeaderIndexPrefix)
+	return h[:][:prefixHashKeyLength]
+}
+
+func GetRelayingETHBestHeaderPrefix() []byte {
+	h := common.HashH(relayingETHBestHeaderPrefix)
+	return h[:][:prefixHashKeyLength]
+}
+
 func PortalCustodianDepositStatusPrefix() []byte {
 	return portalCustodianDepositStatusPrefix
 }
@@ -1145,6 +1145,24 @@ func (stateDB *StateDB) getPDEStatusByKey(key common.Hash) (*PDEStatusState, boo
 	return NewPDEStatusState(), false, nil
 }
 
+func (stateDB *StateDB) getAllPDELimitOrderState() []*PDELimitOrderState {
+	pdeLimitOrderStates := []*PDELimitOrderState{}
+	temp := stateDB.trie.NodeIterator(GetPDELimitOrderPrefix())
+	it := trie.NewIterator(temp)
+	for it.Next() {
+		value := it.Value
+		newValue := make([]byte, len(value))
+		copy(newValue, value)
+		lo := NewPDELimitOrderState()
+		err := json.Unmarshal(newValue, lo)
+		if err != nil {
+			panic("wrong expect type")
+		}
+		pdeLimitOrderStates = append(pdeLimitOrderStates, lo)
+	}
+	return pdeLimitOrderStates
+}
+
 // ================================= Bridge OBJECT =======================================
 func (stateDB *StateDB) getBridgeEthTxState(key common.Hash) (*BridgeEthTxState, bool, error) {
 	ethTxState, err := stateDB.getStateObject(BridgeEthTxObjectType, key)
@@ -1197,6 +1215,29 @@ func (stateDB *StateDB) getBridgeStatusState(key common.Hash) (*BridgeStatusStat
 	return NewBridgeStatusState(), false, nil
 }
 
+// ================================= Relaying OBJECT =======================================
+func (stateDB *StateDB) getRelayingETHHeaderState(key common.Hash) (*RelayingETHHeaderState, bool, error) {
+	headerState, err := stateDB.getStateObject(RelayingETHHeaderObjectType, key)
+	if err != nil {
+		return nil, false, err
+	}
+	if headerState != nil {
+		return headerState.GetValue().(*RelayingETHHeaderState), true, nil
+	}
+	return NewRelayingETHHeaderState(), false, nil
+}
+
+func (stateDB *StateDB) getRelayingETHHeaderIndexState(key common.Hash) (*RelayingETHHeaderIndexState, bool, error) {
+	indexState, err := stateDB.getStateObject(RelayingETHHeaderIndexObjectType, key)
+	if err != nil {
+		return nil, false, err
+	}
+	if indexState != nil {
+		return indexState.GetValue().(*RelayingETHHeaderIndexState), true, nil
+	}
+	return NewRelayingETHHeaderIndexState(), false, nil
+}
+
 // ================================= Burn OBJECT =======================================
 func (stateDB *StateDB) getBurningConfirmState(key common.Hash) (*BurningConfirmState, bool, error) {
 	burningConfirmState, err := stateDB.getStateObject(BurningConfirmObjectType, key)
@@ -1358,6 +1399,24 @@ func (stateDB *StateDB) getAllCustodianStatePool() map[string]*CustodianState {
 	return custodians
 }
 
+func (stateDB *StateDB) getAllExchangeRatesFeederState() map[string]*ExchangeRatesFeederState {
+	feeders := make(map[string]*ExchangeRatesFeederState)
+	temp := stateDB.trie.NodeIterator(GetPortalExchangeRatesFeederStatePrefix())
+	it := trie.NewIterator(temp)
+	for it.Next() {
+		value := it.Value
+		newValue := make([]byte, len(value))
+		copy(newValue, value)
+		feeder := NewExchangeRatesFeederState()
+		err := json.Unmarshal(newValue, feeder)
+		if err != nil {
+			panic("wrong expect type")
+		}
+		feeders[feeder.FeederAddress()] = feeder
+	}
+	return feeders
+}
+
 func (stateDB *StateDB) getPortalRewards(beaconHeight uint64) []*PortalRewardInfo {
 	portalRewards := make([]*PortalRewardInfo, 0)
 	temp := stateDB.trie.NodeIterator(GetPortalRewardInfoStatePrefix(beaconHeight))
@@ -53,6 +53,14 @@ func newStateObjectWithValue(db *StateDB, objectType int, hash common.Hash, valu
 		return newPDEShareObjectWithValue(db, hash, value)
 	case PDEStatusObjectType:
 		return newPDEStatusObjectWithValue(db, hash, value)
+	case PDELimitOrderObjectType:
+		return newPDELimitOrderObjectWithValue(db, hash, value)
+	case RelayingETHHeaderObjectType:
+		return newRelayingETHHeaderObjectWithValue(db, hash, value)
+	case RelayingETHHeaderIndexObjectType:
+		return newRelayingETHHeaderIndexObjectWithValue(db, hash, value)
+	case PortalExchangeRatesFeederObjectType:
+		return newExchangeRatesFeederObjectWithValue(db, hash, value)
 	case BridgeEthTxObjectType:
 		return newBridgeEthTxObjectWithValue(db, hash, value)
 	case BridgeTokenInfoObjectType:
@@ -116,6 +124,14 @@ func newStateObject(db *StateDB, objectType int, hash common.Hash) StateObject {
 		return newPDEShareObject(db, hash)
 	case PDEStatusObjectType:
 		return newPDEStatusObject(db, hash)
+	case PDELimitOrderObjectType:
+		return newPDELimitOrderObject(db, hash)
+	case RelayingETHHeaderObjectType:
+		return newRelayingETHHeaderObject(db, hash)
+	case RelayingETHHeaderIndexObjectType:
+		return newRelayingETHHeaderIndexObject(db, hash)
+	case PortalExchangeRatesFeederObjectType:
+		return newExchangeRatesFeederObject(db, hash)
 	case BridgeEthTxObjectType:
 		return newBridgeEthTxObject(db, hash)
 	case BridgeTokenInfoObjectType:
@@ -0,0 +1,306 @@
+package statedb
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/incognitochain/incognito-chain/common"
+	"reflect"
+)
+
+type PDELimitOrderState struct {
+	orderID             common.Hash
+	traderAddress       string
+	tokenIDToBuy        string
+	tokenIDToSell       string
+	sellAmount          uint64
+	minAcceptableAmount uint64
+	tradingFee          uint64
+	shardID             byte
+	expiryHeight        uint64
+}
+
+func (s PDELimitOrderState) OrderID() common.Hash {
+	return s.orderID
+}
+
+func (s *PDELimitOrderState) SetOrderID(orderID common.Hash) {
+	s.orderID = orderID
+}
+
+func (s PDELimitOrderState) TraderAddress() string {
+	return s.traderAddress
+}
+
+func (s *PDELimitOrderState) SetTraderAddress(traderAddress string) {
+	s.traderAddress = traderAddress
+}
+
+func (s PDELimitOrderState) TokenIDToBuy() string {
+	return s.tokenIDToBuy
+}
+
+func (s *PDELimitOrderState) SetTokenIDToBuy(tokenIDToBuy string) {
+	s.tokenIDToBuy = tokenIDToBuy
+}
+
+func (s PDELimitOrderState) TokenIDToSell() string {
+	return s.tokenIDToSell
+}
+
+func (s *PDELimitOrderState) SetTokenIDToSell(tokenIDToSell string) {
+	s.tokenIDToSell = tokenIDToSell
+}
+
+func (s PDELimitOrderState) SellAmount() uint64 {
+	return s.sellAmount
+}
+
+func (s *PDELimitOrderState) SetSellAmount(sellAmount uint64) {
+	s.sellAmount = sellAmount
+}
+
+func (s PDELimitOrderState) MinAcceptableAmount() uint64 {
+	return s.minAcceptableAmount
+}
+
+func (s *PDELimitOrderState) SetMinAcceptableAmount(minAcceptableAmount uint64) {
+	s.minAcceptableAmount = minAcceptableAmount
+}
+
+func (s PDELimitOrderState) TradingFee() uint64 {
+	return s.tradingFee
+}
+
+func (s *PDELimitOrderState) SetTradingFee(tradingFee uint64) {
+	s.tradingFee = tradingFee
+}
+
+func (s PDELimitOrderState) ShardID() byte {
+	return s.shardID
+}
+
+func (s *PDELimitOrderState) SetShardID(shardID byte) {
+	s.shardID = shardID
+}
+
+func (s PDELimitOrderState) ExpiryHeight() uint64 {
+	return s.expiryHeight
+}
+
+func (s *PDELimitOrderState) SetExpiryHeight(expiryHeight uint64) {
+	s.expiryHeight = expiryHeight
+}
+
+func (s PDELimitOrderState) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(struct {
+		OrderID             common.Hash
+		TraderAddress       string
+		TokenIDToBuy        string
+		TokenIDToSell       string
+		SellAmount          uint64
+		MinAcceptableAmount uint64
+		TradingFee          uint64
+		ShardID             byte
+		ExpiryHeight        uint64
+	}{
+		OrderID:             s.orderID,
+		TraderAddress:       s.traderAddress,
+		TokenIDToBuy:        s.tokenIDToBuy,
+		TokenIDToSell:       s.tokenIDToSell,
+		SellAmount:          s.sellAmount,
+		MinAcceptableAmount: s.minAcceptableAmount,
+		TradingFee:          s.tradingFee,
+		ShardID:             s.shardID,
+		ExpiryHeight:        s.expiryHeight,
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+	return data, nil
+}
+
+func (s *PDELimitOrderState) UnmarshalJSON(data []byte) error {
+	temp := struct {
+		OrderID             common.Hash
+		TraderAddress       string
+		TokenIDToBuy        string
+		TokenIDToSell       string
+		SellAmount          uint64
+		MinAcceptableAmount uint64
+		TradingFee          uint64
+		ShardID             byte
+		ExpiryHeight        uint64
+	}{}
+	err := json.Unmarshal(data, &temp)
+	if err != nil {
+		return err
+	}
+	s.orderID = temp.OrderID
+	s.traderAddress = temp.TraderAddress
+	s.tokenIDToBuy = temp.TokenIDToBuy
+	s.tokenIDToSell = temp.TokenIDToSell
+	s.sellAmount = temp.SellAmount
+	s.minAcceptableAmount = temp.MinAcceptableAmount
+	s.tradingFee = temp.TradingFee
+	s.shardID = temp.ShardID
+	s.expiryHeight = temp.ExpiryHeight
+	return nil
+}
+
+func NewPDELimitOrderState() *PDELimitOrderState {
+	return &PDELimitOrderState{}
+}
+
+func NewPDELimitOrderStateWithValue(
+	orderID common.Hash,
+	traderAddress string,
+	tokenIDToBuy string,
+	tokenIDToSell string,
+	sellAmount uint64,
+	minAcceptableAmount uint64,
+	tradingFee uint64,
+	shardID byte,
+	expiryHeight uint64,
+) *PDELimitOrderState {
+	return &PDELimitOrderState{
+		orderID:             orderID,
+		traderAddress:       traderAddress,
+		tokenIDToBuy:        tokenIDToBuy,
+		tokenIDToSell:       tokenIDToSell,
+		sellAmount:          sellAmount,
+		minAcceptableAmount: minAcceptableAmount,
+		tradingFee:          tradingFee,
+		shardID:             shardID,
+		expiryHeight:        expiryHeight,
+	}
+}
+
+type PDELimitOrderObject struct {
+	db *StateDB
+	// Write caches.
+	trie Trie // storage trie, which becomes non-nil on first access
+
+	version            int
+	pdeLimitOrderHash  common.Hash
+	pdeLimitOrderState *PDELimitOrderState
+	objectType         int
+	deleted            bool
+
+	// DB error.
+	// State objects are used by the consensus core and VM which are
+	// unable to deal with database-level errors. Any error that occurs
+	// during a database read is memoized here and will eventually be returned
+	// by StateDB.Commit.
+	dbErr error
+}
+
+func newPDELimitOrderObject(db *StateDB, hash common.Hash) *PDELimitOrderObject {
+	return &PDELimitOrderObject{
+		version:            defaultVersion,
+		db:                 db,
+		pdeLimitOrderHash:  hash,
+		pdeLimitOrderState: NewPDELimitOrderState(),
+		objectType:         PDELimitOrderObjectType,
+		deleted:            false,
+	}
+}
+
+func newPDELimitOrderObjectWithValue(db *StateDB, key common.Hash, data interface{}) (*PDELimitOrderObject, error) {
+	var newPDELimitOrderState = NewPDELimitOrderState()
+	var ok bool
+	var dataBytes []byte
+	if dataBytes, ok = data.([]byte); ok {
+		err := json.Unmarshal(dataBytes, newPDELimitOrderState)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		newPDELimitOrderState, ok = data.(*PDELimitOrderState)
+		if !ok {
+			return nil, fmt.Errorf("%+v, got type %+v", ErrInvalidPDELimitOrderStateType, reflect.TypeOf(data))
+		}
+	}
+	return &PDELimitOrderObject{
+		version:            defaultVersion,
+		pdeLimitOrderHash:  key,
+		pdeLimitOrderState: newPDELimitOrderState,
+		db:                 db,
+		objectType:         PDELimitOrderObjectType,
+		deleted:            false,
+	}, nil
+}
+
+func GeneratePDELimitOrderObjectKey(orderID common.Hash) common.Hash {
+	prefixHash := GetPDELimitOrderPrefix()
+	valueHash := common.HashH(orderID[:])
+	return common.BytesToHash(append(prefixHash, valueHash[:][:prefixKeyLength]...))
+}
+
+func (t PDELimitOrderObject) GetVersion() int {
+	return t.version
+}
+
+// setError remembers the first non-nil error it is called with.
+func (t *PDELimitOrderObject) SetError(err error) {
+	if t.dbErr == nil {
+		t.dbErr = err
+	}
+}
+
+func (t PDELimitOrderObject) GetTrie(db DatabaseAccessWarper) Trie {
+	return t.trie
+}
+
+func (t *PDELimitOrderObject) SetValue(data interface{}) error {
+	newPDELimitOrderState, ok := data.(*PDELimitOrderState)
+	if !ok {
+		return fmt.Errorf("%+v, got type %+v", ErrInvalidPDELimitOrderStateType, reflect.TypeOf(data))
+	}
+	t.pdeLimitOrderState = newPDELimitOrderState
+	return nil
+}
+
+func (t PDELimitOrderObject) GetValue() interface{} {
+	return t.pdeLimitOrderState
+}
+
+func (t PDELimitOrderObject) GetValueBytes() []byte {
+	pdeLimitOrderState, ok := t.GetValue().(*PDELimitOrderState)
+	if !ok {
+		panic("wrong expected value type")
+	}
+	value, err := json.Marshal(pdeLimitOrderState)
+	if err != nil {
+		panic("failed to marshal pde limit order state")
+	}
+	return value
+}
+
+func (t PDELimitOrderObject) GetHash() common.Hash {
+	return t.pdeLimitOrderHash
+}
+
+func (t PDELimitOrderObject) GetType() int {
+	return t.objectType
+}
+
+// MarkDelete will delete an object in trie
+func (t *PDELimitOrderObject) MarkDelete() {
+	t.deleted = true
+}
+
+// reset all shard committee value into default value
+func (t *PDELimitOrderObject) Reset() bool {
+	t.pdeLimitOrderState = NewPDELimitOrderState()
+	return true
+}
+
+func (t PDELimitOrderObject) IsDeleted() bool {
+	return t.deleted
+}
+
+// value is either default or nil
+func (t PDELimitOrderObject) IsEmpty() bool {
+	temp := NewPDELimitOrderState()
+	return reflect.DeepEqual(temp, t.pdeLimitOrderState) || t.pdeLimitOrderState == nil
+}
@@ -12,6 +12,7 @@ type PDEShareState struct {
 	token2ID           string
 	contributorAddress string
 	amount             uint64
+	tradingFees        map[string]uint64 // trading fees earned by the shares, by token id
 }
 
 func (s PDEShareState) Token1ID() string {
@@ -46,17 +47,27 @@ func (s *PDEShareState) SetContributorAddress(contributorAddress string) {
 	s.contributorAddress = contributorAddress
 }
 
+func (s PDEShareState) TradingFees() map[string]uint64 {
+	return s.tradingFees
+}
+
+func (s *PDEShareState) SetTradingFees(tradingFees map[string]uint64) {
+	s.tradingFees = tradingFees
+}
+
 func (s PDEShareState) MarshalJSON() ([]byte, error) {
 	data, err := json.Marshal(struct {
 		Token1ID           string
 		Token2ID           string
 		ContributorAddress string
 		Amount             uint64
+		TradingFees        map[string]uint64 `json:",omitempty"`
 	}{
 		Token1ID:           s.token1ID,
 		Token2ID:           s.token2ID,
 		ContributorAddress: s.contributorAddress,
 		Amount:             s.amount,
+		TradingFees:        s.tradingFees,
 	})
 	if err != nil {
 		return []byte{}, err
@@ -70,6 +81,7 @@ func (s *PDEShareState) UnmarshalJSON(data []byte) error {
 		Token2ID           string
 		ContributorAddress string
 		Amount             uint64
+		TradingFees        map[string]uint64
 	}{}
 	err := json.Unmarshal(data, &temp)
 	if err != nil {
@@ -79,6 +91,7 @@ func (s *PDEShareState) UnmarshalJSON(data []byte) error {
 	s.token2ID = temp.Token2ID
 	s.contributorAddress = temp.ContributorAddress
 	s.amount = temp.Amount
+	s.tradingFees = temp.TradingFees
 	return nil
 }
 
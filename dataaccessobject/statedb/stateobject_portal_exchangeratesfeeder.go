@@ -0,0 +1,254 @@
+package statedb
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// FeederExchangeRate is the last rate of a token submitted by a feeder
+type FeederExchangeRate struct {
+	Rate         uint64
+	BeaconHeight uint64
+}
+
+// ExchangeRatesFeederState is the submission history of an exchange rates feeder
+type ExchangeRatesFeederState struct {
+	feederAddress             string
+	lastRates                 map[string]*FeederExchangeRate // tokenID : last accepted rate
+	lastSubmittedBeaconHeight uint64
+	acceptedSubmissions       uint64
+	rejectedSubmissions       uint64
+}
+
+func (s ExchangeRatesFeederState) FeederAddress() string {
+	return s.feederAddress
+}
+
+func (s *ExchangeRatesFeederState) SetFeederAddress(feederAddress string) {
+	s.feederAddress = feederAddress
+}
+
+func (s ExchangeRatesFeederState) LastRates() map[string]*FeederExchangeRate {
+	return s.lastRates
+}
+
+func (s *ExchangeRatesFeederState) SetLastRates(lastRates map[string]*FeederExchangeRate) {
+	s.lastRates = lastRates
+}
+
+func (s ExchangeRatesFeederState) LastSubmittedBeaconHeight() uint64 {
+	return s.lastSubmittedBeaconHeight
+}
+
+func (s *ExchangeRatesFeederState) SetLastSubmittedBeaconHeight(beaconHeight uint64) {
+	s.lastSubmittedBeaconHeight = beaconHeight
+}
+
+func (s ExchangeRatesFeederState) AcceptedSubmissions() uint64 {
+	return s.acceptedSubmissions
+}
+
+func (s *ExchangeRatesFeederState) SetAcceptedSubmissions(acceptedSubmissions uint64) {
+	s.acceptedSubmissions = acceptedSubmissions
+}
+
+func (s ExchangeRatesFeederState) RejectedSubmissions() uint64 {
+	return s.rejectedSubmissions
+}
+
+func (s *ExchangeRatesFeederState) SetRejectedSubmissions(rejectedSubmissions uint64) {
+	s.rejectedSubmissions = rejectedSubmissions
+}
+
+func (s ExchangeRatesFeederState) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(struct {
+		FeederAddress             string
+		LastRates                 map[string]*FeederExchangeRate
+		LastSubmittedBeaconHeight uint64
+		AcceptedSubmissions       uint64
+		RejectedSubmissions       uint64
+	}{
+		FeederAddress:             s.feederAddress,
+		LastRates:                 s.lastRates,
+		LastSubmittedBeaconHeight: s.lastSubmittedBeaconHeight,
+		AcceptedSubmissions:       s.acceptedSubmissions,
+		RejectedSubmissions:       s.rejectedSubmissions,
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+	return data, nil
+}
+
+func (s *ExchangeRatesFeederState) UnmarshalJSON(data []byte) error {
+	temp := struct {
+		FeederAddress             string
+		LastRates                 map[string]*FeederExchangeRate
+		LastSubmittedBeaconHeight uint64
+		AcceptedSubmissions       uint64
+		RejectedSubmissions       uint64
+	}{}
+	err := json.Unmarshal(data, &temp)
+	if err != nil {
+		return err
+	}
+	s.feederAddress = temp.FeederAddress
+	s.lastRates = temp.LastRates
+	s.lastSubmittedBeaconHeight = temp.LastSubmittedBeaconHeight
+	s.acceptedSubmissions = temp.AcceptedSubmissions
+	s.rejectedSubmissions = temp.RejectedSubmissions
+	return nil
+}
+
+func NewExchangeRatesFeederState() *ExchangeRatesFeederState {
+	return &ExchangeRatesFeederState{}
+}
+
+func NewExchangeRatesFeederStateWithValue(
+	feederAddress string,
+	lastRates map[string]*FeederExchangeRate,
+	lastSubmittedBeaconHeight uint64,
+	acceptedSubmissions uint64,
+	rejectedSubmissions uint64,
+) *ExchangeRatesFeederState {
+	return &ExchangeRatesFeederState{
+		feederAddress:             feederAddress,
+		lastRates:                 lastRates,
+		lastSubmittedBeaconHeight: lastSubmittedBeaconHeight,
+		acceptedSubmissions:       acceptedSubmissions,
+		rejectedSubmissions:       rejectedSubmissions,
+	}
+}
+
+type ExchangeRatesFeederObject struct {
+	db *StateDB
+	// Write caches.
+	trie Trie // storage trie, which becomes non-nil on first access
+
+	version                  int
+	exchangeRatesFeederHash  common.Hash
+	exchangeRatesFeederState *ExchangeRatesFeederState
+	objectType               int
+	deleted                  bool
+
+	// DB error.
+	// State objects are used by the consensus core and VM which are
+	// unable to deal with database-level errors. Any error that occurs
+	// during a database read is memoized here and will eventually be returned
+	// by StateDB.Commit.
+	dbErr error
+}
+
+func newExchangeRatesFeederObject(db *StateDB, hash common.Hash) *ExchangeRatesFeederObject {
+	return &ExchangeRatesFeederObject{
+		version:                  defaultVersion,
+		db:                       db,
+		exchangeRatesFeederHash:  hash,
+		exchangeRatesFeederState: NewExchangeRatesFeederState(),
+		objectType:               PortalExchangeRatesFeederObjectType,
+		deleted:                  false,
+	}
+}
+
+func newExchangeRatesFeederObjectWithValue(db *StateDB, key common.Hash, data interface{}) (*ExchangeRatesFeederObject, error) {
+	var newExchangeRatesFeederState = NewExchangeRatesFeederState()
+	var ok bool
+	var dataBytes []byte
+	if dataBytes, ok = data.([]byte); ok {
+		err := json.Unmarshal(dataBytes, newExchangeRatesFeederState)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		newExchangeRatesFeederState, ok = data.(*ExchangeRatesFeederState)
+		if !ok {
+			return nil, fmt.Errorf("%+v, got type %+v", ErrInvalidExchangeRatesFeederStateType, reflect.TypeOf(data))
+		}
+	}
+	return &ExchangeRatesFeederObject{
+		version:                  defaultVersion,
+		exchangeRatesFeederHash:  key,
+		exchangeRatesFeederState: newExchangeRatesFeederState,
+		db:                       db,
+		objectType:               PortalExchangeRatesFeederObjectType,
+		deleted:                  false,
+	}, nil
+}
+
+func GenerateExchangeRatesFeederObjectKey(feederAddress string) common.Hash {
+	prefixHash := GetPortalExchangeRatesFeederStatePrefix()
+	valueHash := common.HashH([]byte(feederAddress))
+	return common.BytesToHash(append(prefixHash, valueHash[:][:prefixKeyLength]...))
+}
+
+func (t ExchangeRatesFeederObject) GetVersion() int {
+	return t.version
+}
+
+// setError remembers the first non-nil error it is called with.
+func (t *ExchangeRatesFeederObject) SetError(err error) {
+	if t.dbErr == nil {
+		t.dbErr = err
+	}
+}
+
+func (t ExchangeRatesFeederObject) GetTrie(db DatabaseAccessWarper) Trie {
+	return t.trie
+}
+
+func (t *ExchangeRatesFeederObject) SetValue(data interface{}) error {
+	newExchangeRatesFeederState, ok := data.(*ExchangeRatesFeederState)
+	if !ok {
+		return fmt.Errorf("%+v, got type %+v", ErrInvalidExchangeRatesFeederStateType, reflect.TypeOf(data))
+	}
+	t.exchangeRatesFeederState = newExchangeRatesFeederState
+	return nil
+}
+
+func (t ExchangeRatesFeederObject) GetValue() interface{} {
+	return t.exchangeRatesFeederState
+}
+
+func (t ExchangeRatesFeederObject) GetValueBytes() []byte {
+	exchangeRatesFeederState, ok := t.GetValue().(*ExchangeRatesFeederState)
+	if !ok {
+		panic("wrong expected value type")
+	}
+	value, err := json.Marshal(exchangeRatesFeederState)
+	if err != nil {
+		panic("failed to marshal exchange rates feeder state")
+	}
+	return value
+}
+
+func (t ExchangeRatesFeederObject) GetHash() common.Hash {
+	return t.exchangeRatesFeederHash
+}
+
+func (t ExchangeRatesFeederObject) GetType() int {
+	return t.objectType
+}
+
+// MarkDelete will delete an object in trie
+func (t *ExchangeRatesFeederObject) MarkDelete() {
+	t.deleted = true
+}
+
+// reset all shard committee value into default value
+func (t *ExchangeRatesFeederObject) Reset() bool {
+	t.exchangeRatesFeederState = NewExchangeRatesFeederState()
+	return true
+}
+
+func (t ExchangeRatesFeederObject) IsDeleted() bool {
+	return t.deleted
+}
+
+// value is either default or nil
+func (t ExchangeRatesFeederObject) IsEmpty() bool {
+	temp := NewExchangeRatesFeederState()
+	return reflect.DeepEqual(temp, t.exchangeRatesFeederState) || t.exchangeRatesFeederState == nil
+}
@@ -0,0 +1,64 @@
+package statedb
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStateDB_StoreAndGetExchangeRatesFeeders(t *testing.T) {
+	feeders := map[string]*ExchangeRatesFeederState{
+		"feeder1": NewExchangeRatesFeederStateWithValue(
+			"feeder1",
+			map[string]*FeederExchangeRate{
+				"btc": {Rate: 9000, BeaconHeight: 10},
+				"prv": {Rate: 1, BeaconHeight: 9},
+			},
+			10, 5, 1,
+		),
+		"feeder2": NewExchangeRatesFeederStateWithValue("feeder2", map[string]*FeederExchangeRate{}, 8, 1, 0),
+	}
+	sDB, err := NewWithPrefixTrie(emptyRoot, warperDBStatedbTest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = StoreExchangeRatesFeeders(sDB, feeders)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootHash, err := sDB.Commit(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = sDB.Database().TrieDB().Commit(rootHash, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tempStateDB, err := NewWithPrefixTrie(rootHash, warperDBStatedbTest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gotFeeders := GetExchangeRatesFeeders(tempStateDB)
+	if !reflect.DeepEqual(feeders, gotFeeders) {
+		t.Fatalf("want %+v but got %+v", feeders, gotFeeders)
+	}
+
+	// a new submission overwrites the history of the feeder
+	feeders["feeder2"].SetAcceptedSubmissions(2)
+	feeders["feeder2"].SetLastSubmittedBeaconHeight(11)
+	err = StoreExchangeRatesFeeders(tempStateDB, map[string]*ExchangeRatesFeederState{"feeder2": feeders["feeder2"]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = tempStateDB.Commit(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gotFeeders = GetExchangeRatesFeeders(tempStateDB)
+	if len(gotFeeders) != 2 {
+		t.Fatalf("want 2 feeders but got %v", len(gotFeeders))
+	}
+	if !reflect.DeepEqual(feeders["feeder2"], gotFeeders["feeder2"]) {
+		t.Fatalf("want %+v but got %+v", feeders["feeder2"], gotFeeders["feeder2"])
+	}
+}
@@ -0,0 +1,235 @@
+package statedb
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// RelayingETHHeaderState is an ethereum block header relayed on-chain,
+// header holds the rlp encoded header, totalDifficulty is accumulated from the relaying checkpoint
+type RelayingETHHeaderState struct {
+	blockHash       []byte
+	blockNumber     uint64
+	header          []byte
+	totalDifficulty *big.Int
+}
+
+func (s RelayingETHHeaderState) BlockHash() []byte {
+	return s.blockHash
+}
+
+func (s *RelayingETHHeaderState) SetBlockHash(blockHash []byte) {
+	s.blockHash = blockHash
+}
+
+func (s RelayingETHHeaderState) BlockNumber() uint64 {
+	return s.blockNumber
+}
+
+func (s *RelayingETHHeaderState) SetBlockNumber(blockNumber uint64) {
+	s.blockNumber = blockNumber
+}
+
+func (s RelayingETHHeaderState) Header() []byte {
+	return s.header
+}
+
+func (s *RelayingETHHeaderState) SetHeader(header []byte) {
+	s.header = header
+}
+
+func (s RelayingETHHeaderState) TotalDifficulty() *big.Int {
+	return s.totalDifficulty
+}
+
+func (s *RelayingETHHeaderState) SetTotalDifficulty(totalDifficulty *big.Int) {
+	s.totalDifficulty = totalDifficulty
+}
+
+func (s RelayingETHHeaderState) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(struct {
+		BlockHash       []byte
+		BlockNumber     uint64
+		Header          []byte
+		TotalDifficulty *big.Int
+	}{
+		BlockHash:       s.blockHash,
+		BlockNumber:     s.blockNumber,
+		Header:          s.header,
+		TotalDifficulty: s.totalDifficulty,
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+	return data, nil
+}
+
+func (s *RelayingETHHeaderState) UnmarshalJSON(data []byte) error {
+	temp := struct {
+		BlockHash       []byte
+		BlockNumber     uint64
+		Header          []byte
+		TotalDifficulty *big.Int
+	}{}
+	err := json.Unmarshal(data, &temp)
+	if err != nil {
+		return err
+	}
+	s.blockHash = temp.BlockHash
+	s.blockNumber = temp.BlockNumber
+	s.header = temp.Header
+	s.totalDifficulty = temp.TotalDifficulty
+	return nil
+}
+
+func NewRelayingETHHeaderState() *RelayingETHHeaderState {
+	return &RelayingETHHeaderState{}
+}
+
+func NewRelayingETHHeaderStateWithValue(
+	blockHash []byte,
+	blockNumber uint64,
+	header []byte,
+	totalDifficulty *big.Int,
+) *RelayingETHHeaderState {
+	return &RelayingETHHeaderState{
+		blockHash:       blockHash,
+		blockNumber:     blockNumber,
+		header:          header,
+		totalDifficulty: totalDifficulty,
+	}
+}
+
+type RelayingETHHeaderObject struct {
+	db *StateDB
+	// Write caches.
+	trie Trie // storage trie, which becomes non-nil on first access
+
+	version                int
+	relayingETHHeaderHash  common.Hash
+	relayingETHHeaderState *RelayingETHHeaderState
+	objectType             int
+	deleted                bool
+
+	// DB error.
+	// State objects are used by the consensus core and VM which are
+	// unable to deal with database-level errors. Any error that occurs
+	// during a database read is memoized here and will eventually be returned
+	// by StateDB.Commit.
+	dbErr error
+}
+
+func newRelayingETHHeaderObject(db *StateDB, hash common.Hash) *RelayingETHHeaderObject {
+	return &RelayingETHHeaderObject{
+		version:                defaultVersion,
+		db:                     db,
+		relayingETHHeaderHash:  hash,
+		relayingETHHeaderState: NewRelayingETHHeaderState(),
+		objectType:             RelayingETHHeaderObjectType,
+		deleted:                false,
+	}
+}
+
+func newRelayingETHHeaderObjectWithValue(db *StateDB, key common.Hash, data interface{}) (*RelayingETHHeaderObject, error) {
+	var newRelayingETHHeaderState = NewRelayingETHHeaderState()
+	var ok bool
+	var dataBytes []byte
+	if dataBytes, ok = data.([]byte); ok {
+		err := json.Unmarshal(dataBytes, newRelayingETHHeaderState)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		newRelayingETHHeaderState, ok = data.(*RelayingETHHeaderState)
+		if !ok {
+			return nil, fmt.Errorf("%+v, got type %+v", ErrInvalidRelayingETHHeaderStateType, reflect.TypeOf(data))
+		}
+	}
+	return &RelayingETHHeaderObject{
+		version:                defaultVersion,
+		relayingETHHeaderHash:  key,
+		relayingETHHeaderState: newRelayingETHHeaderState,
+		db:                     db,
+		objectType:             RelayingETHHeaderObjectType,
+		deleted:                false,
+	}, nil
+}
+
+func GenerateRelayingETHHeaderObjectKey(blockHash []byte) common.Hash {
+	prefixHash := GetRelayingETHHeaderPrefix()
+	valueHash := common.HashH(blockHash)
+	return common.BytesToHash(append(prefixHash, valueHash[:][:prefixKeyLength]...))
+}
+
+func (t RelayingETHHeaderObject) GetVersion() int {
+	return t.version
+}
+
+// setError remembers the first non-nil error it is called with.
+func (t *RelayingETHHeaderObject) SetError(err error) {
+	if t.dbErr == nil {
+		t.dbErr = err
+	}
+}
+
+func (t RelayingETHHeaderObject) GetTrie(db DatabaseAccessWarper) Trie {
+	return t.trie
+}
+
+func (t *RelayingETHHeaderObject) SetValue(data interface{}) error {
+	newRelayingETHHeaderState, ok := data.(*RelayingETHHeaderState)
+	if !ok {
+		return fmt.Errorf("%+v, got type %+v", ErrInvalidRelayingETHHeaderStateType, reflect.TypeOf(data))
+	}
+	t.relayingETHHeaderState = newRelayingETHHeaderState
+	return nil
+}
+
+func (t RelayingETHHeaderObject) GetValue() interface{} {
+	return t.relayingETHHeaderState
+}
+
+func (t RelayingETHHeaderObject) GetValueBytes() []byte {
+	relayingETHHeaderState, ok := t.GetValue().(*RelayingETHHeaderState)
+	if !ok {
+		panic("wrong expected value type")
+	}
+	value, err := json.Marshal(relayingETHHeaderState)
+	if err != nil {
+		panic("failed to marshal relaying eth header state")
+	}
+	return value
+}
+
+func (t RelayingETHHeaderObject) GetHash() common.Hash {
+	return t.relayingETHHeaderHash
+}
+
+func (t RelayingETHHeaderObject) GetType() int {
+	return t.objectType
+}
+
+// MarkDelete will delete an object in trie
+func (t *RelayingETHHeaderObject) MarkDelete() {
+	t.deleted = true
+}
+
+// reset all shard committee value into default value
+func (t *RelayingETHHeaderObject) Reset() bool {
+	t.relayingETHHeaderState = NewRelayingETHHeaderState()
+	return true
+}
+
+func (t RelayingETHHeaderObject) IsDeleted() bool {
+	return t.deleted
+}
+
+// value is either default or nil
+func (t RelayingETHHeaderObject) IsEmpty() bool {
+	temp := NewRelayingETHHeaderState()
+	return reflect.DeepEqual(temp, t.relayingETHHeaderState) || t.relayingETHHeaderState == nil
+}
@@ -0,0 +1,207 @@
+package statedb
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// RelayingETHHeaderIndexState points to a relayed ethereum header by its hash,
+// it is used for the canonical chain index (block number => block hash) and for the best header
+type RelayingETHHeaderIndexState struct {
+	blockNumber uint64
+	blockHash   []byte
+}
+
+func (s RelayingETHHeaderIndexState) BlockNumber() uint64 {
+	return s.blockNumber
+}
+
+func (s *RelayingETHHeaderIndexState) SetBlockNumber(blockNumber uint64) {
+	s.blockNumber = blockNumber
+}
+
+func (s RelayingETHHeaderIndexState) BlockHash() []byte {
+	return s.blockHash
+}
+
+func (s *RelayingETHHeaderIndexState) SetBlockHash(blockHash []byte) {
+	s.blockHash = blockHash
+}
+
+func (s RelayingETHHeaderIndexState) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(struct {
+		BlockNumber uint64
+		BlockHash   []byte
+	}{
+		BlockNumber: s.blockNumber,
+		BlockHash:   s.blockHash,
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+	return data, nil
+}
+
+func (s *RelayingETHHeaderIndexState) UnmarshalJSON(data []byte) error {
+	temp := struct {
+		BlockNumber uint64
+		BlockHash   []byte
+	}{}
+	err := json.Unmarshal(data, &temp)
+	if err != nil {
+		return err
+	}
+	s.blockNumber = temp.BlockNumber
+	s.blockHash = temp.BlockHash
+	return nil
+}
+
+func NewRelayingETHHeaderIndexState() *RelayingETHHeaderIndexState {
+	return &RelayingETHHeaderIndexState{}
+}
+
+func NewRelayingETHHeaderIndexStateWithValue(blockNumber uint64, blockHash []byte) *RelayingETHHeaderIndexState {
+	return &RelayingETHHeaderIndexState{
+		blockNumber: blockNumber,
+		blockHash:   blockHash,
+	}
+}
+
+type RelayingETHHeaderIndexObject struct {
+	db *StateDB
+	// Write caches.
+	trie Trie // storage trie, which becomes non-nil on first access
+
+	version                     int
+	relayingETHHeaderIndexHash  common.Hash
+	relayingETHHeaderIndexState *RelayingETHHeaderIndexState
+	objectType                  int
+	deleted                     bool
+
+	// DB error.
+	// State objects are used by the consensus core and VM which are
+	// unable to deal with database-level errors. Any error that occurs
+	// during a database read is memoized here and will eventually be returned
+	// by StateDB.Commit.
+	dbErr error
+}
+
+func newRelayingETHHeaderIndexObject(db *StateDB, hash common.Hash) *RelayingETHHeaderIndexObject {
+	return &RelayingETHHeaderIndexObject{
+		version:                     defaultVersion,
+		db:                          db,
+		relayingETHHeaderIndexHash:  hash,
+		relayingETHHeaderIndexState: NewRelayingETHHeaderIndexState(),
+		objectType:                  RelayingETHHeaderIndexObjectType,
+		deleted:                     false,
+	}
+}
+
+func newRelayingETHHeaderIndexObjectWithValue(db *StateDB, key common.Hash, data interface{}) (*RelayingETHHeaderIndexObject, error) {
+	var newRelayingETHHeaderIndexState = NewRelayingETHHeaderIndexState()
+	var ok bool
+	var dataBytes []byte
+	if dataBytes, ok = data.([]byte); ok {
+		err := json.Unmarshal(dataBytes, newRelayingETHHeaderIndexState)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		newRelayingETHHeaderIndexState, ok = data.(*RelayingETHHeaderIndexState)
+		if !ok {
+			return nil, fmt.Errorf("%+v, got type %+v", ErrInvalidRelayingETHHeaderIndexStateType, reflect.TypeOf(data))
+		}
+	}
+	return &RelayingETHHeaderIndexObject{
+		version:                     defaultVersion,
+		relayingETHHeaderIndexHash:  key,
+		relayingETHHeaderIndexState: newRelayingETHHeaderIndexState,
+		db:                          db,
+		objectType:                  RelayingETHHeaderIndexObjectType,
+		deleted:                     false,
+	}, nil
+}
+
+func GenerateRelayingETHHeaderIndexObjectKey(blockNumber uint64) common.Hash {
+	prefixHash := GetRelayingETHHeaderIndexPrefix()
+	valueHash := common.HashH(common.Uint64ToBytes(blockNumber))
+	return common.BytesToHash(append(prefixHash, valueHash[:][:prefixKeyLength]...))
+}
+
+func GenerateRelayingETHBestHeaderObjectKey() common.Hash {
+	prefixHash := GetRelayingETHBestHeaderPrefix()
+	valueHash := common.HashH([]byte{})
+	return common.BytesToHash(append(prefixHash, valueHash[:][:prefixKeyLength]...))
+}
+
+func (t RelayingETHHeaderIndexObject) GetVersion() int {
+	return t.version
+}
+
+// setError remembers the first non-nil error it is called with.
+func (t *RelayingETHHeaderIndexObject) SetError(err error) {
+	if t.dbErr == nil {
+		t.dbErr = err
+	}
+}
+
+func (t RelayingETHHeaderIndexObject) GetTrie(db DatabaseAccessWarper) Trie {
+	return t.trie
+}
+
+func (t *RelayingETHHeaderIndexObject) SetValue(data interface{}) error {
+	newRelayingETHHeaderIndexState, ok := data.(*RelayingETHHeaderIndexState)
+	if !ok {
+		return fmt.Errorf("%+v, got type %+v", ErrInvalidRelayingETHHeaderIndexStateType, reflect.TypeOf(data))
+	}
+	t.relayingETHHeaderIndexState = newRelayingETHHeaderIndexState
+	return nil
+}
+
+func (t RelayingETHHeaderIndexObject) GetValue() interface{} {
+	return t.relayingETHHeaderIndexState
+}
+
+func (t RelayingETHHeaderIndexObject) GetValueBytes() []byte {
+	relayingETHHeaderIndexState, ok := t.GetValue().(*RelayingETHHeaderIndexState)
+	if !ok {
+		panic("wrong expected value type")
+	}
+	value, err := json.Marshal(relayingETHHeaderIndexState)
+	if err != nil {
+		panic("failed to marshal relaying eth header index state")
+	}
+	return value
+}
+
+func (t RelayingETHHeaderIndexObject) GetHash() common.Hash {
+	return t.relayingETHHeaderIndexHash
+}
+
+func (t RelayingETHHeaderIndexObject) GetType() int {
+	return t.objectType
+}
+
+// MarkDelete will delete an object in trie
+func (t *RelayingETHHeaderIndexObject) MarkDelete() {
+	t.deleted = true
+}
+
+// reset all shard committee value into default value
+func (t *RelayingETHHeaderIndexObject) Reset() bool {
+	t.relayingETHHeaderIndexState = NewRelayingETHHeaderIndexState()
+	return true
+}
+
+func (t RelayingETHHeaderIndexObject) IsDeleted() bool {
+	return t.deleted
+}
+
+// value is either default or nil
+func (t RelayingETHHeaderIndexObject) IsEmpty() bool {
+	temp := NewRelayingETHHeaderIndexState()
+	return reflect.DeepEqual(temp, t.relayingETHHeaderIndexState) || t.relayingETHHeaderIndexState == nil
+}
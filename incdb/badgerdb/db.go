@@ -0,0 +1,202 @@
+package badgerdb
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/dgraph-io/badger"
+	"github.com/pkg/errors"
+
+	"github.com/incognitochain/incognito-chain/incdb"
+)
+
+const (
+	// compactionDiscardRatio is the ratio of stale data in a value log file
+	// above which the file is rewritten during Compact.
+	compactionDiscardRatio = 0.5
+
+	// compactionWorkers is the number of goroutines used to flatten the LSM tree.
+	compactionWorkers = 2
+)
+
+type db struct {
+	fn    string // filename for reporting
+	badgr *badger.DB
+}
+
+func init() {
+	driver := incdb.Driver{
+		DbType: "badgerdb",
+		Open:   openDriver,
+	}
+	if err := incdb.RegisterDriver(driver); err != nil {
+		panic("failed to register db driver")
+	}
+}
+
+func openDriver(args ...interface{}) (incdb.Database, error) {
+	if len(args) != 1 {
+		return nil, errors.New("invalid arguments")
+	}
+	dbPath, ok := args[0].(string)
+	if !ok {
+		return nil, errors.New("expected db path")
+	}
+	return open(dbPath)
+}
+
+func open(dbPath string) (incdb.Database, error) {
+	// badger only creates the last element of the path, create the parents the
+	// way leveldb does
+	if err := os.MkdirAll(dbPath, 0700); err != nil {
+		return nil, errors.Wrapf(err, "os.MkdirAll %s", dbPath)
+	}
+	opts := badger.DefaultOptions(dbPath).
+		WithLogger(&logger{}).
+		WithTruncate(true)
+	badgr, err := badger.Open(opts)
+	if err != nil {
+		return nil, errors.Wrapf(err, "badger.Open %s", dbPath)
+	}
+	return &db{fn: dbPath, badgr: badgr}, nil
+}
+
+func (db *db) Close() error {
+	return errors.Wrap(db.badgr.Close(), "db.badgr.Close")
+}
+
+func (db *db) Has(key []byte) (bool, error) {
+	err := db.badgr.View(func(txn *badger.Txn) error {
+		_, err := txn.Get(key)
+		return err
+	})
+	if err == badger.ErrKeyNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (db *db) Get(key []byte) ([]byte, error) {
+	var value []byte
+	err := db.badgr.View(func(txn *badger.Txn) error {
+		item, err := txn.Get(key)
+		if err != nil {
+			return err
+		}
+		value, err = item.ValueCopy(nil)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func (db *db) Put(key, value []byte) error {
+	return db.badgr.Update(func(txn *badger.Txn) error {
+		return txn.Set(copyBytes(key), copyBytes(value))
+	})
+}
+
+func (db *db) Delete(key []byte) error {
+	return db.badgr.Update(func(txn *badger.Txn) error {
+		return txn.Delete(copyBytes(key))
+	})
+}
+
+// NewBatch creates a write-only key-value store that buffers changes to its host
+// database until a final write is called.
+func (db *db) NewBatch() incdb.Batch {
+	return &batch{
+		db: db.badgr,
+	}
+}
+
+// NewIterator creates a binary-alphabetical iterator over the entire keyspace
+// contained within the badger database.
+func (db *db) NewIterator() incdb.Iterator {
+	return newIterator(db.badgr, nil, nil)
+}
+
+// NewIteratorWithStart creates a binary-alphabetical iterator over a subset of
+// database content starting at a particular initial key (or after, if it does
+// not exist).
+func (db *db) NewIteratorWithStart(start []byte) incdb.Iterator {
+	return newIterator(db.badgr, start, nil)
+}
+
+// NewIteratorWithPrefix creates a binary-alphabetical iterator over a subset
+// of database content with a particular key prefix.
+func (db *db) NewIteratorWithPrefix(prefix []byte) incdb.Iterator {
+	return newIterator(db.badgr, prefix, bytesPrefixLimit(prefix))
+}
+
+// Stat returns a particular internal stat of the database. Supported properties
+// are "badger.size", reporting the LSM and value log sizes in bytes, and
+// "badger.tables", listing the tables of the LSM tree.
+func (db *db) Stat(property string) (string, error) {
+	switch property {
+	case "badger.size":
+		lsm, vlog := db.badgr.Size()
+		return fmt.Sprintf("lsm: %d, vlog: %d", lsm, vlog), nil
+	case "badger.tables":
+		var stats string
+		for _, table := range db.badgr.Tables(false) {
+			stats += fmt.Sprintf("level: %d, id: %d, left: %x, right: %x\n", table.Level, table.ID, table.Left, table.Right)
+		}
+		return stats, nil
+	}
+	return "", errors.Errorf("unknown property %s", property)
+}
+
+// Compact flattens the underlying data store. In essence, deleted and
+// overwritten versions are discarded, and the data is rearranged to reduce the
+// cost of operations needed to access them.
+//
+// Badger cannot compact a key range on its own, so start and limit are ignored
+// and the entire data store is flattened, after which stale value log files are
+// garbage collected.
+func (db *db) Compact(start []byte, limit []byte) error {
+	if err := db.badgr.Flatten(compactionWorkers); err != nil {
+		return errors.Wrap(err, "db.badgr.Flatten")
+	}
+	for {
+		err := db.badgr.RunValueLogGC(compactionDiscardRatio)
+		if err == badger.ErrNoRewrite || err == badger.ErrRejected {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "db.badgr.RunValueLogGC")
+		}
+	}
+}
+
+// GetPath returns the path to the database directory.
+func (db *db) GetPath() string {
+	return db.fn
+}
+
+// copyBytes returns a copy of b, badger keeps references to the slices it is given
+// until the transaction is committed.
+func copyBytes(b []byte) []byte {
+	return append([]byte{}, b...)
+}
+
+// bytesPrefixLimit returns the smallest key that is larger than every key with
+// the given prefix, or nil if there is no such key.
+func bytesPrefixLimit(prefix []byte) []byte {
+	var limit []byte
+	for i := len(prefix) - 1; i >= 0; i-- {
+		c := prefix[i]
+		if c < 0xff {
+			limit = make([]byte, i+1)
+			copy(limit, prefix)
+			limit[i] = c + 1
+			break
+		}
+	}
+	return limit
+}
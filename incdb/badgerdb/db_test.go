@@ -0,0 +1,78 @@
+package badgerdb_test
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/badgerdb"
+	"github.com/incognitochain/incognito-chain/incdb/dbtest"
+)
+
+func TestDb_Setup(t *testing.T) {
+	dbPath, err := ioutil.TempDir(os.TempDir(), "test_")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %+v", err)
+	}
+	t.Log(dbPath)
+	db, err := incdb.Open("badgerdb", dbPath)
+	if err != nil {
+		t.Fatalf("could not open db path: %s, %+v", dbPath, err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatalf("db.close %+v", err)
+	}
+	os.RemoveAll(dbPath)
+}
+
+func TestDatabaseSuite(t *testing.T) {
+	var dbPaths []string
+	defer func() {
+		for _, dbPath := range dbPaths {
+			os.RemoveAll(dbPath)
+		}
+	}()
+	dbtest.TestDatabaseSuite(t, func() incdb.Database {
+		dbPath, err := ioutil.TempDir(os.TempDir(), "test_")
+		if err != nil {
+			t.Fatalf("failed to create temp dir: %+v", err)
+		}
+		dbPaths = append(dbPaths, dbPath)
+		db, err := incdb.Open("badgerdb", dbPath)
+		if err != nil {
+			t.Fatalf("could not open db path: %s, %+v", dbPath, err)
+		}
+		return db
+	})
+}
+
+func TestBatch_WriteTooBigForOneTxn(t *testing.T) {
+	dbPath, err := ioutil.TempDir(os.TempDir(), "test_")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %+v", err)
+	}
+	defer os.RemoveAll(dbPath)
+	db, err := incdb.Open("badgerdb", dbPath)
+	if err != nil {
+		t.Fatalf("could not open db path: %s, %+v", dbPath, err)
+	}
+	defer db.Close()
+
+	// too many writes to fit into one badger transaction
+	const n = 300000
+	batch := db.NewBatch()
+	for i := 0; i < n; i++ {
+		if err := batch.Put([]byte{byte(i >> 16), byte(i >> 8), byte(i)}, []byte("value")); err != nil {
+			t.Fatalf("batch.Put %+v", err)
+		}
+	}
+	if err := batch.Write(); err != nil {
+		t.Fatalf("batch.Write %+v", err)
+	}
+	for i := 0; i < n; i++ {
+		if ok, err := db.Has([]byte{byte(i >> 16), byte(i >> 8), byte(i)}); err != nil || !ok {
+			t.Fatalf("key %v is not written, %+v", i, err)
+		}
+	}
+}
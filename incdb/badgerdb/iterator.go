@@ -0,0 +1,137 @@
+package badgerdb
+
+import (
+	"bytes"
+
+	"github.com/dgraph-io/badger"
+)
+
+// iterator iterates over the key/value pairs in [start, limit) of a badger
+// database, using a read-only transaction as a consistent snapshot. A nil start
+// is treated as a key before all keys and a nil limit as a key after all keys.
+type iterator struct {
+	txn   *badger.Txn
+	it    *badger.Iterator
+	start []byte
+	limit []byte
+
+	started  bool
+	released bool
+	key      []byte
+	value    []byte
+	err      error
+}
+
+func newIterator(badgr *badger.DB, start []byte, limit []byte) *iterator {
+	txn := badgr.NewTransaction(false)
+	return &iterator{
+		txn:   txn,
+		it:    txn.NewIterator(badger.DefaultIteratorOptions),
+		start: start,
+		limit: limit,
+	}
+}
+
+// Next moves the iterator to the next key/value pair. It returns whether the
+// iterator is exhausted.
+func (it *iterator) Next() bool {
+	if it.released || it.err != nil {
+		return false
+	}
+	if !it.started {
+		it.started = true
+		if it.start == nil {
+			it.it.Rewind()
+		} else {
+			it.it.Seek(it.start)
+		}
+	} else {
+		it.it.Next()
+	}
+	if !it.it.Valid() || !it.inRange(it.it.Item().Key()) {
+		it.key, it.value = nil, nil
+		return false
+	}
+	return it.load(it.it.Item())
+}
+
+// Last moves the iterator to the last key/value pair. It returns whether such
+// pair exist.
+func (it *iterator) Last() bool {
+	if it.released || it.err != nil {
+		return false
+	}
+	opts := badger.DefaultIteratorOptions
+	opts.Reverse = true
+	rev := it.txn.NewIterator(opts)
+	defer rev.Close()
+	if it.limit == nil {
+		rev.Rewind()
+	} else {
+		// A reverse seek lands on the largest key less than or equal to limit,
+		// the range is exclusive so skip limit itself.
+		rev.Seek(it.limit)
+		if rev.Valid() && bytes.Equal(rev.Item().Key(), it.limit) {
+			rev.Next()
+		}
+	}
+	it.started = true
+	if !rev.Valid() || !it.inRange(rev.Item().Key()) {
+		it.key, it.value = nil, nil
+		return false
+	}
+	if !it.load(rev.Item()) {
+		return false
+	}
+	// Position the forward iterator on the last pair, so the next call to Next
+	// exhausts the iterator.
+	it.it.Seek(it.key)
+	return true
+}
+
+// Error returns any accumulated error. Exhausting all the key/value pairs is
+// not considered to be an error.
+func (it *iterator) Error() error {
+	return it.err
+}
+
+// Key returns the key of the current key/value pair, or nil if done.
+func (it *iterator) Key() []byte {
+	return it.key
+}
+
+// Value returns the value of the current key/value pair, or nil if done.
+func (it *iterator) Value() []byte {
+	return it.value
+}
+
+// Release releases associated resources. Release should always succeed and can
+// be called multiple times without causing error.
+func (it *iterator) Release() {
+	if it.released {
+		return
+	}
+	it.released = true
+	it.it.Close()
+	it.txn.Discard()
+	it.key, it.value = nil, nil
+}
+
+func (it *iterator) inRange(key []byte) bool {
+	if it.start != nil && bytes.Compare(key, it.start) < 0 {
+		return false
+	}
+	return it.limit == nil || bytes.Compare(key, it.limit) < 0
+}
+
+func (it *iterator) load(item *badger.Item) bool {
+	value, err := item.ValueCopy(nil)
+	if err != nil {
+		it.err = err
+		it.key, it.value = nil, nil
+		return false
+	}
+	it.key = item.KeyCopy(nil)
+	it.value = value
+	return true
+}
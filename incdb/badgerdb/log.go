@@ -0,0 +1,32 @@
+package badgerdb
+
+import (
+	"github.com/incognitochain/incognito-chain/incdb"
+)
+
+// logger forwards badger's internal logs to the incdb logger.
+type logger struct{}
+
+func (l *logger) Errorf(format string, params ...interface{}) {
+	if incdb.Logger.Log != nil {
+		incdb.Logger.Log.Errorf(format, params...)
+	}
+}
+
+func (l *logger) Warningf(format string, params ...interface{}) {
+	if incdb.Logger.Log != nil {
+		incdb.Logger.Log.Warnf(format, params...)
+	}
+}
+
+func (l *logger) Infof(format string, params ...interface{}) {
+	if incdb.Logger.Log != nil {
+		incdb.Logger.Log.Infof(format, params...)
+	}
+}
+
+func (l *logger) Debugf(format string, params ...interface{}) {
+	if incdb.Logger.Log != nil {
+		incdb.Logger.Log.Debugf(format, params...)
+	}
+}
@@ -1,14 +1,15 @@
 package incdb
 
 import (
-	"github.com/stretchr/testify/assert"
 	"path/filepath"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func Test_RegisterDriver(t *testing.T) {
 	driver := Driver{
-		DbType: "leveldb",
+		DbType: "testdb",
 		Open: func(args ...interface{}) (databaseInterface Database, e error) {
 			return nil, nil
 		},
@@ -20,8 +21,8 @@ func Test_RegisterDriver(t *testing.T) {
 }
 
 func Test_Open(t *testing.T) {
-	_, err := Open("leveldb", filepath.Join("./", "test"))
+	_, err := Open("testdb", filepath.Join("./", "test"))
 	assert.Equal(t, nil, err)
-	_, err = Open("leveldb1", filepath.Join("./", "test"))
+	_, err = Open("testdb1", filepath.Join("./", "test"))
 	assert.NotEqual(t, nil, err)
 }
@@ -0,0 +1,15 @@
+package memorydb_test
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/incdb/dbtest"
+	"github.com/incognitochain/incognito-chain/incdb/memorydb"
+)
+
+func TestDatabaseSuite(t *testing.T) {
+	dbtest.TestDatabaseSuite(t, func() incdb.Database {
+		return memorydb.New()
+	})
+}
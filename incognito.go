@@ -14,12 +14,14 @@ import (
 	"strconv"
 
 	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
 	_ "github.com/incognitochain/incognito-chain/consensus/blsbft"
 	"github.com/incognitochain/incognito-chain/databasemp"
 	_ "github.com/incognitochain/incognito-chain/databasemp/lvdb"
 	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/badgerdb"
 	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
 	"github.com/incognitochain/incognito-chain/limits"
 	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
@@ -64,6 +66,17 @@ func getBNBRelayingChainState(bnbRelayingChainID string) (*bnbrelaying.BNBChainS
 	return bnbChainState, nil
 }
 
+// getETHRelayingSealVerifier creates the ethash engine verifying proof of work of relayed eth headers,
+// its verification caches are kept in the data dir
+func getETHRelayingSealVerifier() *ethash.Ethash {
+	return ethash.New(ethash.Config{
+		CacheDir:     filepath.Join(cfg.DataDir, "ethrelayingethash"),
+		CachesInMem:  2,
+		CachesOnDisk: 3,
+		PowMode:      ethash.ModeNormal,
+	}, nil, false)
+}
+
 // mainMaster is the real main function for Incognito network.  It is necessary to work around
 // the fact that deferred functions do not run when os.Exit() is called.  The
 // optional serverChan parameter is mainly used by the service code to be
@@ -85,14 +98,22 @@ func mainMaster(serverChan chan<- *Server) error {
 	// Show version at startup.
 	version := version()
 	Logger.log.Infof("Version %s", version)
+
+	// Load the protocol params schedule of the network, compiled-in params are validated too
+	err = blockchain.LoadNetworkParamsSchedule(activeNetParams.Params)
+	if err != nil {
+		Logger.log.Error("Invalid params schedule")
+		Logger.log.Error(err)
+		return err
+	}
 	// Return now if an interrupt signal was triggered.
 	if interruptRequested(interrupt) {
 		return nil
 	}
-	db, err := incdb.OpenMultipleDB("leveldb", filepath.Join(cfg.DataDir, cfg.DatabaseDir))
+	db, err := incdb.OpenMultipleDB(cfg.DatabaseDriver, filepath.Join(cfg.DataDir, cfg.DatabaseDir))
 	// Create db and use it.
 	if err != nil {
-		Logger.log.Error("could not open connection to leveldb")
+		Logger.log.Errorf("could not open connection to %s", cfg.DatabaseDriver)
 		Logger.log.Error(err)
 		panic(err)
 	}
@@ -154,10 +175,14 @@ func mainMaster(serverChan chan<- *Server) error {
 		panic(err)
 	}
 
+	// Create ethash engine for eth relaying
+	ethSealVerifier := getETHRelayingSealVerifier()
+	defer ethSealVerifier.Close()
+
 	// Create server and start it.
 	server := Server{}
 	server.wallet = walletObj
-	err = server.NewServer(cfg.Listener, db, dbmp, activeNetParams.Params, version, btcChain, bnbChainState, interrupt)
+	err = server.NewServer(cfg.Listener, db, dbmp, activeNetParams.Params, version, btcChain, bnbChainState, ethSealVerifier, interrupt)
 	if err != nil {
 		Logger.log.Errorf("Unable to start server on %+v", cfg.Listener)
 		Logger.log.Error(err)
@@ -36,3 +36,11 @@ const (
 const (
 	maxPendingCrossShardInPool = 2000 //per shardID
 )
+
+// Tx pool eviction
+const (
+	EvictReasonLowFeeRate = "LowFeeRate" // pool is full and a tx with higher fee per kb comes
+	EvictReasonExpired    = "Expired"    // tx stays in pool longer than its life time
+	EvictReasonReplaced   = "Replaced"   // tx is replaced by another tx using the same input coins with higher fee
+	maxEvictedTxsInPool   = 100          // number of latest evicted txs are kept for getmempoolinfo
+)
@@ -0,0 +1,10 @@
+package mempool
+
+import (
+	"github.com/incognitochain/incognito-chain/metrics"
+)
+
+var (
+	proofCacheHitMeter  = metrics.NewRegisteredMeter("mempool/proofcache/hit", nil)
+	proofCacheMissMeter = metrics.NewRegisteredMeter("mempool/proofcache/miss", nil)
+)
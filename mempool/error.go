@@ -45,6 +45,8 @@ const (
 	ValidateAggSignatureForCrossShardBlockError
 	DuplicateSerialNumbersHashError
 	CouldNotGetExchangeRateError
+	RejectSenderQuotaError
+	RejectMetadataTypeQuotaError
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -87,6 +89,8 @@ var ErrCodeMessage = map[int]struct {
 	CouldNotGetExchangeRateError:                {-1032, "Could not get the exchange rate error"},
 	RejectSanityTxLocktime:                      {-1033, "Wrong tx locktime"},
 	RejectMetadataWithBlockchainTx:              {-1034, "Reject invalid metadata with blockchain"},
+	RejectSenderQuotaError:                      {-1035, "Reject tx over quota of sender in pool"},
+	RejectMetadataTypeQuotaError:                {-1036, "Reject tx over quota of metadata type in pool"},
 }
 
 type MempoolTxError struct {
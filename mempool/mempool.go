@@ -6,6 +6,7 @@ import (
 	"github.com/incognitochain/incognito-chain/incdb"
 	"math"
 	"reflect"
+	"runtime"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -28,6 +29,7 @@ const (
 	defaultRoleInCommittees  = -1
 	defaultIsTest            = false
 	defaultReplaceFeeRatio   = 1.1
+	defaultProofCacheSize    = 20000
 )
 
 // config is a descriptor containing the memory pool configuration.
@@ -39,9 +41,13 @@ type Config struct {
 	FeeEstimator      map[byte]*FeeEstimator // FeeEstimatator provides a feeEstimator. If it is not nil, the mempool records all new transactions it observes into the feeEstimator.
 	TxLifeTime        uint                   // Transaction life time in pool
 	MaxTx             uint64                 //Max transaction pool may have
+	MaxTxPerSender    uint64                 //Max transaction of a sender pool may have, 0 is unlimited (privacy txs are not limited, see getSenderKey)
+	MaxTxPerMetaType  uint64                 //Max transaction of each metadata type pool may have, 0 is unlimited
 	IsLoadFromMempool bool                   //Reset mempool database when run node
 	PersistMempool    bool
 	RelayShards       []byte
+	ProofCache        *ProofCache // txs already verified by themselves, shared with the temp pool verifying new blocks
+	MaxProofWorkers   int         // Max number of txs verified by themselves at the same time, 0 is the number of CPUs
 	// UserKeyset            *incognitokey.KeySet
 	PubSubManager         *pubsub.PubSubManager
 	RoleInCommitteesEvent pubsub.EventChannel
@@ -52,6 +58,8 @@ type TxDesc struct {
 	Desc            metadata.TxDesc // transaction details
 	StartTime       time.Time       //Unix Time that transaction enter mempool
 	IsFowardMessage bool
+	FeePerKB        uint64 // fee in native token per kb, used to order txs for eviction
+	queueIndex      int    // index of tx in fee rate queue
 }
 
 type TxPool struct {
@@ -61,6 +69,11 @@ type TxPool struct {
 	pool                      map[common.Hash]*TxDesc
 	poolSerialNumbersHashList map[common.Hash][]common.Hash // [txHash] -> list hash serialNumbers of input coin
 	poolSerialNumberHash      map[common.Hash]common.Hash   // [hash from list of serialNumber] -> txHash
+	feeRateQueue              txFeeRateQueue                // all txs in pool ordered by fee per kb
+	poolTxsBySender           map[string]uint64             // [sender key] -> number of txs in pool
+	poolTxsByMetaType         map[int]uint64                // [metadata type] -> number of txs in pool
+	evictedTxs                []EvictedTx                   // latest evicted txs
+	evictionCount             map[string]uint64             // [eviction reason] -> number of evicted txs
 	mtx                       sync.RWMutex
 	poolCandidate             map[common.Hash]string //Candidate List in mempool
 	candidateMtx              sync.RWMutex
@@ -74,6 +87,7 @@ type TxPool struct {
 	IsBlockGenStarted         bool
 	IsUnlockMempool           bool
 	ReplaceFeeRatio           float64
+	proofWorkers              chan struct{} // bounds the number of txs verified by themselves outside of mtx
 
 	//for testing
 	IsTest       bool
@@ -88,9 +102,21 @@ func (tp *TxPool) Init(cfg *Config) {
 	tp.pool = make(map[common.Hash]*TxDesc)
 	tp.poolSerialNumbersHashList = make(map[common.Hash][]common.Hash)
 	tp.poolSerialNumberHash = make(map[common.Hash]common.Hash)
+	tp.feeRateQueue = txFeeRateQueue{}
+	tp.poolTxsBySender = make(map[string]uint64)
+	tp.poolTxsByMetaType = make(map[int]uint64)
+	tp.evictedTxs = []EvictedTx{}
+	tp.evictionCount = make(map[string]uint64)
 	tp.poolCandidate = make(map[common.Hash]string)
 	tp.poolRequestStopStaking = make(map[common.Hash]string)
 	tp.duplicateTxs = make(map[common.Hash]uint64)
+	if tp.config.ProofCache == nil {
+		tp.config.ProofCache, _ = NewProofCache(defaultProofCacheSize)
+	}
+	if tp.config.MaxProofWorkers <= 0 {
+		tp.config.MaxProofWorkers = runtime.NumCPU()
+	}
+	tp.proofWorkers = make(chan struct{}, tp.config.MaxProofWorkers)
 	_, subChanRole, _ := tp.config.PubSubManager.RegisterNewSubscriber(pubsub.ShardRoleTopic)
 	tp.config.RoleInCommitteesEvent = subChanRole
 	tp.ScanTime = defaultScanTime
@@ -178,8 +204,10 @@ func (tp *TxPool) MonitorPool() {
 			}
 		}
 		Logger.log.Infof("MonitorPool: End to collect timeout ttl tx - Count of txsToBeRemoved=%+v", len(txsToBeRemoved))
+		evictedTxs := []EvictedTx{}
 		for _, txDesc := range txsToBeRemoved {
 			txHash := *txDesc.Desc.Tx.Hash()
+			evictedTxs = append(evictedTxs, tp.recordEvictedTx(txDesc, EvictReasonExpired))
 			tp.removeTx(txDesc.Desc.Tx)
 			tp.TriggerCRemoveTxs(txDesc.Desc.Tx)
 			tp.removeCandidateByTxHash(txHash)
@@ -190,6 +218,9 @@ func (tp *TxPool) MonitorPool() {
 				Logger.log.Error(err)
 			}
 		}
+		if len(evictedTxs) > 0 {
+			go tp.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.MempoolInfoTopic, &MempoolInfo{ListTxs: tp.listTxs(), EvictedTxs: evictedTxs}))
+		}
 		tp.mtx.Unlock()
 	}
 }
@@ -209,8 +240,6 @@ func (tp *TxPool) MonitorPool() {
 // #2: default nil, contain input coins hash, which are used for creating this tx
 func (tp *TxPool) MaybeAcceptTransaction(tx metadata.Transaction, beaconHeight int64) (*common.Hash, *TxDesc, error) {
 	//beaconView.BeaconHeight
-	tp.mtx.Lock()
-	defer tp.mtx.Unlock()
 	if tp.IsTest {
 		return &common.Hash{}, &TxDesc{}, nil
 	}
@@ -225,15 +254,26 @@ func (tp *TxPool) MaybeAcceptTransaction(tx metadata.Transaction, beaconHeight i
 	}
 	beaconView := tp.config.BlockChain.BeaconChain.GetFinalView().(*blockchain.BeaconBestState)
 	shardView := tp.config.BlockChain.ShardChain[senderShardID].GetBestView().(*blockchain.ShardBestState)
+	// proof is verified without holding the pool lock, validateTransaction then finds it in proof cache
+	err := tp.verifyTransactionByItself(shardView, beaconView, tx, beaconHeight)
+	if err != nil {
+		Logger.log.Error(err)
+		return nil, nil, err
+	}
+	tp.mtx.Lock()
+	defer tp.mtx.Unlock()
 	//==========
-	if uint64(len(tp.pool)) >= tp.config.MaxTx {
-		return nil, nil, NewMempoolTxError(MaxPoolSizeError, errors.New("Pool reach max number of transaction"))
+	err = tp.checkPoolQuotas(tx, calFeePerKB(beaconView, tx, beaconHeight))
+	if err != nil {
+		Logger.log.Error(err)
+		return nil, nil, err
 	}
 	hash, txDesc, err := tp.maybeAcceptTransaction(shardView, beaconView, tx, tp.config.PersistMempool, true, beaconHeight)
 	//==========
 	if err != nil {
 		Logger.log.Error(err)
 	} else {
+		evictedTxs := tp.evictLowFeeRateTxs()
 		if tp.IsBlockGenStarted {
 			if tp.IsUnlockMempool {
 				go func(tx metadata.Transaction) {
@@ -242,11 +282,36 @@ func (tp *TxPool) MaybeAcceptTransaction(tx metadata.Transaction, beaconHeight i
 			}
 		}
 		// Publish Message
-		go tp.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.MempoolInfoTopic, tp.listTxs()))
+		go tp.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.MempoolInfoTopic, &MempoolInfo{ListTxs: tp.listTxs(), EvictedTxs: evictedTxs}))
 	}
 	return hash, txDesc, err
 }
 
+// verifyTransactionByItself validates sanity data, proof, signature and metadata of a new tx,
+// at most MaxProofWorkers txs are verified at the same time.
+// A verified tx is added to proof cache with the transaction state root of shardView and the feature state root of beaconView.
+// This function is safe for concurrent access.
+func (tp *TxPool) verifyTransactionByItself(shardView *blockchain.ShardBestState, beaconView *blockchain.BeaconBestState, tx metadata.Transaction, beaconHeight int64) error {
+	err := tp.validateSanityData(shardView, beaconView, tx, beaconHeight, true)
+	if err != nil {
+		return err
+	}
+	stateRoot := shardView.TransactionStateDBRootHash
+	featureStateRoot := beaconView.FeatureStateDBRootHash
+	if tp.config.ProofCache.IsVerified(tx, stateRoot, featureStateRoot, true) {
+		return nil
+	}
+	tp.proofWorkers <- struct{}{}
+	defer func() { <-tp.proofWorkers }()
+	shardID := common.GetShardIDFromLastByte(tx.GetSenderAddrLastByte())
+	validated, errValidateTxByItself := tx.ValidateTxByItself(tx.IsPrivacy(), shardView.GetCopiedTransactionStateDB(), beaconView.GetBeaconFeatureStateDB(), tp.config.BlockChain, shardID, true, nil, nil)
+	if !validated {
+		return NewMempoolTxError(RejectInvalidTx, errValidateTxByItself)
+	}
+	tp.config.ProofCache.Add(tx, stateRoot, featureStateRoot, true)
+	return nil
+}
+
 // This function is safe for concurrent access.
 func (tp *TxPool) MaybeAcceptTransactionForBlockProducing(tx metadata.Transaction, beaconHeight int64, shardView *blockchain.ShardBestState) (*metadata.TxDesc, error) {
 	tp.mtx.Lock()
@@ -271,13 +336,27 @@ func (tp *TxPool) MaybeAcceptBatchTransactionForBlockProducing(shardID byte, txs
 func (tp *TxPool) maybeAcceptBatchTransaction(shardView *blockchain.ShardBestState, beaconView *blockchain.BeaconBestState, shardID byte, txs []metadata.Transaction, beaconHeight int64) ([]common.Hash, []*metadata.TxDesc, error) {
 	txDescs := []*metadata.TxDesc{}
 	txHashes := []common.Hash{}
-	batch := transaction.NewBatchTransaction(txs)
-	ok, err, _ := batch.Validate(shardView.GetCopiedTransactionStateDB(), beaconView.GetBeaconFeatureStateDB())
-	if err != nil {
-		return nil, nil, err
+	// txs verified by themselves against the same state, when they entered mempool or a previous verification of this block, are not verified again
+	stateRoot := shardView.TransactionStateDBRootHash
+	featureStateRoot := beaconView.FeatureStateDBRootHash
+	unverifiedTxs := []metadata.Transaction{}
+	for _, tx := range txs {
+		if !tp.config.ProofCache.IsVerified(tx, stateRoot, featureStateRoot, false) {
+			unverifiedTxs = append(unverifiedTxs, tx)
+		}
 	}
-	if !ok {
-		return nil, nil, fmt.Errorf("Verify Batch Transaction failed %+v", txs)
+	if len(unverifiedTxs) > 0 {
+		batch := transaction.NewBatchTransaction(unverifiedTxs)
+		ok, err, _ := batch.Validate(shardView.GetCopiedTransactionStateDB(), beaconView.GetBeaconFeatureStateDB())
+		if err != nil {
+			return nil, nil, err
+		}
+		if !ok {
+			return nil, nil, fmt.Errorf("Verify Batch Transaction failed %+v", unverifiedTxs)
+		}
+		for _, tx := range unverifiedTxs {
+			tp.config.ProofCache.Add(tx, stateRoot, featureStateRoot, false)
+		}
 	}
 	for _, tx := range txs {
 		// validate tx
@@ -290,6 +369,7 @@ func (tp *TxPool) maybeAcceptBatchTransaction(shardView *blockchain.ShardBestSta
 		txFee := tx.GetTxFee()
 		txFeeToken := tx.GetTxFeeToken()
 		txD := createTxDescMempool(tx, bestHeight, txFee, txFeeToken)
+		txD.FeePerKB = calFeePerKB(beaconView, tx, beaconHeight)
 		err = tp.addTx(txD, false)
 		if err != nil {
 			return nil, nil, err
@@ -317,6 +397,7 @@ func (tp *TxPool) maybeAcceptTransaction(shardView *blockchain.ShardBestState, b
 	txFee := tx.GetTxFee()
 	txFeeToken := tx.GetTxFeeToken()
 	txD := createTxDescMempool(tx, bestHeight, txFee, txFeeToken)
+	txD.FeePerKB = calFeePerKB(beaconView, tx, beaconHeight)
 	err = tp.addTx(txD, isStore)
 	if err != nil {
 		return nil, nil, err
@@ -442,33 +523,9 @@ func (tp *TxPool) validateTransaction(shardView *blockchain.ShardBestState, beac
 	txHash := tx.Hash()
 	shardID := common.GetShardIDFromLastByte(tx.GetSenderAddrLastByte())
 	// Condition 1: sanity data
-	validated := false
-	if !isNewTransaction {
-		// need to use beacon height from
-		validated, err = tx.ValidateSanityData(tp.config.BlockChain, shardView, beaconView, uint64(beaconHeight))
-	} else {
-		validated, err = tx.ValidateSanityData(tp.config.BlockChain, shardView, beaconView, 0)
-	}
-	if !validated {
-		// try parse to TransactionError
-		sanityError, ok := err.(*transaction.TransactionError)
-		if ok {
-			switch sanityError.Code {
-			case transaction.ErrCodeMessage[transaction.RejectInvalidLockTime].Code:
-				{
-					return NewMempoolTxError(RejectSanityTxLocktime, fmt.Errorf("transaction's sansity %v is error %v", txHash.String(), sanityError))
-				}
-			case transaction.ErrCodeMessage[transaction.RejectTxType].Code:
-				{
-					return NewMempoolTxError(RejectInvalidTxType, fmt.Errorf("transaction's sansity %v is error %v", txHash.String(), sanityError))
-				}
-			case transaction.ErrCodeMessage[transaction.RejectTxVersion].Code:
-				{
-					return NewMempoolTxError(RejectVersion, fmt.Errorf("transaction's sansity %v is error %v", txHash.String(), sanityError))
-				}
-			}
-		}
-		return NewMempoolTxError(RejectSanityTx, fmt.Errorf("transaction's sansity %v is error %v", txHash.String(), err))
+	err = tp.validateSanityData(shardView, beaconView, tx, beaconHeight, isNewTransaction)
+	if err != nil {
+		return err
 	}
 
 	// Condition 2: Don't accept the transaction if it already exists in the pool.
@@ -503,11 +560,13 @@ func (tp *TxPool) validateTransaction(shardView *blockchain.ShardBestState, beac
 		}
 	}
 	// Condition 6: ValidateTransaction tx by it self
-	if !isBatch {
+	// skipped if tx was already verified against the same transaction and feature state
+	if !isBatch && !tp.config.ProofCache.IsVerified(tx, shardView.TransactionStateDBRootHash, beaconView.FeatureStateDBRootHash, isNewTransaction) {
 		validated, errValidateTxByItself := tx.ValidateTxByItself(tx.IsPrivacy(), shardView.GetCopiedTransactionStateDB(), beaconView.GetBeaconFeatureStateDB(), tp.config.BlockChain, shardID, isNewTransaction, nil, nil)
 		if !validated {
 			return NewMempoolTxError(RejectInvalidTx, errValidateTxByItself)
 		}
+		tp.config.ProofCache.Add(tx, shardView.TransactionStateDBRootHash, beaconView.FeatureStateDBRootHash, isNewTransaction)
 	}
 	// Condition 7: validate tx with data of blockchain
 	err = tx.ValidateTxWithBlockChain(tp.config.BlockChain, shardView, beaconView, shardID, shardView.GetCopiedTransactionStateDB())
@@ -563,6 +622,41 @@ func (tp *TxPool) validateTransaction(shardView *blockchain.ShardBestState, beac
 	return nil
 }
 
+// validateSanityData checks sanity data of tx, new txs are checked with the latest beacon height
+func (tp *TxPool) validateSanityData(shardView *blockchain.ShardBestState, beaconView *blockchain.BeaconBestState, tx metadata.Transaction, beaconHeight int64, isNewTransaction bool) error {
+	txHash := tx.Hash()
+	validated := false
+	var err error
+	if !isNewTransaction {
+		// need to use beacon height from
+		validated, err = tx.ValidateSanityData(tp.config.BlockChain, shardView, beaconView, uint64(beaconHeight))
+	} else {
+		validated, err = tx.ValidateSanityData(tp.config.BlockChain, shardView, beaconView, 0)
+	}
+	if !validated {
+		// try parse to TransactionError
+		sanityError, ok := err.(*transaction.TransactionError)
+		if ok {
+			switch sanityError.Code {
+			case transaction.ErrCodeMessage[transaction.RejectInvalidLockTime].Code:
+				{
+					return NewMempoolTxError(RejectSanityTxLocktime, fmt.Errorf("transaction's sansity %v is error %v", txHash.String(), sanityError))
+				}
+			case transaction.ErrCodeMessage[transaction.RejectTxType].Code:
+				{
+					return NewMempoolTxError(RejectInvalidTxType, fmt.Errorf("transaction's sansity %v is error %v", txHash.String(), sanityError))
+				}
+			case transaction.ErrCodeMessage[transaction.RejectTxVersion].Code:
+				{
+					return NewMempoolTxError(RejectVersion, fmt.Errorf("transaction's sansity %v is error %v", txHash.String(), sanityError))
+				}
+			}
+		}
+		return NewMempoolTxError(RejectSanityTx, fmt.Errorf("transaction's sansity %v is error %v", txHash.String(), err))
+	}
+	return nil
+}
+
 // check transaction in pool
 func (tp *TxPool) isTxInPool(hash *common.Hash) bool {
 	if _, exists := tp.pool[*hash]; exists {
@@ -616,6 +710,7 @@ func (tp *TxPool) validateTransactionReplacement(tx metadata.Transaction) (error
 			}
 			if isReplaced {
 				txToBeReplaced := txDescToBeReplaced.Desc.Tx
+				tp.recordEvictedTx(txDescToBeReplaced, EvictReasonReplaced)
 				tp.removeTx(txToBeReplaced)
 				tp.TriggerCRemoveTxs(txToBeReplaced)
 				//tp.removeRequestStopStakingByTxHash(*txToBeReplaced.Hash())
@@ -662,6 +757,11 @@ func (tp *TxPool) addTx(txD *TxDesc, isStore bool) error {
 		}
 	}
 	tp.pool[*txHash] = txD
+	tp.feeRateQueue.add(txD)
+	tp.poolTxsBySender[getSenderKey(tx)]++
+	if tx.GetMetadata() != nil {
+		tp.poolTxsByMetaType[tx.GetMetadata().GetType()]++
+	}
 	var serialNumberList []common.Hash
 	serialNumberList = append(serialNumberList, txD.Desc.Tx.ListSerialNumbersHashH()...)
 	serialNumberListHash := common.HashArrayOfHashArray(serialNumberList)
@@ -771,7 +871,7 @@ func (tp *TxPool) RemoveTx(txs []metadata.Transaction, isInBlock bool) {
 func (tp *TxPool) removeTx(tx metadata.Transaction) {
 	//Logger.log.Infof((*tx).Hash().String())
 	if _, exists := tp.pool[*tx.Hash()]; exists {
-		delete(tp.pool, *tx.Hash())
+		tp.deleteTxDesc(*tx.Hash())
 		atomic.StoreInt64(&tp.lastUpdated, time.Now().Unix())
 	}
 	if _, exists := tp.poolSerialNumbersHashList[*tx.Hash()]; exists {
@@ -784,7 +884,7 @@ func (tp *TxPool) removeTx(tx metadata.Transaction) {
 		// Using the same list serial number to delete new transaction out of pool
 		// this new transaction maybe not exist
 		if _, exists := tp.pool[hash]; exists {
-			delete(tp.pool, hash)
+			tp.deleteTxDesc(hash)
 			atomic.StoreInt64(&tp.lastUpdated, time.Now().Unix())
 		}
 		if _, exists := tp.poolSerialNumbersHashList[hash]; exists {
@@ -794,6 +894,99 @@ func (tp *TxPool) removeTx(tx metadata.Transaction) {
 	tp.removeRequestStopStakingByTxHash(*tx.Hash())
 }
 
+// deleteTxDesc - delete tx desc out of pool, fee rate queue and quota counters
+func (tp *TxPool) deleteTxDesc(txHash common.Hash) {
+	txDesc, exists := tp.pool[txHash]
+	if !exists {
+		return
+	}
+	delete(tp.pool, txHash)
+	tp.feeRateQueue.remove(txDesc)
+	senderKey := getSenderKey(txDesc.Desc.Tx)
+	if tp.poolTxsBySender[senderKey] <= 1 {
+		delete(tp.poolTxsBySender, senderKey)
+	} else {
+		tp.poolTxsBySender[senderKey]--
+	}
+	if txDesc.Desc.Tx.GetMetadata() != nil {
+		metaType := txDesc.Desc.Tx.GetMetadata().GetType()
+		if tp.poolTxsByMetaType[metaType] <= 1 {
+			delete(tp.poolTxsByMetaType, metaType)
+		} else {
+			tp.poolTxsByMetaType[metaType]--
+		}
+	}
+}
+
+/*
+	Check a new tx with limits of pool before validating it:
+		+ Number of txs of the same sender
+		+ Number of txs of the same metadata type
+		+ Pool is full: fee per kb of new tx must be higher than the lowest one in pool,
+		then the lowest one will be evicted to make room for new tx
+*/
+func (tp *TxPool) checkPoolQuotas(tx metadata.Transaction, feePerKB uint64) error {
+	if tp.config.MaxTxPerSender > 0 && tp.poolTxsBySender[getSenderKey(tx)] >= tp.config.MaxTxPerSender {
+		return NewMempoolTxError(RejectSenderQuotaError, fmt.Errorf("Sender of tx %+v already has %+v txs in pool", tx.Hash().String(), tp.config.MaxTxPerSender))
+	}
+	if tp.config.MaxTxPerMetaType > 0 && tx.GetMetadata() != nil {
+		metaType := tx.GetMetadata().GetType()
+		if tp.poolTxsByMetaType[metaType] >= tp.config.MaxTxPerMetaType {
+			return NewMempoolTxError(RejectMetadataTypeQuotaError, fmt.Errorf("Pool already has %+v txs with metadata type %+v", tp.config.MaxTxPerMetaType, metaType))
+		}
+	}
+	if uint64(len(tp.pool)) >= tp.config.MaxTx {
+		lowest := tp.feeRateQueue.lowest()
+		if lowest == nil {
+			return NewMempoolTxError(MaxPoolSizeError, errors.New("Pool reach max number of transaction"))
+		}
+		if feePerKB <= lowest.FeePerKB {
+			return NewMempoolTxError(MaxPoolSizeError, fmt.Errorf("Pool reach max number of transaction, expect fee per kb to be greater than %+v but get %+v", lowest.FeePerKB, feePerKB))
+		}
+	}
+	return nil
+}
+
+// evictLowFeeRateTxs - remove txs with the lowest fee per kb out of pool until pool size is under MaxTx
+func (tp *TxPool) evictLowFeeRateTxs() []EvictedTx {
+	evictedTxs := []EvictedTx{}
+	for uint64(len(tp.pool)) > tp.config.MaxTx {
+		txDesc := tp.feeRateQueue.lowest()
+		if txDesc == nil {
+			break
+		}
+		tx := txDesc.Desc.Tx
+		Logger.log.Infof("Evict tx %+v with fee per kb %+v out of full pool", tx.Hash().String(), txDesc.FeePerKB)
+		evictedTxs = append(evictedTxs, tp.recordEvictedTx(txDesc, EvictReasonLowFeeRate))
+		if tp.config.PersistMempool {
+			err := tp.removeTransactionFromDatabaseMP(tx.Hash())
+			if err != nil {
+				Logger.log.Error(err)
+			}
+		}
+		tp.removeTx(tx)
+		tp.TriggerCRemoveTxs(tx)
+		tp.removeCandidateByTxHash(*tx.Hash())
+	}
+	return evictedTxs
+}
+
+// recordEvictedTx - keep info of an evicted tx for getmempoolinfo, only the latest maxEvictedTxsInPool txs are kept
+func (tp *TxPool) recordEvictedTx(txDesc *TxDesc, reason string) EvictedTx {
+	evictedTx := EvictedTx{
+		TxID:        txDesc.Desc.Tx.Hash().String(),
+		Reason:      reason,
+		FeePerKB:    txDesc.FeePerKB,
+		EvictedTime: time.Now().Unix(),
+	}
+	tp.evictedTxs = append(tp.evictedTxs, evictedTx)
+	if len(tp.evictedTxs) > maxEvictedTxsInPool {
+		tp.evictedTxs = tp.evictedTxs[len(tp.evictedTxs)-maxEvictedTxsInPool:]
+	}
+	tp.evictionCount[reason]++
+	return evictedTx
+}
+
 func (tp *TxPool) addCandidateToList(txHash common.Hash, candidate string) {
 	tp.candidateMtx.Lock()
 	defer tp.candidateMtx.Unlock()
@@ -958,6 +1151,35 @@ func (tp *TxPool) MaxFee() uint64 {
 	return fee
 }
 
+// MinFeePerKB - return the lowest fee per kb of txs in pool
+func (tp *TxPool) MinFeePerKB() uint64 {
+	tp.mtx.RLock()
+	defer tp.mtx.RUnlock()
+	lowest := tp.feeRateQueue.lowest()
+	if lowest == nil {
+		return 0
+	}
+	return lowest.FeePerKB
+}
+
+// MaxTx - return max number of txs in pool
+func (tp *TxPool) MaxTx() uint64 {
+	return tp.config.MaxTx
+}
+
+// EvictionInfo - return number of evicted txs by reason and the latest evicted txs
+func (tp *TxPool) EvictionInfo() (map[string]uint64, []EvictedTx) {
+	tp.mtx.RLock()
+	defer tp.mtx.RUnlock()
+	evictionCount := make(map[string]uint64)
+	for reason, count := range tp.evictionCount {
+		evictionCount[reason] = count
+	}
+	evictedTxs := make([]EvictedTx, len(tp.evictedTxs))
+	copy(evictedTxs, tp.evictedTxs)
+	return evictionCount, evictedTxs
+}
+
 /*
 // LastUpdated returns the last time a transaction was added to or
 	// removed from the source pool.
@@ -1036,6 +1258,9 @@ func (tp *TxPool) EmptyPool() bool {
 	tp.pool = make(map[common.Hash]*TxDesc)
 	tp.poolSerialNumbersHashList = make(map[common.Hash][]common.Hash)
 	tp.poolSerialNumberHash = make(map[common.Hash]common.Hash)
+	tp.feeRateQueue = txFeeRateQueue{}
+	tp.poolTxsBySender = make(map[string]uint64)
+	tp.poolTxsByMetaType = make(map[int]uint64)
 	tp.poolCandidate = make(map[common.Hash]string)
 	tp.poolRequestStopStaking = make(map[common.Hash]string)
 	if len(tp.pool) == 0 && len(tp.poolSerialNumbersHashList) == 0 && len(tp.poolSerialNumberHash) == 0 && len(tp.poolCandidate) == 0 && len(tp.poolRequestStopStaking) == 0 {
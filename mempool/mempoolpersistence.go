@@ -132,6 +132,7 @@ func (tp *TxPool) loadDatabaseMP() ([]TxDesc, error) {
 			continue
 		}
 
+		txDesc.FeePerKB = calFeePerKB(beaconView, txDesc.Desc.Tx, int64(beaconView.BeaconHeight))
 		err = tp.addTx(txDesc, false)
 		if err != nil {
 			Logger.log.Error(err)
@@ -0,0 +1,84 @@
+package mempool
+
+import (
+	"encoding/json"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+// proofCacheKey ties a verified tx to the shard transaction statedb root and the beacon feature statedb root
+// it was verified against: commitments, serial number derivators... and bridge tokens read during verification
+// depend on these roots
+type proofCacheKey struct {
+	txHash           common.Hash
+	stateRoot        common.Hash
+	featureStateRoot common.Hash
+}
+
+type proofCacheEntry struct {
+	digest        common.Hash // hash of the whole tx, tx hash does not cover signatures
+	verifiedAsNew bool
+}
+
+// ProofCache remembers txs whose proof, signature and metadata were already verified by themselves,
+// it is shared by the mempool and the temp pool used to verify new shard blocks so a tx verified when it
+// entered the mempool is not verified again when it comes in a block built on the same view.
+// A tx verified as a new tx, which also checks that its output serial number derivators do not exist yet,
+// is valid for txs from blocks too but not the opposite.
+type ProofCache struct {
+	cache *lru.Cache
+}
+
+func NewProofCache(size int) (*ProofCache, error) {
+	cache, err := lru.New(size)
+	if err != nil {
+		return nil, err
+	}
+	return &ProofCache{cache: cache}, nil
+}
+
+// Add records tx as verified against stateRoot and featureStateRoot
+func (proofCache *ProofCache) Add(tx metadata.Transaction, stateRoot common.Hash, featureStateRoot common.Hash, isNewTransaction bool) {
+	digest, err := txDigest(tx)
+	if err != nil {
+		return
+	}
+	key := proofCacheKey{txHash: *tx.Hash(), stateRoot: stateRoot, featureStateRoot: featureStateRoot}
+	if value, ok := proofCache.cache.Peek(key); ok {
+		entry := value.(proofCacheEntry)
+		if entry.digest == digest && entry.verifiedAsNew {
+			return
+		}
+	}
+	proofCache.cache.Add(key, proofCacheEntry{digest: digest, verifiedAsNew: isNewTransaction})
+}
+
+// IsVerified returns true if the same tx was verified against stateRoot and featureStateRoot,
+// hit and miss are recorded in metrics
+func (proofCache *ProofCache) IsVerified(tx metadata.Transaction, stateRoot common.Hash, featureStateRoot common.Hash, isNewTransaction bool) bool {
+	if value, ok := proofCache.cache.Get(proofCacheKey{txHash: *tx.Hash(), stateRoot: stateRoot, featureStateRoot: featureStateRoot}); ok {
+		entry := value.(proofCacheEntry)
+		digest, err := txDigest(tx)
+		if err == nil && entry.digest == digest && (entry.verifiedAsNew || !isNewTransaction) {
+			proofCacheHitMeter.Mark(1)
+			return true
+		}
+	}
+	proofCacheMissMeter.Mark(1)
+	return false
+}
+
+// Len returns the number of verified txs in cache
+func (proofCache *ProofCache) Len() int {
+	return proofCache.cache.Len()
+}
+
+func txDigest(tx metadata.Transaction) (common.Hash, error) {
+	txBytes, err := json.Marshal(tx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.HashH(txBytes), nil
+}
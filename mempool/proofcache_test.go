@@ -0,0 +1,42 @@
+package mempool
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProofCache(t *testing.T) {
+	proofCache, err := NewProofCache(2)
+	assert.Nil(t, err)
+	tx := &transaction.Tx{Version: 1, Fee: 10, LockTime: 1, Sig: []byte{1, 2, 3}}
+	root := common.HashH([]byte("root"))
+	otherRoot := common.HashH([]byte("other root"))
+	featureRoot := common.HashH([]byte("feature root"))
+	otherFeatureRoot := common.HashH([]byte("other feature root"))
+
+	assert.False(t, proofCache.IsVerified(tx, root, featureRoot, false))
+	proofCache.Add(tx, root, featureRoot, false)
+	assert.True(t, proofCache.IsVerified(tx, root, featureRoot, false))
+	assert.False(t, proofCache.IsVerified(tx, root, featureRoot, true), "tx verified from a block is not verified as a new tx")
+	assert.False(t, proofCache.IsVerified(tx, otherRoot, featureRoot, false), "tx is verified against another state")
+	assert.False(t, proofCache.IsVerified(tx, root, otherFeatureRoot, false), "tx is verified against another beacon feature state")
+
+	proofCache.Add(tx, root, featureRoot, true)
+	assert.True(t, proofCache.IsVerified(tx, root, featureRoot, true))
+	proofCache.Add(tx, root, featureRoot, false)
+	assert.True(t, proofCache.IsVerified(tx, root, featureRoot, true), "verified as new tx is kept")
+	assert.True(t, proofCache.IsVerified(tx, root, featureRoot, false))
+
+	// same hash, signature is not covered by tx hash
+	forgedTx := &transaction.Tx{Version: 1, Fee: 10, LockTime: 1, Sig: []byte{3, 2, 1}}
+	assert.Equal(t, *tx.Hash(), *forgedTx.Hash())
+	assert.False(t, proofCache.IsVerified(forgedTx, root, featureRoot, false))
+
+	proofCache.Add(tx, otherRoot, featureRoot, false)
+	proofCache.Add(&transaction.Tx{Version: 1, Fee: 20}, root, featureRoot, false)
+	assert.Equal(t, 2, proofCache.Len())
+	assert.False(t, proofCache.IsVerified(tx, root, featureRoot, false), "oldest tx is evicted")
+}
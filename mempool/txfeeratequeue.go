@@ -0,0 +1,107 @@
+package mempool
+
+import (
+	"container/heap"
+	"encoding/hex"
+	"math"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+// EvictedTx - a tx which is removed out of pool before getting into a block
+type EvictedTx struct {
+	TxID        string `json:"TxID"`
+	Reason      string `json:"Reason"`
+	FeePerKB    uint64 `json:"FeePerKB"`
+	EvictedTime int64  `json:"EvictedTime"`
+}
+
+// MempoolInfo - message is published into pubsub.MempoolInfoTopic whenever txs enter or leave pool
+type MempoolInfo struct {
+	ListTxs    []string    `json:"ListTxs"`
+	EvictedTxs []EvictedTx `json:"EvictedTxs"`
+}
+
+// txFeeRateQueue - min heap of all tx descs in pool ordered by fee per kb,
+// the head of queue is the first tx to be evicted when pool is full
+type txFeeRateQueue []*TxDesc
+
+func (q txFeeRateQueue) Len() int { return len(q) }
+
+func (q txFeeRateQueue) Less(i, j int) bool {
+	if q[i].FeePerKB == q[j].FeePerKB {
+		// with the same fee rate, the newer tx is evicted first
+		return q[i].StartTime.After(q[j].StartTime)
+	}
+	return q[i].FeePerKB < q[j].FeePerKB
+}
+
+func (q txFeeRateQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].queueIndex = i
+	q[j].queueIndex = j
+}
+
+func (q *txFeeRateQueue) Push(x interface{}) {
+	txDesc := x.(*TxDesc)
+	txDesc.queueIndex = len(*q)
+	*q = append(*q, txDesc)
+}
+
+func (q *txFeeRateQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	txDesc := old[n-1]
+	old[n-1] = nil
+	txDesc.queueIndex = -1
+	*q = old[:n-1]
+	return txDesc
+}
+
+// lowest - return tx desc with the lowest fee per kb in queue, nil if queue is empty
+func (q txFeeRateQueue) lowest() *TxDesc {
+	if len(q) == 0 {
+		return nil
+	}
+	return q[0]
+}
+
+func (q *txFeeRateQueue) add(txDesc *TxDesc) {
+	heap.Push(q, txDesc)
+}
+
+func (q *txFeeRateQueue) remove(txDesc *TxDesc) {
+	if txDesc.queueIndex < 0 || txDesc.queueIndex >= len(*q) || (*q)[txDesc.queueIndex] != txDesc {
+		return
+	}
+	heap.Remove(q, txDesc.queueIndex)
+}
+
+// calFeePerKB - return fee of tx in native token per kb of tx size,
+// fee in privacy token is converted into native token with pde exchange rate if possible
+func calFeePerKB(beaconView *blockchain.BeaconBestState, tx metadata.Transaction, beaconHeight int64) uint64 {
+	fee := tx.GetTxFee()
+	feeToken := tx.GetTxFeeToken()
+	if feeToken > 0 && beaconView != nil {
+		feeTokenToNativeToken, err := metadata.ConvertPrivacyTokenToNativeToken(feeToken, tx.GetTokenID(), beaconHeight, beaconView.GetBeaconFeatureStateDB())
+		if err != nil {
+			Logger.log.Debugf("Can not convert fee token of tx %+v to native token %+v", tx.Hash().String(), err)
+		} else {
+			fee += uint64(math.Ceil(feeTokenToNativeToken))
+		}
+	}
+	size := tx.GetTxActualSize()
+	if size == 0 {
+		size = 1
+	}
+	return fee / size
+}
+
+// getSenderKey - return key used for counting txs of a sender in pool,
+// txs are grouped by their signing public key.
+// Privacy txs are signed by a one-time key, so each of them is counted as
+// a different sender and MaxTxPerSender does not limit them
+func getSenderKey(tx metadata.Transaction) string {
+	return hex.EncodeToString(tx.GetSigPubKey())
+}
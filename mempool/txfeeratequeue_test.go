@@ -0,0 +1,100 @@
+package mempool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/pubsub"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ = func() (_ struct{}) {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	return
+}()
+
+func createTestFeeRateTransaction(fee uint64, sender byte, info string, meta metadata.Metadata) *transaction.Tx {
+	return &transaction.Tx{
+		Type:      common.TxNormalType,
+		Fee:       fee,
+		SigPubKey: []byte{sender, sender, sender},
+		Info:      []byte(info),
+		Metadata:  meta,
+	}
+}
+
+func TestTxFeeRateQueue(t *testing.T) {
+	queue := txFeeRateQueue{}
+	now := time.Now()
+	txDescs := []*TxDesc{
+		{FeePerKB: 30, StartTime: now},
+		{FeePerKB: 10, StartTime: now},
+		{FeePerKB: 20, StartTime: now},
+		{FeePerKB: 10, StartTime: now.Add(time.Second)},
+	}
+	for _, txDesc := range txDescs {
+		queue.add(txDesc)
+	}
+	// newer tx is evicted first with the same fee rate
+	assert.Equal(t, txDescs[3], queue.lowest())
+	queue.remove(txDescs[3])
+	assert.Equal(t, txDescs[1], queue.lowest())
+	queue.remove(txDescs[1])
+	queue.remove(txDescs[1])
+	assert.Equal(t, 2, queue.Len())
+	assert.Equal(t, txDescs[2], queue.lowest())
+	queue.remove(txDescs[2])
+	queue.remove(txDescs[0])
+	assert.Nil(t, queue.lowest())
+}
+
+func TestTxPoolCheckPoolQuotasAndEviction(t *testing.T) {
+	pool := &TxPool{}
+	pool.Init(&Config{
+		PubSubManager:    pubsub.NewPubSubManager(),
+		MaxTx:            3,
+		MaxTxPerSender:   2,
+		MaxTxPerMetaType: 1,
+	})
+	addTx := func(tx *transaction.Tx, feePerKB uint64) {
+		txDesc := createTxDescMempool(tx, 1, tx.GetTxFee(), 0)
+		txDesc.FeePerKB = feePerKB
+		if err := pool.addTx(txDesc, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tx1 := createTestFeeRateTransaction(20, 1, "tx1", nil)
+	tx2 := createTestFeeRateTransaction(10, 1, "tx2", nil)
+	addTx(tx1, 20)
+	addTx(tx2, 10)
+	// sender quota
+	err := pool.checkPoolQuotas(createTestFeeRateTransaction(30, 1, "tx3", nil), 30)
+	assert.Equal(t, ErrCodeMessage[RejectSenderQuotaError].Code, err.(*MempoolTxError).Code)
+	// metadata type quota
+	withdrawalMeta := &metadata.PDEWithdrawalRequest{MetadataBase: metadata.MetadataBase{Type: metadata.PDEWithdrawalRequestMeta}}
+	tx3 := createTestFeeRateTransaction(30, 2, "tx3", withdrawalMeta)
+	assert.Nil(t, pool.checkPoolQuotas(tx3, 30))
+	addTx(tx3, 30)
+	err = pool.checkPoolQuotas(createTestFeeRateTransaction(30, 3, "tx4", withdrawalMeta), 30)
+	assert.Equal(t, ErrCodeMessage[RejectMetadataTypeQuotaError].Code, err.(*MempoolTxError).Code)
+	// pool is full, only tx with fee rate higher than the lowest one in pool is accepted
+	err = pool.checkPoolQuotas(createTestFeeRateTransaction(10, 3, "tx4", nil), 10)
+	assert.Equal(t, ErrCodeMessage[MaxPoolSizeError].Code, err.(*MempoolTxError).Code)
+	tx4 := createTestFeeRateTransaction(15, 3, "tx4", nil)
+	assert.Nil(t, pool.checkPoolQuotas(tx4, 15))
+	addTx(tx4, 15)
+	evictedTxs := pool.evictLowFeeRateTxs()
+	assert.Equal(t, 1, len(evictedTxs))
+	assert.Equal(t, tx2.Hash().String(), evictedTxs[0].TxID)
+	assert.Equal(t, EvictReasonLowFeeRate, evictedTxs[0].Reason)
+	assert.Equal(t, 3, len(pool.pool))
+	assert.False(t, pool.isTxInPool(tx2.Hash()))
+	assert.Equal(t, uint64(1), pool.poolTxsBySender[getSenderKey(tx1)])
+	assert.Equal(t, uint64(15), pool.MinFeePerKB())
+	evictionCount, latestEvictedTxs := pool.EvictionInfo()
+	assert.Equal(t, uint64(1), evictionCount[EvictReasonLowFeeRate])
+	assert.Equal(t, evictedTxs, latestEvictedTxs)
+}
@@ -69,6 +69,18 @@ func ParseMetadata(meta interface{}) (Metadata, error) {
 		md = &PDEWithdrawalResponse{}
 	case PDEContributionResponseMeta:
 		md = &PDEContributionResponse{}
+	case PDECrossPoolTradeRequestMeta:
+		md = &PDECrossPoolTradeRequest{}
+	case PDECrossPoolTradeResponseMeta:
+		md = &PDECrossPoolTradeResponse{}
+	case PDELimitOrderRequestMeta:
+		md = &PDELimitOrderRequest{}
+	case PDELimitOrderResponseMeta:
+		md = &PDELimitOrderResponse{}
+	case PDECancelOrderRequestMeta:
+		md = &PDECancelOrderRequest{}
+	case EquivocationEvidenceMeta:
+		md = &EquivocationEvidenceRequest{}
 	case PortalCustodianDepositMeta:
 		md = &PortalCustodianDeposit{}
 	case PortalUserRegisterMeta:
@@ -91,6 +103,8 @@ func ParseMetadata(meta interface{}) (Metadata, error) {
 		md = &RelayingHeader{}
 	case RelayingBTCHeaderMeta:
 		md = &RelayingHeader{}
+	case RelayingETHHeaderMeta:
+		md = &RelayingHeader{}
 	case PortalCustodianWithdrawRequestMeta:
 		md = &PortalCustodianWithdrawRequest{}
 	case PortalCustodianWithdrawResponseMeta:
@@ -28,6 +28,9 @@ const (
 	StopAutoStakingMeta = 127
 	BeaconStakingMeta   = 64
 
+	// slashing
+	EquivocationEvidenceMeta = 131
+
 	// Incognito -> Ethereum bridge
 	BeaconSwapConfirmMeta = 70
 	BridgeSwapConfirmMeta = 71
@@ -40,6 +43,13 @@ const (
 	PDEWithdrawalRequestMeta    = 93
 	PDEWithdrawalResponseMeta   = 94
 	PDEContributionResponseMeta = 95
+	// trade through a route of pool pairs
+	PDECrossPoolTradeRequestMeta  = 98
+	PDECrossPoolTradeResponseMeta = 99
+	// limit orders of the order book
+	PDELimitOrderRequestMeta  = 86
+	PDELimitOrderResponseMeta = 87
+	PDECancelOrderRequestMeta = 88
 
 	// portal
 	PortalCustodianDepositMeta                      = 100
@@ -77,6 +87,7 @@ const (
 	// relaying
 	RelayingBNBHeaderMeta = 200
 	RelayingBTCHeaderMeta = 201
+	RelayingETHHeaderMeta = 204
 
 	PortalTopUpWaitingPortingRequestMeta  = 202
 	PortalTopUpWaitingPortingResponseMeta = 203
@@ -96,6 +107,8 @@ var minerCreatedMetaTypes = []int{
 	PDETradeResponseMeta,
 	PDEWithdrawalResponseMeta,
 	PDEContributionResponseMeta,
+	PDECrossPoolTradeResponseMeta,
+	PDELimitOrderResponseMeta,
 	PortalUserRequestPTokenResponseMeta,
 	PortalCustodianDepositResponseMeta,
 	PortalRedeemRequestResponseMeta,
@@ -122,6 +135,10 @@ var (
 	EthereumLightNodeHost     = common.GetENV("GETH_NAME", "127.0.0.1")
 	EthereumLightNodeProtocol = common.GetENV("GETH_PROTOCOL", "http")
 	EthereumLightNodePort     = common.GetENV("GETH_PORT", "8545")
+	// comma separated urls of Ethereum RPC endpoints and number of them which must agree on a result,
+	// quorum is majority of endpoints by default
+	EthereumLightNodeEndpoints = common.GetENV("GETH_ENDPOINTS", "")
+	EthereumLightNodeQuorum    = common.GetENV("GETH_QUORUM", "")
 )
 
 //const (
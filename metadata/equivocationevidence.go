@@ -0,0 +1,136 @@
+package metadata
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+)
+
+// types of equivocation
+const (
+	EquivocationProposeType = "propose" // propose two different blocks at the same height in the same time slot
+	EquivocationVoteType    = "vote"    // vote for two different blocks at the same height, created and proposed in the same time slots
+)
+
+// SignedConsensusMessage - a propose or vote message signed by a committee member,
+// the header of the signed block is attached so that its height and time slots can be checked against the signed block hash
+type SignedConsensusMessage struct {
+	BlockHeader json.RawMessage
+	// propose: bridge signature of the proposer on the block hash
+	ProducerSig []byte
+	// vote: signatures of the validator on the block hash and the confirmation on all of them
+	BLS          []byte
+	BRI          []byte
+	Confirmation []byte
+}
+
+// EquivocationEvidence - two conflicting consensus messages signed by the same committee member
+type EquivocationEvidence struct {
+	Type     string
+	ChainID  int    // -1 for beacon chain
+	Offender string // committee public key in base58 of the signer
+	Messages [2]SignedConsensusMessage
+}
+
+// EquivocationEvidenceRequest - report an equivocation evidence to beacon chain,
+// the offender is put into producers black list and loses its stake once beacon verifies the evidence
+type EquivocationEvidenceRequest struct {
+	Evidence EquivocationEvidence
+	MetadataBase
+}
+
+type EquivocationEvidenceRequestAction struct {
+	Meta    EquivocationEvidenceRequest
+	TxReqID common.Hash
+	ShardID byte
+}
+
+type EquivocationEvidenceAcceptedContent struct {
+	Offender        string
+	ChainID         int
+	Type            string
+	PunishedEpoches uint8
+	TxReqID         common.Hash
+	ShardID         byte
+}
+
+func NewEquivocationEvidenceRequest(
+	evidence EquivocationEvidence,
+	metaType int,
+) (*EquivocationEvidenceRequest, error) {
+	metadataBase := MetadataBase{
+		Type: metaType,
+	}
+	equivocationEvidenceRequest := &EquivocationEvidenceRequest{
+		Evidence: evidence,
+	}
+	equivocationEvidenceRequest.MetadataBase = metadataBase
+	return equivocationEvidenceRequest, nil
+}
+
+func (req EquivocationEvidenceRequest) ValidateTxWithBlockChain(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, transactionStateDB *statedb.StateDB) (bool, error) {
+	// signatures and committee of the offender are verified by beacon
+	return true, nil
+}
+
+func (req EquivocationEvidenceRequest) ValidateSanityData(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, beaconHeight uint64, tx Transaction) (bool, bool, error) {
+	evidence := req.Evidence
+	if evidence.Type != EquivocationProposeType && evidence.Type != EquivocationVoteType {
+		return false, false, NewMetadataTxError(EquivocationEvidenceInvalidError, errors.New("Evidence type is invalid"))
+	}
+	if evidence.ChainID < -1 {
+		return false, false, NewMetadataTxError(EquivocationEvidenceInvalidError, errors.New("Evidence chain id is invalid"))
+	}
+	if !incognitokey.IsInBase58ShortFormat([]string{evidence.Offender}) {
+		return false, false, NewMetadataTxError(EquivocationEvidenceInvalidError, errors.New("Offender is not a committee public key"))
+	}
+	for _, msg := range evidence.Messages {
+		if len(msg.BlockHeader) == 0 {
+			return false, false, NewMetadataTxError(EquivocationEvidenceInvalidError, errors.New("Block header of signed message is empty"))
+		}
+		if evidence.Type == EquivocationProposeType && len(msg.ProducerSig) == 0 {
+			return false, false, NewMetadataTxError(EquivocationEvidenceInvalidError, errors.New("Producer signature of signed message is empty"))
+		}
+		if evidence.Type == EquivocationVoteType && len(msg.Confirmation) == 0 {
+			return false, false, NewMetadataTxError(EquivocationEvidenceInvalidError, errors.New("Vote confirmation of signed message is empty"))
+		}
+	}
+	return true, true, nil
+}
+
+func (req EquivocationEvidenceRequest) ValidateMetadataByItself() bool {
+	return req.Type == EquivocationEvidenceMeta
+}
+
+func (req EquivocationEvidenceRequest) Hash() *common.Hash {
+	record := req.MetadataBase.Hash().String()
+	evidenceBytes, _ := json.Marshal(req.Evidence)
+	record += string(evidenceBytes)
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (req *EquivocationEvidenceRequest) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte) ([][]string, error) {
+	actionContent := EquivocationEvidenceRequestAction{
+		Meta:    *req,
+		TxReqID: *tx.Hash(),
+		ShardID: shardID,
+	}
+	actionContentBytes, err := json.Marshal(actionContent)
+	if err != nil {
+		return [][]string{}, err
+	}
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	action := []string{strconv.Itoa(EquivocationEvidenceMeta), actionContentBase64Str}
+	return [][]string{action}, nil
+}
+
+func (req *EquivocationEvidenceRequest) CalculateSize() uint64 {
+	return calculateSize(req)
+}
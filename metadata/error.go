@@ -34,6 +34,7 @@ const (
 	StopAutoStakingRequestNoAutoStakingAvaiableError
 	StopAutoStakingRequestTypeAssertionError
 	StopAutoStakingRequestAlreadyStopError
+	EquivocationEvidenceInvalidError
 
 	WrongIncognitoDAOPaymentAddressError
 
@@ -41,6 +42,8 @@ const (
 	PDEWithdrawalRequestFromMapError
 	CouldNotGetExchangeRateError
 	RejectInvalidFee
+	PDELimitOrderRequestFromMapError
+	PDECancelOrderRequestFromMapError
 
 	// portal
 	PortalRequestPTokenParamError
@@ -83,14 +86,17 @@ var ErrCodeMessage = map[int]struct {
 	StopAutoStakingRequestNoAutoStakingAvaiableError:      {-4003, "Stop Auto-Staking Request No Auto Staking Avaliable Error"},
 	StopAutoStakingRequestTypeAssertionError:              {-4004, "Stop Auto-Staking Request Type Assertion Error"},
 	StopAutoStakingRequestAlreadyStopError:                {-4005, "Stop Auto Staking Request Already Stop Error"},
+	EquivocationEvidenceInvalidError:                      {-4006, "Equivocation Evidence Invalid Error"},
 
 	// -5xxx dev reward error
 	WrongIncognitoDAOPaymentAddressError: {-5001, "Invalid dev account"},
 
 	// pde
-	PDEWithdrawalRequestFromMapError: {-6001, "PDE withdrawal request Error"},
-	CouldNotGetExchangeRateError:     {-6002, "Could not get the exchange rate error"},
-	RejectInvalidFee:                 {-6003, "Reject invalid fee"},
+	PDEWithdrawalRequestFromMapError:  {-6001, "PDE withdrawal request Error"},
+	CouldNotGetExchangeRateError:      {-6002, "Could not get the exchange rate error"},
+	RejectInvalidFee:                  {-6003, "Reject invalid fee"},
+	PDELimitOrderRequestFromMapError:  {-6004, "PDE limit order request Error"},
+	PDECancelOrderRequestFromMapError: {-6005, "PDE cancel order request Error"},
 
 	// portal
 	PortalRequestPTokenParamError:                {-7001, "Portal request ptoken param error"},
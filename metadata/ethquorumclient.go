@@ -0,0 +1,307 @@
+package metadata
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	rCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/incognitochain/incognito-chain/metadata/rpccaller"
+	"github.com/incognitochain/incognito-chain/metrics"
+	"github.com/pkg/errors"
+)
+
+const (
+	ethEndpointMaxFailures = 3                // consecutive failures before an endpoint is considered unhealthy
+	ethEndpointRetryAfter  = 30 * time.Second // unhealthy endpoint is not called until this duration passes
+)
+
+// ETHEndpointStatus - health of an Ethereum RPC endpoint
+type ETHEndpointStatus struct {
+	Index               int
+	Healthy             bool
+	ConsecutiveFailures int
+	Requests            int64
+	Failures            int64
+	Disagreements       int64
+	LastError           string
+	LastSuccess         time.Time
+}
+
+type ethEndpoint struct {
+	index               int
+	url                 string
+	consecutiveFailures int
+	unhealthyUntil      time.Time
+	lastError           string
+	lastSuccess         time.Time
+
+	// endpoints are named by index in metrics, urls may contain api keys
+	requests      metrics.Counter
+	failures      metrics.Counter
+	disagreements metrics.Counter
+	latency       metrics.Timer
+}
+
+// ETHQuorumClient - call a list of Ethereum RPC endpoints and accept a result only if quorum of them agree on it,
+// so a single bad or lagging endpoint can neither stall nor fool the shard
+type ETHQuorumClient struct {
+	lock      sync.Mutex
+	endpoints []*ethEndpoint
+	quorum    int
+	rpcClient *rpccaller.RPCClient
+}
+
+// NewETHQuorumClient - metrics of endpoints are registered in registry, nil for the default registry
+func NewETHQuorumClient(urls []string, quorum int, registry metrics.Registry) (*ETHQuorumClient, error) {
+	if len(urls) == 0 {
+		return nil, errors.New("no Ethereum RPC endpoint is configured")
+	}
+	if quorum <= 0 || quorum > len(urls) {
+		return nil, errors.Errorf("quorum %d is invalid for %d Ethereum RPC endpoints", quorum, len(urls))
+	}
+	client := &ETHQuorumClient{
+		quorum:    quorum,
+		rpcClient: rpccaller.NewRPCClient(),
+	}
+	for i, url := range urls {
+		prefix := fmt.Sprintf("eth/endpoint/%d/", i)
+		client.endpoints = append(client.endpoints, &ethEndpoint{
+			index:         i,
+			url:           url,
+			requests:      metrics.GetOrRegisterCounter(prefix+"requests", registry),
+			failures:      metrics.GetOrRegisterCounter(prefix+"failures", registry),
+			disagreements: metrics.GetOrRegisterCounter(prefix+"disagreements", registry),
+			latency:       metrics.GetOrRegisterTimer(prefix+"latency", registry),
+		})
+	}
+	return client, nil
+}
+
+var (
+	ethQuorumClient     *ETHQuorumClient
+	ethQuorumClientErr  error
+	ethQuorumClientOnce sync.Once
+)
+
+// getETHQuorumClient - endpoints are taken from GETH_ENDPOINTS (comma separated urls) and GETH_QUORUM,
+// the single endpoint of GETH_NAME, GETH_PROTOCOL and GETH_PORT is used if GETH_ENDPOINTS is not set
+func getETHQuorumClient() (*ETHQuorumClient, error) {
+	ethQuorumClientOnce.Do(func() {
+		urls := []string{}
+		for _, url := range strings.Split(EthereumLightNodeEndpoints, ",") {
+			if url = strings.TrimSpace(url); url != "" {
+				urls = append(urls, url)
+			}
+		}
+		if len(urls) == 0 {
+			urls = append(urls, rpccaller.BuildRPCServerAddress(EthereumLightNodeProtocol, EthereumLightNodeHost, EthereumLightNodePort))
+		}
+		quorum := len(urls)/2 + 1
+		if EthereumLightNodeQuorum != "" {
+			quorum, ethQuorumClientErr = strconv.Atoi(EthereumLightNodeQuorum)
+			if ethQuorumClientErr != nil {
+				return
+			}
+		}
+		ethQuorumClient, ethQuorumClientErr = NewETHQuorumClient(urls, quorum, nil)
+	})
+	return ethQuorumClient, ethQuorumClientErr
+}
+
+// getCallableEndpoints - healthy endpoints, all endpoints are called if there are not enough healthy ones for quorum
+func (c *ETHQuorumClient) getCallableEndpoints() []*ethEndpoint {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	now := time.Now()
+	healthy := []*ethEndpoint{}
+	for _, endpoint := range c.endpoints {
+		if !now.Before(endpoint.unhealthyUntil) {
+			healthy = append(healthy, endpoint)
+		}
+	}
+	if len(healthy) < c.quorum {
+		return c.endpoints
+	}
+	return healthy
+}
+
+func (c *ETHQuorumClient) markSuccess(endpoint *ethEndpoint) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	endpoint.consecutiveFailures = 0
+	endpoint.unhealthyUntil = time.Time{}
+	endpoint.lastSuccess = time.Now()
+}
+
+func (c *ETHQuorumClient) markFailure(endpoint *ethEndpoint, err error, isDisagreement bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if isDisagreement {
+		endpoint.disagreements.Inc(1)
+	} else {
+		endpoint.failures.Inc(1)
+	}
+	endpoint.lastError = err.Error()
+	endpoint.consecutiveFailures++
+	if endpoint.consecutiveFailures >= ethEndpointMaxFailures {
+		endpoint.unhealthyUntil = time.Now().Add(ethEndpointRetryAfter)
+	}
+	Logger.log.Warnf("WARNING: Ethereum RPC endpoint %d: %v", endpoint.index, err)
+}
+
+type ethCallResult struct {
+	endpoint *ethEndpoint
+	response interface{}
+	err      error
+}
+
+// callAll - call method on callable endpoints concurrently, newResponse creates the response object of an endpoint
+func (c *ETHQuorumClient) callAll(method string, params []interface{}, newResponse func() interface{}) []ethCallResult {
+	endpoints := c.getCallableEndpoints()
+	results := make([]ethCallResult, len(endpoints))
+	var wg sync.WaitGroup
+	for i, endpoint := range endpoints {
+		wg.Add(1)
+		go func(i int, endpoint *ethEndpoint) {
+			defer wg.Done()
+			response := newResponse()
+			start := time.Now()
+			endpoint.requests.Inc(1)
+			// url is passed as host, it already contains protocol and port
+			err := c.rpcClient.RPCCall("", endpoint.url, "", method, params, response)
+			endpoint.latency.UpdateSince(start)
+			results[i] = ethCallResult{endpoint: endpoint, response: response, err: err}
+		}(i, endpoint)
+	}
+	wg.Wait()
+	for _, result := range results {
+		if result.err != nil {
+			c.markFailure(result.endpoint, result.err, false)
+		}
+	}
+	return results
+}
+
+// GetHeader - header of the block hash agreed by quorum of endpoints on its hash and receipt root,
+// nil if quorum of endpoints can not find the block
+func (c *ETHQuorumClient) GetHeader(ethBlockHash rCommon.Hash) (*types.Header, error) {
+	params := []interface{}{ethBlockHash, false}
+	results := c.callAll("eth_getBlockByHash", params, func() interface{} { return &GetBlockByNumberRes{} })
+	votes := map[string][]ethCallResult{}
+	headers := map[string]*types.Header{}
+	for _, result := range results {
+		if result.err != nil {
+			continue
+		}
+		res := result.response.(*GetBlockByNumberRes)
+		key := "not found"
+		if res.RPCError != nil {
+			Logger.log.Infof("WARNING: an error occured during calling eth_getBlockByHash: %s", res.RPCError.Message)
+		} else if res.Result != nil {
+			key = res.Result.Hash().String() + res.Result.ReceiptHash.String()
+			headers[key] = res.Result
+		}
+		votes[key] = append(votes[key], result)
+	}
+	key, err := c.pickQuorum(votes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "eth_getBlockByHash %s", ethBlockHash.String())
+	}
+	return headers[key], nil
+}
+
+// pickQuorum - the result which at least quorum of endpoints agree on, endpoints returning other results are penalized
+func (c *ETHQuorumClient) pickQuorum(votes map[string][]ethCallResult) (string, error) {
+	agreedKeys := []string{}
+	for key, agreed := range votes {
+		if len(agreed) >= c.quorum {
+			agreedKeys = append(agreedKeys, key)
+		}
+	}
+	if len(agreedKeys) == 0 {
+		return "", errors.Errorf("less than %d Ethereum RPC endpoints agree on result", c.quorum)
+	}
+	if len(agreedKeys) > 1 {
+		return "", errors.Errorf("%d conflicting results are returned by at least %d Ethereum RPC endpoints", len(agreedKeys), c.quorum)
+	}
+	for key, results := range votes {
+		for _, result := range results {
+			if key == agreedKeys[0] {
+				c.markSuccess(result.endpoint)
+			} else {
+				c.markFailure(result.endpoint, errors.New("result disagrees with quorum"), true)
+			}
+		}
+	}
+	return agreedKeys[0], nil
+}
+
+// GetMostRecentBlockHeight - the highest block number reached by at least quorum of endpoints,
+// a lagging endpoint does not stall and a single endpoint reporting a far future block does not fool the shard
+func (c *ETHQuorumClient) GetMostRecentBlockHeight() (*big.Int, error) {
+	results := c.callAll("eth_blockNumber", []interface{}{}, func() interface{} { return &GetETHBlockNumRes{} })
+	blockNumbers := []*big.Int{}
+	for _, result := range results {
+		if result.err != nil {
+			continue
+		}
+		res := result.response.(*GetETHBlockNumRes)
+		if res.RPCError != nil {
+			c.markFailure(result.endpoint, errors.Errorf("eth_blockNumber: %s", res.RPCError.Message), false)
+			continue
+		}
+		blockNumber := new(big.Int)
+		if len(res.Result) < 2 {
+			c.markFailure(result.endpoint, errors.New("eth_blockNumber: result is empty"), false)
+			continue
+		}
+		if _, ok := blockNumber.SetString(res.Result[2:], 16); !ok {
+			c.markFailure(result.endpoint, errors.New("Cannot convert blockNumber into integer"), false)
+			continue
+		}
+		c.markSuccess(result.endpoint)
+		blockNumbers = append(blockNumbers, blockNumber)
+	}
+	if len(blockNumbers) < c.quorum {
+		return nil, errors.Errorf("only %d of %d Ethereum RPC endpoints return block number", len(blockNumbers), c.quorum)
+	}
+	sort.Slice(blockNumbers, func(i, j int) bool {
+		return blockNumbers[i].Cmp(blockNumbers[j]) > 0
+	})
+	return blockNumbers[c.quorum-1], nil
+}
+
+// GetETHEndpointStatuses - health of the Ethereum RPC endpoints used to verify ETH deposits of this node
+func GetETHEndpointStatuses() ([]ETHEndpointStatus, error) {
+	client, err := getETHQuorumClient()
+	if err != nil {
+		return nil, err
+	}
+	return client.GetEndpointStatuses(), nil
+}
+
+func (c *ETHQuorumClient) GetEndpointStatuses() []ETHEndpointStatus {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	now := time.Now()
+	statuses := []ETHEndpointStatus{}
+	for _, endpoint := range c.endpoints {
+		statuses = append(statuses, ETHEndpointStatus{
+			Index:               endpoint.index,
+			Healthy:             !now.Before(endpoint.unhealthyUntil),
+			ConsecutiveFailures: endpoint.consecutiveFailures,
+			Requests:            endpoint.requests.Count(),
+			Failures:            endpoint.failures.Count(),
+			Disagreements:       endpoint.disagreements.Count(),
+			LastError:           endpoint.lastError,
+			LastSuccess:         endpoint.lastSuccess,
+		})
+	}
+	return statuses
+}
@@ -0,0 +1,143 @@
+package metadata
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	rCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ = func() (_ struct{}) {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	return
+}()
+
+// newETHStandInServer - local JSON-RPC server standing in for an Ethereum node,
+// header is nil for a node which can not find the block
+func newETHStandInServer(t *testing.T, header *types.Header, blockNumber string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := struct {
+			Method string `json:"method"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+			return
+		}
+		res := map[string]interface{}{"jsonrpc": "2.0", "id": 1}
+		switch req.Method {
+		case "eth_getBlockByHash":
+			res["result"] = header
+		case "eth_blockNumber":
+			res["result"] = blockNumber
+		default:
+			res["error"] = map[string]interface{}{"code": -32601, "message": "method not found"}
+		}
+		json.NewEncoder(w).Encode(res)
+	}))
+}
+
+func newTestETHHeader(receiptHash string) *types.Header {
+	return &types.Header{
+		ReceiptHash: rCommon.HexToHash(receiptHash),
+		Difficulty:  big.NewInt(1),
+		Number:      big.NewInt(100),
+		Time:        1590000000,
+		Extra:       []byte{},
+	}
+}
+
+func TestETHQuorumClientGetHeader(t *testing.T) {
+	header := newTestETHHeader("0x01")
+	fakeHeader := newTestETHHeader("0x02")
+	good1 := newETHStandInServer(t, header, "0x64")
+	defer good1.Close()
+	good2 := newETHStandInServer(t, header, "0x64")
+	defer good2.Close()
+	bad := newETHStandInServer(t, fakeHeader, "0x64")
+	defer bad.Close()
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	client, err := NewETHQuorumClient([]string{good1.URL, good2.URL, bad.URL, down.URL}, 2, metrics.NewRegistry())
+	assert.Nil(t, err)
+	result, err := client.GetHeader(header.Hash())
+	assert.Nil(t, err)
+	assert.Equal(t, header.Hash(), result.Hash())
+	assert.Equal(t, header.ReceiptHash, result.ReceiptHash)
+
+	statuses := client.GetEndpointStatuses()
+	assert.Equal(t, int64(0), statuses[0].Disagreements)
+	assert.Equal(t, int64(1), statuses[2].Disagreements)
+	assert.Equal(t, int64(1), statuses[3].Failures)
+
+	// endpoints failing repeatedly are not called until they can be retried
+	for i := 0; i < ethEndpointMaxFailures; i++ {
+		client.GetHeader(header.Hash())
+	}
+	statuses = client.GetEndpointStatuses()
+	assert.False(t, statuses[2].Healthy)
+	assert.False(t, statuses[3].Healthy)
+	assert.True(t, statuses[0].Healthy)
+	requests := statuses[3].Requests
+	client.GetHeader(header.Hash())
+	assert.Equal(t, requests, client.GetEndpointStatuses()[3].Requests)
+}
+
+func TestETHQuorumClientNoQuorum(t *testing.T) {
+	good := newETHStandInServer(t, newTestETHHeader("0x01"), "0x64")
+	defer good.Close()
+	bad := newETHStandInServer(t, newTestETHHeader("0x02"), "0x64")
+	defer bad.Close()
+	notFound := newETHStandInServer(t, nil, "0x64")
+	defer notFound.Close()
+
+	client, err := NewETHQuorumClient([]string{good.URL, bad.URL, notFound.URL}, 2, metrics.NewRegistry())
+	assert.Nil(t, err)
+	result, err := client.GetHeader(newTestETHHeader("0x01").Hash())
+	assert.NotNil(t, err)
+	assert.Nil(t, result)
+
+	// quorum of endpoints can not find the block
+	notFound2 := newETHStandInServer(t, nil, "0x64")
+	defer notFound2.Close()
+	client, err = NewETHQuorumClient([]string{good.URL, notFound.URL, notFound2.URL}, 2, metrics.NewRegistry())
+	assert.Nil(t, err)
+	result, err = client.GetHeader(newTestETHHeader("0x01").Hash())
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+
+	_, err = NewETHQuorumClient([]string{good.URL}, 2, metrics.NewRegistry())
+	assert.NotNil(t, err)
+}
+
+func TestETHQuorumClientGetMostRecentBlockHeight(t *testing.T) {
+	lagging := newETHStandInServer(t, nil, "0x0a")
+	defer lagging.Close()
+	synced1 := newETHStandInServer(t, nil, "0x64")
+	defer synced1.Close()
+	synced2 := newETHStandInServer(t, nil, "0x65")
+	defer synced2.Close()
+	lying := newETHStandInServer(t, nil, "0xffffff")
+	defer lying.Close()
+
+	client, err := NewETHQuorumClient([]string{lagging.URL, synced1.URL, synced2.URL, lying.URL}, 3, metrics.NewRegistry())
+	assert.Nil(t, err)
+	blockNumber, err := client.GetMostRecentBlockHeight()
+	assert.Nil(t, err)
+	// the highest block reached by 3 endpoints
+	assert.Equal(t, int64(0x64), blockNumber.Int64())
+
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+	client, err = NewETHQuorumClient([]string{synced1.URL, down.URL}, 2, metrics.NewRegistry())
+	assert.Nil(t, err)
+	_, err = client.GetMostRecentBlockHeight()
+	assert.NotNil(t, err)
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/metadata/rpccaller"
+	ethrelaying "github.com/incognitochain/incognito-chain/relaying/eth"
 	"github.com/pkg/errors"
 )
 
@@ -115,7 +116,7 @@ func NewIssuingETHRequestFromMap(
 }
 
 func (iReq IssuingETHRequest) ValidateTxWithBlockChain(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, transactionStateDB *statedb.StateDB) (bool, error) {
-	ethReceipt, err := iReq.verifyProofAndParseReceipt()
+	ethReceipt, err := iReq.verifyProofAndParseReceipt(chainRetriever, beaconViewRetriever)
 	if err != nil {
 		return false, NewMetadataTxError(IssuingEthRequestValidateTxWithBlockChainError, err)
 	}
@@ -168,7 +169,7 @@ func (iReq IssuingETHRequest) Hash() *common.Hash {
 }
 
 func (iReq *IssuingETHRequest) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte) ([][]string, error) {
-	ethReceipt, err := iReq.verifyProofAndParseReceipt()
+	ethReceipt, err := iReq.verifyProofAndParseReceipt(chainRetriever, beaconViewRetriever)
 	if err != nil {
 		return [][]string{}, NewMetadataTxError(IssuingEthRequestBuildReqActionsError, err)
 	}
@@ -199,28 +200,12 @@ func (iReq *IssuingETHRequest) CalculateSize() uint64 {
 	return calculateSize(iReq)
 }
 
-func (iReq *IssuingETHRequest) verifyProofAndParseReceipt() (*types.Receipt, error) {
-	ethHeader, err := GetETHHeader(iReq.BlockHash)
-	if err != nil {
-		return nil, NewMetadataTxError(IssuingEthRequestVerifyProofAndParseReceipt, err)
-	}
-	if ethHeader == nil {
-		Logger.log.Info("WARNING: Could not find out the ETH block header with the hash: ", iReq.BlockHash)
-		return nil, NewMetadataTxError(IssuingEthRequestVerifyProofAndParseReceipt, errors.Errorf("WARNING: Could not find out the ETH block header with the hash: %s", iReq.BlockHash.String()))
-	}
-
-	mostRecentBlkNum, err := GetMostRecentETHBlockHeight()
+func (iReq *IssuingETHRequest) verifyProofAndParseReceipt(chainRetriever ChainRetriever, beaconViewRetriever BeaconViewRetriever) (*types.Receipt, error) {
+	ethHeader, err := iReq.getConfirmedETHHeader(chainRetriever, beaconViewRetriever)
 	if err != nil {
-		Logger.log.Info("WARNING: Could not find the most recent block height on Ethereum")
 		return nil, NewMetadataTxError(IssuingEthRequestVerifyProofAndParseReceipt, err)
 	}
 
-	if mostRecentBlkNum.Cmp(big.NewInt(0).Add(ethHeader.Number, big.NewInt(ETHConfirmationBlocks))) == -1 {
-		errMsg := fmt.Sprintf("WARNING: It needs 15 confirmation blocks for the process, the requested block (%s) but the latest block (%s)", ethHeader.Number.String(), mostRecentBlkNum.String())
-		Logger.log.Info(errMsg)
-		return nil, NewMetadataTxError(IssuingEthRequestVerifyProofAndParseReceipt, errors.New(errMsg))
-	}
-
 	keybuf := new(bytes.Buffer)
 	keybuf.Reset()
 	rlp.Encode(keybuf, iReq.TxIndex)
@@ -253,6 +238,43 @@ func (iReq *IssuingETHRequest) verifyProofAndParseReceipt() (*types.Receipt, err
 	return constructedReceipt, nil
 }
 
+// getConfirmedETHHeader gets the header of the block containing the deposit once it has enough confirmations.
+// Once eth relaying is activated (BeaconHeightBreakPointETHRelay) the header must be finalized on the chain relayed
+// by RelayingETHHeaderMeta, before that it is fetched from the Ethereum RPC endpoints.
+func (iReq *IssuingETHRequest) getConfirmedETHHeader(chainRetriever ChainRetriever, beaconViewRetriever BeaconViewRetriever) (*types.Header, error) {
+	if chainRetriever.IsETHRelayActivated(0) {
+		chainParams, err := ethrelaying.GetChainParams(chainRetriever.GetETHChainID())
+		if err != nil {
+			return nil, err
+		}
+		store := ethrelaying.NewStateDBHeaderStore(beaconViewRetriever.GetBeaconFeatureStateDB())
+		ethHeaderChain := ethrelaying.NewHeaderChain(store, chainParams, nil)
+		return ethHeaderChain.GetFinalizedHeader(iReq.BlockHash)
+	}
+
+	ethHeader, err := GetETHHeader(iReq.BlockHash)
+	if err != nil {
+		return nil, err
+	}
+	if ethHeader == nil {
+		Logger.log.Info("WARNING: Could not find out the ETH block header with the hash: ", iReq.BlockHash)
+		return nil, errors.Errorf("WARNING: Could not find out the ETH block header with the hash: %s", iReq.BlockHash.String())
+	}
+
+	mostRecentBlkNum, err := GetMostRecentETHBlockHeight()
+	if err != nil {
+		Logger.log.Info("WARNING: Could not find the most recent block height on Ethereum")
+		return nil, err
+	}
+
+	if mostRecentBlkNum.Cmp(big.NewInt(0).Add(ethHeader.Number, big.NewInt(ETHConfirmationBlocks))) == -1 {
+		errMsg := fmt.Sprintf("WARNING: It needs 15 confirmation blocks for the process, the requested block (%s) but the latest block (%s)", ethHeader.Number.String(), mostRecentBlkNum.String())
+		Logger.log.Info(errMsg)
+		return nil, errors.New(errMsg)
+	}
+	return ethHeader, nil
+}
+
 func ParseETHLogData(data []byte) (map[string]interface{}, error) {
 	abiIns, err := abi.JSON(strings.NewReader(common.AbiJson))
 	if err != nil {
@@ -277,54 +299,20 @@ func IsETHTxHashUsedInBlock(uniqETHTx []byte, uniqETHTxsUsed [][]byte) bool {
 func GetETHHeader(
 	ethBlockHash rCommon.Hash,
 ) (*types.Header, error) {
-	rpcClient := rpccaller.NewRPCClient()
-	params := []interface{}{ethBlockHash, false}
-	var getBlockByNumberRes GetBlockByNumberRes
-	err := rpcClient.RPCCall(
-		EthereumLightNodeProtocol,
-		EthereumLightNodeHost,
-		EthereumLightNodePort,
-		"eth_getBlockByHash",
-		params,
-		&getBlockByNumberRes,
-	)
+	client, err := getETHQuorumClient()
 	if err != nil {
 		return nil, err
 	}
-	if getBlockByNumberRes.RPCError != nil {
-		Logger.log.Infof("WARNING: an error occured during calling eth_getBlockByHash: %s", getBlockByNumberRes.RPCError.Message)
-		return nil, nil
-	}
-	return getBlockByNumberRes.Result, nil
+	return client.GetHeader(ethBlockHash)
 }
 
 // GetMostRecentETHBlockHeight get most recent block height on Ethereum
 func GetMostRecentETHBlockHeight() (*big.Int, error) {
-	rpcClient := rpccaller.NewRPCClient()
-	params := []interface{}{}
-	var getETHBlockNumRes GetETHBlockNumRes
-	err := rpcClient.RPCCall(
-		EthereumLightNodeProtocol,
-		EthereumLightNodeHost,
-		EthereumLightNodePort,
-		"eth_blockNumber",
-		params,
-		&getETHBlockNumRes,
-	)
+	client, err := getETHQuorumClient()
 	if err != nil {
 		return nil, err
 	}
-	if getETHBlockNumRes.RPCError != nil {
-		Logger.log.Debugf("WARNING: an error occured during calling eth_blockNumber: %s", getETHBlockNumRes.RPCError.Message)
-		return nil, nil
-	}
-
-	blockNumber := new(big.Int)
-	_, ok := blockNumber.SetString(getETHBlockNumRes.Result[2:], 16)
-	if !ok {
-		return nil, errors.New("Cannot convert blockNumber into integer")
-	}
-	return blockNumber, nil
+	return client.GetMostRecentBlockHeight()
 }
 
 func PickAndParseLogMapFromReceipt(constructedReceipt *types.Receipt, ethContractAddressStr string) (map[string]interface{}, error) {
@@ -5,13 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
-	"github.com/incognitochain/incognito-chain/relaying/bnb"
 
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/portal"
 	zkp "github.com/incognitochain/incognito-chain/privacy/zeroknowledge"
-	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
 )
 
 // Interface for all types of metadata in tx
@@ -62,8 +61,12 @@ type ChainRetriever interface {
 	ListPrivacyTokenAndBridgeTokenAndPRVByShardID(byte) ([]common.Hash, error)
 	GetBNBChainID() string
 	GetBTCChainID() string
-	GetBTCHeaderChain() *btcrelaying.BlockChain
-	GetPortalFeederAddress() string
+	GetETHChainID() string
+	IsETHRelayActivated(beaconHeight uint64) bool
+	GetPortalExternalChain(tokenID string) portal.ExternalChain
+	IsPortalToken(tokenID string) bool
+	IsPortalExchangeRateToken(tokenID string) bool
+	GetPortalFeederAddresses() []string
 }
 
 type BeaconViewRetriever interface {
@@ -244,25 +247,20 @@ func IsValidRemoteAddress(
 	bcr ChainRetriever,
 	remoteAddress string,
 	tokenID string,
-	chainID string,
 ) bool {
-	if tokenID == common.PortalBNBIDStr {
-		return bnb.IsValidBNBAddress(remoteAddress, chainID)
-	} else if tokenID == common.PortalBTCIDStr {
-		btcHeaderChain := bcr.GetBTCHeaderChain()
-		if btcHeaderChain == nil {
-			return false
-		}
-		return btcHeaderChain.IsBTCAddressValid(remoteAddress)
+	externalChain := bcr.GetPortalExternalChain(tokenID)
+	if externalChain == nil {
+		return false
 	}
-	return false
+	return externalChain.IsValidAddress(remoteAddress)
 }
 
-func GetChainIDByTokenID(tokenID string, chainRetriever ChainRetriever) string {
-	if tokenID == common.PortalBNBIDStr {
-		return chainRetriever.GetBNBChainID()
-	} else if tokenID == common.PortalBTCIDStr {
-		return chainRetriever.GetBTCChainID()
+// GetMinAmountPortalPToken returns the smallest amount of the ptoken in porting and redeem requests,
+// 0 if the token is not supported on the Portal
+func GetMinAmountPortalPToken(bcr ChainRetriever, tokenID string) uint64 {
+	externalChain := bcr.GetPortalExternalChain(tokenID)
+	if externalChain == nil {
+		return 0
 	}
-	return ""
+	return externalChain.MinAmount()
 }
@@ -0,0 +1,99 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// PDECancelOrderRequest - request to remove an open limit order from the pde order book,
+// the selling amount and trading fee of the order are refunded to its trader
+type PDECancelOrderRequest struct {
+	OrderID          string // id of the limit order request tx
+	TraderAddressStr string
+	MetadataBase
+}
+
+type PDECancelOrderRequestAction struct {
+	Meta    PDECancelOrderRequest
+	TxReqID common.Hash
+	ShardID byte
+}
+
+func NewPDECancelOrderRequest(
+	orderID string,
+	traderAddressStr string,
+	metaType int,
+) (*PDECancelOrderRequest, error) {
+	metadataBase := MetadataBase{
+		Type: metaType,
+	}
+	pdeCancelOrderRequest := &PDECancelOrderRequest{
+		OrderID:          orderID,
+		TraderAddressStr: traderAddressStr,
+	}
+	pdeCancelOrderRequest.MetadataBase = metadataBase
+	return pdeCancelOrderRequest, nil
+}
+
+func (pc PDECancelOrderRequest) ValidateTxWithBlockChain(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, transactionStateDB *statedb.StateDB) (bool, error) {
+	// NOTE: the order is looked up in the order book by beacon
+	return true, nil
+}
+
+func (pc PDECancelOrderRequest) ValidateSanityData(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, beaconHeight uint64, tx Transaction) (bool, bool, error) {
+	keyWallet, err := wallet.Base58CheckDeserialize(pc.TraderAddressStr)
+	if err != nil {
+		return false, false, NewMetadataTxError(PDECancelOrderRequestFromMapError, errors.New("TraderAddressStr incorrect"))
+	}
+	traderAddr := keyWallet.KeySet.PaymentAddress
+	if len(traderAddr.Pk) == 0 {
+		return false, false, errors.New("Wrong request info's trader address")
+	}
+	if !bytes.Equal(tx.GetSigPubKey()[:], traderAddr.Pk[:]) {
+		return false, false, errors.New("TraderAddress incorrect")
+	}
+	_, err = common.Hash{}.NewHashFromStr(pc.OrderID)
+	if err != nil {
+		return false, false, NewMetadataTxError(PDECancelOrderRequestFromMapError, errors.New("OrderID incorrect"))
+	}
+	return true, true, nil
+}
+
+func (pc PDECancelOrderRequest) ValidateMetadataByItself() bool {
+	return pc.Type == PDECancelOrderRequestMeta
+}
+
+func (pc PDECancelOrderRequest) Hash() *common.Hash {
+	record := pc.MetadataBase.Hash().String()
+	record += pc.OrderID
+	record += pc.TraderAddressStr
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (pc *PDECancelOrderRequest) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte) ([][]string, error) {
+	actionContent := PDECancelOrderRequestAction{
+		Meta:    *pc,
+		TxReqID: *tx.Hash(),
+		ShardID: shardID,
+	}
+	actionContentBytes, err := json.Marshal(actionContent)
+	if err != nil {
+		return [][]string{}, err
+	}
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	action := []string{strconv.Itoa(PDECancelOrderRequestMeta), actionContentBase64Str}
+	return [][]string{action}, nil
+}
+
+func (pc *PDECancelOrderRequest) CalculateSize() uint64 {
+	return calculateSize(pc)
+}
@@ -0,0 +1,184 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// MaxPDECrossPoolTradeHops - max number of pool pairs a cross pool trade can go through
+const MaxPDECrossPoolTradeHops = 4
+
+// PDECrossPoolTradeRequest - privacy dex trade through a route of pool pairs,
+// all hops are executed in one beacon instruction, the trade is refunded if any of them fails
+type PDECrossPoolTradeRequest struct {
+	TokenIDToBuyStr     string
+	TokenIDToSellStr    string
+	Route               []string // token ids from TokenIDToSellStr to TokenIDToBuyStr, each two consecutive ones name a pool pair
+	SellAmount          uint64   // must be equal to vout value
+	MinAcceptableAmount uint64   // of TokenIDToBuyStr, for the whole route
+	TradingFee          uint64
+	TraderAddressStr    string
+	MetadataBase
+}
+
+type PDECrossPoolTradeRequestAction struct {
+	Meta    PDECrossPoolTradeRequest
+	TxReqID common.Hash
+	ShardID byte
+}
+
+// PDETradeHop - pool value changes of a pool pair by one hop of a cross pool trade
+type PDETradeHop struct {
+	Token1IDStr              string
+	Token2IDStr              string
+	Token1PoolValueOperation TokenPoolValueOperation
+	Token2PoolValueOperation TokenPoolValueOperation
+	PoolFee                  uint64 `json:",omitempty"` // of the sold token, kept in the pool for liquidity providers
+}
+
+type PDECrossPoolTradeAcceptedContent struct {
+	TraderAddressStr string
+	TokenIDToBuyStr  string
+	ReceiveAmount    uint64
+	Hops             []PDETradeHop
+	ShardID          byte
+	RequestedTxID    common.Hash
+}
+
+func NewPDECrossPoolTradeRequest(
+	tokenIDToBuyStr string,
+	tokenIDToSellStr string,
+	route []string,
+	sellAmount uint64,
+	minAcceptableAmount uint64,
+	tradingFee uint64,
+	traderAddressStr string,
+	metaType int,
+) (*PDECrossPoolTradeRequest, error) {
+	metadataBase := MetadataBase{
+		Type: metaType,
+	}
+	pdeCrossPoolTradeRequest := &PDECrossPoolTradeRequest{
+		TokenIDToBuyStr:     tokenIDToBuyStr,
+		TokenIDToSellStr:    tokenIDToSellStr,
+		Route:               route,
+		SellAmount:          sellAmount,
+		MinAcceptableAmount: minAcceptableAmount,
+		TradingFee:          tradingFee,
+		TraderAddressStr:    traderAddressStr,
+	}
+	pdeCrossPoolTradeRequest.MetadataBase = metadataBase
+	return pdeCrossPoolTradeRequest, nil
+}
+
+func (pc PDECrossPoolTradeRequest) ValidateTxWithBlockChain(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, transactionStateDB *statedb.StateDB) (bool, error) {
+	// NOTE: pool pairs of the route are checked by beacon when the trade is executed
+	return true, nil
+}
+
+func (pc PDECrossPoolTradeRequest) ValidateSanityData(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, beaconHeight uint64, tx Transaction) (bool, bool, error) {
+	// Note: the metadata was already verified with *transaction.TxCustomToken level so no need to verify with *transaction.Tx level again as *transaction.Tx is embedding property of *transaction.TxCustomToken
+	if tx.GetType() == common.TxCustomTokenPrivacyType && reflect.TypeOf(tx).String() == "*transaction.Tx" {
+		return true, true, nil
+	}
+
+	keyWallet, err := wallet.Base58CheckDeserialize(pc.TraderAddressStr)
+	if err != nil {
+		return false, false, NewMetadataTxError(IssuingRequestNewIssuingRequestFromMapEror, errors.New("TraderAddressStr incorrect"))
+	}
+	traderAddr := keyWallet.KeySet.PaymentAddress
+
+	if len(traderAddr.Pk) == 0 {
+		return false, false, errors.New("Wrong request info's trader address")
+	}
+	if !tx.IsCoinsBurning(chainRetriever, shardViewRetriever, beaconViewRetriever, beaconHeight) {
+		return false, false, errors.New("Must send coin to burning address")
+	}
+	if (pc.SellAmount + pc.TradingFee) != tx.CalculateTxValue() {
+		return false, false, errors.New("Total of selling amount and trading fee should be equal to the tx value")
+	}
+	if !bytes.Equal(tx.GetSigPubKey()[:], traderAddr.Pk[:]) {
+		return false, false, errors.New("TraderAddress incorrect")
+	}
+
+	if len(pc.Route) < 2 || len(pc.Route) > MaxPDECrossPoolTradeHops+1 {
+		return false, false, errors.New("Route should name from 1 to " + strconv.Itoa(MaxPDECrossPoolTradeHops) + " pool pairs")
+	}
+	if pc.Route[0] != pc.TokenIDToSellStr || pc.Route[len(pc.Route)-1] != pc.TokenIDToBuyStr {
+		return false, false, errors.New("Route should start with TokenIDToSellStr and end with TokenIDToBuyStr")
+	}
+	for i, tokenIDStr := range pc.Route {
+		_, err = common.Hash{}.NewHashFromStr(tokenIDStr)
+		if err != nil {
+			return false, false, NewMetadataTxError(IssuingRequestNewIssuingRequestFromMapEror, errors.New("Route token id incorrect"))
+		}
+		if i > 0 && tokenIDStr == pc.Route[i-1] {
+			return false, false, errors.New("Route should not trade a token for itself")
+		}
+	}
+
+	tokenIDToSell, err := common.Hash{}.NewHashFromStr(pc.TokenIDToSellStr)
+	if err != nil {
+		return false, false, NewMetadataTxError(IssuingRequestNewIssuingRequestFromMapEror, errors.New("TokenIDToSellStr incorrect"))
+	}
+
+	if !bytes.Equal(tx.GetTokenID()[:], tokenIDToSell[:]) {
+		return false, false, errors.New("Wrong request info's token id, it should be equal to tx's token id.")
+	}
+
+	if tx.GetType() == common.TxNormalType && pc.TokenIDToSellStr != common.PRVCoinID.String() {
+		return false, false, errors.New("With tx normal privacy, the tokenIDStr should be PRV, not custom token.")
+	}
+
+	if tx.GetType() == common.TxCustomTokenPrivacyType && pc.TokenIDToSellStr == common.PRVCoinID.String() {
+		return false, false, errors.New("With tx custome token privacy, the tokenIDStr should not be PRV, but custom token.")
+	}
+
+	return true, true, nil
+}
+
+func (pc PDECrossPoolTradeRequest) ValidateMetadataByItself() bool {
+	return pc.Type == PDECrossPoolTradeRequestMeta
+}
+
+func (pc PDECrossPoolTradeRequest) Hash() *common.Hash {
+	record := pc.MetadataBase.Hash().String()
+	record += pc.TokenIDToBuyStr
+	record += pc.TokenIDToSellStr
+	record += strings.Join(pc.Route, "")
+	record += pc.TraderAddressStr
+	record += strconv.FormatUint(pc.SellAmount, 10)
+	record += strconv.FormatUint(pc.MinAcceptableAmount, 10)
+	record += strconv.FormatUint(pc.TradingFee, 10)
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (pc *PDECrossPoolTradeRequest) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte) ([][]string, error) {
+	actionContent := PDECrossPoolTradeRequestAction{
+		Meta:    *pc,
+		TxReqID: *tx.Hash(),
+		ShardID: shardID,
+	}
+	actionContentBytes, err := json.Marshal(actionContent)
+	if err != nil {
+		return [][]string{}, err
+	}
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	action := []string{strconv.Itoa(PDECrossPoolTradeRequestMeta), actionContentBase64Str}
+	return [][]string{action}, nil
+}
+
+func (pc *PDECrossPoolTradeRequest) CalculateSize() uint64 {
+	return calculateSize(pc)
+}
@@ -0,0 +1,144 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+type PDECrossPoolTradeResponse struct {
+	MetadataBase
+	TradeStatus   string
+	RequestedTxID common.Hash
+}
+
+func NewPDECrossPoolTradeResponse(
+	tradeStatus string,
+	requestedTxID common.Hash,
+	metaType int,
+) *PDECrossPoolTradeResponse {
+	metadataBase := MetadataBase{
+		Type: metaType,
+	}
+	return &PDECrossPoolTradeResponse{
+		TradeStatus:   tradeStatus,
+		RequestedTxID: requestedTxID,
+		MetadataBase:  metadataBase,
+	}
+}
+
+func (iRes PDECrossPoolTradeResponse) CheckTransactionFee(tr Transaction, minFee uint64, beaconHeight int64, db *statedb.StateDB) bool {
+	// no need to have fee for this tx
+	return true
+}
+
+func (iRes PDECrossPoolTradeResponse) ValidateTxWithBlockChain(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, transactionStateDB *statedb.StateDB) (bool, error) {
+	// no need to validate tx with blockchain, just need to validate with requested tx (via RequestedTxID)
+	return false, nil
+}
+
+func (iRes PDECrossPoolTradeResponse) ValidateSanityData(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, beaconHeight uint64, tx Transaction) (bool, bool, error) {
+	return false, true, nil
+}
+
+func (iRes PDECrossPoolTradeResponse) ValidateMetadataByItself() bool {
+	// The validation just need to check at tx level, so returning true here
+	return iRes.Type == PDECrossPoolTradeResponseMeta
+}
+
+func (iRes PDECrossPoolTradeResponse) Hash() *common.Hash {
+	record := iRes.RequestedTxID.String()
+	record += iRes.TradeStatus
+	record += iRes.MetadataBase.Hash().String()
+
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (iRes *PDECrossPoolTradeResponse) CalculateSize() uint64 {
+	return calculateSize(iRes)
+}
+
+func (iRes PDECrossPoolTradeResponse) VerifyMinerCreatedTxBeforeGettingInBlock(txsInBlock []Transaction, txsUsed []int, insts [][]string, instUsed []int, shardID byte, tx Transaction, chainRetriever ChainRetriever, ac *AccumulatedValues, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever) (bool, error) {
+	idx := -1
+	for i, inst := range insts {
+		if len(inst) < 4 { // this is not PDECrossPoolTradeRequest instruction
+			continue
+		}
+		instMetaType := inst[0]
+		if instUsed[i] > 0 ||
+			instMetaType != strconv.Itoa(PDECrossPoolTradeRequestMeta) {
+			continue
+		}
+		instTradeStatus := inst[2]
+		if instTradeStatus != iRes.TradeStatus || (instTradeStatus != common.PDETradeRefundChainStatus && instTradeStatus != common.PDETradeAcceptedChainStatus) {
+			continue
+		}
+
+		var shardIDFromInst byte
+		var txReqIDFromInst common.Hash
+		var receiverAddrStrFromInst string
+		var receivingAmtFromInst uint64
+		var receivingTokenIDStr string
+		if instTradeStatus == common.PDETradeRefundChainStatus {
+			contentBytes, err := base64.StdEncoding.DecodeString(inst[3])
+			if err != nil {
+				Logger.log.Error("WARNING - VALIDATION: an error occured while parsing instruction content: ", err)
+				continue
+			}
+			var tradeRequestAction PDECrossPoolTradeRequestAction
+			err = json.Unmarshal(contentBytes, &tradeRequestAction)
+			if err != nil {
+				Logger.log.Error("WARNING - VALIDATION: an error occured while parsing instruction content: ", err)
+				continue
+			}
+			shardIDFromInst = tradeRequestAction.ShardID
+			txReqIDFromInst = tradeRequestAction.TxReqID
+			receiverAddrStrFromInst = tradeRequestAction.Meta.TraderAddressStr
+			receivingTokenIDStr = tradeRequestAction.Meta.TokenIDToSellStr
+			receivingAmtFromInst = tradeRequestAction.Meta.SellAmount + tradeRequestAction.Meta.TradingFee
+		} else { // trade accepted
+			var tradeAcceptedContent PDECrossPoolTradeAcceptedContent
+			err := json.Unmarshal([]byte(inst[3]), &tradeAcceptedContent)
+			if err != nil {
+				Logger.log.Error("WARNING - VALIDATION: an error occured while parsing instruction content: ", err)
+				continue
+			}
+			shardIDFromInst = tradeAcceptedContent.ShardID
+			txReqIDFromInst = tradeAcceptedContent.RequestedTxID
+			receiverAddrStrFromInst = tradeAcceptedContent.TraderAddressStr
+			receivingTokenIDStr = tradeAcceptedContent.TokenIDToBuyStr
+			receivingAmtFromInst = tradeAcceptedContent.ReceiveAmount
+		}
+
+		if !bytes.Equal(iRes.RequestedTxID[:], txReqIDFromInst[:]) ||
+			shardID != shardIDFromInst {
+			continue
+		}
+		key, err := wallet.Base58CheckDeserialize(receiverAddrStrFromInst)
+		if err != nil {
+			Logger.log.Info("WARNING - VALIDATION: an error occured while deserializing receiver address string: ", err)
+			continue
+		}
+		_, pk, paidAmount, assetID := tx.GetTransferData()
+		if !bytes.Equal(key.KeySet.PaymentAddress.Pk[:], pk[:]) ||
+			receivingAmtFromInst != paidAmount ||
+			receivingTokenIDStr != assetID.String() {
+			continue
+		}
+		idx = i
+		break
+	}
+	if idx == -1 { // not found the issuance request tx for this response
+		return false, fmt.Errorf(fmt.Sprintf("no PDECrossPoolTradeRequest tx found for PDECrossPoolTradeResponse tx %s", tx.Hash().String()))
+	}
+	instUsed[idx] = 1
+	return true, nil
+}
@@ -0,0 +1,175 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// MaxPDELimitOrderActiveHeights - max number of beacon heights a limit order can stay in the order book
+const MaxPDELimitOrderActiveHeights = 100000
+
+// MaxPDELimitOrdersPerPair - max number of open limit orders of a pool pair in the order book
+const MaxPDELimitOrdersPerPair = 500
+
+// PDELimitOrderRequest - privacy dex limit order, it stays in the order book for ActiveHeights beacon heights
+// and is filled by beacon as soon as the pool price gives at least MinAcceptableAmount for the whole SellAmount
+type PDELimitOrderRequest struct {
+	TokenIDToBuyStr     string
+	TokenIDToSellStr    string
+	SellAmount          uint64 // must be equal to vout value
+	MinAcceptableAmount uint64 // of TokenIDToBuyStr, the limit price of the order
+	TradingFee          uint64
+	TraderAddressStr    string
+	ActiveHeights       uint64
+	MetadataBase
+}
+
+type PDELimitOrderRequestAction struct {
+	Meta    PDELimitOrderRequest
+	TxReqID common.Hash
+	ShardID byte
+}
+
+// PDELimitOrderRefundContent - content of instructions giving back the selling amount and trading fee of an order
+// that is removed from the order book without being filled
+type PDELimitOrderRefundContent struct {
+	OrderID          common.Hash
+	TraderAddressStr string
+	TokenIDStr       string
+	Amount           uint64
+	ShardID          byte
+}
+
+func NewPDELimitOrderRequest(
+	tokenIDToBuyStr string,
+	tokenIDToSellStr string,
+	sellAmount uint64,
+	minAcceptableAmount uint64,
+	tradingFee uint64,
+	traderAddressStr string,
+	activeHeights uint64,
+	metaType int,
+) (*PDELimitOrderRequest, error) {
+	metadataBase := MetadataBase{
+		Type: metaType,
+	}
+	pdeLimitOrderRequest := &PDELimitOrderRequest{
+		TokenIDToBuyStr:     tokenIDToBuyStr,
+		TokenIDToSellStr:    tokenIDToSellStr,
+		SellAmount:          sellAmount,
+		MinAcceptableAmount: minAcceptableAmount,
+		TradingFee:          tradingFee,
+		TraderAddressStr:    traderAddressStr,
+		ActiveHeights:       activeHeights,
+	}
+	pdeLimitOrderRequest.MetadataBase = metadataBase
+	return pdeLimitOrderRequest, nil
+}
+
+func (pc PDELimitOrderRequest) ValidateTxWithBlockChain(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, transactionStateDB *statedb.StateDB) (bool, error) {
+	// NOTE: the pool pair is checked by beacon when the order is opened
+	return true, nil
+}
+
+func (pc PDELimitOrderRequest) ValidateSanityData(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, beaconHeight uint64, tx Transaction) (bool, bool, error) {
+	// Note: the metadata was already verified with *transaction.TxCustomToken level so no need to verify with *transaction.Tx level again as *transaction.Tx is embedding property of *transaction.TxCustomToken
+	if tx.GetType() == common.TxCustomTokenPrivacyType && reflect.TypeOf(tx).String() == "*transaction.Tx" {
+		return true, true, nil
+	}
+
+	keyWallet, err := wallet.Base58CheckDeserialize(pc.TraderAddressStr)
+	if err != nil {
+		return false, false, NewMetadataTxError(PDELimitOrderRequestFromMapError, errors.New("TraderAddressStr incorrect"))
+	}
+	traderAddr := keyWallet.KeySet.PaymentAddress
+
+	if len(traderAddr.Pk) == 0 {
+		return false, false, errors.New("Wrong request info's trader address")
+	}
+	if !tx.IsCoinsBurning(chainRetriever, shardViewRetriever, beaconViewRetriever, beaconHeight) {
+		return false, false, errors.New("Must send coin to burning address")
+	}
+	if (pc.SellAmount + pc.TradingFee) != tx.CalculateTxValue() {
+		return false, false, errors.New("Total of selling amount and trading fee should be equal to the tx value")
+	}
+	if !bytes.Equal(tx.GetSigPubKey()[:], traderAddr.Pk[:]) {
+		return false, false, errors.New("TraderAddress incorrect")
+	}
+	if pc.SellAmount == 0 || pc.MinAcceptableAmount == 0 {
+		return false, false, NewMetadataTxError(PDELimitOrderRequestFromMapError, errors.New("SellAmount and MinAcceptableAmount should be large than 0"))
+	}
+	if pc.ActiveHeights == 0 || pc.ActiveHeights > MaxPDELimitOrderActiveHeights {
+		return false, false, NewMetadataTxError(PDELimitOrderRequestFromMapError, errors.New("ActiveHeights should be from 1 to "+strconv.Itoa(MaxPDELimitOrderActiveHeights)))
+	}
+
+	_, err = common.Hash{}.NewHashFromStr(pc.TokenIDToBuyStr)
+	if err != nil {
+		return false, false, NewMetadataTxError(PDELimitOrderRequestFromMapError, errors.New("TokenIDToBuyStr incorrect"))
+	}
+	tokenIDToSell, err := common.Hash{}.NewHashFromStr(pc.TokenIDToSellStr)
+	if err != nil {
+		return false, false, NewMetadataTxError(PDELimitOrderRequestFromMapError, errors.New("TokenIDToSellStr incorrect"))
+	}
+	if pc.TokenIDToBuyStr == pc.TokenIDToSellStr {
+		return false, false, errors.New("TokenIDToBuyStr should be different from TokenIDToSellStr")
+	}
+
+	if !bytes.Equal(tx.GetTokenID()[:], tokenIDToSell[:]) {
+		return false, false, errors.New("Wrong request info's token id, it should be equal to tx's token id.")
+	}
+
+	if tx.GetType() == common.TxNormalType && pc.TokenIDToSellStr != common.PRVCoinID.String() {
+		return false, false, errors.New("With tx normal privacy, the tokenIDStr should be PRV, not custom token.")
+	}
+
+	if tx.GetType() == common.TxCustomTokenPrivacyType && pc.TokenIDToSellStr == common.PRVCoinID.String() {
+		return false, false, errors.New("With tx custome token privacy, the tokenIDStr should not be PRV, but custom token.")
+	}
+
+	return true, true, nil
+}
+
+func (pc PDELimitOrderRequest) ValidateMetadataByItself() bool {
+	return pc.Type == PDELimitOrderRequestMeta
+}
+
+func (pc PDELimitOrderRequest) Hash() *common.Hash {
+	record := pc.MetadataBase.Hash().String()
+	record += pc.TokenIDToBuyStr
+	record += pc.TokenIDToSellStr
+	record += pc.TraderAddressStr
+	record += strconv.FormatUint(pc.SellAmount, 10)
+	record += strconv.FormatUint(pc.MinAcceptableAmount, 10)
+	record += strconv.FormatUint(pc.TradingFee, 10)
+	record += strconv.FormatUint(pc.ActiveHeights, 10)
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (pc *PDELimitOrderRequest) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte) ([][]string, error) {
+	actionContent := PDELimitOrderRequestAction{
+		Meta:    *pc,
+		TxReqID: *tx.Hash(),
+		ShardID: shardID,
+	}
+	actionContentBytes, err := json.Marshal(actionContent)
+	if err != nil {
+		return [][]string{}, err
+	}
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	action := []string{strconv.Itoa(PDELimitOrderRequestMeta), actionContentBase64Str}
+	return [][]string{action}, nil
+}
+
+func (pc *PDELimitOrderRequest) CalculateSize() uint64 {
+	return calculateSize(pc)
+}
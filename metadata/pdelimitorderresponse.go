@@ -0,0 +1,168 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// PDELimitOrderResponse - pays the trader of a limit order when the order is filled, refunded, expired or cancelled,
+// RequestedTxID is always the id of the limit order request tx
+type PDELimitOrderResponse struct {
+	MetadataBase
+	OrderStatus   string
+	RequestedTxID common.Hash
+}
+
+func NewPDELimitOrderResponse(
+	orderStatus string,
+	requestedTxID common.Hash,
+	metaType int,
+) *PDELimitOrderResponse {
+	metadataBase := MetadataBase{
+		Type: metaType,
+	}
+	return &PDELimitOrderResponse{
+		OrderStatus:   orderStatus,
+		RequestedTxID: requestedTxID,
+		MetadataBase:  metadataBase,
+	}
+}
+
+func (iRes PDELimitOrderResponse) CheckTransactionFee(tr Transaction, minFee uint64, beaconHeight int64, db *statedb.StateDB) bool {
+	// no need to have fee for this tx
+	return true
+}
+
+func (iRes PDELimitOrderResponse) ValidateTxWithBlockChain(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, transactionStateDB *statedb.StateDB) (bool, error) {
+	// no need to validate tx with blockchain, just need to validate with requested tx (via RequestedTxID)
+	return false, nil
+}
+
+func (iRes PDELimitOrderResponse) ValidateSanityData(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, beaconHeight uint64, tx Transaction) (bool, bool, error) {
+	return false, true, nil
+}
+
+func (iRes PDELimitOrderResponse) ValidateMetadataByItself() bool {
+	// The validation just need to check at tx level, so returning true here
+	return iRes.Type == PDELimitOrderResponseMeta
+}
+
+func (iRes PDELimitOrderResponse) Hash() *common.Hash {
+	record := iRes.RequestedTxID.String()
+	record += iRes.OrderStatus
+	record += iRes.MetadataBase.Hash().String()
+
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (iRes *PDELimitOrderResponse) CalculateSize() uint64 {
+	return calculateSize(iRes)
+}
+
+// instMetaTypeAndStatus returns the type and status of the beacon instruction paid by the response
+func (iRes PDELimitOrderResponse) instMetaTypeAndStatus() (string, string) {
+	if iRes.OrderStatus == common.PDELimitOrderCancelledChainStatus {
+		return strconv.Itoa(PDECancelOrderRequestMeta), common.PDECancelOrderAcceptedChainStatus
+	}
+	return strconv.Itoa(PDELimitOrderRequestMeta), iRes.OrderStatus
+}
+
+func (iRes PDELimitOrderResponse) VerifyMinerCreatedTxBeforeGettingInBlock(txsInBlock []Transaction, txsUsed []int, insts [][]string, instUsed []int, shardID byte, tx Transaction, chainRetriever ChainRetriever, ac *AccumulatedValues, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever) (bool, error) {
+	if iRes.OrderStatus != common.PDELimitOrderFilledChainStatus &&
+		iRes.OrderStatus != common.PDELimitOrderRefundChainStatus &&
+		iRes.OrderStatus != common.PDELimitOrderExpiredChainStatus &&
+		iRes.OrderStatus != common.PDELimitOrderCancelledChainStatus {
+		return false, fmt.Errorf("invalid order status %s of PDELimitOrderResponse tx %s", iRes.OrderStatus, tx.Hash().String())
+	}
+	expectedMetaType, expectedStatus := iRes.instMetaTypeAndStatus()
+	idx := -1
+	for i, inst := range insts {
+		if len(inst) < 4 { // this is not PDELimitOrderRequest or PDECancelOrderRequest instruction
+			continue
+		}
+		if instUsed[i] > 0 || inst[0] != expectedMetaType || inst[2] != expectedStatus {
+			continue
+		}
+
+		var shardIDFromInst byte
+		var txReqIDFromInst common.Hash
+		var receiverAddrStrFromInst string
+		var receivingAmtFromInst uint64
+		var receivingTokenIDStr string
+		switch expectedStatus {
+		case common.PDELimitOrderRefundChainStatus:
+			contentBytes, err := base64.StdEncoding.DecodeString(inst[3])
+			if err != nil {
+				Logger.log.Error("WARNING - VALIDATION: an error occured while parsing instruction content: ", err)
+				continue
+			}
+			var limitOrderRequestAction PDELimitOrderRequestAction
+			err = json.Unmarshal(contentBytes, &limitOrderRequestAction)
+			if err != nil {
+				Logger.log.Error("WARNING - VALIDATION: an error occured while parsing instruction content: ", err)
+				continue
+			}
+			shardIDFromInst = limitOrderRequestAction.ShardID
+			txReqIDFromInst = limitOrderRequestAction.TxReqID
+			receiverAddrStrFromInst = limitOrderRequestAction.Meta.TraderAddressStr
+			receivingTokenIDStr = limitOrderRequestAction.Meta.TokenIDToSellStr
+			receivingAmtFromInst = limitOrderRequestAction.Meta.SellAmount + limitOrderRequestAction.Meta.TradingFee
+		case common.PDELimitOrderFilledChainStatus:
+			var filledContent PDETradeAcceptedContent
+			err := json.Unmarshal([]byte(inst[3]), &filledContent)
+			if err != nil {
+				Logger.log.Error("WARNING - VALIDATION: an error occured while parsing instruction content: ", err)
+				continue
+			}
+			shardIDFromInst = filledContent.ShardID
+			txReqIDFromInst = filledContent.RequestedTxID
+			receiverAddrStrFromInst = filledContent.TraderAddressStr
+			receivingTokenIDStr = filledContent.TokenIDToBuyStr
+			receivingAmtFromInst = filledContent.ReceiveAmount
+		default: // expired or cancelled
+			var refundContent PDELimitOrderRefundContent
+			err := json.Unmarshal([]byte(inst[3]), &refundContent)
+			if err != nil {
+				Logger.log.Error("WARNING - VALIDATION: an error occured while parsing instruction content: ", err)
+				continue
+			}
+			shardIDFromInst = refundContent.ShardID
+			txReqIDFromInst = refundContent.OrderID
+			receiverAddrStrFromInst = refundContent.TraderAddressStr
+			receivingTokenIDStr = refundContent.TokenIDStr
+			receivingAmtFromInst = refundContent.Amount
+		}
+
+		if !bytes.Equal(iRes.RequestedTxID[:], txReqIDFromInst[:]) ||
+			shardID != shardIDFromInst {
+			continue
+		}
+		key, err := wallet.Base58CheckDeserialize(receiverAddrStrFromInst)
+		if err != nil {
+			Logger.log.Info("WARNING - VALIDATION: an error occured while deserializing receiver address string: ", err)
+			continue
+		}
+		_, pk, paidAmount, assetID := tx.GetTransferData()
+		if !bytes.Equal(key.KeySet.PaymentAddress.Pk[:], pk[:]) ||
+			receivingAmtFromInst != paidAmount ||
+			receivingTokenIDStr != assetID.String() {
+			continue
+		}
+		idx = i
+		break
+	}
+	if idx == -1 { // not found the issuance request tx for this response
+		return false, fmt.Errorf(fmt.Sprintf("no PDELimitOrderRequest tx found for PDELimitOrderResponse tx %s", tx.Hash().String()))
+	}
+	instUsed[idx] = 1
+	return true, nil
+}
@@ -45,6 +45,7 @@ type PDETradeAcceptedContent struct {
 	Token2PoolValueOperation TokenPoolValueOperation
 	ShardID                  byte
 	RequestedTxID            common.Hash
+	PoolFee                  uint64 `json:",omitempty"` // of the sold token, kept in the pool for liquidity providers
 }
 
 func NewPDETradeRequest(
@@ -116,14 +116,13 @@ func (custodianDeposit PortalCustodianDeposit) ValidateSanityData(chainRetriever
 	}
 
 	for tokenID, remoteAddr := range custodianDeposit.RemoteAddresses {
-		if !common.IsPortalToken(tokenID) {
+		if !chainRetriever.IsPortalToken(tokenID) {
 			return false, false, errors.New("TokenID in remote address is invalid")
 		}
 		if len(remoteAddr) == 0 {
 			return false, false, errors.New("Remote address is invalid")
 		}
-		chainID := GetChainIDByTokenID(tokenID, chainRetriever)
-		if !IsValidRemoteAddress(chainRetriever, remoteAddr, tokenID, chainID) {
+		if !IsValidRemoteAddress(chainRetriever, remoteAddr, tokenID) {
 			return false, false, fmt.Errorf("Remote address %v is not a valid address of tokenID %v", remoteAddr, tokenID)
 		}
 	}
@@ -73,9 +73,9 @@ func (portalExchangeRates PortalExchangeRates) ValidateTxWithBlockChain(
 }
 
 func (portalExchangeRates PortalExchangeRates) ValidateSanityData(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, beaconHeight uint64, txr Transaction) (bool, bool, error) {
-	feederAddress := chainRetriever.GetPortalFeederAddress()
-	if portalExchangeRates.SenderAddress != feederAddress {
-		return false, false, fmt.Errorf("Sender must be feeder's address %v\n", feederAddress)
+	feederAddresses := chainRetriever.GetPortalFeederAddresses()
+	if common.IndexOfStr(portalExchangeRates.SenderAddress, feederAddresses) == -1 {
+		return false, false, fmt.Errorf("Sender must be one of feeders' addresses %v\n", feederAddresses)
 	}
 
 	keyWallet, err := wallet.Base58CheckDeserialize(portalExchangeRates.SenderAddress)
@@ -97,7 +97,7 @@ func (portalExchangeRates PortalExchangeRates) ValidateSanityData(chainRetriever
 	}
 
 	for _, value := range portalExchangeRates.Rates {
-		if !common.IsPortalExchangeRateToken(value.PTokenID) {
+		if !chainRetriever.IsPortalExchangeRateToken(value.PTokenID) {
 			return false, false, errors.New("Public token is not supported currently")
 		}
 
@@ -105,7 +105,7 @@ func (custodianDeposit PortalLiquidationCustodianDeposit) ValidateSanityData(cha
 		return false, false, errors.New("deposit amount should be equal to the tx value")
 	}
 
-	if !common.IsPortalToken(custodianDeposit.PTokenId) {
+	if !chainRetriever.IsPortalToken(custodianDeposit.PTokenId) {
 		return false, false, errors.New("TokenID in remote address is invalid")
 	}
 
@@ -132,7 +132,7 @@ func (portalUserRegister PortalUserRegister) ValidateSanityData(chainRetriever C
 	}
 
 	// validate amount register
-	minAmount := common.MinAmountPortalPToken[portalUserRegister.PTokenId]
+	minAmount := GetMinAmountPortalPToken(chainRetriever, portalUserRegister.PTokenId)
 	if portalUserRegister.RegisterAmount < minAmount {
 		return false, false, fmt.Errorf("register amount should be larger or equal to %v", minAmount)
 	}
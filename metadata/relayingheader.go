@@ -9,6 +9,7 @@ import (
 
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	ethrelaying "github.com/incognitochain/incognito-chain/relaying/eth"
 	"github.com/incognitochain/incognito-chain/wallet"
 )
 
@@ -105,11 +106,29 @@ func (rh RelayingHeader) ValidateSanityData(chainRetriever ChainRetriever, shard
 		return false, false, errors.New("header is invalid")
 	}
 
+	// eth headers are relayed in batches starting at BlockHeight
+	if rh.Type == RelayingETHHeaderMeta {
+		if !chainRetriever.IsETHRelayActivated(beaconHeight) {
+			return false, false, errors.New("eth header relaying is not activated yet")
+		}
+		headers, err := ethrelaying.ParseHeadersFromB64EncodeStr(rh.Header)
+		if err != nil {
+			return false, false, err
+		}
+		err = ethrelaying.ValidateHeaderBatch(headers)
+		if err != nil {
+			return false, false, err
+		}
+		if headers[0].Number.Uint64() != rh.BlockHeight {
+			return false, false, errors.New("BlockHeight must be the number of the first header")
+		}
+	}
+
 	return true, true, nil
 }
 
 func (rh RelayingHeader) ValidateMetadataByItself() bool {
-	return rh.Type == RelayingBNBHeaderMeta || rh.Type == RelayingBTCHeaderMeta
+	return rh.Type == RelayingBNBHeaderMeta || rh.Type == RelayingBTCHeaderMeta || rh.Type == RelayingETHHeaderMeta
 }
 
 func (rh RelayingHeader) Hash() *common.Hash {
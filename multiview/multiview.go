@@ -97,6 +97,19 @@ func (multiView *MultiView) AddView(view View) bool {
 	return <-res
 }
 
+// Reset replaces all views by view, which becomes both best and final view (e.g view downloaded by fast sync)
+func (multiView *MultiView) Reset(view View) {
+	res := make(chan struct{})
+	multiView.actionCh <- func() {
+		multiView.viewByHash = map[common.Hash]View{*view.GetHash(): view}
+		multiView.viewByPrevHash = make(map[common.Hash][]View)
+		multiView.finalView = nil
+		multiView.updateViewState(view)
+		res <- struct{}{}
+	}
+	<-res
+}
+
 func (multiView *MultiView) GetBestView() View {
 	return multiView.bestView
 }
@@ -140,7 +153,7 @@ func (multiView *MultiView) updateViewState(newView View) {
 			return
 		}
 		multiView.finalView = prev1View
-	} else if newView.GetBlock().GetVersion() == 2 {
+	} else if newView.GetBlock().GetVersion() >= 2 {
 		////update finalView: consensus 2
 		prev1Hash := multiView.bestView.GetPreviousHash()
 		prev1View := multiView.viewByHash[*prev1Hash]
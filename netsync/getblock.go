@@ -420,3 +420,11 @@ func (netSync *NetSync) streamBlkByHash(
 	close(blkCh)
 	return
 }
+
+func (netSync *NetSync) GetShardFastSyncView(shardID byte) ([]byte, error) {
+	return netSync.config.BlockChain.GetShardFastSyncView(shardID)
+}
+
+func (netSync *NetSync) GetShardStateNodes(shardID byte, hashes []common.Hash) [][]byte {
+	return netSync.config.BlockChain.GetShardStateNodes(shardID, hashes)
+}
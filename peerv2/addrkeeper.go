@@ -132,6 +132,57 @@ func (keeper *AddrKeeper) chooseHighwayFromList(ourPID peer.ID) (rpcclient.Highw
 	return addr, nil
 }
 
+// ChooseBackupHighways returns up to n highways of the known list to connect to in addition to the excluded ones (already connected);
+// ignored addresses are never chosen. Unlike ChooseHighway, the list of highways is not refreshed.
+func (keeper *AddrKeeper) ChooseBackupHighways(ourPID peer.ID, n int, excluded addresses) (addresses, error) {
+	filterAddrs := addresses{}
+	for _, addr := range getNonIgnoredAddrs(keeper.addrs, keeper.ignoreHWUntil) {
+		if len(addr.Libp2pAddr) == 0 { // bootnode address
+			continue
+		}
+		isExcluded := false
+		for _, excludedAddr := range excluded {
+			if addr == excludedAddr {
+				isExcluded = true
+				break
+			}
+		}
+		if !isExcluded {
+			filterAddrs = append(filterAddrs, addr)
+		}
+	}
+	if n <= 0 || len(filterAddrs) == 0 {
+		return addresses{}, nil
+	}
+	Logger.Infof("Choosing %v backup highways from list %v", n, filterAddrs)
+	return choosePeers(filterAddrs, ourPID, n)
+}
+
+// choosePeers picks n distinct peers from a list using consistent hashing
+func choosePeers(peers addresses, id peer.ID, n int) (addresses, error) {
+	cst := consistent.New()
+	cst.NumberOfReplicas = 1000
+	for _, p := range peers {
+		cst.Add(p.Libp2pAddr)
+	}
+
+	closests, err := cst.GetN(string(id), n)
+	if err != nil {
+		return nil, errors.Errorf("could not get consistent-hashing peers %v %v", peers, id)
+	}
+
+	chosen := addresses{}
+	for _, closest := range closests {
+		for _, p := range peers {
+			if p.Libp2pAddr == closest {
+				chosen = append(chosen, p)
+				break
+			}
+		}
+	}
+	return chosen, nil
+}
+
 // choosePeer picks a peer from a list using consistent hashing
 func choosePeer(peers addresses, id peer.ID) (rpcclient.HighwayAddr, error) {
 	cst := consistent.New()
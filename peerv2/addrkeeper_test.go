@@ -192,3 +192,23 @@ func TestGetAllHighways(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, addresses(hwAddrs["all"]), hws)
 }
+
+// Checks if backup highways never include the excluded (already connected) and ignored addresses
+func TestChooseBackupHighways(t *testing.T) {
+	hwAddrs := []rpcclient.HighwayAddr{
+		rpcclient.HighwayAddr{Libp2pAddr: testHighwayAddress},
+		rpcclient.HighwayAddr{Libp2pAddr: testHighwayAddress2},
+	}
+	keeper := NewAddrKeeper()
+	keeper.addrs = hwAddrs
+
+	pid := peer.ID("")
+	chosen, err := keeper.ChooseBackupHighways(pid, 2, addresses{hwAddrs[0]})
+	assert.Nil(t, err)
+	assert.Equal(t, addresses{hwAddrs[1]}, chosen)
+
+	keeper.IgnoreAddress(hwAddrs[1])
+	chosen, err = keeper.ChooseBackupHighways(pid, 2, addresses{hwAddrs[0]})
+	assert.Nil(t, err)
+	assert.Len(t, chosen, 0)
+}
@@ -13,6 +13,7 @@ import (
 func NewBlockProvider(p *p2pgrpc.GRPCProtocol, ns NetSync) *BlockProvider {
 	bp := &BlockProvider{NetSync: ns}
 	proto.RegisterHighwayServiceServer(p.GetGRPCServer(), bp)
+	proto.RegisterStateSyncServiceServer(p.GetGRPCServer(), bp)
 	go p.Serve() // NOTE: must serve after registering all services
 	return bp
 }
@@ -83,6 +84,29 @@ func (bp *BlockProvider) GetBlockCrossShardByHash(ctx context.Context, req *prot
 	return nil, nil
 }
 
+func (bp *BlockProvider) GetShardView(ctx context.Context, req *proto.GetShardViewRequest) (*proto.GetShardViewResponse, error) {
+	Logger.Infof("[fastsync] Receive GetShardView shard %v request, uuid = %s", req.Shard, req.GetUUID())
+	data, err := bp.NetSync.GetShardFastSyncView(byte(req.Shard))
+	if err != nil {
+		return nil, err
+	}
+	return &proto.GetShardViewResponse{Data: data}, nil
+}
+
+func (bp *BlockProvider) GetStateNodes(ctx context.Context, req *proto.GetStateNodesRequest) (*proto.GetStateNodesResponse, error) {
+	uuid := req.GetUUID()
+	hashes := []common.Hash{}
+	for _, hashBytes := range req.Hashes {
+		hash := common.Hash{}
+		if err := hash.SetBytes(hashBytes); err != nil {
+			return nil, err
+		}
+		hashes = append(hashes, hash)
+	}
+	Logger.Infof("[fastsync] Receive GetStateNodes shard %v request %v nodes, uuid = %s", req.Shard, len(hashes), uuid)
+	return &proto.GetStateNodesResponse{Data: bp.NetSync.GetShardStateNodes(byte(req.Shard), hashes)}, nil
+}
+
 func (bp *BlockProvider) StreamBlockByHeight(
 	req *proto.BlockByHeightRequest,
 	stream proto.HighwayService_StreamBlockByHeightServer,
@@ -136,6 +160,7 @@ func (bp *BlockProvider) StreamBlockByHash(
 
 type BlockProvider struct {
 	proto.UnimplementedHighwayServiceServer
+	proto.UnimplementedStateSyncServiceServer
 	NetSync NetSync
 }
 
@@ -144,4 +169,6 @@ type NetSync interface {
 	GetBlockBeaconByHash(blkHashes []common.Hash) []wire.Message
 	StreamBlockByHeight(fromPool bool, req *proto.BlockByHeightRequest) chan interface{}
 	StreamBlockByHash(fromPool bool, req *proto.BlockByHashRequest) chan interface{}
+	GetShardFastSyncView(shardID byte) ([]byte, error)
+	GetShardStateNodes(shardID byte, hashes []common.Hash) [][]byte
 }
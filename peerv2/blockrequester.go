@@ -121,6 +121,11 @@ func (c *BlockRequester) UpdateTarget(p peer.ID) {
 	c.peerIDs <- p
 }
 
+// Stop closes the connection to the highway and stops dialing it
+func (c *BlockRequester) Stop() {
+	c.stop <- 1
+}
+
 func (c *BlockRequester) Target() string {
 	if c.conn == nil {
 		return ""
@@ -305,3 +310,61 @@ type syncBlkInfo struct {
 	heights       []uint64
 	hashes        [][]byte
 }
+
+func (c *BlockRequester) GetShardView(
+	ctx context.Context,
+	shardID int32,
+	peerID string,
+) ([]byte, error) {
+	c.RLock()
+	defer c.RUnlock()
+	if !c.ready() {
+		return nil, errors.New("requester still not ready")
+	}
+	uuid := genUUID()
+	Logger.Infof("[fastsync] Requesting shard %v view from peer %v, uuid = %s", shardID, peerID, uuid)
+	client := proto.NewStateSyncServiceClient(c.conn)
+	reply, err := client.GetShardView(
+		ctx,
+		&proto.GetShardViewRequest{
+			Shard:        shardID,
+			UUID:         uuid,
+			SyncFromPeer: peerID,
+		},
+		grpc.MaxCallRecvMsgSize(MaxCallRecvMsgSize),
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return reply.Data, nil
+}
+
+func (c *BlockRequester) GetStateNodes(
+	ctx context.Context,
+	shardID int32,
+	hashes [][]byte,
+	peerID string,
+) ([][]byte, error) {
+	c.RLock()
+	defer c.RUnlock()
+	if !c.ready() {
+		return nil, errors.New("requester still not ready")
+	}
+	uuid := genUUID()
+	Logger.Infof("[fastsync] Requesting %v state nodes of shard %v from peer %v, uuid = %s", len(hashes), shardID, peerID, uuid)
+	client := proto.NewStateSyncServiceClient(c.conn)
+	reply, err := client.GetStateNodes(
+		ctx,
+		&proto.GetStateNodesRequest{
+			Shard:        shardID,
+			Hashes:       hashes,
+			UUID:         uuid,
+			SyncFromPeer: peerID,
+		},
+		grpc.MaxCallRecvMsgSize(MaxCallRecvMsgSize),
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return reply.Data, nil
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/hex"
 	"reflect"
+	"strings"
 	"time"
 
+	lru "github.com/hashicorp/golang-lru"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/peerv2/proto"
@@ -27,8 +29,11 @@ func NewConnManager(
 	dispatcher *Dispatcher,
 	nodeMode string,
 	relayShard []byte,
+	wireCodec string,
+	numHighways int,
 ) *ConnManager {
 	pubkey, _ := ikey.ToBase58()
+	seenMessages, _ := lru.New(SeenMessagesCacheSize)
 	return &ConnManager{
 		info: info{
 			consensusData: cd,
@@ -36,6 +41,7 @@ func NewConnManager(
 			relayShard:    relayShard,
 			nodeMode:      nodeMode,
 			peerID:        host.Host.ID(),
+			wireCodec:     wireCodec,
 		},
 		keeper:               NewAddrKeeper(),
 		LocalHost:            host,
@@ -44,6 +50,12 @@ func NewConnManager(
 		disp:                 dispatcher,
 		IsMasterNode:         false,
 		registerRequests:     make(chan peer.ID, 100),
+		binaryPublishers:     make(map[peer.ID]time.Time),
+		numHighways:          numHighways,
+		scorer:               NewHighwayScorer(),
+		prober:               &pingProber{host: host.Host},
+		newBackupRequester:   newBackupRequester(host),
+		seenMessages:         seenMessages,
 		stop:                 make(chan int),
 	}
 }
@@ -62,7 +74,7 @@ func (cm *ConnManager) PublishMessage(msg wire.Message) error {
 				// Logger.Info("[hy]", availableTopic)
 				if (availableTopic.Act == proto.MessageTopicPair_PUB) || (availableTopic.Act == proto.MessageTopicPair_PUBSUB) {
 					topic = availableTopic.Name
-					err := broadcastMessage(msg, topic, cm.ps)
+					err := broadcastMessage(msg, topic, cm.ps, cm.wireCodec)
 					if err != nil {
 						Logger.Errorf("Broadcast to topic %v error %v", topic, err)
 						return err
@@ -92,7 +104,7 @@ func (cm *ConnManager) PublishMessageToShard(msg wire.Message, shardID byte) err
 				Logger.Info(availableTopic)
 				cID := GetCommitteeIDOfTopic(availableTopic.Name)
 				if (byte(cID) == shardID) && ((availableTopic.Act == proto.MessageTopicPair_PUB) || (availableTopic.Act == proto.MessageTopicPair_PUBSUB)) {
-					return broadcastMessage(msg, availableTopic.Name, cm.ps)
+					return broadcastMessage(msg, availableTopic.Name, cm.ps, cm.wireCodec)
 				}
 			}
 		}
@@ -159,6 +171,8 @@ func (cm *ConnManager) BroadcastCommittee(
 type ForcedSubscriber interface {
 	Subscribe(forced bool) error
 	GetMsgToTopics() msgToTopics
+	AddBackupHighway(hwID peer.ID, registerer Registerer)
+	RemoveBackupHighway(hwID peer.ID)
 }
 
 type ConnManager struct {
@@ -175,6 +189,7 @@ type ConnManager struct {
 	messages         chan *pubsub.Message // queue messages from all topics
 	data             chan []byte
 	registerRequests chan peer.ID
+	binaryPublishers map[peer.ID]time.Time // last time a publisher was seen on a binary topic
 
 	keeper     *AddrKeeper
 	discoverer HighwayDiscoverer
@@ -182,6 +197,13 @@ type ConnManager struct {
 	Requester  *BlockRequester
 	Provider   *BlockProvider
 
+	numHighways        int // number of highways to keep connections to, the current one and backups
+	backups            []*backupHighway
+	scorer             *HighwayScorer
+	prober             HighwayProber
+	newBackupRequester func(hwID peer.ID) (Registerer, func())
+	seenMessages       *lru.Cache // hashes of the last messages received from topics
+
 	stop chan int
 }
 
@@ -198,7 +220,10 @@ func (cm *ConnManager) process() {
 	for {
 		select {
 		case msg := <-cm.messages:
-			err := cm.disp.processInMessageString(string(msg.Data))
+			if cm.isLegacyDuplicate(msg) || (len(msg.GetTopicIDs()) > 0 && cm.isSeenMessage(msg.Data)) {
+				continue
+			}
+			err := cm.disp.processInMessage(msg.Data)
 			if err != nil {
 				Logger.Warn(err)
 			}
@@ -214,6 +239,26 @@ func (cm *ConnManager) process() {
 	}
 }
 
+// isLegacyDuplicate tells if msg is the legacy copy of a message also published on a binary topic,
+// publishers with dual codec send every message on both topics
+func (cm *ConnManager) isLegacyDuplicate(msg *pubsub.Message) bool {
+	topics := msg.GetTopicIDs()
+	if (cm.wireCodec != wire.WireCodecDual && cm.wireCodec != wire.WireCodecBinary) || len(topics) == 0 {
+		return false
+	}
+	from := msg.GetFrom()
+	if strings.HasSuffix(topics[0], wire.BinaryTopicSuffix) {
+		cm.binaryPublishers[from] = time.Now()
+		return false
+	}
+	lastSeen, ok := cm.binaryPublishers[from]
+	if ok && time.Since(lastSeen) > BinaryPublisherTimeout {
+		delete(cm.binaryPublishers, from)
+		return false
+	}
+	return ok
+}
+
 // keepHighwayConnection periodically checks liveliness of connection to highway
 // and try to connect if it's not available.
 func (cm *ConnManager) keepHighwayConnection() {
@@ -254,9 +299,10 @@ func (cm *ConnManager) keepHighwayConnection() {
 
 			addrInfo, err := getAddressInfo(currentHighway.Libp2pAddr)
 			if err != nil || cm.checkConnection(addrInfo) {
-				cm.keeper.IgnoreAddress(*currentHighway) // Not reconnect to this address for some time
-				currentHighway = nil                     // Failed retries, connect to new highway next iteration
+				cm.keeper.IgnoreAddress(*currentHighway)   // Not reconnect to this address for some time
+				currentHighway = cm.promoteBackupHighway() // Failed retries, use a backup or connect to new highway next iteration
 			}
+			currentHighway = cm.manageBackupHighways(currentHighway)
 
 		case <-refreshTimestep.C:
 			currentHighway, _ = refreshHighway()
@@ -380,16 +426,32 @@ func encodeMessage(msg wire.Message) (string, error) {
 	return messageHex, nil
 }
 
-func broadcastMessage(msg wire.Message, topic string, ps *pubsub.PubSub) error {
-	// Encode message to string first
-	messageHex, err := encodeMessage(msg)
+func broadcastMessage(msg wire.Message, topic string, ps *pubsub.PubSub, wireCodec string) error {
+	if wireCodec != wire.WireCodecBinary {
+		// Encode message to string first
+		messageHex, err := encodeMessage(msg)
+		if err != nil {
+			return err
+		}
+
+		// Broadcast
+		Logger.Infof("Publishing to topic %s", topic)
+		err = ps.Publish(topic, []byte(messageHex))
+		if err != nil {
+			return err
+		}
+	}
+	if wireCodec != wire.WireCodecDual && wireCodec != wire.WireCodecBinary {
+		return nil
+	}
+
+	messageBytes, err := wire.EncodeBinaryMessage(msg)
 	if err != nil {
+		Logger.Error("Can not encode binary message:"+msg.MessageType(), err)
 		return err
 	}
-
-	// Broadcast
-	Logger.Infof("Publishing to topic %s", topic)
-	return ps.Publish(topic, []byte(messageHex))
+	Logger.Infof("Publishing to topic %s", topic+wire.BinaryTopicSuffix)
+	return ps.Publish(topic+wire.BinaryTopicSuffix, messageBytes)
 }
 
 type HighwayDiscoverer interface {
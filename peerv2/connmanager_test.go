@@ -8,8 +8,11 @@ import (
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/peerv2/mocks"
 	"github.com/incognitochain/incognito-chain/peerv2/rpcclient"
+	"github.com/incognitochain/incognito-chain/wire"
 	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pb "github.com/libp2p/go-libp2p-pubsub/pb"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -204,9 +207,34 @@ func TestForcedSub(t *testing.T) {
 	assert.Equal(t, 1, sc.forced, "not subbed")
 }
 
+func TestIsLegacyDuplicate(t *testing.T) {
+	newMessage := func(from peer.ID, topic string) *pubsub.Message {
+		return &pubsub.Message{Message: &pb.Message{From: []byte(from), TopicIDs: []string{topic}}}
+	}
+	cm := ConnManager{
+		info:             info{wireCodec: wire.WireCodecDual},
+		binaryPublishers: make(map[peer.ID]time.Time),
+	}
+	// Legacy messages are kept until their publisher is seen on a binary topic
+	assert.False(t, cm.isLegacyDuplicate(newMessage("a", "abc")))
+	assert.False(t, cm.isLegacyDuplicate(newMessage("a", "abc"+wire.BinaryTopicSuffix)))
+	assert.True(t, cm.isLegacyDuplicate(newMessage("a", "abc")))
+	assert.False(t, cm.isLegacyDuplicate(newMessage("b", "abc")))
+
+	// Publisher not seen on binary topics for a while
+	cm.binaryPublishers["a"] = time.Now().Add(-BinaryPublisherTimeout - time.Second)
+	assert.False(t, cm.isLegacyDuplicate(newMessage("a", "abc")))
+
+	// Nodes with legacy codec never drop messages
+	cm = ConnManager{info: info{wireCodec: wire.WireCodecLegacy}, binaryPublishers: make(map[peer.ID]time.Time)}
+	assert.False(t, cm.isLegacyDuplicate(newMessage("a", "abc"+wire.BinaryTopicSuffix)))
+	assert.False(t, cm.isLegacyDuplicate(newMessage("a", "abc")))
+}
+
 type subscribeCounter struct {
-	normal int
-	forced int
+	normal  int
+	forced  int
+	backups []peer.ID
 }
 
 func (subCounter *subscribeCounter) Subscribe(forced bool) error {
@@ -222,6 +250,19 @@ func (subCounter *subscribeCounter) GetMsgToTopics() msgToTopics {
 	return msgToTopics{}
 }
 
+func (subCounter *subscribeCounter) AddBackupHighway(hwID peer.ID, _ Registerer) {
+	subCounter.backups = append(subCounter.backups, hwID)
+}
+
+func (subCounter *subscribeCounter) RemoveBackupHighway(hwID peer.ID) {
+	for i, id := range subCounter.backups {
+		if id == hwID {
+			subCounter.backups = append(subCounter.backups[:i], subCounter.backups[i+1:]...)
+			break
+		}
+	}
+}
+
 func setupHost() (*mocks.Host, *mocks.Network) {
 	net := &mocks.Network{}
 	h := &mocks.Host{}
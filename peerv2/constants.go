@@ -26,4 +26,13 @@ var (
 
 	IgnoreRPCDuration = 60 * time.Minute  // Ignore an address after a failed RPC
 	IgnoreHWDuration  = 360 * time.Minute // Ignore a highway when cannot connect
+
+	BinaryPublisherTimeout = 1 * time.Minute // Stop dropping legacy messages of a publisher not seen on binary topics
+
+	HighwayProbeTimeout     = 5 * time.Second // Timeout of a probe to a highway
+	HighwayScoreWindow      = 6               // Number of last probes a highway is scored on
+	HighwayLatencyWeight    = 0.3             // Weight of the last probe in the average latency
+	MinHighwayDeliveryRatio = 0.5             // Rotate out highways answering less probes
+	MaxHighwayLatency       = 2 * time.Second // Rotate out highways answering slower
+	SeenMessagesCacheSize   = 10000           // Number of inbound message hashes kept to drop copies relayed by other highways
 )
@@ -67,6 +67,22 @@ func (d *Dispatcher) processStreamBlk(blktype byte, data []byte) error {
 	return nil
 }
 
+// processInMessage decodes a message of the binary codec, other messages are legacy hex strings
+func (d *Dispatcher) processInMessage(data []byte) error {
+	if !wire.IsBinaryMessage(data) {
+		return d.processInMessageString(string(data))
+	}
+	message, err := wire.DecodeBinaryMessage(data)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	errProcessMessage := d.processMessageForEachType(reflect.TypeOf(message), message)
+	if errProcessMessage != nil {
+		return errors.WithStack(errProcessMessage)
+	}
+	return nil
+}
+
 //TODO hy parse msg here
 // processInMessageString - this is sub-function of InMessageHandler
 // after receiving a good message from stream,
@@ -0,0 +1,228 @@
+package peerv2
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/peerv2/rpcclient"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+// backupHighway is a highway connected in addition to the current one. FloodSub publishes to every
+// connected highway and receives from all of them, so a slow or partitioned highway doesn't isolate the node.
+// Block requests and topic registration still go through the current highway, a backup takes its place when it fails.
+type backupHighway struct {
+	addr          rpcclient.HighwayAddr
+	addrInfo      *peer.AddrInfo
+	registerer    Registerer // registers our role to the backup so it relays our topics too
+	stopRequester func()
+	disconnected  int
+	registered    bool
+}
+
+// newBackupRequester creates the gRPC requester registering our role to a backup highway
+func newBackupRequester(host *Host) func(hwID peer.ID) (Registerer, func()) {
+	return func(hwID peer.ID) (Registerer, func()) {
+		requester := NewRequester(host.GRPC)
+		requester.UpdateTarget(hwID)
+		return requester, requester.Stop
+	}
+}
+
+// manageBackupHighways keeps connections to numHighways-1 backup highways besides the current one:
+// it probes all of them, rotates out the backups failing to connect or badly scored and chooses new ones.
+// It returns the highway to use as current one, a better backup replaces a badly scored current highway.
+func (cm *ConnManager) manageBackupHighways(currentHighway *rpcclient.HighwayAddr) *rpcclient.HighwayAddr {
+	if cm.numHighways <= 1 {
+		return currentHighway
+	}
+
+	// The current highway might have been chosen among backups when refreshing the list
+	if currentHighway != nil {
+		for _, backup := range cm.backups {
+			if backup.addr == *currentHighway {
+				cm.removeBackupHighway(backup, false)
+				break
+			}
+		}
+	}
+
+	for _, backup := range append([]*backupHighway{}, cm.backups...) {
+		if cm.checkBackupConnection(backup) || cm.scorer.IsBad(backup.addrInfo.ID) {
+			Logger.Warnf("Rotating out backup highway %v, delivery ratio = %v, latency = %v", backup.addr, cm.scorer.DeliveryRatio(backup.addrInfo.ID), cm.scorer.Latency(backup.addrInfo.ID))
+			cm.removeBackupHighway(backup, true)
+		}
+	}
+
+	if currentHighway != nil {
+		cm.probeHighways(currentHighway)
+		if currentInfo, err := getAddressInfo(currentHighway.Libp2pAddr); err == nil && cm.scorer.IsBad(currentInfo.ID) {
+			if best := cm.bestBackupHighway(); best != nil && cm.scorer.Better(best.addrInfo.ID, currentInfo.ID) {
+				Logger.Warnf("Rotating out current highway %v, delivery ratio = %v, latency = %v", *currentHighway, cm.scorer.DeliveryRatio(currentInfo.ID), cm.scorer.Latency(currentInfo.ID))
+				cm.keeper.IgnoreAddress(*currentHighway)
+				cm.scorer.Remove(currentInfo.ID)
+				currentHighway = cm.promoteBackupHighway()
+			}
+		}
+	}
+
+	cm.addBackupHighways(currentHighway)
+	return currentHighway
+}
+
+// checkBackupConnection connects to a backup highway if needed and returns true when retries are maxed out
+func (cm *ConnManager) checkBackupConnection(backup *backupHighway) bool {
+	net := cm.LocalHost.Host.Network()
+	if net.Connectedness(backup.addrInfo.ID) != network.Connected {
+		backup.disconnected++
+		backup.registered = false // Register again once reconnected
+		Logger.Infof("Not connected to backup highway %v, connecting", backup.addr)
+		ctx, cancel := context.WithTimeout(context.Background(), DialTimeout)
+		defer cancel()
+		if err := cm.LocalHost.Host.Connect(ctx, *backup.addrInfo); err != nil {
+			Logger.Errorf("Could not connect to backup highway: %v %v", err, backup.addrInfo)
+		}
+		if backup.disconnected > MaxConnectionRetry {
+			return true
+		}
+	}
+
+	if !backup.registered && net.Connectedness(backup.addrInfo.ID) == network.Connected {
+		Logger.Infof("Connected to backup highway %v, registering", backup.addr)
+		cm.subscriber.AddBackupHighway(backup.addrInfo.ID, backup.registerer)
+		backup.disconnected = 0
+		backup.registered = true
+	}
+	return false
+}
+
+// probeHighways probes the current highway and the connected backups at the same time and scores them
+func (cm *ConnManager) probeHighways(currentHighway *rpcclient.HighwayAddr) {
+	hwIDs := []peer.ID{}
+	if currentInfo, err := getAddressInfo(currentHighway.Libp2pAddr); err == nil {
+		hwIDs = append(hwIDs, currentInfo.ID)
+	}
+	for _, backup := range cm.backups {
+		if backup.registered {
+			hwIDs = append(hwIDs, backup.addrInfo.ID)
+		}
+	}
+
+	rtts := make([]time.Duration, len(hwIDs))
+	errs := make([]error, len(hwIDs))
+	wg := sync.WaitGroup{}
+	for i, hwID := range hwIDs {
+		wg.Add(1)
+		go func(i int, hwID peer.ID) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), HighwayProbeTimeout)
+			defer cancel()
+			rtts[i], errs[i] = cm.prober.Probe(ctx, hwID)
+		}(i, hwID)
+	}
+	wg.Wait()
+
+	for i, hwID := range hwIDs {
+		if errs[i] != nil {
+			Logger.Warnf("Failed probing highway %s: %v", hwID.String(), errs[i])
+		}
+		cm.scorer.AddProbe(hwID, rtts[i], errs[i])
+	}
+}
+
+// addBackupHighways chooses new backups until there are numHighways-1 of them
+func (cm *ConnManager) addBackupHighways(currentHighway *rpcclient.HighwayAddr) {
+	missing := cm.numHighways - 1 - len(cm.backups)
+	if missing <= 0 {
+		return
+	}
+	excluded := addresses{}
+	if currentHighway != nil {
+		excluded = append(excluded, *currentHighway)
+	}
+	for _, backup := range cm.backups {
+		excluded = append(excluded, backup.addr)
+	}
+	newAddrs, err := cm.keeper.ChooseBackupHighways(cm.LocalHost.Host.ID(), missing, excluded)
+	if err != nil {
+		Logger.Errorf("Failed choosing backup highways: %v", err)
+		return
+	}
+	for _, addr := range newAddrs {
+		addrInfo, err := getAddressInfo(addr.Libp2pAddr)
+		if err != nil {
+			Logger.Error(err)
+			cm.keeper.IgnoreAddress(addr)
+			continue
+		}
+		registerer, stopRequester := cm.newBackupRequester(addrInfo.ID)
+		cm.backups = append(cm.backups, &backupHighway{
+			addr:          addr,
+			addrInfo:      addrInfo,
+			registerer:    registerer,
+			stopRequester: stopRequester,
+		})
+		Logger.Infof("Chosen backup highway: %+v", addr)
+	}
+}
+
+// bestBackupHighway returns the best scored backup among the connected ones
+func (cm *ConnManager) bestBackupHighway() *backupHighway {
+	var best *backupHighway
+	for _, backup := range cm.backups {
+		if backup.registered && (best == nil || cm.scorer.Better(backup.addrInfo.ID, best.addrInfo.ID)) {
+			best = backup
+		}
+	}
+	return best
+}
+
+// promoteBackupHighway makes the best backup the current highway so we don't wait
+// for a new connection when the current one fails, nil if no backup is connected
+func (cm *ConnManager) promoteBackupHighway() *rpcclient.HighwayAddr {
+	best := cm.bestBackupHighway()
+	if best == nil {
+		return nil
+	}
+	Logger.Infof("Promoting backup highway %v to current highway", best.addr)
+	cm.removeBackupHighway(best, false)
+	cm.registered = false // Register to the new current highway
+	cm.disconnected = 0
+	addr := best.addr
+	return &addr
+}
+
+// removeBackupHighway stops using a backup; rotated out backups are disconnected and ignored for a while,
+// promoted backups stay connected
+func (cm *ConnManager) removeBackupHighway(backup *backupHighway, rotatedOut bool) {
+	for i, b := range cm.backups {
+		if b == backup {
+			cm.backups = append(cm.backups[:i], cm.backups[i+1:]...)
+			break
+		}
+	}
+	cm.subscriber.RemoveBackupHighway(backup.addrInfo.ID)
+	if backup.stopRequester != nil {
+		backup.stopRequester()
+	}
+	if !rotatedOut {
+		return
+	}
+	cm.scorer.Remove(backup.addrInfo.ID)
+	cm.keeper.IgnoreAddress(backup.addr)
+	if err := cm.LocalHost.Host.Network().ClosePeer(backup.addrInfo.ID); err != nil {
+		Logger.Errorf("Failed closing connection to backup highway: hwID = %s err = %v", backup.addrInfo.ID.String(), err)
+	}
+}
+
+// isSeenMessage tells if the same message was already received from a topic, e.g. relayed by another highway
+func (cm *ConnManager) isSeenMessage(data []byte) bool {
+	if cm.seenMessages == nil {
+		return false
+	}
+	seen, _ := cm.seenMessages.ContainsOrAdd(common.HashH(data), struct{}{})
+	return seen
+}
@@ -0,0 +1,143 @@
+package peerv2
+
+import (
+	"testing"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/incognitochain/incognito-chain/peerv2/mocks"
+	"github.com/incognitochain/incognito-chain/peerv2/rpcclient"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupBackupConnManager(prober HighwayProber) (*ConnManager, *subscribeCounter, *mocks.Network) {
+	h, net := setupHost()
+	net.On("Connectedness", mock.Anything).Return(network.Connected)
+	net.On("ClosePeer", mock.Anything).Return(nil)
+
+	keeper := NewAddrKeeper()
+	keeper.addrs = addresses{
+		rpcclient.HighwayAddr{Libp2pAddr: testHighwayAddress},
+		rpcclient.HighwayAddr{Libp2pAddr: testHighwayAddress2},
+	}
+	sc := new(subscribeCounter)
+	cm := &ConnManager{
+		LocalHost:   &Host{Host: h},
+		keeper:      keeper,
+		subscriber:  sc,
+		numHighways: 2,
+		scorer:      NewHighwayScorer(),
+		prober:      prober,
+		newBackupRequester: func(hwID peer.ID) (Registerer, func()) {
+			return &mocks.Registerer{}, nil
+		},
+	}
+	return cm, sc, net
+}
+
+func getTestHighwayID(t *testing.T, addr string) peer.ID {
+	addrInfo, err := getAddressInfo(addr)
+	assert.Nil(t, err)
+	return addrInfo.ID
+}
+
+// TestManageBackupHighwaysAddsBackup checks if a backup highway is chosen besides the current one and registered once connected
+func TestManageBackupHighwaysAddsBackup(t *testing.T) {
+	prober := &mocks.HighwayProber{}
+	prober.On("Probe", mock.Anything, mock.Anything).Return(10*time.Millisecond, nil)
+	cm, sc, _ := setupBackupConnManager(prober)
+	current := &rpcclient.HighwayAddr{Libp2pAddr: testHighwayAddress}
+
+	assert.Equal(t, current, cm.manageBackupHighways(current))
+	if assert.Len(t, cm.backups, 1) {
+		assert.Equal(t, testHighwayAddress2, cm.backups[0].addr.Libp2pAddr)
+	}
+	assert.Len(t, sc.backups, 0, "registered before connecting")
+
+	assert.Equal(t, current, cm.manageBackupHighways(current))
+	assert.Equal(t, []peer.ID{getTestHighwayID(t, testHighwayAddress2)}, sc.backups)
+}
+
+// TestManageBackupHighwaysSingleHighway makes sure nothing changes when only one highway is wanted
+func TestManageBackupHighwaysSingleHighway(t *testing.T) {
+	prober := &mocks.HighwayProber{}
+	cm, _, _ := setupBackupConnManager(prober)
+	cm.numHighways = 1
+	current := &rpcclient.HighwayAddr{Libp2pAddr: testHighwayAddress}
+
+	assert.Equal(t, current, cm.manageBackupHighways(current))
+	assert.Len(t, cm.backups, 0)
+	prober.AssertNotCalled(t, "Probe", mock.Anything, mock.Anything)
+}
+
+// TestPromoteBackupHighway checks if a connected backup replaces the current highway when it fails
+func TestPromoteBackupHighway(t *testing.T) {
+	prober := &mocks.HighwayProber{}
+	prober.On("Probe", mock.Anything, mock.Anything).Return(10*time.Millisecond, nil)
+	cm, sc, _ := setupBackupConnManager(prober)
+	current := &rpcclient.HighwayAddr{Libp2pAddr: testHighwayAddress}
+	cm.manageBackupHighways(current)
+	cm.manageBackupHighways(current)
+	cm.registered = true
+
+	promoted := cm.promoteBackupHighway()
+	if assert.NotNil(t, promoted) {
+		assert.Equal(t, testHighwayAddress2, promoted.Libp2pAddr)
+	}
+	assert.False(t, cm.registered, "not registering to the promoted highway")
+	assert.Len(t, cm.backups, 0)
+	assert.Len(t, sc.backups, 0)
+
+	assert.Nil(t, cm.promoteBackupHighway(), "promoted without backup")
+}
+
+// TestRotateOutBadBackupHighway checks if a backup not answering probes is disconnected and ignored
+func TestRotateOutBadBackupHighway(t *testing.T) {
+	backupID := getTestHighwayID(t, testHighwayAddress2)
+	prober := &mocks.HighwayProber{}
+	prober.On("Probe", mock.Anything, backupID).Return(time.Duration(0), errors.New("timeout"))
+	prober.On("Probe", mock.Anything, mock.Anything).Return(10*time.Millisecond, nil)
+	cm, sc, net := setupBackupConnManager(prober)
+	current := &rpcclient.HighwayAddr{Libp2pAddr: testHighwayAddress}
+
+	for i := 0; i < HighwayScoreWindow+2; i++ {
+		assert.Equal(t, current, cm.manageBackupHighways(current))
+	}
+
+	assert.Len(t, cm.backups, 0, "bad backup kept")
+	assert.Len(t, sc.backups, 0)
+	assert.Contains(t, cm.keeper.ignoreHWUntil, rpcclient.HighwayAddr{Libp2pAddr: testHighwayAddress2})
+	net.AssertCalled(t, "ClosePeer", backupID)
+}
+
+// TestRotateOutBadCurrentHighway checks if a backup replaces the current highway when it doesn't answer probes
+func TestRotateOutBadCurrentHighway(t *testing.T) {
+	currentID := getTestHighwayID(t, testHighwayAddress)
+	prober := &mocks.HighwayProber{}
+	prober.On("Probe", mock.Anything, currentID).Return(time.Duration(0), errors.New("timeout"))
+	prober.On("Probe", mock.Anything, mock.Anything).Return(10*time.Millisecond, nil)
+	cm, _, _ := setupBackupConnManager(prober)
+	current := &rpcclient.HighwayAddr{Libp2pAddr: testHighwayAddress}
+
+	for i := 0; i < HighwayScoreWindow+1; i++ {
+		current = cm.manageBackupHighways(current)
+	}
+
+	if assert.NotNil(t, current) {
+		assert.Equal(t, testHighwayAddress2, current.Libp2pAddr)
+	}
+	assert.Contains(t, cm.keeper.ignoreHWUntil, rpcclient.HighwayAddr{Libp2pAddr: testHighwayAddress})
+}
+
+func TestIsSeenMessage(t *testing.T) {
+	seenMessages, _ := lru.New(SeenMessagesCacheSize)
+	cm := &ConnManager{seenMessages: seenMessages}
+
+	assert.False(t, cm.isSeenMessage([]byte("msg1")))
+	assert.True(t, cm.isSeenMessage([]byte("msg1")), "duplicate from another highway not detected")
+	assert.False(t, cm.isSeenMessage([]byte("msg2")))
+}
@@ -0,0 +1,121 @@
+package peerv2
+
+import (
+	"context"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p/p2p/protocol/ping"
+	"github.com/pkg/errors"
+)
+
+// HighwayProber measures the round trip to a connected highway
+type HighwayProber interface {
+	Probe(ctx context.Context, hwID peer.ID) (time.Duration, error)
+}
+
+// pingProber probes highways with the libp2p ping protocol
+type pingProber struct {
+	host host.Host
+}
+
+func (p *pingProber) Probe(ctx context.Context, hwID peer.ID) (time.Duration, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel() // stop pinging after the first result
+	result, ok := <-ping.Ping(ctx, p.host, hwID)
+	if !ok {
+		return 0, errors.Errorf("no ping result from highway %s", hwID.String())
+	}
+	return result.RTT, result.Error
+}
+
+// highwayScore keeps the outcome of the last probes of a highway
+type highwayScore struct {
+	latency time.Duration // exponentially weighted moving average of round trips
+	results []bool        // true for each answered probe, at most HighwayScoreWindow of them
+}
+
+// HighwayScorer scores highways on the latency and the delivery ratio of their probes,
+// so ConnManager can rotate out the slow or partitioned ones
+type HighwayScorer struct {
+	scores map[peer.ID]*highwayScore
+}
+
+func NewHighwayScorer() *HighwayScorer {
+	return &HighwayScorer{
+		scores: map[peer.ID]*highwayScore{},
+	}
+}
+
+// AddProbe saves the round trip of a probe, err is not nil if the highway did not answer
+func (s *HighwayScorer) AddProbe(hwID peer.ID, rtt time.Duration, err error) {
+	score, ok := s.scores[hwID]
+	if !ok {
+		score = &highwayScore{}
+		s.scores[hwID] = score
+	}
+
+	score.results = append(score.results, err == nil)
+	if len(score.results) > HighwayScoreWindow {
+		score.results = score.results[len(score.results)-HighwayScoreWindow:]
+	}
+	if err != nil {
+		return
+	}
+	if score.latency == 0 {
+		score.latency = rtt
+	} else {
+		score.latency = time.Duration(HighwayLatencyWeight*float64(rtt) + (1-HighwayLatencyWeight)*float64(score.latency))
+	}
+}
+
+// Latency returns the average round trip of a highway, 0 if it never answered
+func (s *HighwayScorer) Latency(hwID peer.ID) time.Duration {
+	if score, ok := s.scores[hwID]; ok {
+		return score.latency
+	}
+	return 0
+}
+
+// DeliveryRatio returns the ratio of answered probes of a highway, 1 if it was never probed
+func (s *HighwayScorer) DeliveryRatio(hwID peer.ID) float64 {
+	score, ok := s.scores[hwID]
+	if !ok || len(score.results) == 0 {
+		return 1
+	}
+	delivered := 0
+	for _, result := range score.results {
+		if result {
+			delivered++
+		}
+	}
+	return float64(delivered) / float64(len(score.results))
+}
+
+// IsBad tells if a highway answered too few of a full window of probes or answered too slowly
+func (s *HighwayScorer) IsBad(hwID peer.ID) bool {
+	score, ok := s.scores[hwID]
+	if !ok || len(score.results) < HighwayScoreWindow {
+		return false // not enough probes to judge
+	}
+	return s.DeliveryRatio(hwID) < MinHighwayDeliveryRatio || score.latency > MaxHighwayLatency
+}
+
+// Better tells if highway a is scored higher than b: higher delivery ratio first, lower latency then
+func (s *HighwayScorer) Better(a, b peer.ID) bool {
+	ratioA, ratioB := s.DeliveryRatio(a), s.DeliveryRatio(b)
+	if ratioA != ratioB {
+		return ratioA > ratioB
+	}
+	latencyA, latencyB := s.Latency(a), s.Latency(b)
+	if latencyA == 0 || latencyB == 0 {
+		return latencyA != 0 // a highway which answered beats one never probed
+	}
+	return latencyA < latencyB
+}
+
+// Remove forgets the probes of a highway we are not connected to anymore
+func (s *HighwayScorer) Remove(hwID peer.ID) {
+	delete(s.scores, hwID)
+}
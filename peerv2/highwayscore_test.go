@@ -0,0 +1,71 @@
+package peerv2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHighwayScorerDeliveryRatio(t *testing.T) {
+	scorer := NewHighwayScorer()
+	hwID := peer.ID("hw")
+	assert.Equal(t, float64(1), scorer.DeliveryRatio(hwID), "never probed highway penalized")
+
+	scorer.AddProbe(hwID, 10*time.Millisecond, nil)
+	scorer.AddProbe(hwID, 0, errors.New("timeout"))
+	assert.Equal(t, 0.5, scorer.DeliveryRatio(hwID))
+
+	// Only the last probes count
+	for i := 0; i < HighwayScoreWindow; i++ {
+		scorer.AddProbe(hwID, 10*time.Millisecond, nil)
+	}
+	assert.Equal(t, float64(1), scorer.DeliveryRatio(hwID))
+}
+
+func TestHighwayScorerLatency(t *testing.T) {
+	scorer := NewHighwayScorer()
+	hwID := peer.ID("hw")
+	scorer.AddProbe(hwID, 100*time.Millisecond, nil)
+	assert.Equal(t, 100*time.Millisecond, scorer.Latency(hwID))
+
+	scorer.AddProbe(hwID, 0, errors.New("timeout"))
+	assert.Equal(t, 100*time.Millisecond, scorer.Latency(hwID), "failed probe changed latency")
+
+	scorer.AddProbe(hwID, 200*time.Millisecond, nil)
+	assert.Equal(t, time.Duration(HighwayLatencyWeight*float64(200*time.Millisecond)+(1-HighwayLatencyWeight)*float64(100*time.Millisecond)), scorer.Latency(hwID))
+
+	scorer.Remove(hwID)
+	assert.Equal(t, time.Duration(0), scorer.Latency(hwID))
+}
+
+func TestHighwayScorerIsBad(t *testing.T) {
+	scorer := NewHighwayScorer()
+	lossy, slow, good := peer.ID("lossy"), peer.ID("slow"), peer.ID("good")
+	for i := 0; i < HighwayScoreWindow; i++ {
+		assert.False(t, scorer.IsBad(lossy), "judged before a full window of probes")
+		scorer.AddProbe(lossy, 0, errors.New("timeout"))
+		scorer.AddProbe(slow, 2*MaxHighwayLatency, nil)
+		scorer.AddProbe(good, 10*time.Millisecond, nil)
+	}
+	assert.True(t, scorer.IsBad(lossy))
+	assert.True(t, scorer.IsBad(slow))
+	assert.False(t, scorer.IsBad(good))
+}
+
+func TestHighwayScorerBetter(t *testing.T) {
+	scorer := NewHighwayScorer()
+	fast, slow, lossy, unknown := peer.ID("fast"), peer.ID("slow"), peer.ID("lossy"), peer.ID("unknown")
+	scorer.AddProbe(fast, 10*time.Millisecond, nil)
+	scorer.AddProbe(slow, 100*time.Millisecond, nil)
+	scorer.AddProbe(lossy, 10*time.Millisecond, nil)
+	scorer.AddProbe(lossy, 0, errors.New("timeout"))
+
+	assert.True(t, scorer.Better(fast, slow))
+	assert.False(t, scorer.Better(slow, fast))
+	assert.True(t, scorer.Better(slow, lossy), "delivery ratio not preferred over latency")
+	assert.True(t, scorer.Better(slow, unknown))
+	assert.False(t, scorer.Better(unknown, slow))
+}
@@ -0,0 +1,369 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: statesync.proto
+
+package proto
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type GetShardViewRequest struct {
+	Shard                int32    `protobuf:"varint,1,opt,name=Shard,proto3" json:"Shard,omitempty"`
+	UUID                 string   `protobuf:"bytes,2,opt,name=UUID,proto3" json:"UUID,omitempty"`
+	SyncFromPeer         string   `protobuf:"bytes,3,opt,name=SyncFromPeer,proto3" json:"SyncFromPeer,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetShardViewRequest) Reset()         { *m = GetShardViewRequest{} }
+func (m *GetShardViewRequest) String() string { return proto.CompactTextString(m) }
+func (*GetShardViewRequest) ProtoMessage()    {}
+func (*GetShardViewRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f598460bc3852c73, []int{0}
+}
+
+func (m *GetShardViewRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetShardViewRequest.Unmarshal(m, b)
+}
+func (m *GetShardViewRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetShardViewRequest.Marshal(b, m, deterministic)
+}
+func (m *GetShardViewRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetShardViewRequest.Merge(m, src)
+}
+func (m *GetShardViewRequest) XXX_Size() int {
+	return xxx_messageInfo_GetShardViewRequest.Size(m)
+}
+func (m *GetShardViewRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetShardViewRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetShardViewRequest proto.InternalMessageInfo
+
+func (m *GetShardViewRequest) GetShard() int32 {
+	if m != nil {
+		return m.Shard
+	}
+	return 0
+}
+
+func (m *GetShardViewRequest) GetUUID() string {
+	if m != nil {
+		return m.UUID
+	}
+	return ""
+}
+
+func (m *GetShardViewRequest) GetSyncFromPeer() string {
+	if m != nil {
+		return m.SyncFromPeer
+	}
+	return ""
+}
+
+type GetShardViewResponse struct {
+	Data                 []byte   `protobuf:"bytes,1,opt,name=Data,proto3" json:"Data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetShardViewResponse) Reset()         { *m = GetShardViewResponse{} }
+func (m *GetShardViewResponse) String() string { return proto.CompactTextString(m) }
+func (*GetShardViewResponse) ProtoMessage()    {}
+func (*GetShardViewResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f598460bc3852c73, []int{1}
+}
+
+func (m *GetShardViewResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetShardViewResponse.Unmarshal(m, b)
+}
+func (m *GetShardViewResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetShardViewResponse.Marshal(b, m, deterministic)
+}
+func (m *GetShardViewResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetShardViewResponse.Merge(m, src)
+}
+func (m *GetShardViewResponse) XXX_Size() int {
+	return xxx_messageInfo_GetShardViewResponse.Size(m)
+}
+func (m *GetShardViewResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetShardViewResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetShardViewResponse proto.InternalMessageInfo
+
+func (m *GetShardViewResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type GetStateNodesRequest struct {
+	Shard                int32    `protobuf:"varint,1,opt,name=Shard,proto3" json:"Shard,omitempty"`
+	Hashes               [][]byte `protobuf:"bytes,2,rep,name=Hashes,proto3" json:"Hashes,omitempty"`
+	UUID                 string   `protobuf:"bytes,3,opt,name=UUID,proto3" json:"UUID,omitempty"`
+	SyncFromPeer         string   `protobuf:"bytes,4,opt,name=SyncFromPeer,proto3" json:"SyncFromPeer,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStateNodesRequest) Reset()         { *m = GetStateNodesRequest{} }
+func (m *GetStateNodesRequest) String() string { return proto.CompactTextString(m) }
+func (*GetStateNodesRequest) ProtoMessage()    {}
+func (*GetStateNodesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f598460bc3852c73, []int{2}
+}
+
+func (m *GetStateNodesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStateNodesRequest.Unmarshal(m, b)
+}
+func (m *GetStateNodesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStateNodesRequest.Marshal(b, m, deterministic)
+}
+func (m *GetStateNodesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStateNodesRequest.Merge(m, src)
+}
+func (m *GetStateNodesRequest) XXX_Size() int {
+	return xxx_messageInfo_GetStateNodesRequest.Size(m)
+}
+func (m *GetStateNodesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStateNodesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStateNodesRequest proto.InternalMessageInfo
+
+func (m *GetStateNodesRequest) GetShard() int32 {
+	if m != nil {
+		return m.Shard
+	}
+	return 0
+}
+
+func (m *GetStateNodesRequest) GetHashes() [][]byte {
+	if m != nil {
+		return m.Hashes
+	}
+	return nil
+}
+
+func (m *GetStateNodesRequest) GetUUID() string {
+	if m != nil {
+		return m.UUID
+	}
+	return ""
+}
+
+func (m *GetStateNodesRequest) GetSyncFromPeer() string {
+	if m != nil {
+		return m.SyncFromPeer
+	}
+	return ""
+}
+
+// GetStateNodesResponse holds the encoded trie nodes in the order of the
+// requested hashes, empty for the ones the peer does not have.
+type GetStateNodesResponse struct {
+	Data                 [][]byte `protobuf:"bytes,1,rep,name=Data,proto3" json:"Data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStateNodesResponse) Reset()         { *m = GetStateNodesResponse{} }
+func (m *GetStateNodesResponse) String() string { return proto.CompactTextString(m) }
+func (*GetStateNodesResponse) ProtoMessage()    {}
+func (*GetStateNodesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f598460bc3852c73, []int{3}
+}
+
+func (m *GetStateNodesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStateNodesResponse.Unmarshal(m, b)
+}
+func (m *GetStateNodesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStateNodesResponse.Marshal(b, m, deterministic)
+}
+func (m *GetStateNodesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStateNodesResponse.Merge(m, src)
+}
+func (m *GetStateNodesResponse) XXX_Size() int {
+	return xxx_messageInfo_GetStateNodesResponse.Size(m)
+}
+func (m *GetStateNodesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStateNodesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStateNodesResponse proto.InternalMessageInfo
+
+func (m *GetStateNodesResponse) GetData() [][]byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*GetShardViewRequest)(nil), "GetShardViewRequest")
+	proto.RegisterType((*GetShardViewResponse)(nil), "GetShardViewResponse")
+	proto.RegisterType((*GetStateNodesRequest)(nil), "GetStateNodesRequest")
+	proto.RegisterType((*GetStateNodesResponse)(nil), "GetStateNodesResponse")
+}
+
+func init() { proto.RegisterFile("statesync.proto", fileDescriptor_f598460bc3852c73) }
+
+var fileDescriptor_f598460bc3852c73 = []byte{
+	// 257 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x2f, 0x2e, 0x49, 0x2c,
+	0x49, 0x2d, 0xae, 0xcc, 0x4b, 0xd6, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x57, 0x4a, 0xe6, 0x12, 0x76,
+	0x4f, 0x2d, 0x09, 0xce, 0x48, 0x2c, 0x4a, 0x09, 0xcb, 0x4c, 0x2d, 0x0f, 0x4a, 0x2d, 0x2c, 0x4d,
+	0x2d, 0x2e, 0x11, 0x12, 0xe1, 0x62, 0x05, 0x8b, 0x49, 0x30, 0x2a, 0x30, 0x6a, 0xb0, 0x06, 0x41,
+	0x38, 0x42, 0x42, 0x5c, 0x2c, 0xa1, 0xa1, 0x9e, 0x2e, 0x12, 0x4c, 0x0a, 0x8c, 0x1a, 0x9c, 0x41,
+	0x60, 0xb6, 0x90, 0x12, 0x17, 0x4f, 0x70, 0x65, 0x5e, 0xb2, 0x5b, 0x51, 0x7e, 0x6e, 0x40, 0x6a,
+	0x6a, 0x91, 0x04, 0x33, 0x58, 0x0e, 0x45, 0x4c, 0x49, 0x8b, 0x4b, 0x04, 0xd5, 0x92, 0xe2, 0x82,
+	0xfc, 0xbc, 0xe2, 0x54, 0x90, 0x79, 0x2e, 0x89, 0x25, 0x89, 0x60, 0x4b, 0x78, 0x82, 0xc0, 0x6c,
+	0xa5, 0x1a, 0x88, 0x5a, 0x90, 0x33, 0xfd, 0xf2, 0x53, 0x52, 0x8b, 0xf1, 0xbb, 0x48, 0x8c, 0x8b,
+	0xcd, 0x23, 0xb1, 0x38, 0x23, 0xb5, 0x58, 0x82, 0x49, 0x81, 0x59, 0x83, 0x27, 0x08, 0xca, 0x83,
+	0xbb, 0x94, 0x19, 0x8f, 0x4b, 0x59, 0xb0, 0xb8, 0x54, 0x9b, 0x4b, 0x14, 0xcd, 0x76, 0x0c, 0xa7,
+	0x32, 0xc3, 0x9c, 0x6a, 0x34, 0x99, 0x91, 0x4b, 0x00, 0xac, 0x14, 0x64, 0x44, 0x70, 0x6a, 0x51,
+	0x59, 0x66, 0x72, 0xaa, 0x90, 0x2d, 0x17, 0x0f, 0xb2, 0x5f, 0x85, 0x44, 0xf4, 0xb0, 0x84, 0xaf,
+	0x94, 0xa8, 0x1e, 0xb6, 0x00, 0x51, 0x62, 0x10, 0x72, 0xe0, 0xe2, 0x45, 0x71, 0x80, 0x90, 0xa8,
+	0x1e, 0x0a, 0x1f, 0x66, 0x80, 0x98, 0x1e, 0x56, 0x77, 0x2a, 0x31, 0x38, 0xb1, 0x47, 0xb1, 0x82,
+	0xa3, 0x36, 0x89, 0x0d, 0x4c, 0x19, 0x03, 0x06, 0x00, 0xb7, 0x61, 0x49, 0xb5, 0xf4, 0x01, 0x00,
+	0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// StateSyncServiceClient is the client API for StateSyncService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type StateSyncServiceClient interface {
+	GetShardView(ctx context.Context, in *GetShardViewRequest, opts ...grpc.CallOption) (*GetShardViewResponse, error)
+	GetStateNodes(ctx context.Context, in *GetStateNodesRequest, opts ...grpc.CallOption) (*GetStateNodesResponse, error)
+}
+
+type stateSyncServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewStateSyncServiceClient(cc *grpc.ClientConn) StateSyncServiceClient {
+	return &stateSyncServiceClient{cc}
+}
+
+func (c *stateSyncServiceClient) GetShardView(ctx context.Context, in *GetShardViewRequest, opts ...grpc.CallOption) (*GetShardViewResponse, error) {
+	out := new(GetShardViewResponse)
+	err := c.cc.Invoke(ctx, "/StateSyncService/GetShardView", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stateSyncServiceClient) GetStateNodes(ctx context.Context, in *GetStateNodesRequest, opts ...grpc.CallOption) (*GetStateNodesResponse, error) {
+	out := new(GetStateNodesResponse)
+	err := c.cc.Invoke(ctx, "/StateSyncService/GetStateNodes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StateSyncServiceServer is the server API for StateSyncService service.
+type StateSyncServiceServer interface {
+	GetShardView(context.Context, *GetShardViewRequest) (*GetShardViewResponse, error)
+	GetStateNodes(context.Context, *GetStateNodesRequest) (*GetStateNodesResponse, error)
+}
+
+// UnimplementedStateSyncServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedStateSyncServiceServer struct {
+}
+
+func (*UnimplementedStateSyncServiceServer) GetShardView(ctx context.Context, req *GetShardViewRequest) (*GetShardViewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShardView not implemented")
+}
+func (*UnimplementedStateSyncServiceServer) GetStateNodes(ctx context.Context, req *GetStateNodesRequest) (*GetStateNodesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStateNodes not implemented")
+}
+
+func RegisterStateSyncServiceServer(s *grpc.Server, srv StateSyncServiceServer) {
+	s.RegisterService(&_StateSyncService_serviceDesc, srv)
+}
+
+func _StateSyncService_GetShardView_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetShardViewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StateSyncServiceServer).GetShardView(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/StateSyncService/GetShardView",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StateSyncServiceServer).GetShardView(ctx, req.(*GetShardViewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StateSyncService_GetStateNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStateNodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StateSyncServiceServer).GetStateNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/StateSyncService/GetStateNodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StateSyncServiceServer).GetStateNodes(ctx, req.(*GetStateNodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _StateSyncService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "StateSyncService",
+	HandlerType: (*StateSyncServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetShardView",
+			Handler:    _StateSyncService_GetShardView_Handler,
+		},
+		{
+			MethodName: "GetStateNodes",
+			Handler:    _StateSyncService_GetStateNodes_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "statesync.proto",
+}
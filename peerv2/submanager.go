@@ -2,6 +2,7 @@ package peerv2
 
 import (
 	"context"
+	"sync"
 
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/peerv2/proto"
@@ -27,6 +28,10 @@ type SubManager struct {
 	role   userRole
 	topics msgToTopics
 	subs   msgToTopics // mapping from message to topic's subscription
+
+	backupLock  sync.Mutex
+	backups     map[peer.ID]Registerer // registerers of backup highways
+	backupRoles map[peer.ID]userRole   // role last registered to each backup highway
 }
 
 type info struct {
@@ -36,6 +41,7 @@ type info struct {
 	nodeMode   string
 	relayShard []byte
 	peerID     peer.ID
+	wireCodec  string
 }
 
 type Subscriber interface {
@@ -55,13 +61,15 @@ func NewSubManager(
 	messages chan *pubsub.Message,
 ) *SubManager {
 	return &SubManager{
-		info:       info,
-		subscriber: subscriber,
-		registerer: registerer,
-		messages:   messages,
-		role:       newUserRole("dummyLayer", "dummyRole", -1000),
-		topics:     msgToTopics{},
-		subs:       msgToTopics{},
+		info:        info,
+		subscriber:  subscriber,
+		registerer:  registerer,
+		messages:    messages,
+		role:        newUserRole("dummyLayer", "dummyRole", -1000),
+		topics:      msgToTopics{},
+		subs:        msgToTopics{},
+		backups:     map[peer.ID]Registerer{},
+		backupRoles: map[peer.ID]userRole{},
 	}
 }
 
@@ -73,6 +81,7 @@ func (sub *SubManager) GetMsgToTopics() msgToTopics {
 func (sub *SubManager) Subscribe(forced bool) error {
 	newRole := newUserRole(sub.consensusData.GetUserRole())
 	if newRole == sub.role && !forced { // Not forced => no need to subscribe when role stays the same
+		sub.registerToBackups()
 		return nil
 	}
 	pubKey, _ := sub.consensusData.GetCurrentMiningPublicKey()
@@ -115,9 +124,52 @@ func (sub *SubManager) Subscribe(forced bool) error {
 
 	sub.role = newRole
 	sub.topics = newTopics
+	sub.registerToBackups()
 	return nil
 }
 
+// AddBackupHighway saves a highway connected in addition to the current one, the role is registered to it
+// with the next Subscribe call. Adding it again registers the role again, e.g. after a reconnection.
+func (sub *SubManager) AddBackupHighway(hwID peer.ID, registerer Registerer) {
+	sub.backupLock.Lock()
+	defer sub.backupLock.Unlock()
+	if sub.backups == nil {
+		sub.backups = map[peer.ID]Registerer{}
+		sub.backupRoles = map[peer.ID]userRole{}
+	}
+	sub.backups[hwID] = registerer
+	delete(sub.backupRoles, hwID)
+}
+
+func (sub *SubManager) RemoveBackupHighway(hwID peer.ID) {
+	sub.backupLock.Lock()
+	defer sub.backupLock.Unlock()
+	delete(sub.backups, hwID)
+	delete(sub.backupRoles, hwID)
+}
+
+// registerToBackups registers the current role to backup highways which don't have it yet
+// so they relay the subscribed topics too; failed registrations are retried with the next Subscribe call
+func (sub *SubManager) registerToBackups() {
+	if sub.role.role == "dummyRole" { // Not registered to the current highway yet
+		return
+	}
+	sub.backupLock.Lock()
+	defer sub.backupLock.Unlock()
+	shardIDs := getWantedShardIDs(sub.role, sub.nodeMode, sub.relayShard)
+	for hwID, registerer := range sub.backups {
+		if role, ok := sub.backupRoles[hwID]; ok && role == sub.role {
+			continue
+		}
+		_, _, err := sub.registerToHighway(registerer, sub.pubkey, sub.role.layer, sub.role.role, shardIDs)
+		if err != nil {
+			Logger.Warnf("Failed registering to backup highway %s: %v", hwID.String(), err)
+			continue
+		}
+		sub.backupRoles[hwID] = sub.role
+	}
+}
+
 func getWantedShardIDs(role userRole, nodeMode string, relayShard []byte) []byte {
 	roleSID := role.shardID
 	if roleSID == -2 { // not waiting/pending/validator right now
@@ -174,6 +226,9 @@ func (sub *SubManager) subscribeNewTopics(newTopics, subscribed msgToTopics, for
 						if s.Sub != nil {
 							go s.Sub.Cancel()
 						}
+						if s.BinSub != nil {
+							go s.BinSub.Cancel()
+						}
 					}
 					idx = i
 					break
@@ -213,8 +268,20 @@ func (sub *SubManager) subscribeNewTopics(newTopics, subscribed msgToTopics, for
 			if err != nil {
 				return errors.WithStack(err)
 			}
-			sub.subs[m] = append(sub.subs[m], Topic{Name: t.Name, Sub: s, Act: t.Act})
+			topic := Topic{Name: t.Name, Sub: s, Act: t.Act}
 			go processSubscriptionMessage(sub.messages, s)
+
+			// Nodes understanding the binary codec also listen to the binary topic
+			if sub.wireCodec == wire.WireCodecDual || sub.wireCodec == wire.WireCodecBinary {
+				Logger.Infof("subscribing message: %v, topic: %v", m, t.Name+wire.BinaryTopicSuffix)
+				binSub, err := sub.subscriber.Subscribe(t.Name + wire.BinaryTopicSuffix)
+				if err != nil {
+					return errors.WithStack(err)
+				}
+				topic.BinSub = binSub
+				go processSubscriptionMessage(sub.messages, binSub)
+			}
+			sub.subs[m] = append(sub.subs[m], topic)
 		}
 	}
 	return nil
@@ -236,9 +303,10 @@ func processSubscriptionMessage(inbox chan *pubsub.Message, sub *pubsub.Subscrip
 }
 
 type Topic struct {
-	Name string
-	Sub  *pubsub.Subscription
-	Act  proto.MessageTopicPair_Action
+	Name   string
+	Sub    *pubsub.Subscription
+	BinSub *pubsub.Subscription // subscription of the binary topic, nil with legacy codec
+	Act    proto.MessageTopicPair_Action
 }
 
 type msgToTopics map[string][]Topic // Message to topics
@@ -248,6 +316,16 @@ func (sub *SubManager) registerToProxy(
 	layer string,
 	role string,
 	shardID []byte,
+) (msgToTopics, userRole, error) {
+	return sub.registerToHighway(sub.registerer, pubkey, layer, role, shardID)
+}
+
+func (sub *SubManager) registerToHighway(
+	registerer Registerer,
+	pubkey string,
+	layer string,
+	role string,
+	shardID []byte,
 ) (msgToTopics, userRole, error) {
 	messagesWanted := getMessagesForLayer(sub.nodeMode, layer, shardID)
 	Logger.Infof("Registering: message: %v", messagesWanted)
@@ -258,7 +336,7 @@ func (sub *SubManager) registerToProxy(
 	Logger.Infof("Registering: peerID: %v", sub.peerID.String())
 	Logger.Infof("Registering: pubkey: %v", pubkey)
 
-	pairs, topicRole, err := sub.registerer.Register(
+	pairs, topicRole, err := registerer.Register(
 		context.Background(),
 		pubkey,
 		messagesWanted,
@@ -81,6 +81,7 @@ func TestSubscribeRoleChanged(t *testing.T) {
 	registerer.On("Register", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(pairs, &proto.UserRole{}, err)
 	consensusData := &mocks.ConsensusData{}
 	consensusData.On("GetUserRole").Once().Return(common.ShardRole, common.PendingRole, 1)
+	consensusData.On("GetCurrentMiningPublicKey").Return("pubkey", common.BlsConsensus)
 	sub := &SubManager{
 		info: info{
 			consensusData: consensusData,
@@ -107,6 +108,7 @@ func TestSubscribeForced(t *testing.T) {
 	registerer.On("Register", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(pairs, &proto.UserRole{}, err)
 	consensusData := &mocks.ConsensusData{}
 	consensusData.On("GetUserRole").Return(role.layer, role.role, role.shardID)
+	consensusData.On("GetCurrentMiningPublicKey").Return("pubkey", common.BlsConsensus)
 	sub := &SubManager{
 		info: info{
 			consensusData: consensusData,
@@ -330,3 +332,62 @@ func TestRegisterToProxy(t *testing.T) {
 		}
 	}
 }
+
+func TestSubscribeNewTopicsBinaryCodec(t *testing.T) {
+	newTopics := msgToTopics{
+		wire.CmdBFT: []Topic{Topic{Name: "abc"}, Topic{Name: "xyz", Act: proto.MessageTopicPair_PUB}},
+	}
+	for _, wireCodec := range []string{wire.WireCodecDual, wire.WireCodecBinary} {
+		subscription := &pubsub.Subscription{}
+		subscriber := &mocks.Subscriber{}
+		var err error
+		subscriber.On("Subscribe", mock.Anything).Return(subscription, err)
+		sub := &SubManager{info: info{wireCodec: wireCodec}, subs: msgToTopics{}, subscriber: subscriber}
+
+		err = sub.subscribeNewTopics(newTopics, msgToTopics{}, false)
+		assert.Nil(t, err)
+		subscriber.AssertNumberOfCalls(t, "Subscribe", 2)
+		subscriber.AssertCalled(t, "Subscribe", "abc")
+		subscriber.AssertCalled(t, "Subscribe", "abc"+wire.BinaryTopicSuffix)
+		assert.Equal(t, subscription, sub.subs[wire.CmdBFT][0].BinSub)
+		assert.Nil(t, sub.subs[wire.CmdBFT][1].BinSub)
+	}
+}
+
+// TestRegisterToBackups checks if the current role is registered to backup highways once, and again after a backup is re-added
+func TestRegisterToBackups(t *testing.T) {
+	role := userRole{
+		layer:   common.ShardRole,
+		role:    common.CommitteeRole,
+		shardID: 1,
+	}
+	consensusData := &mocks.ConsensusData{}
+	consensusData.On("GetUserRole").Return(role.layer, role.role, role.shardID)
+	registerer := &mocks.Registerer{}
+	var err error
+	registerer.On("Register", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]*proto.MessageTopicPair{}, &proto.UserRole{}, err)
+	sub := &SubManager{
+		info: info{
+			consensusData: consensusData,
+			nodeMode:      common.NodeModeAuto,
+			relayShard:    []byte{},
+		},
+		role: role,
+	}
+	sub.AddBackupHighway(peer.ID("backup"), registerer)
+
+	forced := false
+	assert.Nil(t, sub.Subscribe(forced))
+	assert.Nil(t, sub.Subscribe(forced))
+	registerer.AssertNumberOfCalls(t, "Register", 1)
+
+	sub.AddBackupHighway(peer.ID("backup"), registerer) // Reconnected
+	assert.Nil(t, sub.Subscribe(forced))
+	registerer.AssertNumberOfCalls(t, "Register", 2)
+
+	sub.RemoveBackupHighway(peer.ID("backup"))
+	sub.AddBackupHighway(peer.ID("backup2"), registerer)
+	sub.role = newUserRole("dummyLayer", "dummyRole", -1000)
+	sub.registerToBackups()
+	registerer.AssertNumberOfCalls(t, "Register", 2)
+}
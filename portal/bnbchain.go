@@ -0,0 +1,170 @@
+package portal
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/binance-chain/go-sdk/types/msg"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/relaying/bnb"
+)
+
+type RedeemMemoBNB struct {
+	RedeemID                  string `json:"RedeemID"`
+	CustodianIncognitoAddress string `json:"CustodianIncognitoAddress"`
+}
+
+type PortingMemoBNB struct {
+	PortingID string `json:"PortingID"`
+}
+
+// BNBHeaderRetriever gives the relayed bnb headers, it is implemented by the blockchain
+type BNBHeaderRetriever interface {
+	GetLatestBNBBlkHeight() (int64, error)
+	GetBNBDataHash(blockHeight int64) ([]byte, error)
+}
+
+// BNBChain verifies txs of the Binance chain against the relayed bnb headers
+type BNBChain struct {
+	headerRetriever BNBHeaderRetriever
+	chainID         string
+}
+
+func NewBNBChain(headerRetriever BNBHeaderRetriever, chainID string) *BNBChain {
+	return &BNBChain{
+		headerRetriever: headerRetriever,
+		chainID:         chainID,
+	}
+}
+
+func (c *BNBChain) IsValidAddress(address string) bool {
+	return bnb.IsValidBNBAddress(address, c.chainID)
+}
+
+// EncodePortingMemo returns the base64 encoded json of PortingMemoBNB
+func (c *BNBChain) EncodePortingMemo(portingID string) (string, error) {
+	memoBytes, err := json.Marshal(PortingMemoBNB{PortingID: portingID})
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(memoBytes), nil
+}
+
+// EncodeRedeemMemo returns the base64 encoded hash of the json of RedeemMemoBNB
+func (c *BNBChain) EncodeRedeemMemo(redeemID string, custodianIncAddress string) (string, error) {
+	memoBytes, err := json.Marshal(RedeemMemoBNB{
+		RedeemID:                  redeemID,
+		CustodianIncognitoAddress: custodianIncAddress,
+	})
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(common.HashB(memoBytes)), nil
+}
+
+func (c *BNBChain) MatchPortingMemo(memo string, portingID string) bool {
+	memoBytes, err := base64.StdEncoding.DecodeString(memo)
+	if err != nil {
+		return false
+	}
+	var portingMemo PortingMemoBNB
+	err = json.Unmarshal(memoBytes, &portingMemo)
+	if err != nil {
+		return false
+	}
+	return portingMemo.PortingID == portingID
+}
+
+func (c *BNBChain) MatchRedeemMemo(memo string, redeemID string, custodianIncAddress string) bool {
+	memoHashBytes, err := base64.StdEncoding.DecodeString(memo)
+	if err != nil {
+		return false
+	}
+	expectedRedeemMemoBytes, _ := json.Marshal(RedeemMemoBNB{
+		RedeemID:                  redeemID,
+		CustodianIncognitoAddress: custodianIncAddress,
+	})
+	return bytes.Equal(memoHashBytes, common.HashB(expectedRedeemMemoBytes))
+}
+
+func (c *BNBChain) ParseAndVerifyProof(proof string) (*ExternalTx, error) {
+	txProofBNB, err := bnb.ParseBNBProofFromB64EncodeStr(proof)
+	if err != nil {
+		return nil, NewPortalExternalChainError(ParseProofErr, err)
+	}
+
+	// check minimum confirmations block of bnb proof
+	latestBNBBlockHeight, err2 := c.headerRetriever.GetLatestBNBBlkHeight()
+	if err2 != nil {
+		return nil, NewPortalExternalChainError(GetLatestBlockHeightErr, err2)
+	}
+	if latestBNBBlockHeight < txProofBNB.BlockHeight+int64(c.MinConfirmations()) {
+		return nil, NewPortalExternalChainError(NotEnoughConfirmationsErr,
+			fmt.Errorf("need %v confirmations, latest bnb block height %v, proof block height %v", c.MinConfirmations(), latestBNBBlockHeight, txProofBNB.BlockHeight))
+	}
+
+	dataHash, err2 := c.headerRetriever.GetBNBDataHash(txProofBNB.BlockHeight)
+	if err2 != nil {
+		return nil, NewPortalExternalChainError(VerifyProofErr, err2)
+	}
+	isValid, err := txProofBNB.Verify(dataHash)
+	if err != nil {
+		return nil, NewPortalExternalChainError(VerifyProofErr, err)
+	}
+	if !isValid {
+		return nil, NewPortalExternalChainError(VerifyProofErr, errors.New("bnb tx proof is invalid"))
+	}
+
+	// parse Tx from Data in txProofBNB
+	txBNB, err := bnb.ParseTxFromData(txProofBNB.Proof.Data)
+	if err != nil {
+		return nil, NewPortalExternalChainError(ParseTxErr, err)
+	}
+	if len(txBNB.Msgs) == 0 {
+		return nil, NewPortalExternalChainError(ParseTxErr, errors.New("bnb tx has no msg"))
+	}
+	sendMsg, ok := txBNB.Msgs[0].(msg.SendMsg)
+	if !ok {
+		return nil, NewPortalExternalChainError(ParseTxErr, errors.New("bnb tx is not a send tx"))
+	}
+
+	tx := &ExternalTx{
+		Memo:    txBNB.Memo,
+		Outputs: []*ExternalTxOutput{},
+	}
+	for _, out := range sendMsg.Outputs {
+		addr, err := bnb.GetAccAddressString(&out.Address, c.chainID)
+		if err != nil {
+			continue
+		}
+		// calculate amount that was transferred to the address
+		amountTransfer := int64(0)
+		for _, coin := range out.Coins {
+			if coin.Denom == bnb.DenomBNB {
+				amountTransfer += coin.Amount
+			}
+		}
+		tx.Outputs = append(tx.Outputs, &ExternalTxOutput{
+			Address: addr,
+			Amount:  amountTransfer,
+		})
+	}
+	return tx, nil
+}
+
+func (c *BNBChain) MinConfirmations() uint64 {
+	return bnb.MinConfirmationsBlock
+}
+
+// MinAmount is 1 jager, the smallest unit of bnb
+func (c *BNBChain) MinAmount() uint64 {
+	return 10
+}
+
+// ConvertIncAmountToExternalAmount converts amount in inc chain (decimal 9) to amount in bnb chain (decimal 8)
+func (c *BNBChain) ConvertIncAmountToExternalAmount(incAmount int64) int64 {
+	return incAmount / 10 // incPBNBAmount / 1^9 * 1^8
+}
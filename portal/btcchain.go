@@ -0,0 +1,97 @@
+package portal
+
+import (
+	"errors"
+	"fmt"
+
+	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
+)
+
+// BTCChain verifies txs against a header chain relayed by relaying/btc,
+// other UTXO chains relayed the same way can reuse it with their own header chain
+type BTCChain struct {
+	headerChain *btcrelaying.BlockChain
+}
+
+func NewBTCChain(headerChain *btcrelaying.BlockChain) *BTCChain {
+	return &BTCChain{headerChain: headerChain}
+}
+
+func (c *BTCChain) IsValidAddress(address string) bool {
+	if c.headerChain == nil {
+		return false
+	}
+	return c.headerChain.IsBTCAddressValid(address)
+}
+
+// EncodePortingMemo returns the message attached to the OP_RETURN output of the tx
+func (c *BTCChain) EncodePortingMemo(portingID string) (string, error) {
+	return btcrelaying.HashAndEncodeBase58(portingID), nil
+}
+
+func (c *BTCChain) EncodeRedeemMemo(redeemID string, custodianIncAddress string) (string, error) {
+	rawMsg := fmt.Sprintf("%s%s", redeemID, custodianIncAddress)
+	return btcrelaying.HashAndEncodeBase58(rawMsg), nil
+}
+
+func (c *BTCChain) MatchPortingMemo(memo string, portingID string) bool {
+	encodedMsg, _ := c.EncodePortingMemo(portingID)
+	return memo == encodedMsg
+}
+
+func (c *BTCChain) MatchRedeemMemo(memo string, redeemID string, custodianIncAddress string) bool {
+	encodedMsg, _ := c.EncodeRedeemMemo(redeemID, custodianIncAddress)
+	return memo == encodedMsg
+}
+
+func (c *BTCChain) ParseAndVerifyProof(proof string) (*ExternalTx, error) {
+	if c.headerChain == nil {
+		return nil, NewPortalExternalChainError(NilHeaderChainErr, errors.New("btc relaying chain should not be null"))
+	}
+	btcTxProof, err := btcrelaying.ParseBTCProofFromB64EncodeStr(proof)
+	if err != nil {
+		return nil, NewPortalExternalChainError(ParseProofErr, err)
+	}
+	// the confirmations are checked by the relaying chain
+	isValid, err := c.headerChain.VerifyTxWithMerkleProofs(btcTxProof)
+	if err != nil {
+		return nil, NewPortalExternalChainError(VerifyProofErr, err)
+	}
+	if !isValid {
+		return nil, NewPortalExternalChainError(VerifyProofErr, errors.New("merkle proof is invalid or the block does not have enough confirmations"))
+	}
+
+	// extract attached message from txOut's OP_RETURN
+	attachedMsg, err := btcrelaying.ExtractAttachedMsgFromTx(btcTxProof.BTCTx)
+	if err != nil {
+		return nil, NewPortalExternalChainError(ParseTxErr, err)
+	}
+	tx := &ExternalTx{
+		Memo:    attachedMsg,
+		Outputs: []*ExternalTxOutput{},
+	}
+	for _, out := range btcTxProof.BTCTx.TxOut {
+		addrStr, err := c.headerChain.ExtractPaymentAddrStrFromPkScript(out.PkScript)
+		if err != nil {
+			continue
+		}
+		tx.Outputs = append(tx.Outputs, &ExternalTxOutput{
+			Address: addrStr,
+			Amount:  out.Value,
+		})
+	}
+	return tx, nil
+}
+
+func (c *BTCChain) MinConfirmations() uint64 {
+	return btcrelaying.BTCBlockConfirmations
+}
+
+// MinAmount is 1 satoshi, the smallest unit of btc
+func (c *BTCChain) MinAmount() uint64 {
+	return 10
+}
+
+func (c *BTCChain) ConvertIncAmountToExternalAmount(incAmount int64) int64 {
+	return btcrelaying.ConvertIncPBTCAmountToExternalBTCAmount(incAmount)
+}
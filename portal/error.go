@@ -0,0 +1,53 @@
+package portal
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	UnexpectedErr = iota
+	NilHeaderChainErr
+	ParseProofErr
+	VerifyProofErr
+	NotEnoughConfirmationsErr
+	ParseTxErr
+	GetLatestBlockHeightErr
+)
+
+var ErrCodeMessage = map[int]struct {
+	Code    int
+	Message string
+}{
+	UnexpectedErr: {-14300, "Unexpected error"},
+
+	NilHeaderChainErr:         {-14301, "Relaying header chain of the external chain should not be null error"},
+	ParseProofErr:             {-14302, "Parse external tx proof from base64 string error"},
+	VerifyProofErr:            {-14303, "Verify external tx proof error"},
+	NotEnoughConfirmationsErr: {-14304, "Not enough confirmations of external tx error"},
+	ParseTxErr:                {-14305, "Parse external tx from proof error"},
+	GetLatestBlockHeightErr:   {-14306, "Get latest block height of external chain error"},
+}
+
+type PortalExternalChainError struct {
+	Code    int
+	Message string
+	err     error
+}
+
+func (e PortalExternalChainError) Error() string {
+	return fmt.Sprintf("%+v: %+v %+v", e.Code, e.Message, e.err)
+}
+
+func (e PortalExternalChainError) GetCode() int {
+	return e.Code
+}
+
+func NewPortalExternalChainError(key int, err error) *PortalExternalChainError {
+	return &PortalExternalChainError{
+		err:     errors.Wrap(err, ErrCodeMessage[key].Message),
+		Code:    ErrCodeMessage[key].Code,
+		Message: ErrCodeMessage[key].Message,
+	}
+}
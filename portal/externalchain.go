@@ -0,0 +1,46 @@
+package portal
+
+// ExternalChain is a chain whose public tokens can be ported to the Portal.
+// Users and custodians prove transfers on it with inclusion proofs verified against its relaying header chain.
+type ExternalChain interface {
+	// IsValidAddress checks a remote address of custodians and redeemers on the chain
+	IsValidAddress(address string) bool
+	// EncodePortingMemo returns the memo users attach to txs sending public tokens to custodians
+	EncodePortingMemo(portingID string) (string, error)
+	// EncodeRedeemMemo returns the memo custodians attach to txs returning public tokens to redeemers
+	EncodeRedeemMemo(redeemID string, custodianIncAddress string) (string, error)
+	MatchPortingMemo(memo string, portingID string) bool
+	MatchRedeemMemo(memo string, redeemID string, custodianIncAddress string) bool
+	// ParseAndVerifyProof parses a base64 encoded inclusion proof and verifies it against the relaying header chain,
+	// the block including the tx must have at least MinConfirmations blocks on top of it
+	ParseAndVerifyProof(proof string) (*ExternalTx, error)
+	MinConfirmations() uint64
+	// MinAmount is the smallest amount of the ptoken in porting and redeem requests,
+	// it must not be less than the smallest unit of the public token to avoid requests that can not be transferred
+	MinAmount() uint64
+	// ConvertIncAmountToExternalAmount converts an amount of the ptoken (decimal 9) to an amount of the public token
+	ConvertIncAmountToExternalAmount(incAmount int64) int64
+}
+
+// ExternalTx is a tx of an external chain whose inclusion proof was verified
+type ExternalTx struct {
+	Memo    string
+	Outputs []*ExternalTxOutput
+}
+
+// ExternalTxOutput is an amount of the public token sent to an address,
+// outputs whose address could not be parsed are not kept
+type ExternalTxOutput struct {
+	Address string
+	Amount  int64
+}
+
+// GetTransferredAmount returns the amount of the first output to the address, false if there is none
+func (tx *ExternalTx) GetTransferredAmount(address string) (int64, bool) {
+	for _, out := range tx.Outputs {
+		if out.Address == address {
+			return out.Amount, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,67 @@
+package portal
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBNBMemo(t *testing.T) {
+	bnbChain := NewBNBChain(nil, "")
+	portingID := "porting-1"
+	redeemID := "redeem-1"
+	custodianIncAddress := "12RuEdPjq4yxivzm8xPxRVHmkL74t4eAdUKPdKKhMEnpxPH3k8GEyULbwq4hjwHWmHQr7MmGBJsMpdCHsYAqNE18jipWQwciBf9yqvQ"
+
+	portingMemo, err := bnbChain.EncodePortingMemo(portingID)
+	assert.Nil(t, err)
+	assert.True(t, bnbChain.MatchPortingMemo(portingMemo, portingID))
+	assert.False(t, bnbChain.MatchPortingMemo(portingMemo, "porting-2"))
+	assert.False(t, bnbChain.MatchPortingMemo("invalid", portingID))
+
+	// the porting memo is matched on its json fields
+	memoBytes, _ := json.Marshal(map[string]string{"PortingID": portingID, "Note": "porting"})
+	assert.True(t, bnbChain.MatchPortingMemo(base64.StdEncoding.EncodeToString(memoBytes), portingID))
+
+	redeemMemo, err := bnbChain.EncodeRedeemMemo(redeemID, custodianIncAddress)
+	assert.Nil(t, err)
+	assert.True(t, bnbChain.MatchRedeemMemo(redeemMemo, redeemID, custodianIncAddress))
+	assert.False(t, bnbChain.MatchRedeemMemo(redeemMemo, redeemID, ""))
+	assert.False(t, bnbChain.MatchRedeemMemo(portingMemo, redeemID, custodianIncAddress))
+}
+
+func TestBTCMemo(t *testing.T) {
+	btcChain := NewBTCChain(nil)
+	portingMemo, err := btcChain.EncodePortingMemo("porting-1")
+	assert.Nil(t, err)
+	assert.True(t, btcChain.MatchPortingMemo(portingMemo, "porting-1"))
+	assert.False(t, btcChain.MatchPortingMemo(portingMemo, "porting-2"))
+
+	redeemMemo, err := btcChain.EncodeRedeemMemo("redeem-1", "custodian")
+	assert.Nil(t, err)
+	assert.True(t, btcChain.MatchRedeemMemo(redeemMemo, "redeem-1", "custodian"))
+	assert.False(t, btcChain.MatchRedeemMemo(redeemMemo, "redeem-1", "other"))
+
+	// without a relaying header chain nothing can be verified
+	assert.False(t, btcChain.IsValidAddress("mgLFmRTFRakf5zs9QXPN9XAhbSdD1ADq5W"))
+	_, err = btcChain.ParseAndVerifyProof("")
+	assert.NotNil(t, err)
+}
+
+func TestGetTransferredAmount(t *testing.T) {
+	tx := &ExternalTx{
+		Outputs: []*ExternalTxOutput{
+			{Address: "addr1", Amount: 100},
+			{Address: "addr2", Amount: 50},
+			{Address: "addr1", Amount: 200},
+		},
+	}
+	amount, isFound := tx.GetTransferredAmount("addr1")
+	assert.True(t, isFound)
+	assert.Equal(t, int64(100), amount)
+	_, isFound = tx.GetTransferredAmount("addr3")
+	assert.False(t, isFound)
+
+	assert.Equal(t, int64(1000), NewBNBChain(nil, "").ConvertIncAmountToExternalAmount(10000))
+}
@@ -0,0 +1,86 @@
+package privacy
+
+import (
+	"sync"
+
+	C25519 "github.com/incognitochain/incognito-chain/privacy/curve25519"
+)
+
+// BatchVerifier checks many equations of the form sum(scalars[i] * points[i]) == identity at once.
+// Each equation is weighted by a random scalar and all of them are summed in one multi-scalar multiplication:
+// if any equation is false, the sum is the identity only with negligible probability.
+// Terms sharing a point (e.g. Pedersen generators) are merged so the multiplication stays small.
+// BatchVerifier is not safe for concurrent use while adding equations.
+type BatchVerifier struct {
+	scalars []*Scalar
+	points  []*Point
+	indices map[C25519.Key]int // index of each point in points
+}
+
+func NewBatchVerifier() *BatchVerifier {
+	return &BatchVerifier{
+		scalars: []*Scalar{},
+		points:  []*Point{},
+		indices: map[C25519.Key]int{},
+	}
+}
+
+// AddEquation adds the equation sum(scalars[i] * points[i]) == identity
+func (verifier *BatchVerifier) AddEquation(scalars []*Scalar, points []*Point) {
+	if len(scalars) != len(points) {
+		panic("Cannot add equation with different size inputs")
+	}
+	weight := RandomScalar()
+	for i := range scalars {
+		term := new(Scalar).Mul(scalars[i], weight)
+		if index, ok := verifier.indices[points[i].key]; ok {
+			verifier.scalars[index].Add(verifier.scalars[index], term)
+			continue
+		}
+		verifier.indices[points[i].key] = len(verifier.points)
+		verifier.scalars = append(verifier.scalars, term)
+		verifier.points = append(verifier.points, points[i])
+	}
+}
+
+// Len returns the number of distinct points of the added equations
+func (verifier *BatchVerifier) Len() int {
+	return len(verifier.points)
+}
+
+// Verify returns true if all added equations hold, the multi-scalar multiplication is split among numWorkers goroutines
+func (verifier *BatchVerifier) Verify(numWorkers int) bool {
+	n := len(verifier.points)
+	if n == 0 {
+		return true
+	}
+	if numWorkers < 1 {
+		numWorkers = 1
+	}
+	chunkSize := (n + numWorkers - 1) / numWorkers
+
+	sums := make([]*Point, 0, numWorkers)
+	for from := 0; from < n; from += chunkSize {
+		sums = append(sums, nil)
+	}
+	wg := sync.WaitGroup{}
+	for i := range sums {
+		from := i * chunkSize
+		to := from + chunkSize
+		if to > n {
+			to = n
+		}
+		wg.Add(1)
+		go func(i, from, to int) {
+			defer wg.Done()
+			sums[i] = new(Point).MultiScalarMult(verifier.scalars[from:to], verifier.points[from:to])
+		}(i, from, to)
+	}
+	wg.Wait()
+
+	result := new(Point).Identity()
+	for _, sum := range sums {
+		result.Add(result, sum)
+	}
+	return result.IsIdentity()
+}
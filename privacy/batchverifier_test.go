@@ -0,0 +1,49 @@
+package privacy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// randomEquation returns a true equation a*G + b*H - P == identity with P = a*G + b*H
+func randomEquation() ([]*Scalar, []*Point) {
+	a := RandomScalar()
+	b := RandomScalar()
+	p := PedCom.CommitAtIndex(a, b, PedersenValueIndex)
+	minusOne := new(Scalar).Sub(new(Scalar).FromUint64(0), new(Scalar).FromUint64(1))
+	return []*Scalar{a, b, minusOne}, []*Point{PedCom.G[PedersenValueIndex], PedCom.G[PedersenRandomnessIndex], p}
+}
+
+func TestBatchVerifier(t *testing.T) {
+	verifier := NewBatchVerifier()
+	assert.True(t, verifier.Verify(1), "empty batch failed")
+
+	for i := 0; i < 50; i++ {
+		verifier.AddEquation(randomEquation())
+	}
+	// Generators are merged, each equation adds 1 point
+	assert.Equal(t, 52, verifier.Len())
+	for _, numWorkers := range []int{0, 1, 3, 8, 100} {
+		assert.True(t, verifier.Verify(numWorkers), "true equations failed with %v workers", numWorkers)
+	}
+
+	scalars, points := randomEquation()
+	scalars[0] = RandomScalar()
+	verifier.AddEquation(scalars, points)
+	for _, numWorkers := range []int{1, 3, 8} {
+		assert.False(t, verifier.Verify(numWorkers), "false equation passed with %v workers", numWorkers)
+	}
+}
+
+// TestBatchVerifierOpposite makes sure two false equations cancelling each other don't pass thanks to the random weights
+func TestBatchVerifierOpposite(t *testing.T) {
+	g := PedCom.G[PedersenValueIndex]
+	one := new(Scalar).FromUint64(1)
+	minusOne := new(Scalar).Sub(new(Scalar).FromUint64(0), one)
+
+	verifier := NewBatchVerifier()
+	verifier.AddEquation([]*Scalar{one}, []*Point{g})
+	verifier.AddEquation([]*Scalar{minusOne}, []*Point{g})
+	assert.False(t, verifier.Verify(1))
+}
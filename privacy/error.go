@@ -33,6 +33,8 @@ const (
 	SignMultiSigErr
 	InvalidLengthMultiSigErr
 	InvalidMultiSigErr
+	VerifyBatchProofsFailedErr
+	VerifyBatchSignaturesFailedErr
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -69,6 +71,8 @@ var ErrCodeMessage = map[int]struct {
 	VerifySerialNumberPrivacyProofFailedErr:   {-9206, "Verify serial number privacy proof failed"},
 	VerifyAggregatedProofFailedErr:            {-9207, "Verify aggregated proof failed"},
 	VerifyAmountPrivacyFailedErr:              {-9208, "Sum of input coins' amount is not equal sum of output coins' amount when creating private tx"},
+	VerifyBatchProofsFailedErr:                {-9209, "Verify batch of one out of many and serial number proofs failed"},
+	VerifyBatchSignaturesFailedErr:            {-9210, "Verify batch of signatures failed"},
 }
 
 type PrivacyError struct {
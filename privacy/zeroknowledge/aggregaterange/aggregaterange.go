@@ -592,14 +592,7 @@ func VerifyBatchingAggregatedRangeProofs(proofs []*AggregatedRangeProof) (bool,
 		xSquare := new(privacy.Scalar).Mul(x, x)
 
 		yVector := powerVector(y, n*numValuePad)
-		// HPrime = H^(y^(1-i)
-		HPrime := make([]*privacy.Point, n*numValuePad)
-		yInverse := new(privacy.Scalar).Invert(y)
-		expyInverse := new(privacy.Scalar).FromUint64(1)
-		for i := 0; i < n*numValuePad; i++ {
-			HPrime[i] = new(privacy.Point).ScalarMult(aggParam.h[i], expyInverse)
-			expyInverse.Mul(expyInverse, yInverse)
-		}
+		// HPrime = H^(y^(1-i)) is not needed here, VerifyBatchingInnerProductProofs checks the proofs against AggParam.h
 
 		// g^tHat * h^tauX = V^(z^2) * g^delta(y,z) * T1^x * T2^(x^2)
 		deltaYZ := new(privacy.Scalar).Sub(z, zSquare)
@@ -459,6 +459,75 @@ func (proof OneOutOfManyProof) Verify() (bool, error) {
 	return true, nil
 }
 
+// AddToBatch adds the statements checked by Verify to a batch verifier instead of checking them one by one
+func (proof OneOutOfManyProof) AddToBatch(verifier *privacy.BatchVerifier) error {
+	N := len(proof.Statement.Commitments)
+
+	// the number of Commitment list's elements must be equal to CMRingSize
+	if N != privacy.CommitmentRingSize {
+		return errors.New("Invalid length of commitments list in one out of many proof")
+	}
+	n := privacy.CommitmentRingSizeExp
+
+	//Calculate x
+	x := new(privacy.Scalar).FromUint64(0)
+
+	for j := 0; j < n; j++ {
+		x = utils.GenerateChallenge([][]byte{x.ToBytesS(), proof.cl[j].ToBytesS(), proof.ca[j].ToBytesS(), proof.cb[j].ToBytesS(), proof.cd[j].ToBytesS()})
+	}
+
+	zero := new(privacy.Scalar).FromUint64(0)
+	one := new(privacy.Scalar).FromUint64(1)
+	gSK := privacy.PedCom.G[privacy.PedersenPrivateKeyIndex]
+	h := privacy.PedCom.G[privacy.PedersenRandomnessIndex]
+
+	for i := 0; i < n; i++ {
+		//cl^x * ca = Com(f, za)
+		verifier.AddEquation(
+			[]*privacy.Scalar{x, one, new(privacy.Scalar).Sub(zero, proof.f[i]), new(privacy.Scalar).Sub(zero, proof.za[i])},
+			[]*privacy.Point{proof.cl[i], proof.ca[i], gSK, h})
+
+		//cl^(x-f) * cb = Com(0, zb)
+		verifier.AddEquation(
+			[]*privacy.Scalar{new(privacy.Scalar).Sub(x, proof.f[i]), one, new(privacy.Scalar).Sub(zero, proof.zb[i])},
+			[]*privacy.Point{proof.cl[i], proof.cb[i], h})
+	}
+
+	//prod(Commitments[i]^exp_i) * prod(cd[k]^(-x^k)) = Com(0, zd)
+	scalars := make([]*privacy.Scalar, 0, N+n+1)
+	points := make([]*privacy.Point, 0, N+n+1)
+	for i := 0; i < N; i++ {
+		iBinary := privacy.ConvertIntToBinary(i, n)
+
+		exp := new(privacy.Scalar).FromUint64(1)
+		fji := new(privacy.Scalar).FromUint64(1)
+		for j := 0; j < n; j++ {
+			if iBinary[j] == 1 {
+				fji.Set(proof.f[j])
+			} else {
+				fji.Sub(x, proof.f[j])
+			}
+
+			exp.Mul(exp, fji)
+		}
+		scalars = append(scalars, exp)
+		points = append(points, proof.Statement.Commitments[i])
+	}
+
+	xk := new(privacy.Scalar).FromUint64(1)
+	for k := 0; k < n; k++ {
+		scalars = append(scalars, new(privacy.Scalar).Sub(zero, xk))
+		points = append(points, proof.cd[k])
+		xk = new(privacy.Scalar).Mul(xk, x)
+	}
+
+	scalars = append(scalars, new(privacy.Scalar).Sub(zero, proof.zd))
+	points = append(points, h)
+	verifier.AddEquation(scalars, points)
+
+	return nil
+}
+
 // Get coefficient of x^k in the polynomial p_i(x)
 func getCoefficient(iBinary []byte, k int, n int, scLs []*privacy.Scalar, l []byte) *privacy.Scalar {
 
@@ -90,3 +90,49 @@ func TestPKOneOfMany(t *testing.T) {
 	}
 }
 
+
+func TestPKOneOfManyAddToBatch(t *testing.T) {
+	verifier := privacy.NewBatchVerifier()
+	for i := 0; i < 10; i++ {
+		witness := new(OneOutOfManyWitness)
+		indexIsZero := int(common.RandInt() % privacy.CommitmentRingSize)
+
+		commitments := make([]*privacy.Point, privacy.CommitmentRingSize)
+		randoms := make([]*privacy.Scalar, privacy.CommitmentRingSize)
+		for j := 0; j < privacy.CommitmentRingSize; j++ {
+			randoms[j] = privacy.RandomScalar()
+			commitments[j] = privacy.PedCom.CommitAtIndex(privacy.RandomScalar(), randoms[j], privacy.PedersenPrivateKeyIndex)
+		}
+		commitments[indexIsZero] = privacy.PedCom.CommitAtIndex(new(privacy.Scalar).FromUint64(0), randoms[indexIsZero], privacy.PedersenPrivateKeyIndex)
+
+		witness.Set(commitments, randoms[indexIsZero], uint64(indexIsZero))
+		proof, err := witness.Prove()
+		assert.Equal(t, nil, err)
+
+		err = proof.AddToBatch(verifier)
+		assert.Equal(t, nil, err)
+	}
+	assert.Equal(t, true, verifier.Verify(4))
+
+	// a proof on other commitments fails the whole batch
+	witness := new(OneOutOfManyWitness)
+	commitments := make([]*privacy.Point, privacy.CommitmentRingSize)
+	rand := privacy.RandomScalar()
+	for j := 0; j < privacy.CommitmentRingSize; j++ {
+		commitments[j] = privacy.PedCom.CommitAtIndex(privacy.RandomScalar(), privacy.RandomScalar(), privacy.PedersenPrivateKeyIndex)
+	}
+	commitments[0] = privacy.PedCom.CommitAtIndex(new(privacy.Scalar).FromUint64(0), rand, privacy.PedersenPrivateKeyIndex)
+	witness.Set(commitments, rand, 0)
+	proof, err := witness.Prove()
+	assert.Equal(t, nil, err)
+	proof.Statement.Commitments = append([]*privacy.Point{privacy.RandomPoint()}, commitments[1:]...)
+
+	res, _ := proof.Verify()
+	assert.Equal(t, false, res)
+	err = proof.AddToBatch(verifier)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, verifier.Verify(4))
+
+	proof.Statement.Commitments = commitments[1:]
+	assert.NotEqual(t, nil, proof.AddToBatch(verifier))
+}
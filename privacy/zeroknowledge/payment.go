@@ -716,7 +716,9 @@ func (proof PaymentProof) verifyNoPrivacy(pubKey privacy.PublicKey, fee uint64,
 	return true, nil
 }
 
-func (proof PaymentProof) verifyHasPrivacy(pubKey privacy.PublicKey, fee uint64, stateDB *statedb.StateDB, shardID byte, tokenID *common.Hash, isBatch bool) (bool, error) {
+// verifyHasPrivacy verifies a payment proof with privacy, the one-out-of-many, serial number and range proofs are
+// added to batch instead of being verified when batch is not nil; the range proof is not verified either when isBatch is true
+func (proof PaymentProof) verifyHasPrivacy(pubKey privacy.PublicKey, fee uint64, stateDB *statedb.StateDB, shardID byte, tokenID *common.Hash, isBatch bool, batch *ProofBatch) (bool, error) {
 	// verify for input coins
 	cmInputSum := make([]*privacy.Point, len(proof.oneOfManyProof))
 	for i := 0; i < len(proof.oneOfManyProof); i++ {
@@ -757,6 +759,15 @@ func (proof PaymentProof) verifyHasPrivacy(pubKey privacy.PublicKey, fee uint64,
 
 		proof.oneOfManyProof[i].Statement.Commitments = commitments
 
+		if batch != nil {
+			if err := proof.oneOfManyProof[i].AddToBatch(batch.verifier); err != nil {
+				privacy.Logger.Log.Errorf("VERIFICATION PAYMENT PROOF: One out of many failed")
+				return false, privacy.NewPrivacyErr(privacy.VerifyOneOutOfManyProofFailedErr, err)
+			}
+			proof.serialNumberProof[i].AddToBatch(nil, batch.verifier)
+			continue
+		}
+
 		valid, err := proof.oneOfManyProof[i].Verify()
 		if !valid {
 			privacy.Logger.Log.Errorf("VERIFICATION PAYMENT PROOF: One out of many failed")
@@ -783,7 +794,9 @@ func (proof PaymentProof) verifyHasPrivacy(pubKey privacy.PublicKey, fee uint64,
 	}
 
 	// Verify the proof that output values and sum of them do not exceed v_max
-	if isBatch == false {
+	if batch != nil {
+		batch.rangeProofs = append(batch.rangeProofs, proof.aggregatedRangeProof)
+	} else if isBatch == false {
 		valid, err := proof.aggregatedRangeProof.Verify()
 		if !valid {
 			privacy.Logger.Log.Errorf("VERIFICATION PAYMENT PROOF: Multi-range failed")
@@ -825,5 +838,16 @@ func (proof PaymentProof) Verify(hasPrivacy bool, pubKey privacy.PublicKey, fee
 		return proof.verifyNoPrivacy(pubKey, fee, stateDB, shardID, tokenID)
 	}
 
-	return proof.verifyHasPrivacy(pubKey, fee, stateDB, shardID, tokenID, isBatch)
+	return proof.verifyHasPrivacy(pubKey, fee, stateDB, shardID, tokenID, isBatch, nil)
+}
+
+// VerifyInBatch verifies a payment proof like Verify but adds its one-out-of-many, serial number and range proofs
+// to batch, they are verified with the proofs of the other transactions by batch.Verify
+func (proof PaymentProof) VerifyInBatch(hasPrivacy bool, pubKey privacy.PublicKey, fee uint64, stateDB *statedb.StateDB, shardID byte, tokenID *common.Hash, batch *ProofBatch) (bool, error) {
+	// has no privacy
+	if !hasPrivacy {
+		return proof.verifyNoPrivacy(pubKey, fee, stateDB, shardID, tokenID)
+	}
+
+	return proof.verifyHasPrivacy(pubKey, fee, stateDB, shardID, tokenID, true, batch)
 }
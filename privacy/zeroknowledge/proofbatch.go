@@ -0,0 +1,113 @@
+package zkp
+
+import (
+	"sync"
+
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/privacy/zeroknowledge/aggregaterange"
+	"github.com/pkg/errors"
+)
+
+// ProofBatch collects the signatures and payment proofs of many transactions, e.g. all transactions of a shard block,
+// to verify them together:
+//   - one-out-of-many and serial number proofs are checked with a random linear combination of their statements
+//   - range proofs are checked with aggregaterange.VerifyBatchingAggregatedRangeProofs
+//   - Schnorr signatures hash the recomputed nonce so they can't be combined, they are verified concurrently
+//
+// A failed batch doesn't tell which transaction is invalid, callers verify them one by one to find it.
+type ProofBatch struct {
+	verifier    *privacy.BatchVerifier
+	rangeProofs []*aggregaterange.AggregatedRangeProof
+	signatures  []batchSignature
+}
+
+type batchSignature struct {
+	verifyKey *privacy.SchnorrPublicKey
+	signature *privacy.SchnSignature
+	data      []byte
+}
+
+func NewProofBatch() *ProofBatch {
+	return &ProofBatch{
+		verifier:    privacy.NewBatchVerifier(),
+		rangeProofs: []*aggregaterange.AggregatedRangeProof{},
+		signatures:  []batchSignature{},
+	}
+}
+
+// AddSignature adds a Schnorr signature on data to verify with the batch
+func (batch *ProofBatch) AddSignature(verifyKey *privacy.SchnorrPublicKey, signature *privacy.SchnSignature, data []byte) {
+	batch.signatures = append(batch.signatures, batchSignature{
+		verifyKey: verifyKey,
+		signature: signature,
+		data:      data,
+	})
+}
+
+// Verify verifies all signatures and proofs added to the batch with numWorkers goroutines
+func (batch *ProofBatch) Verify(numWorkers int) (bool, error) {
+	if numWorkers < 1 {
+		numWorkers = 1
+	}
+	var sigErr, proofErr, rangeErr error
+	wg := sync.WaitGroup{}
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		sigErr = batch.verifySignatures(numWorkers)
+	}()
+	go func() {
+		defer wg.Done()
+		if !batch.verifier.Verify(numWorkers) {
+			proofErr = privacy.NewPrivacyErr(privacy.VerifyBatchProofsFailedErr, nil)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		if len(batch.rangeProofs) == 0 {
+			return
+		}
+		valid, err, _ := aggregaterange.VerifyBatchingAggregatedRangeProofs(batch.rangeProofs)
+		if !valid {
+			rangeErr = privacy.NewPrivacyErr(privacy.VerifyAggregatedProofFailedErr, err)
+		}
+	}()
+	wg.Wait()
+
+	for _, err := range []error{sigErr, proofErr, rangeErr} {
+		if err != nil {
+			privacy.Logger.Log.Errorf("VERIFICATION PROOF BATCH: %v", err)
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func (batch *ProofBatch) verifySignatures(numWorkers int) error {
+	indices := make(chan int, len(batch.signatures))
+	for i := range batch.signatures {
+		indices <- i
+	}
+	close(indices)
+
+	failed := make([]bool, len(batch.signatures))
+	wg := sync.WaitGroup{}
+	for w := 0; w < numWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				sig := batch.signatures[i]
+				failed[i] = !sig.verifyKey.Verify(sig.signature, sig.data)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i := range failed {
+		if failed[i] {
+			return privacy.NewPrivacyErr(privacy.VerifyBatchSignaturesFailedErr, errors.Errorf("signature %v is invalid", i))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+package zkp
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/privacy/zeroknowledge/aggregaterange"
+	"github.com/incognitochain/incognito-chain/privacy/zeroknowledge/oneoutofmany"
+	"github.com/incognitochain/incognito-chain/privacy/zeroknowledge/serialnumberprivacy"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	privacy.Logger.Init(common.NewBackend(nil).Logger("test", true))
+}
+
+// blockTx holds what is verified for a privacy tx spending one coin to two outputs
+type blockTx struct {
+	verifyKey  *privacy.SchnorrPublicKey
+	signature  *privacy.SchnSignature
+	hash       []byte
+	oneOfMany  *oneoutofmany.OneOutOfManyProof
+	snProof    *serialnumberprivacy.SNPrivacyProof
+	rangeProof *aggregaterange.AggregatedRangeProof
+}
+
+func newBlockTx(t testing.TB) *blockTx {
+	tx := &blockTx{hash: privacy.RandomScalar().ToBytesS()}
+
+	sk := privacy.RandomScalar()
+	sigKey := new(privacy.SchnorrPrivateKey)
+	sigKey.Set(sk, privacy.RandomScalar())
+	signature, err := sigKey.Sign(tx.hash)
+	assert.Nil(t, err)
+	tx.verifyKey = sigKey.GetPublicKey()
+	tx.signature = signature
+
+	commitments := make([]*privacy.Point, privacy.CommitmentRingSize)
+	for i := range commitments {
+		commitments[i] = privacy.PedCom.CommitAtIndex(privacy.RandomScalar(), privacy.RandomScalar(), privacy.PedersenPrivateKeyIndex)
+	}
+	rand := privacy.RandomScalar()
+	indexIsZero := int(common.RandInt() % privacy.CommitmentRingSize)
+	commitments[indexIsZero] = privacy.PedCom.CommitAtIndex(new(privacy.Scalar).FromUint64(0), rand, privacy.PedersenPrivateKeyIndex)
+	oomWitness := new(oneoutofmany.OneOutOfManyWitness)
+	oomWitness.Set(commitments, rand, uint64(indexIsZero))
+	tx.oneOfMany, err = oomWitness.Prove()
+	assert.Nil(t, err)
+
+	snd := privacy.RandomScalar()
+	rSK := privacy.RandomScalar()
+	rSND := privacy.RandomScalar()
+	stmt := new(serialnumberprivacy.SerialNumberPrivacyStatement)
+	stmt.Set(
+		new(privacy.Point).Derive(privacy.PedCom.G[privacy.PedersenPrivateKeyIndex], sk, snd),
+		privacy.PedCom.CommitAtIndex(sk, rSK, privacy.PedersenPrivateKeyIndex),
+		privacy.PedCom.CommitAtIndex(snd, rSND, privacy.PedersenSndIndex))
+	snWitness := new(serialnumberprivacy.SNPrivacyWitness)
+	snWitness.Set(stmt, sk, rSK, snd, rSND)
+	tx.snProof, err = snWitness.Prove(nil)
+	assert.Nil(t, err)
+
+	rangeWitness := new(aggregaterange.AggregatedRangeWitness)
+	rangeWitness.Set([]uint64{uint64(common.RandInt64()), uint64(common.RandInt64())}, []*privacy.Scalar{privacy.RandomScalar(), privacy.RandomScalar()})
+	tx.rangeProof, err = rangeWitness.Prove()
+	assert.Nil(t, err)
+	return tx
+}
+
+func newBlockTxs(t testing.TB, n int) []*blockTx {
+	txs := make([]*blockTx, n)
+	for i := range txs {
+		txs[i] = newBlockTx(t)
+	}
+	return txs
+}
+
+// verifyBlockTxsOneByOne verifies txs like block validation did before batching: signatures,
+// one-out-of-many and serial number proofs tx by tx, range proofs batched together
+func verifyBlockTxsOneByOne(txs []*blockTx) bool {
+	rangeProofs := make([]*aggregaterange.AggregatedRangeProof, 0, len(txs))
+	for _, tx := range txs {
+		if !tx.verifyKey.Verify(tx.signature, tx.hash) {
+			return false
+		}
+		if valid, _ := tx.oneOfMany.Verify(); !valid {
+			return false
+		}
+		if valid, _ := tx.snProof.Verify(nil); !valid {
+			return false
+		}
+		rangeProofs = append(rangeProofs, tx.rangeProof)
+	}
+	valid, _, _ := aggregaterange.VerifyBatchingAggregatedRangeProofs(rangeProofs)
+	return valid
+}
+
+func verifyBlockTxsInBatch(txs []*blockTx, numWorkers int) (bool, error) {
+	batch := NewProofBatch()
+	for _, tx := range txs {
+		batch.AddSignature(tx.verifyKey, tx.signature, tx.hash)
+		if err := tx.oneOfMany.AddToBatch(batch.verifier); err != nil {
+			return false, err
+		}
+		tx.snProof.AddToBatch(nil, batch.verifier)
+		batch.rangeProofs = append(batch.rangeProofs, tx.rangeProof)
+	}
+	return batch.Verify(numWorkers)
+}
+
+func TestProofBatchVerify(t *testing.T) {
+	txs := newBlockTxs(t, 5)
+	assert.True(t, verifyBlockTxsOneByOne(txs))
+	valid, err := verifyBlockTxsInBatch(txs, 4)
+	assert.True(t, valid)
+	assert.Nil(t, err)
+
+	valid, err = verifyBlockTxsInBatch(nil, 4)
+	assert.True(t, valid, "empty batch failed")
+	assert.Nil(t, err)
+}
+
+func TestProofBatchVerifyInvalidSignature(t *testing.T) {
+	txs := newBlockTxs(t, 3)
+	txs[1].hash = privacy.RandomScalar().ToBytesS()
+	valid, err := verifyBlockTxsInBatch(txs, 2)
+	assert.False(t, valid)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, privacy.ErrCodeMessage[privacy.VerifyBatchSignaturesFailedErr].Code, err.(*privacy.PrivacyError).Code)
+	}
+}
+
+func TestProofBatchVerifyInvalidProof(t *testing.T) {
+	txs := newBlockTxs(t, 3)
+	txs[2].oneOfMany.Statement.Commitments[0] = privacy.RandomPoint()
+	valid, err := verifyBlockTxsInBatch(txs, 2)
+	assert.False(t, valid)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, privacy.ErrCodeMessage[privacy.VerifyBatchProofsFailedErr].Code, err.(*privacy.PrivacyError).Code)
+	}
+}
+
+func TestProofBatchVerifyInvalidRangeProof(t *testing.T) {
+	txs := newBlockTxs(t, 3)
+	txs[0].rangeProof = txs[1].rangeProof
+	txs[1].rangeProof = newBlockTx(t).rangeProof
+	valid, err := verifyBlockTxsInBatch(txs, 2)
+	assert.True(t, valid, "swapped valid range proofs failed")
+	assert.Nil(t, err)
+
+	invalid := new(aggregaterange.AggregatedRangeProof)
+	invalid.Init()
+	assert.Nil(t, invalid.SetBytes(txs[2].rangeProof.Bytes()))
+	// a range proof of another tx commits to other values
+	invalidBytes := invalid.Bytes()
+	copy(invalidBytes[1:1+privacy.Ed25519KeySize], privacy.RandomPoint().ToBytesS())
+	assert.Nil(t, invalid.SetBytes(invalidBytes))
+	txs[2].rangeProof = invalid
+	valid, err = verifyBlockTxsInBatch(txs, 2)
+	assert.False(t, valid)
+	assert.NotNil(t, err)
+}
+
+func benchmarkBlockVerifyOneByOne(numTxs int, b *testing.B) {
+	txs := newBlockTxs(b, numTxs)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if !verifyBlockTxsOneByOne(txs) {
+			b.Fatal("block verification failed")
+		}
+	}
+}
+
+func benchmarkBlockVerifyBatch(numTxs int, numWorkers int, b *testing.B) {
+	txs := newBlockTxs(b, numTxs)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if valid, err := verifyBlockTxsInBatch(txs, numWorkers); !valid {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBlockVerifyOneByOne_10(b *testing.B)  { benchmarkBlockVerifyOneByOne(10, b) }
+func BenchmarkBlockVerifyBatch_10_1(b *testing.B)   { benchmarkBlockVerifyBatch(10, 1, b) }
+func BenchmarkBlockVerifyBatch_10_4(b *testing.B)   { benchmarkBlockVerifyBatch(10, 4, b) }
+func BenchmarkBlockVerifyOneByOne_30(b *testing.B)  { benchmarkBlockVerifyOneByOne(30, b) }
+func BenchmarkBlockVerifyBatch_30_1(b *testing.B)   { benchmarkBlockVerifyBatch(30, 1, b) }
+func BenchmarkBlockVerifyBatch_30_4(b *testing.B)   { benchmarkBlockVerifyBatch(30, 4, b) }
+func BenchmarkBlockVerifyOneByOne_100(b *testing.B) { benchmarkBlockVerifyOneByOne(100, b) }
+func BenchmarkBlockVerifyBatch_100_1(b *testing.B)  { benchmarkBlockVerifyBatch(100, 1, b) }
+func BenchmarkBlockVerifyBatch_100_4(b *testing.B)  { benchmarkBlockVerifyBatch(100, 4, b) }
@@ -349,3 +349,38 @@ func (proof SNPrivacyProof) Verify(mess []byte) (bool, error) {
 
 	return true, nil
 }
+
+// AddToBatch adds the statements checked by Verify to a batch verifier instead of checking them one by one
+func (proof SNPrivacyProof) AddToBatch(mess []byte, verifier *privacy.BatchVerifier) {
+	// re-calculate x = hash(tSeed || tInput || tSND2 || tOutput)
+	x := new(privacy.Scalar)
+	if mess == nil {
+		x = utils.GenerateChallenge([][]byte{
+			proof.tSK.ToBytesS(),
+			proof.tInput.ToBytesS(),
+			proof.tSN.ToBytesS()})
+	} else {
+		x.FromBytesS(mess)
+	}
+
+	zero := new(privacy.Scalar).FromUint64(0)
+	minusOne := new(privacy.Scalar).Sub(zero, new(privacy.Scalar).FromUint64(1))
+	minusX := new(privacy.Scalar).Sub(zero, x)
+	gSK := privacy.PedCom.G[privacy.PedersenPrivateKeyIndex]
+	h := privacy.PedCom.G[privacy.PedersenRandomnessIndex]
+
+	// gSND^zInput * h^zRInput = input^x * tInput
+	verifier.AddEquation(
+		[]*privacy.Scalar{proof.zInput, proof.zRInput, minusX, minusOne},
+		[]*privacy.Point{privacy.PedCom.G[privacy.PedersenSndIndex], h, proof.stmt.comInput, proof.tInput})
+
+	// gSK^zSeed * h^zRSeed = vKey^x * tSeed
+	verifier.AddEquation(
+		[]*privacy.Scalar{proof.zSK, proof.zRSK, minusX, minusOne},
+		[]*privacy.Point{gSK, h, proof.stmt.comSK, proof.tSK})
+
+	// sn^(zSeed + zInput) = gSK^x * tOutput
+	verifier.AddEquation(
+		[]*privacy.Scalar{new(privacy.Scalar).Add(proof.zSK, proof.zInput), minusX, minusOne},
+		[]*privacy.Point{proof.stmt.sn, gSK, proof.tSN})
+}
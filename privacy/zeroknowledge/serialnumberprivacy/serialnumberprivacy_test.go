@@ -62,3 +62,36 @@ func TestPKSNPrivacy(t *testing.T) {
 		assert.Equal(t, nil, err)
 	}
 }
+
+func TestPKSNPrivacyAddToBatch(t *testing.T) {
+	verifier := privacy.NewBatchVerifier()
+	proofs := make([]*SNPrivacyProof, 10)
+	for i := range proofs {
+		skScalar := new(privacy.Scalar).FromBytesS(privacy.GeneratePrivateKey(privacy.RandBytes(31)))
+		SND := privacy.RandomScalar()
+		rSK := privacy.RandomScalar()
+		rSND := privacy.RandomScalar()
+
+		serialNumber := new(privacy.Point).Derive(privacy.PedCom.G[privacy.PedersenPrivateKeyIndex], skScalar, SND)
+		comSK := privacy.PedCom.CommitAtIndex(skScalar, rSK, privacy.PedersenPrivateKeyIndex)
+		comSND := privacy.PedCom.CommitAtIndex(SND, rSND, privacy.PedersenSndIndex)
+
+		stmt := new(SerialNumberPrivacyStatement)
+		stmt.Set(serialNumber, comSK, comSND)
+		witness := new(SNPrivacyWitness)
+		witness.Set(stmt, skScalar, rSK, SND, rSND)
+
+		proof, err := witness.Prove(nil)
+		assert.Equal(t, nil, err)
+		proofs[i] = proof
+		proof.AddToBatch(nil, verifier)
+	}
+	assert.Equal(t, true, verifier.Verify(4))
+
+	// a proof for another serial number fails the whole batch
+	proofs[0].stmt.sn = privacy.RandomPoint()
+	res, _ := proofs[0].Verify(nil)
+	assert.Equal(t, false, res)
+	proofs[0].AddToBatch(nil, verifier)
+	assert.Equal(t, false, verifier.Verify(4))
+}
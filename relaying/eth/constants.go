@@ -0,0 +1,38 @@
+package eth
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+const (
+	// fixed params
+	MinConfirmationBlocks = 15  // a relayed header is final once it has this number of descendants
+	MaxHeadersPerBatch    = 100 // maximum number of headers submitted in one relaying tx
+
+	// mainnet
+	MainnetETHChainID = "Ethereum-Mainnet"
+
+	// testnet
+	TestnetETHChainID = "Ethereum-Ropsten"
+)
+
+// ChainParams are rules of the relayed ethereum network,
+// the relayed chain starts from the header with CheckpointHash.
+// Relayed headers are verified with ethash, so the checkpoint must be a recent proof-of-work header of the network;
+// a network without a configured checkpoint can not be relayed.
+type ChainParams struct {
+	ChainConfig    *params.ChainConfig
+	CheckpointHash common.Hash
+}
+
+// checkpoints are not configured yet: ethash can not verify headers produced after the merge
+// and ropsten is deprecated, eth deposits are verified through the RPC endpoints meanwhile
+var chainParamsByChainID = map[string]*ChainParams{
+	MainnetETHChainID: {
+		ChainConfig: params.MainnetChainConfig,
+	},
+	TestnetETHChainID: {
+		ChainConfig: params.TestnetChainConfig,
+	},
+}
@@ -0,0 +1,71 @@
+package eth
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	UnexpectedErr = iota
+	InvalidChainIDErr
+	CheckpointNotConfiguredErr
+	ParseHeadersErr
+	InvalidHeaderBatchErr
+	InvalidCheckpointHeaderErr
+	UnknownParentHeaderErr
+	InvalidHeaderErr
+	InvalidSealErr
+	FinalizedHeaderReorgErr
+	KnownHeadersErr
+	StoreHeaderErr
+	GetHeaderErr
+	HeaderNotFoundErr
+	HeaderNotFinalizedErr
+)
+
+var ErrCodeMessage = map[int]struct {
+	Code    int
+	Message string
+}{
+	UnexpectedErr: {-14200, "Unexpected error"},
+
+	InvalidChainIDErr:          {-14201, "Invalid eth relaying chain id error"},
+	ParseHeadersErr:            {-14202, "Parse eth headers from base64 string error"},
+	InvalidHeaderBatchErr:      {-14203, "Invalid eth header batch error"},
+	InvalidCheckpointHeaderErr: {-14204, "First relayed eth header is not the checkpoint error"},
+	UnknownParentHeaderErr:     {-14205, "Parent of eth header was not relayed error"},
+	InvalidHeaderErr:           {-14206, "Invalid eth header error"},
+	InvalidSealErr:             {-14207, "Invalid eth header seal error"},
+	FinalizedHeaderReorgErr:    {-14208, "Eth header forks below a finalized header error"},
+	KnownHeadersErr:            {-14209, "All eth headers were relayed already error"},
+
+	StoreHeaderErr:        {-14210, "Store eth header to statedb error"},
+	GetHeaderErr:          {-14211, "Get eth header from statedb error"},
+	HeaderNotFoundErr:     {-14212, "Eth header was not relayed error"},
+	HeaderNotFinalizedErr: {-14213, "Eth header is not finalized on the relayed chain error"},
+
+	CheckpointNotConfiguredErr: {-14214, "Checkpoint of relayed eth network is not configured error"},
+}
+
+type ETHRelayingError struct {
+	Code    int
+	Message string
+	err     error
+}
+
+func (e ETHRelayingError) Error() string {
+	return fmt.Sprintf("%+v: %+v %+v", e.Code, e.Message, e.err)
+}
+
+func (e ETHRelayingError) GetCode() int {
+	return e.Code
+}
+
+func NewETHRelayingError(key int, err error) *ETHRelayingError {
+	return &ETHRelayingError{
+		err:     errors.Wrap(err, ErrCodeMessage[key].Message),
+		Code:    ErrCodeMessage[key].Code,
+		Message: ErrCodeMessage[key].Message,
+	}
+}
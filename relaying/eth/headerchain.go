@@ -0,0 +1,264 @@
+package eth
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// StoredHeader is a relayed header with the total difficulty of the chain ending at it
+type StoredHeader struct {
+	Header          *types.Header
+	TotalDifficulty *big.Int
+}
+
+// HeaderStore persists relayed headers, the beacon chain keeps them in its feature statedb (see StateDBHeaderStore)
+type HeaderStore interface {
+	// GetHeader returns nil if the header was not relayed
+	GetHeader(hash common.Hash) (*StoredHeader, error)
+	StoreHeader(header *StoredHeader) error
+	GetCanonicalHash(number uint64) (common.Hash, bool, error)
+	StoreCanonicalHash(number uint64, hash common.Hash) error
+	// GetBestHeader returns nil if no header was relayed yet
+	GetBestHeader() (*StoredHeader, error)
+	StoreBestHeader(header *StoredHeader) error
+}
+
+// SealVerifier checks the proof of work of a header, it is implemented by ethash
+type SealVerifier interface {
+	VerifySeal(chain consensus.ChainReader, header *types.Header) error
+}
+
+// HeaderChain is the ethereum header chain relayed on-chain.
+// Headers are appended in batches, the canonical chain is the one with the most total difficulty
+// and a header is final when the canonical chain has MinConfirmationBlocks headers on top of it.
+type HeaderChain struct {
+	store       HeaderStore
+	chainParams *ChainParams
+	engine      SealVerifier
+}
+
+// NewHeaderChain creates a header chain on the store, engine can be nil if the chain is only read
+func NewHeaderChain(store HeaderStore, chainParams *ChainParams, engine SealVerifier) *HeaderChain {
+	return &HeaderChain{
+		store:       store,
+		chainParams: chainParams,
+		engine:      engine,
+	}
+}
+
+// InsertHeaders validates a batch of headers against the relayed chain and appends it,
+// the batch must extend a relayed header, or start with the checkpoint if the relayed chain is empty.
+// Headers of the batch which were relayed already are skipped.
+func (hc *HeaderChain) InsertHeaders(headers []*types.Header) error {
+	if hc.engine == nil {
+		return NewETHRelayingError(UnexpectedErr, errors.New("seal verifier of eth header chain should not be nil"))
+	}
+	err := ValidateHeaderBatch(headers)
+	if err != nil {
+		return err
+	}
+
+	best, err := hc.store.GetBestHeader()
+	if err != nil {
+		return err
+	}
+	var parent *StoredHeader
+	newHeaders := []*StoredHeader{}
+	for _, header := range headers {
+		known, err := hc.store.GetHeader(header.Hash())
+		if err != nil {
+			return err
+		}
+		if known != nil {
+			parent = known
+			continue
+		}
+
+		if best == nil && parent == nil {
+			// the relayed chain is empty, it starts from the checkpoint
+			if header.Hash() != hc.chainParams.CheckpointHash {
+				return NewETHRelayingError(InvalidCheckpointHeaderErr, fmt.Errorf("header %v is not the checkpoint %v", header.Hash().String(), hc.chainParams.CheckpointHash.String()))
+			}
+			parent = &StoredHeader{
+				Header:          header,
+				TotalDifficulty: new(big.Int).Set(header.Difficulty),
+			}
+			newHeaders = append(newHeaders, parent)
+			continue
+		}
+
+		if parent == nil {
+			parent, err = hc.store.GetHeader(header.ParentHash)
+			if err != nil {
+				return err
+			}
+			if parent == nil {
+				return NewETHRelayingError(UnknownParentHeaderErr, fmt.Errorf("parent %v of header %v", header.ParentHash.String(), header.Hash().String()))
+			}
+		}
+		if best != nil && header.Number.Uint64()+MinConfirmationBlocks <= best.Header.Number.Uint64() {
+			return NewETHRelayingError(FinalizedHeaderReorgErr, fmt.Errorf("header %v at %v, best header at %v", header.Hash().String(), header.Number, best.Header.Number))
+		}
+		err = hc.verifyHeader(header, parent.Header)
+		if err != nil {
+			return err
+		}
+		parent = &StoredHeader{
+			Header:          header,
+			TotalDifficulty: new(big.Int).Add(parent.TotalDifficulty, header.Difficulty),
+		}
+		newHeaders = append(newHeaders, parent)
+	}
+	if len(newHeaders) == 0 {
+		return NewETHRelayingError(KnownHeadersErr, fmt.Errorf("headers from %v to %v", headers[0].Number, headers[len(headers)-1].Number))
+	}
+
+	for _, header := range newHeaders {
+		err = hc.store.StoreHeader(header)
+		if err != nil {
+			return err
+		}
+	}
+	last := newHeaders[len(newHeaders)-1]
+	if best == nil || last.TotalDifficulty.Cmp(best.TotalDifficulty) > 0 {
+		return hc.setBestHeader(last, best)
+	}
+	return nil
+}
+
+// verifyHeader follows the header rules of ethash: parent link, timestamp, difficulty, gas limit and seal
+func (hc *HeaderChain) verifyHeader(header *types.Header, parent *types.Header) error {
+	if header.ParentHash != parent.Hash() {
+		return NewETHRelayingError(InvalidHeaderErr, fmt.Errorf("header %v does not link to parent %v", header.Hash().String(), parent.Hash().String()))
+	}
+	if header.Number.Cmp(new(big.Int).Add(parent.Number, big.NewInt(1))) != 0 {
+		return NewETHRelayingError(InvalidHeaderErr, fmt.Errorf("header number %v, parent number %v", header.Number, parent.Number))
+	}
+	if uint64(len(header.Extra)) > params.MaximumExtraDataSize {
+		return NewETHRelayingError(InvalidHeaderErr, fmt.Errorf("extra-data too long: %d > %d", len(header.Extra), params.MaximumExtraDataSize))
+	}
+	if header.Time <= parent.Time {
+		return NewETHRelayingError(InvalidHeaderErr, fmt.Errorf("header time %v is not after parent time %v", header.Time, parent.Time))
+	}
+	expectedDifficulty := ethash.CalcDifficulty(hc.chainParams.ChainConfig, header.Time, parent)
+	if expectedDifficulty.Cmp(header.Difficulty) != 0 {
+		return NewETHRelayingError(InvalidHeaderErr, fmt.Errorf("invalid difficulty: have %v, want %v", header.Difficulty, expectedDifficulty))
+	}
+	if header.GasUsed > header.GasLimit {
+		return NewETHRelayingError(InvalidHeaderErr, fmt.Errorf("invalid gasUsed: have %d, gasLimit %d", header.GasUsed, header.GasLimit))
+	}
+	gasLimitDiff := int64(parent.GasLimit) - int64(header.GasLimit)
+	if gasLimitDiff < 0 {
+		gasLimitDiff *= -1
+	}
+	gasLimitBound := parent.GasLimit / params.GasLimitBoundDivisor
+	if uint64(gasLimitDiff) >= gasLimitBound || header.GasLimit < params.MinGasLimit {
+		return NewETHRelayingError(InvalidHeaderErr, fmt.Errorf("invalid gas limit: have %d, want %d += %d", header.GasLimit, parent.GasLimit, gasLimitBound))
+	}
+	err := hc.engine.VerifySeal(nil, header)
+	if err != nil {
+		return NewETHRelayingError(InvalidSealErr, err)
+	}
+	return nil
+}
+
+// setBestHeader makes the header the head of the canonical chain,
+// the canonical index is rewritten back to the common ancestor with the previous best header
+func (hc *HeaderChain) setBestHeader(newBest *StoredHeader, oldBest *StoredHeader) error {
+	err := hc.store.StoreBestHeader(newBest)
+	if err != nil {
+		return err
+	}
+	header := newBest.Header
+	for {
+		number := header.Number.Uint64()
+		hash := header.Hash()
+		if oldBest != nil && number <= oldBest.Header.Number.Uint64() {
+			canonicalHash, has, err := hc.store.GetCanonicalHash(number)
+			if err != nil {
+				return err
+			}
+			if has && canonicalHash == hash {
+				return nil
+			}
+		}
+		err = hc.store.StoreCanonicalHash(number, hash)
+		if err != nil {
+			return err
+		}
+		if hash == hc.chainParams.CheckpointHash {
+			return nil
+		}
+		parent, err := hc.store.GetHeader(header.ParentHash)
+		if err != nil {
+			return err
+		}
+		if parent == nil {
+			return NewETHRelayingError(UnknownParentHeaderErr, fmt.Errorf("parent %v of canonical header %v", header.ParentHash.String(), hash.String()))
+		}
+		header = parent.Header
+	}
+}
+
+// GetBestHeader returns the head of the canonical chain, nil if no header was relayed yet
+func (hc *HeaderChain) GetBestHeader() (*StoredHeader, error) {
+	return hc.store.GetBestHeader()
+}
+
+// GetHeaderByHash returns a relayed header, nil if it was not relayed
+func (hc *HeaderChain) GetHeaderByHash(hash common.Hash) (*StoredHeader, error) {
+	return hc.store.GetHeader(hash)
+}
+
+// GetHeaderByNumber returns the header of the canonical chain at the number, nil if there is none
+func (hc *HeaderChain) GetHeaderByNumber(number uint64) (*StoredHeader, error) {
+	best, err := hc.store.GetBestHeader()
+	if err != nil {
+		return nil, err
+	}
+	if best == nil || number > best.Header.Number.Uint64() {
+		return nil, nil
+	}
+	hash, has, err := hc.store.GetCanonicalHash(number)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, nil
+	}
+	return hc.store.GetHeader(hash)
+}
+
+// GetFinalizedHeader returns the relayed header if it is on the canonical chain
+// and has at least MinConfirmationBlocks headers on top of it
+func (hc *HeaderChain) GetFinalizedHeader(hash common.Hash) (*types.Header, error) {
+	stored, err := hc.store.GetHeader(hash)
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil {
+		return nil, NewETHRelayingError(HeaderNotFoundErr, fmt.Errorf("header %v", hash.String()))
+	}
+	canonical, err := hc.GetHeaderByNumber(stored.Header.Number.Uint64())
+	if err != nil {
+		return nil, err
+	}
+	if canonical == nil || canonical.Header.Hash() != hash {
+		return nil, NewETHRelayingError(HeaderNotFinalizedErr, fmt.Errorf("header %v is not on the canonical chain", hash.String()))
+	}
+	best, err := hc.store.GetBestHeader()
+	if err != nil {
+		return nil, err
+	}
+	if stored.Header.Number.Uint64()+MinConfirmationBlocks > best.Header.Number.Uint64() {
+		return nil, NewETHRelayingError(HeaderNotFinalizedErr, fmt.Errorf("header %v at %v needs %v confirmations, best header at %v", hash.String(), stored.Header.Number, MinConfirmationBlocks, best.Header.Number))
+	}
+	return stored.Header, nil
+}
@@ -0,0 +1,221 @@
+package eth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/assert"
+)
+
+type memHeaderStore struct {
+	headers   map[common.Hash]*StoredHeader
+	canonical map[uint64]common.Hash
+	best      common.Hash
+}
+
+func newMemHeaderStore() *memHeaderStore {
+	return &memHeaderStore{
+		headers:   map[common.Hash]*StoredHeader{},
+		canonical: map[uint64]common.Hash{},
+	}
+}
+
+func (s *memHeaderStore) GetHeader(hash common.Hash) (*StoredHeader, error) {
+	return s.headers[hash], nil
+}
+
+func (s *memHeaderStore) StoreHeader(header *StoredHeader) error {
+	s.headers[header.Header.Hash()] = header
+	return nil
+}
+
+func (s *memHeaderStore) GetCanonicalHash(number uint64) (common.Hash, bool, error) {
+	hash, has := s.canonical[number]
+	return hash, has, nil
+}
+
+func (s *memHeaderStore) StoreCanonicalHash(number uint64, hash common.Hash) error {
+	s.canonical[number] = hash
+	return nil
+}
+
+func (s *memHeaderStore) GetBestHeader() (*StoredHeader, error) {
+	return s.headers[s.best], nil
+}
+
+func (s *memHeaderStore) StoreBestHeader(header *StoredHeader) error {
+	s.best = header.Header.Hash()
+	return nil
+}
+
+var testCheckpoint = &types.Header{
+	UncleHash:  types.EmptyUncleHash,
+	Difficulty: big.NewInt(1000000),
+	Number:     big.NewInt(100),
+	GasLimit:   8000000,
+	Time:       1000,
+}
+
+// makeHeaders builds n valid children of parent, timeStep seconds apart
+func makeHeaders(parent *types.Header, n int, timeStep uint64) []*types.Header {
+	headers := []*types.Header{}
+	for i := 0; i < n; i++ {
+		header := &types.Header{
+			ParentHash: parent.Hash(),
+			UncleHash:  types.EmptyUncleHash,
+			Number:     new(big.Int).Add(parent.Number, big.NewInt(1)),
+			GasLimit:   parent.GasLimit,
+			Time:       parent.Time + timeStep,
+		}
+		header.Difficulty = ethash.CalcDifficulty(params.TestChainConfig, header.Time, parent)
+		headers = append(headers, header)
+		parent = header
+	}
+	return headers
+}
+
+func newTestHeaderChain() *HeaderChain {
+	chainParams := &ChainParams{
+		ChainConfig:    params.TestChainConfig,
+		CheckpointHash: testCheckpoint.Hash(),
+	}
+	return NewHeaderChain(newMemHeaderStore(), chainParams, ethash.NewFaker())
+}
+
+func TestInsertHeaders(t *testing.T) {
+	hc := newTestHeaderChain()
+
+	// the relayed chain must start from the checkpoint
+	headers := makeHeaders(testCheckpoint, 20, 13)
+	err := hc.InsertHeaders(headers)
+	assert.NotNil(t, err)
+
+	err = hc.InsertHeaders(append([]*types.Header{testCheckpoint}, headers[:10]...))
+	assert.Nil(t, err)
+	best, _ := hc.GetBestHeader()
+	assert.Equal(t, headers[9].Hash(), best.Header.Hash())
+
+	// known headers are skipped
+	err = hc.InsertHeaders(headers[5:20])
+	assert.Nil(t, err)
+	best, _ = hc.GetBestHeader()
+	assert.Equal(t, headers[19].Hash(), best.Header.Hash())
+	err = hc.InsertHeaders(headers[5:20])
+	assert.NotNil(t, err)
+
+	for _, header := range headers {
+		stored, err := hc.GetHeaderByNumber(header.Number.Uint64())
+		assert.Nil(t, err)
+		assert.Equal(t, header.Hash(), stored.Header.Hash())
+	}
+}
+
+func TestInsertInvalidHeaders(t *testing.T) {
+	hc := newTestHeaderChain()
+	assert.Nil(t, hc.InsertHeaders([]*types.Header{testCheckpoint}))
+
+	// unknown parent
+	orphan := makeHeaders(makeHeaders(testCheckpoint, 1, 13)[0], 1, 13)
+	assert.NotNil(t, hc.InsertHeaders(orphan))
+
+	// wrong difficulty
+	headers := makeHeaders(testCheckpoint, 1, 13)
+	headers[0].Difficulty = new(big.Int).Add(headers[0].Difficulty, big.NewInt(1))
+	assert.NotNil(t, hc.InsertHeaders(headers))
+
+	// timestamp not after parent
+	headers = makeHeaders(testCheckpoint, 1, 13)
+	headers[0].Time = testCheckpoint.Time
+	assert.NotNil(t, hc.InsertHeaders(headers))
+
+	// batch does not link
+	headers = makeHeaders(testCheckpoint, 3, 13)
+	headers[2].ParentHash = common.Hash{}
+	assert.NotNil(t, hc.InsertHeaders(headers))
+
+	// invalid seal
+	hc.engine = ethash.NewFakeFailer(testCheckpoint.Number.Uint64() + 1)
+	assert.NotNil(t, hc.InsertHeaders(makeHeaders(testCheckpoint, 1, 13)))
+
+	best, _ := hc.GetBestHeader()
+	assert.Equal(t, testCheckpoint.Hash(), best.Header.Hash())
+}
+
+func TestReorgToHeavierChain(t *testing.T) {
+	hc := newTestHeaderChain()
+	mainChain := makeHeaders(testCheckpoint, 10, 20)
+	assert.Nil(t, hc.InsertHeaders(append([]*types.Header{testCheckpoint}, mainChain...)))
+
+	// a lighter fork is relayed but does not become canonical
+	lightFork := makeHeaders(mainChain[1], 3, 1)
+	assert.Nil(t, hc.InsertHeaders(lightFork))
+	best, _ := hc.GetBestHeader()
+	assert.Equal(t, mainChain[9].Hash(), best.Header.Hash())
+
+	// a longer fork has more total difficulty
+	fork := makeHeaders(mainChain[1], 9, 1)
+	assert.Nil(t, hc.InsertHeaders(fork))
+	best, _ = hc.GetBestHeader()
+	assert.Equal(t, fork[8].Hash(), best.Header.Hash())
+
+	canonicalChain := append([]*types.Header{mainChain[0], mainChain[1]}, fork...)
+	for _, header := range canonicalChain {
+		stored, _ := hc.GetHeaderByNumber(header.Number.Uint64())
+		assert.Equal(t, header.Hash(), stored.Header.Hash())
+	}
+
+	// headers of the old chain stay relayed but are not canonical
+	stored, _ := hc.GetHeaderByHash(mainChain[9].Hash())
+	assert.NotNil(t, stored)
+	_, err := hc.GetFinalizedHeader(mainChain[2].Hash())
+	assert.NotNil(t, err)
+}
+
+func TestFinalizedHeader(t *testing.T) {
+	hc := newTestHeaderChain()
+	headers := makeHeaders(testCheckpoint, MinConfirmationBlocks+5, 13)
+	assert.Nil(t, hc.InsertHeaders(append([]*types.Header{testCheckpoint}, headers[:MinConfirmationBlocks-1]...)))
+
+	_, err := hc.GetFinalizedHeader(headers[0].Hash())
+	assert.NotNil(t, err)
+
+	assert.Nil(t, hc.InsertHeaders(headers[MinConfirmationBlocks-1:]))
+	finalized, err := hc.GetFinalizedHeader(headers[0].Hash())
+	assert.Nil(t, err)
+	assert.Equal(t, headers[0].Hash(), finalized.Hash())
+
+	// a fork below a finalized header is rejected however heavy it is
+	fork := makeHeaders(headers[0], MinConfirmationBlocks+5, 1)
+	assert.NotNil(t, hc.InsertHeaders(fork))
+	best, _ := hc.GetBestHeader()
+	assert.Equal(t, headers[len(headers)-1].Hash(), best.Header.Hash())
+}
+
+func TestParseHeadersFromB64EncodeStr(t *testing.T) {
+	headers := makeHeaders(testCheckpoint, 3, 13)
+	headersBytes, _ := json.Marshal(headers)
+	parsedHeaders, err := ParseHeadersFromB64EncodeStr(base64.StdEncoding.EncodeToString(headersBytes))
+	assert.Nil(t, err)
+	assert.Nil(t, ValidateHeaderBatch(parsedHeaders))
+	for i := range headers {
+		assert.Equal(t, headers[i].Hash(), parsedHeaders[i].Hash())
+	}
+
+	_, err = ParseHeadersFromB64EncodeStr("invalid")
+	assert.NotNil(t, err)
+}
+
+func TestGetChainParams(t *testing.T) {
+	_, err := GetChainParams("Ethereum-Unknown")
+	assert.Equal(t, ErrCodeMessage[InvalidChainIDErr].Code, err.(*ETHRelayingError).GetCode())
+
+	// networks without a configured checkpoint can not be relayed
+	_, err = GetChainParams(MainnetETHChainID)
+	assert.Equal(t, ErrCodeMessage[CheckpointNotConfiguredErr].Code, err.(*ETHRelayingError).GetCode())
+}
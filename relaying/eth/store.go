@@ -0,0 +1,83 @@
+package eth
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+)
+
+// StateDBHeaderStore keeps relayed headers in a statedb, headers are rlp encoded
+type StateDBHeaderStore struct {
+	stateDB *statedb.StateDB
+}
+
+func NewStateDBHeaderStore(stateDB *statedb.StateDB) *StateDBHeaderStore {
+	return &StateDBHeaderStore{stateDB: stateDB}
+}
+
+func (s *StateDBHeaderStore) GetHeader(hash common.Hash) (*StoredHeader, error) {
+	headerState, has, err := statedb.GetRelayingETHHeader(s.stateDB, hash.Bytes())
+	if err != nil {
+		return nil, NewETHRelayingError(GetHeaderErr, err)
+	}
+	if !has {
+		return nil, nil
+	}
+	header := new(types.Header)
+	err = rlp.DecodeBytes(headerState.Header(), header)
+	if err != nil {
+		return nil, NewETHRelayingError(GetHeaderErr, err)
+	}
+	return &StoredHeader{
+		Header:          header,
+		TotalDifficulty: headerState.TotalDifficulty(),
+	}, nil
+}
+
+func (s *StateDBHeaderStore) StoreHeader(header *StoredHeader) error {
+	headerBytes, err := rlp.EncodeToBytes(header.Header)
+	if err != nil {
+		return NewETHRelayingError(StoreHeaderErr, err)
+	}
+	err = statedb.StoreRelayingETHHeader(s.stateDB, header.Header.Hash().Bytes(), header.Header.Number.Uint64(), headerBytes, header.TotalDifficulty)
+	if err != nil {
+		return NewETHRelayingError(StoreHeaderErr, err)
+	}
+	return nil
+}
+
+func (s *StateDBHeaderStore) GetCanonicalHash(number uint64) (common.Hash, bool, error) {
+	hashBytes, has, err := statedb.GetRelayingETHCanonicalHash(s.stateDB, number)
+	if err != nil {
+		return common.Hash{}, false, NewETHRelayingError(GetHeaderErr, err)
+	}
+	return common.BytesToHash(hashBytes), has, nil
+}
+
+func (s *StateDBHeaderStore) StoreCanonicalHash(number uint64, hash common.Hash) error {
+	err := statedb.StoreRelayingETHCanonicalHash(s.stateDB, number, hash.Bytes())
+	if err != nil {
+		return NewETHRelayingError(StoreHeaderErr, err)
+	}
+	return nil
+}
+
+func (s *StateDBHeaderStore) GetBestHeader() (*StoredHeader, error) {
+	_, hashBytes, has, err := statedb.GetRelayingETHBestHeader(s.stateDB)
+	if err != nil {
+		return nil, NewETHRelayingError(GetHeaderErr, err)
+	}
+	if !has {
+		return nil, nil
+	}
+	return s.GetHeader(common.BytesToHash(hashBytes))
+}
+
+func (s *StateDBHeaderStore) StoreBestHeader(header *StoredHeader) error {
+	err := statedb.StoreRelayingETHBestHeader(s.stateDB, header.Header.Number.Uint64(), header.Header.Hash().Bytes())
+	if err != nil {
+		return NewETHRelayingError(StoreHeaderErr, err)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package eth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// GetChainParams returns rules of the relayed ethereum network with the chain id
+func GetChainParams(chainID string) (*ChainParams, error) {
+	chainParams, ok := chainParamsByChainID[chainID]
+	if !ok {
+		return nil, NewETHRelayingError(InvalidChainIDErr, fmt.Errorf("chain id %v is not supported", chainID))
+	}
+	if chainParams.CheckpointHash == (common.Hash{}) {
+		return nil, NewETHRelayingError(CheckpointNotConfiguredErr, fmt.Errorf("chain id %v", chainID))
+	}
+	return chainParams, nil
+}
+
+// ParseHeadersFromB64EncodeStr parses a batch of headers submitted by RelayingETHHeaderMeta,
+// the batch is a json array of headers encoded in base64
+func ParseHeadersFromB64EncodeStr(headersStr string) ([]*types.Header, error) {
+	headersBytes, err := base64.StdEncoding.DecodeString(headersStr)
+	if err != nil {
+		return nil, NewETHRelayingError(ParseHeadersErr, err)
+	}
+	var headers []*types.Header
+	err = json.Unmarshal(headersBytes, &headers)
+	if err != nil {
+		return nil, NewETHRelayingError(ParseHeadersErr, err)
+	}
+	return headers, nil
+}
+
+// ValidateHeaderBatch checks a batch of headers without the relayed chain:
+// the batch is not empty nor too long and every header is the child of the previous one
+func ValidateHeaderBatch(headers []*types.Header) error {
+	if len(headers) == 0 {
+		return NewETHRelayingError(InvalidHeaderBatchErr, errors.New("header batch is empty"))
+	}
+	if len(headers) > MaxHeadersPerBatch {
+		return NewETHRelayingError(InvalidHeaderBatchErr, fmt.Errorf("header batch has %v headers, the maximum is %v", len(headers), MaxHeadersPerBatch))
+	}
+	for i, header := range headers {
+		if header == nil || header.Number == nil || header.Difficulty == nil {
+			return NewETHRelayingError(InvalidHeaderBatchErr, fmt.Errorf("header %v of batch is incomplete", i))
+		}
+		if i == 0 {
+			continue
+		}
+		parent := headers[i-1]
+		if header.ParentHash != parent.Hash() {
+			return NewETHRelayingError(InvalidHeaderBatchErr, fmt.Errorf("header %v does not link to its parent %v", header.Hash().String(), parent.Hash().String()))
+		}
+		if header.Number.Cmp(new(big.Int).Add(parent.Number, big.NewInt(1))) != 0 {
+			return NewETHRelayingError(InvalidHeaderBatchErr, fmt.Errorf("header number %v does not follow its parent number %v", header.Number, parent.Number))
+		}
+	}
+	return nil
+}
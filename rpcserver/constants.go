@@ -21,6 +21,7 @@ const (
 
 	getActiveShards    = "getactiveshards"
 	getMaxShardsNumber = "getmaxshardsnumber"
+	getParamsSchedule  = "getparamsschedule"
 
 	getMiningInfo                 = "getmininginfo"
 	getRawMempool                 = "getrawmempool"
@@ -123,6 +124,12 @@ const (
 	getReceivedByAccount       = "getreceivedbyaccount"
 	setTxFee                   = "settxfee"
 
+	// output coin index
+	registerViewingKey   = "registerviewingkey"
+	rescanViewingKey     = "rescanviewingkey"
+	unregisterViewingKey = "unregisterviewingkey"
+	listViewingKeys      = "listviewingkeys"
+
 	// walletsta
 	getPublicKeyFromPaymentAddress = "getpublickeyfrompaymentaddress"
 	defragmentAccount              = "defragmentaccount"
@@ -143,6 +150,7 @@ const (
 	getAllBridgeTokens               = "getallbridgetokens"
 	getETHHeaderByHash               = "getethheaderbyhash"
 	getBridgeReqWithStatus           = "getbridgereqwithstatus"
+	getETHEndpointStatuses           = "getethendpointstatuses"
 
 	// Incognito -> Ethereum bridge
 	getBeaconSwapProof       = "getbeaconswapproof"
@@ -171,22 +179,42 @@ const (
 	// slash
 	getProducersBlackList       = "getproducersblacklist"
 	getProducersBlackListDetail = "getproducersblacklistdetail"
+	getEquivocationEvidences    = "getequivocationevidences"
+
+	createAndSendTxWithEquivocationEvidence = "createandsendtxwithequivocationevidence"
+
+	// state proof
+	getBeaconStateProof = "getbeaconstateproof"
+	getShardStateProof  = "getshardstateproof"
 
 	// pde
-	getPDEState                           = "getpdestate"
-	createAndSendTxWithWithdrawalReq      = "createandsendtxwithwithdrawalreq"
-	createAndSendTxWithPTokenTradeReq     = "createandsendtxwithptokentradereq"
-	createAndSendTxWithPRVTradeReq        = "createandsendtxwithprvtradereq"
-	createAndSendTxWithPTokenContribution = "createandsendtxwithptokencontribution"
-	createAndSendTxWithPRVContribution    = "createandsendtxwithprvcontribution"
-	convertNativeTokenToPrivacyToken      = "convertnativetokentoprivacytoken"
-	convertPrivacyTokenToNativeToken      = "convertprivacytokentonativetoken"
-	getPDEContributionStatus              = "getpdecontributionstatus"
-	getPDEContributionStatusV2            = "getpdecontributionstatusv2"
-	getPDETradeStatus                     = "getpdetradestatus"
-	getPDEWithdrawalStatus                = "getpdewithdrawalstatus"
-	convertPDEPrices                      = "convertpdeprices"
-	extractPDEInstsFromBeaconBlock        = "extractpdeinstsfrombeaconblock"
+	getPDEState                                = "getpdestate"
+	getPDETradingFees                          = "getpdetradingfees"
+	createAndSendTxWithWithdrawalReq           = "createandsendtxwithwithdrawalreq"
+	createAndSendTxWithPTokenTradeReq          = "createandsendtxwithptokentradereq"
+	createAndSendTxWithPRVTradeReq             = "createandsendtxwithprvtradereq"
+	createAndSendTxWithPTokenCrossPoolTradeReq = "createandsendtxwithptokencrosspooltradereq"
+	createAndSendTxWithPRVCrossPoolTradeReq    = "createandsendtxwithprvcrosspooltradereq"
+	createAndSendTxWithPTokenLimitOrderReq     = "createandsendtxwithptokenlimitorderreq"
+	createAndSendTxWithPRVLimitOrderReq        = "createandsendtxwithprvlimitorderreq"
+	createAndSendTxWithCancelOrderReq          = "createandsendtxwithcancelorderreq"
+	createAndSendTxWithPTokenContribution      = "createandsendtxwithptokencontribution"
+	createAndSendTxWithPRVContribution         = "createandsendtxwithprvcontribution"
+	convertNativeTokenToPrivacyToken           = "convertnativetokentoprivacytoken"
+	convertPrivacyTokenToNativeToken           = "convertprivacytokentonativetoken"
+	getPDEContributionStatus                   = "getpdecontributionstatus"
+	getPDEContributionStatusV2                 = "getpdecontributionstatusv2"
+	getPDETradeStatus                          = "getpdetradestatus"
+	getPDELimitOrderStatus                     = "getpdelimitorderstatus"
+	getPDEOpenOrders                           = "getpdeopenorders"
+	getPDEOrderBook                            = "getpdeorderbook"
+	getPDEWithdrawalStatus                     = "getpdewithdrawalstatus"
+	convertPDEPrices                           = "convertpdeprices"
+	extractPDEInstsFromBeaconBlock             = "extractpdeinstsfrombeaconblock"
+	getPDECandles                              = "getpdecandles"
+	getPDEPairVolume                           = "getpdepairvolume"
+	getPDETradeHistory                         = "getpdetradehistory"
+	getPDEPoolHistory                          = "getpdepoolhistory"
 
 	// get burning address
 	getBurningAddress = "getburningaddress"
@@ -199,6 +227,7 @@ const (
 	createAndSendRegisterPortingPublicTokens      = "createandsendregisterportingpublictokens"
 	createAndSendPortalExchangeRates              = "createandsendportalexchangerates"
 	getPortalFinalExchangeRates                   = "getportalfinalexchangerates"
+	getPortalExchangeRatesFeeders                 = "getportalexchangeratesfeeders"
 	getPortalPortingRequestByKey                  = "getportalportingrequestbykey"
 	getPortalPortingRequestByPortingId            = "getportalportingrequestbyportingid"
 	convertExchangeRates                          = "convertexchangerates"
@@ -226,6 +255,8 @@ const (
 	getAmountTopUpWaitingPorting                  = "getamounttopupwaitingporting"
 	getPortalReqRedeemByTxIDStatus                = "getreqredeemstatusbytxid"
 	getReqRedeemFromLiquidationPoolByTxIDStatus   = "getreqredeemfromliquidationpoolbytxidstatus"
+	getPortalCustodianRisks                       = "getportalcustodianrisks"
+	simulatePortalCustodianLiquidation            = "simulateportalcustodianliquidation"
 
 	// relaying
 	createAndSendTxWithRelayingBNBHeader = "createandsendtxwithrelayingbnbheader"
@@ -235,6 +266,9 @@ const (
 	getBTCRelayingBestState              = "getbtcrelayingbeststate"
 	getBTCBlockByHash                    = "getbtcblockbyhash"
 	getLatestBNBHeaderBlockHeight        = "getlatestbnbheaderblockheight"
+	createAndSendTxWithRelayingETHHeader = "createandsendtxwithrelayingethheader"
+	getETHRelayingBestState              = "getethrelayingbeststate"
+	getRelayingETHHeaderByBlockHeight    = "getrelayingethheaderbyblockheight"
 
 	// incognito mode for sc
 	getBurnProofForDepositToSC                = "getburnprooffordeposittosc"
@@ -3,6 +3,7 @@ package rpcserver
 import (
 	"encoding/json"
 
+	rCommon "github.com/ethereum/go-ethereum/common"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/common/base58"
 	"github.com/incognitochain/incognito-chain/metadata"
@@ -227,6 +228,26 @@ func (httpServer *HttpServer) handleGetETHHeaderByHash(params interface{}, close
 	}
 	ethBlockHash := arrayParams[0].(string)
 
+	// once eth headers are relayed on-chain, they are served from the beacon feature state
+	bc := httpServer.config.BlockChain
+	ethHeaderChain, err := bc.GetETHHeaderChain(bc.GetBeaconBestState().GetBeaconFeatureStateDB())
+	if err == nil {
+		bestHeader, err := ethHeaderChain.GetBestHeader()
+		if err != nil {
+			return false, rpcservice.NewRPCError(rpcservice.GetRelayingETHHeaderError, err)
+		}
+		if bestHeader != nil {
+			stored, err := ethHeaderChain.GetHeaderByHash(rCommon.HexToHash(ethBlockHash))
+			if err != nil {
+				return false, rpcservice.NewRPCError(rpcservice.GetRelayingETHHeaderError, err)
+			}
+			if stored == nil {
+				return false, rpcservice.NewRPCError(rpcservice.GetRelayingETHHeaderError, errors.New("Eth header was not relayed"))
+			}
+			return stored.Header, nil
+		}
+	}
+
 	ethHeader, err := rpcservice.GetETHHeaderByHash(ethBlockHash)
 	if err != nil {
 		return false, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
@@ -248,6 +269,16 @@ func (httpServer *HttpServer) handleGetBridgeReqWithStatus(params interface{}, c
 	return status, nil
 }
 
+// handleGetETHEndpointStatuses - health of the Ethereum RPC endpoints this node verifies ETH deposits against,
+// endpoints are listed by index since their urls may contain api keys
+func (httpServer *HttpServer) handleGetETHEndpointStatuses(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	statuses, err := metadata.GetETHEndpointStatuses()
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	return statuses, nil
+}
+
 func processBurningReq(
 	burningMetaType int,
 	params interface{},
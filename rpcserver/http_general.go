@@ -111,6 +111,37 @@ func (httpServer *HttpServer) handleGetMaxShardsNumber(params interface{}, close
 	return result, nil
 }
 
+// handleGetParamsSchedule - return the portal and pde params activated by beacon height, and the ones applying at
+// the given beacon height or at the best beacon height
+func (httpServer *HttpServer) handleGetParamsSchedule(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	beaconHeight := httpServer.config.BlockChain.GetBeaconBestState().BeaconHeight
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) > 0 {
+		data, ok := arrayParams[0].(map[string]interface{})
+		if !ok {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param is invalid"))
+		}
+		if _, ok := data["BeaconHeight"]; ok {
+			height, err := common.AssertAndConvertStrToNumber(data["BeaconHeight"])
+			if err != nil {
+				return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+			}
+			beaconHeight = uint64(height)
+		}
+	}
+
+	result := jsonresult.ParamsSchedule{
+		Network:            httpServer.config.ChainParams.Name,
+		BeaconHeight:       beaconHeight,
+		ActivePortalParams: httpServer.config.BlockChain.GetPortalParams(beaconHeight),
+		Schedule:           httpServer.config.ChainParams.GetParamsSchedule(),
+	}
+	if pdeParams, ok := httpServer.config.BlockChain.GetPDEParams(beaconHeight); ok {
+		result.ActivePDEParams = &pdeParams
+	}
+	return result, nil
+}
+
 func (httpServer *HttpServer) handleGetStakingAmount(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	arrayParams := common.InterfaceSlice(params)
 	if arrayParams == nil || len(arrayParams) <= 0 {
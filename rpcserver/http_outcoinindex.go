@@ -0,0 +1,146 @@
+package rpcserver
+
+import (
+	"errors"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// viewing key RPCs manage the output coin index, node must be started with --outcoinindex
+// listoutputcoins, listunspentoutputcoins and balance RPCs are served from the index for registered keys which are caught up
+
+func parseOutCoinIndexParams(params interface{}) (map[string]interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) == 0 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
+	}
+	data, ok := arrayParams[0].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
+	}
+	return data, nil
+}
+
+func parseOutCoinIndexHeight(data map[string]interface{}) (uint64, *rpcservice.RPCError) {
+	if _, ok := data["FromHeight"]; !ok {
+		return 0, nil
+	}
+	height, ok := data["FromHeight"].(float64)
+	if !ok || height < 0 {
+		return 0, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("FromHeight is invalid"))
+	}
+	return uint64(height), nil
+}
+
+func parseOutCoinIndexPublicKey(data map[string]interface{}) ([]byte, *rpcservice.RPCError) {
+	paymentAddressStr, ok := data["PaymentAddress"].(string)
+	if !ok || paymentAddressStr == "" {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("PaymentAddress is invalid"))
+	}
+	keyWallet, err := wallet.Base58CheckDeserialize(paymentAddressStr)
+	if err != nil || len(keyWallet.KeySet.PaymentAddress.Pk) == 0 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("PaymentAddress is invalid"))
+	}
+	return keyWallet.KeySet.PaymentAddress.Pk, nil
+}
+
+// handleRegisterViewingKey registers a readonly key to the output coin index, output coins of shard blocks from FromHeight are indexed
+// params: {"ReadonlyKey": string, "FromHeight": uint64 (optional, default 1)}
+func (httpServer *HttpServer) handleRegisterViewingKey(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, rpcErr := parseOutCoinIndexParams(params)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	readonlyKeyStr, ok := data["ReadonlyKey"].(string)
+	if !ok || readonlyKeyStr == "" {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("ReadonlyKey is invalid"))
+	}
+	keyWallet, err := wallet.Base58CheckDeserialize(readonlyKeyStr)
+	if err != nil || len(keyWallet.KeySet.ReadonlyKey.Rk) == 0 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("ReadonlyKey is invalid"))
+	}
+	fromHeight, rpcErr := parseOutCoinIndexHeight(data)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	if err := httpServer.config.BlockChain.RegisterViewingKey(keyWallet.KeySet.ReadonlyKey, fromHeight); err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.OutCoinIndexError, err)
+	}
+	return true, nil
+}
+
+// handleRescanViewingKey indexes again output coins of shard blocks from FromHeight for a registered key
+// params: {"PaymentAddress": string, "FromHeight": uint64}
+func (httpServer *HttpServer) handleRescanViewingKey(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, rpcErr := parseOutCoinIndexParams(params)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	publicKey, rpcErr := parseOutCoinIndexPublicKey(data)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	if _, ok := data["FromHeight"]; !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("FromHeight is invalid"))
+	}
+	fromHeight, rpcErr := parseOutCoinIndexHeight(data)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	if err := httpServer.config.BlockChain.RescanViewingKey(publicKey, fromHeight); err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.OutCoinIndexError, err)
+	}
+	return true, nil
+}
+
+// handleUnregisterViewingKey removes a registered key and its indexed output coins
+// params: {"PaymentAddress": string}
+func (httpServer *HttpServer) handleUnregisterViewingKey(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, rpcErr := parseOutCoinIndexParams(params)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	publicKey, rpcErr := parseOutCoinIndexPublicKey(data)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	if err := httpServer.config.BlockChain.UnregisterViewingKey(publicKey); err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.OutCoinIndexError, err)
+	}
+	return true, nil
+}
+
+// handleListViewingKeys returns registered keys with the height their output coins are indexed up to
+// params: none
+func (httpServer *HttpServer) handleListViewingKeys(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	indexes, err := httpServer.config.BlockChain.GetViewingKeyIndexes()
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.OutCoinIndexError, err)
+	}
+	type viewingKeyResult struct {
+		PublicKey     string
+		ShardID       byte
+		FromHeight    uint64
+		IndexedHeight uint64
+		ShardHeight   uint64
+	}
+	result := []viewingKeyResult{}
+	for _, index := range indexes {
+		shardID := common.GetShardIDFromLastByte(index.PublicKey[len(index.PublicKey)-1])
+		shardHeight := uint64(0)
+		if int(shardID) < len(httpServer.config.BlockChain.ShardChain) {
+			shardHeight = httpServer.config.BlockChain.GetBestStateShard(shardID).ShardHeight
+		}
+		result = append(result, viewingKeyResult{
+			PublicKey:     base58.Base58Check{}.Encode(index.PublicKey, common.ZeroByte),
+			ShardID:       shardID,
+			FromHeight:    index.FromHeight,
+			IndexedHeight: index.IndexedHeight,
+			ShardHeight:   shardHeight,
+		})
+	}
+	return result, nil
+}
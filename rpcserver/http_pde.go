@@ -40,12 +40,25 @@ type PDETrade struct {
 	ReceiveAmount       uint64
 	Token1IDStr         string
 	Token2IDStr         string
+	Route               []string `json:",omitempty"` // token ids from the sold token to the bought one of cross pool trades
 	ShardID             byte
 	RequestedTxID       common.Hash
 	Status              string
 	BeaconHeight        uint64
 }
 
+type PDELimitOrder struct {
+	OrderID             common.Hash
+	TraderAddressStr    string
+	ReceivingTokenIDStr string
+	ReceiveAmount       uint64
+	Token1IDStr         string
+	Token2IDStr         string
+	ShardID             byte
+	Status              string
+	BeaconHeight        uint64
+}
+
 type PDEContribution struct {
 	PDEContributionPairID string
 	ContributorAddressStr string
@@ -61,6 +74,7 @@ type PDEInfoFromBeaconBlock struct {
 	PDEContributions []*PDEContribution `json:"PDEContributions"`
 	PDETrades        []*PDETrade        `json:"PDETrades"`
 	PDEWithdrawals   []*PDEWithdrawal   `json:"PDEWithdrawals"`
+	PDELimitOrders   []*PDELimitOrder   `json:"PDELimitOrders"`
 	BeaconTimeStamp  int64              `json:"BeaconTimeStamp"`
 }
 
@@ -354,41 +368,434 @@ func (httpServer *HttpServer) handleCreateRawTxWithPTokenTradeReq(params interfa
 		metadata.PDETradeRequestMeta,
 	)
 
-	customTokenTx, rpcErr := httpServer.txService.BuildRawPrivacyCustomTokenTransaction(params, meta)
-	if rpcErr != nil {
-		Logger.log.Error(rpcErr)
-		return nil, rpcErr
+	customTokenTx, rpcErr := httpServer.txService.BuildRawPrivacyCustomTokenTransaction(params, meta)
+	if rpcErr != nil {
+		Logger.log.Error(rpcErr)
+		return nil, rpcErr
+	}
+
+	byteArrays, err2 := json.Marshal(customTokenTx)
+	if err2 != nil {
+		Logger.log.Error(err2)
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err2)
+	}
+	result := jsonresult.CreateTransactionResult{
+		TxID:            customTokenTx.Hash().String(),
+		Base58CheckData: base58.Base58Check{}.Encode(byteArrays, 0x00),
+	}
+	return result, nil
+}
+
+func (httpServer *HttpServer) handleCreateAndSendTxWithPTokenTradeReq(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, err := httpServer.handleCreateRawTxWithPTokenTradeReq(params, closeChan)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+
+	tx := data.(jsonresult.CreateTransactionResult)
+	base58CheckData := tx.Base58CheckData
+	newParam := make([]interface{}, 0)
+	newParam = append(newParam, base58CheckData)
+	// sendResult, err1 := httpServer.handleSendRawCustomTokenTransaction(newParam, closeChan)
+	sendResult, err1 := httpServer.handleSendRawPrivacyCustomTokenTransaction(newParam, closeChan)
+	if err1 != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err1)
+	}
+
+	return sendResult, nil
+}
+
+func parsePDECrossPoolTradeRequestMeta(data map[string]interface{}) (*metadata.PDECrossPoolTradeRequest, *rpcservice.RPCError) {
+	tokenIDToBuyStr, ok := data["TokenIDToBuyStr"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	tokenIDToSellStr, ok := data["TokenIDToSellStr"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	routeData, ok := data["Route"].([]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	route := make([]string, 0, len(routeData))
+	for _, tokenIDData := range routeData {
+		tokenIDStr, ok := tokenIDData.(string)
+		if !ok {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+		}
+		route = append(route, tokenIDStr)
+	}
+	sellAmountData, ok := data["SellAmount"].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	sellAmount := uint64(sellAmountData)
+	traderAddressStr, ok := data["TraderAddressStr"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	minAcceptableAmountData, ok := data["MinAcceptableAmount"].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	minAcceptableAmount := uint64(minAcceptableAmountData)
+	tradingFeeData, ok := data["TradingFee"].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	tradingFee := uint64(tradingFeeData)
+	meta, _ := metadata.NewPDECrossPoolTradeRequest(
+		tokenIDToBuyStr,
+		tokenIDToSellStr,
+		route,
+		sellAmount,
+		minAcceptableAmount,
+		tradingFee,
+		traderAddressStr,
+		metadata.PDECrossPoolTradeRequestMeta,
+	)
+	return meta, nil
+}
+
+func (httpServer *HttpServer) handleCreateRawTxWithPRVCrossPoolTradeReq(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 5 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param array must be at least 5"))
+	}
+
+	// get meta data from params
+	data, ok := arrayParams[4].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	meta, rpcErr := parsePDECrossPoolTradeRequestMeta(data)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	// create new param to build raw tx from param interface
+	createRawTxParam, errNewParam := bean.NewCreateRawTxParam(params)
+	if errNewParam != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errNewParam)
+	}
+
+	tx, err1 := httpServer.txService.BuildRawTransaction(createRawTxParam, meta)
+	if err1 != nil {
+		Logger.log.Error(err1)
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err1)
+	}
+
+	byteArrays, err2 := json.Marshal(tx)
+	if err2 != nil {
+		Logger.log.Error(err2)
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err2)
+	}
+	result := jsonresult.CreateTransactionResult{
+		TxID:            tx.Hash().String(),
+		Base58CheckData: base58.Base58Check{}.Encode(byteArrays, 0x00),
+	}
+	return result, nil
+}
+
+func (httpServer *HttpServer) handleCreateAndSendTxWithPRVCrossPoolTradeReq(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, err := httpServer.handleCreateRawTxWithPRVCrossPoolTradeReq(params, closeChan)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	tx := data.(jsonresult.CreateTransactionResult)
+	base58CheckData := tx.Base58CheckData
+	newParam := make([]interface{}, 0)
+	newParam = append(newParam, base58CheckData)
+	sendResult, err := httpServer.handleSendRawTransaction(newParam, closeChan)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	result := jsonresult.NewCreateTransactionResult(nil, sendResult.(jsonresult.CreateTransactionResult).TxID, nil, sendResult.(jsonresult.CreateTransactionResult).ShardID)
+	return result, nil
+}
+
+func (httpServer *HttpServer) handleCreateRawTxWithPTokenCrossPoolTradeReq(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 5 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param array must be at least 5"))
+	}
+
+	if len(arrayParams) >= 7 {
+		hasPrivacyToken := int(arrayParams[6].(float64)) > 0
+		if hasPrivacyToken {
+			return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("The privacy mode must be disabled"))
+		}
+	}
+	tokenParamsRaw, ok := arrayParams[4].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	meta, rpcErr := parsePDECrossPoolTradeRequestMeta(tokenParamsRaw)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	customTokenTx, rpcErr := httpServer.txService.BuildRawPrivacyCustomTokenTransaction(params, meta)
+	if rpcErr != nil {
+		Logger.log.Error(rpcErr)
+		return nil, rpcErr
+	}
+
+	byteArrays, err2 := json.Marshal(customTokenTx)
+	if err2 != nil {
+		Logger.log.Error(err2)
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err2)
+	}
+	result := jsonresult.CreateTransactionResult{
+		TxID:            customTokenTx.Hash().String(),
+		Base58CheckData: base58.Base58Check{}.Encode(byteArrays, 0x00),
+	}
+	return result, nil
+}
+
+func (httpServer *HttpServer) handleCreateAndSendTxWithPTokenCrossPoolTradeReq(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, err := httpServer.handleCreateRawTxWithPTokenCrossPoolTradeReq(params, closeChan)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+
+	tx := data.(jsonresult.CreateTransactionResult)
+	base58CheckData := tx.Base58CheckData
+	newParam := make([]interface{}, 0)
+	newParam = append(newParam, base58CheckData)
+	sendResult, err1 := httpServer.handleSendRawPrivacyCustomTokenTransaction(newParam, closeChan)
+	if err1 != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err1)
+	}
+
+	return sendResult, nil
+}
+
+func parsePDELimitOrderRequestMeta(data map[string]interface{}) (*metadata.PDELimitOrderRequest, *rpcservice.RPCError) {
+	tokenIDToBuyStr, ok := data["TokenIDToBuyStr"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	tokenIDToSellStr, ok := data["TokenIDToSellStr"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	sellAmountData, ok := data["SellAmount"].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	sellAmount := uint64(sellAmountData)
+	traderAddressStr, ok := data["TraderAddressStr"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	minAcceptableAmountData, ok := data["MinAcceptableAmount"].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	minAcceptableAmount := uint64(minAcceptableAmountData)
+	tradingFeeData, ok := data["TradingFee"].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	tradingFee := uint64(tradingFeeData)
+	activeHeightsData, ok := data["ActiveHeights"].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	activeHeights := uint64(activeHeightsData)
+	meta, _ := metadata.NewPDELimitOrderRequest(
+		tokenIDToBuyStr,
+		tokenIDToSellStr,
+		sellAmount,
+		minAcceptableAmount,
+		tradingFee,
+		traderAddressStr,
+		activeHeights,
+		metadata.PDELimitOrderRequestMeta,
+	)
+	return meta, nil
+}
+
+func (httpServer *HttpServer) handleCreateRawTxWithPRVLimitOrderReq(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 5 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param array must be at least 5"))
+	}
+
+	// get meta data from params
+	data, ok := arrayParams[4].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	meta, rpcErr := parsePDELimitOrderRequestMeta(data)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	// create new param to build raw tx from param interface
+	createRawTxParam, errNewParam := bean.NewCreateRawTxParam(params)
+	if errNewParam != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errNewParam)
+	}
+
+	tx, err1 := httpServer.txService.BuildRawTransaction(createRawTxParam, meta)
+	if err1 != nil {
+		Logger.log.Error(err1)
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err1)
+	}
+
+	byteArrays, err2 := json.Marshal(tx)
+	if err2 != nil {
+		Logger.log.Error(err2)
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err2)
+	}
+	result := jsonresult.CreateTransactionResult{
+		TxID:            tx.Hash().String(),
+		Base58CheckData: base58.Base58Check{}.Encode(byteArrays, 0x00),
+	}
+	return result, nil
+}
+
+func (httpServer *HttpServer) handleCreateAndSendTxWithPRVLimitOrderReq(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, err := httpServer.handleCreateRawTxWithPRVLimitOrderReq(params, closeChan)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	tx := data.(jsonresult.CreateTransactionResult)
+	base58CheckData := tx.Base58CheckData
+	newParam := make([]interface{}, 0)
+	newParam = append(newParam, base58CheckData)
+	sendResult, err := httpServer.handleSendRawTransaction(newParam, closeChan)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	result := jsonresult.NewCreateTransactionResult(nil, sendResult.(jsonresult.CreateTransactionResult).TxID, nil, sendResult.(jsonresult.CreateTransactionResult).ShardID)
+	return result, nil
+}
+
+func (httpServer *HttpServer) handleCreateRawTxWithPTokenLimitOrderReq(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 5 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param array must be at least 5"))
+	}
+
+	if len(arrayParams) >= 7 {
+		hasPrivacyToken := int(arrayParams[6].(float64)) > 0
+		if hasPrivacyToken {
+			return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("The privacy mode must be disabled"))
+		}
+	}
+	tokenParamsRaw, ok := arrayParams[4].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	meta, rpcErr := parsePDELimitOrderRequestMeta(tokenParamsRaw)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	customTokenTx, rpcErr := httpServer.txService.BuildRawPrivacyCustomTokenTransaction(params, meta)
+	if rpcErr != nil {
+		Logger.log.Error(rpcErr)
+		return nil, rpcErr
+	}
+
+	byteArrays, err2 := json.Marshal(customTokenTx)
+	if err2 != nil {
+		Logger.log.Error(err2)
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err2)
+	}
+	result := jsonresult.CreateTransactionResult{
+		TxID:            customTokenTx.Hash().String(),
+		Base58CheckData: base58.Base58Check{}.Encode(byteArrays, 0x00),
+	}
+	return result, nil
+}
+
+func (httpServer *HttpServer) handleCreateAndSendTxWithPTokenLimitOrderReq(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, err := httpServer.handleCreateRawTxWithPTokenLimitOrderReq(params, closeChan)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+
+	tx := data.(jsonresult.CreateTransactionResult)
+	base58CheckData := tx.Base58CheckData
+	newParam := make([]interface{}, 0)
+	newParam = append(newParam, base58CheckData)
+	sendResult, err1 := httpServer.handleSendRawPrivacyCustomTokenTransaction(newParam, closeChan)
+	if err1 != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err1)
+	}
+
+	return sendResult, nil
+}
+
+func (httpServer *HttpServer) handleCreateRawTxWithCancelOrderReq(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 5 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param array must be at least 5"))
+	}
+
+	// get meta data from params
+	data, ok := arrayParams[4].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	orderID, ok := data["OrderID"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	traderAddressStr, ok := data["TraderAddressStr"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	meta, _ := metadata.NewPDECancelOrderRequest(
+		orderID,
+		traderAddressStr,
+		metadata.PDECancelOrderRequestMeta,
+	)
+
+	// create new param to build raw tx from param interface
+	createRawTxParam, errNewParam := bean.NewCreateRawTxParam(params)
+	if errNewParam != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errNewParam)
+	}
+
+	tx, err1 := httpServer.txService.BuildRawTransaction(createRawTxParam, meta)
+	if err1 != nil {
+		Logger.log.Error(err1)
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err1)
 	}
 
-	byteArrays, err2 := json.Marshal(customTokenTx)
+	byteArrays, err2 := json.Marshal(tx)
 	if err2 != nil {
 		Logger.log.Error(err2)
 		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err2)
 	}
 	result := jsonresult.CreateTransactionResult{
-		TxID:            customTokenTx.Hash().String(),
+		TxID:            tx.Hash().String(),
 		Base58CheckData: base58.Base58Check{}.Encode(byteArrays, 0x00),
 	}
 	return result, nil
 }
 
-func (httpServer *HttpServer) handleCreateAndSendTxWithPTokenTradeReq(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	data, err := httpServer.handleCreateRawTxWithPTokenTradeReq(params, closeChan)
+func (httpServer *HttpServer) handleCreateAndSendTxWithCancelOrderReq(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, err := httpServer.handleCreateRawTxWithCancelOrderReq(params, closeChan)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
 	}
-
 	tx := data.(jsonresult.CreateTransactionResult)
 	base58CheckData := tx.Base58CheckData
 	newParam := make([]interface{}, 0)
 	newParam = append(newParam, base58CheckData)
-	// sendResult, err1 := httpServer.handleSendRawCustomTokenTransaction(newParam, closeChan)
-	sendResult, err1 := httpServer.handleSendRawPrivacyCustomTokenTransaction(newParam, closeChan)
-	if err1 != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err1)
+	sendResult, err := httpServer.handleSendRawTransaction(newParam, closeChan)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
 	}
-
-	return sendResult, nil
+	result := jsonresult.NewCreateTransactionResult(nil, sendResult.(jsonresult.CreateTransactionResult).TxID, nil, sendResult.(jsonresult.CreateTransactionResult).ShardID)
+	return result, nil
 }
 
 func (httpServer *HttpServer) handleCreateRawTxWithWithdrawalReq(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
@@ -504,17 +911,241 @@ func (httpServer *HttpServer) handleGetPDEState(params interface{}, closeChan <-
 		WaitingPDEContributions map[string]*rawdbv2.PDEContribution `json:"WaitingPDEContributions"`
 		PDEPoolPairs            map[string]*rawdbv2.PDEPoolForPair  `json:"PDEPoolPairs"`
 		PDEShares               map[string]uint64                   `json:"PDEShares"`
+		PDETradingFees          map[string]map[string]uint64        `json:"PDETradingFees"`
+		PDELimitOrders          map[string]*rawdbv2.PDELimitOrder   `json:"PDELimitOrders"`
 		BeaconTimeStamp         int64                               `json:"BeaconTimeStamp"`
 	}
 	result := CurrentPDEState{
 		BeaconTimeStamp:         beaconBlock.Header.Timestamp,
 		PDEPoolPairs:            pdeState.PDEPoolPairs,
 		PDEShares:               pdeState.PDEShares,
+		PDETradingFees:          pdeState.PDETradingFees,
+		PDELimitOrders:          pdeState.PDELimitOrders,
 		WaitingPDEContributions: pdeState.WaitingPDEContributions,
 	}
 	return result, nil
 }
 
+// handleGetPDETradingFees returns the shares of contributors along with the pool fees they have earned by token id
+// params: {"BeaconHeight": uint64, "ContributorAddressStr": string (optional), "Token1IDStr": string (optional), "Token2IDStr": string (optional)}
+func (httpServer *HttpServer) handleGetPDETradingFees(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) == 0 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
+	}
+	data, ok := arrayParams[0].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
+	}
+	beaconHeight, ok := data["BeaconHeight"].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Beacon height is invalid"))
+	}
+	contributorAddressStr, _ := data["ContributorAddressStr"].(string)
+	token1IDStr, _ := data["Token1IDStr"].(string)
+	token2IDStr, _ := data["Token2IDStr"].(string)
+	if token1IDStr > token2IDStr {
+		token1IDStr, token2IDStr = token2IDStr, token1IDStr
+	}
+	beaconFeatureStateRootHash, err := httpServer.config.BlockChain.GetBeaconFeatureRootHash(httpServer.GetBeaconChainDatabase(), uint64(beaconHeight))
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDEStateError, fmt.Errorf("Can't found ConsensusStateRootHash of beacon height %+v, error %+v", beaconHeight, err))
+	}
+	beaconFeatureStateDB, err := statedb.NewWithPrefixTrie(beaconFeatureStateRootHash, statedb.NewDatabaseAccessWarper(httpServer.GetBeaconChainDatabase()))
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDEStateError, err)
+	}
+	pdeState, err := blockchain.InitCurrentPDEStateFromDB(beaconFeatureStateDB, uint64(beaconHeight))
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDEStateError, err)
+	}
+	type PDEContributorTradingFees struct {
+		Token1IDStr           string            `json:"Token1IDStr"`
+		Token2IDStr           string            `json:"Token2IDStr"`
+		ContributorAddressStr string            `json:"ContributorAddressStr"`
+		Shares                uint64            `json:"Shares"`
+		TradingFees           map[string]uint64 `json:"TradingFees"`
+	}
+	result := []PDEContributorTradingFees{}
+	for shareKey, shares := range pdeState.PDEShares {
+		// share key: prefix - beacon height - token1 id - token2 id - contributor address
+		parts := strings.Split(shareKey, "-")
+		if len(parts) != 5 {
+			continue
+		}
+		if (contributorAddressStr != "" && parts[4] != contributorAddressStr) ||
+			(token1IDStr != "" && parts[2] != token1IDStr) ||
+			(token2IDStr != "" && parts[3] != token2IDStr) {
+			continue
+		}
+		tradingFees := pdeState.PDETradingFees[shareKey]
+		if tradingFees == nil {
+			tradingFees = map[string]uint64{}
+		}
+		result = append(result, PDEContributorTradingFees{
+			Token1IDStr:           parts[2],
+			Token2IDStr:           parts[3],
+			ContributorAddressStr: parts[4],
+			Shares:                shares,
+			TradingFees:           tradingFees,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Token1IDStr != result[j].Token1IDStr {
+			return result[i].Token1IDStr < result[j].Token1IDStr
+		}
+		if result[i].Token2IDStr != result[j].Token2IDStr {
+			return result[i].Token2IDStr < result[j].Token2IDStr
+		}
+		return result[i].ContributorAddressStr < result[j].ContributorAddressStr
+	})
+	return result, nil
+}
+
+// getPDEStateByBeaconHeight loads the pde state stored at a beacon height
+func (httpServer *HttpServer) getPDEStateByBeaconHeight(beaconHeight uint64) (*blockchain.CurrentPDEState, *rpcservice.RPCError) {
+	beaconFeatureStateRootHash, err := httpServer.config.BlockChain.GetBeaconFeatureRootHash(httpServer.GetBeaconChainDatabase(), beaconHeight)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDEStateError, fmt.Errorf("Can't found ConsensusStateRootHash of beacon height %+v, error %+v", beaconHeight, err))
+	}
+	beaconFeatureStateDB, err := statedb.NewWithPrefixTrie(beaconFeatureStateRootHash, statedb.NewDatabaseAccessWarper(httpServer.GetBeaconChainDatabase()))
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDEStateError, err)
+	}
+	pdeState, err := blockchain.InitCurrentPDEStateFromDB(beaconFeatureStateDB, beaconHeight)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDEStateError, err)
+	}
+	return pdeState, nil
+}
+
+// handleGetPDEOpenOrders returns the open limit orders of a trader, sorted by expiry height
+// params: {"BeaconHeight": uint64, "TraderAddressStr": string, "Token1IDStr": string (optional), "Token2IDStr": string (optional)}
+func (httpServer *HttpServer) handleGetPDEOpenOrders(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) == 0 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
+	}
+	data, ok := arrayParams[0].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
+	}
+	beaconHeight, ok := data["BeaconHeight"].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Beacon height is invalid"))
+	}
+	traderAddressStr, ok := data["TraderAddressStr"].(string)
+	if !ok || traderAddressStr == "" {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Trader address is invalid"))
+	}
+	token1IDStr, _ := data["Token1IDStr"].(string)
+	token2IDStr, _ := data["Token2IDStr"].(string)
+	pdeState, rpcErr := httpServer.getPDEStateByBeaconHeight(uint64(beaconHeight))
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	result := []*rawdbv2.PDELimitOrder{}
+	for _, order := range pdeState.PDELimitOrders {
+		if order.TraderAddressStr != traderAddressStr {
+			continue
+		}
+		if (token1IDStr != "" && order.TokenIDToBuyStr != token1IDStr && order.TokenIDToSellStr != token1IDStr) ||
+			(token2IDStr != "" && order.TokenIDToBuyStr != token2IDStr && order.TokenIDToSellStr != token2IDStr) {
+			continue
+		}
+		result = append(result, order)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].ExpiryHeight != result[j].ExpiryHeight {
+			return result[i].ExpiryHeight < result[j].ExpiryHeight
+		}
+		return result[i].OrderID.String() < result[j].OrderID.String()
+	})
+	return result, nil
+}
+
+// handleGetPDEOrderBook returns open limit orders of a pool pair aggregated by limit price for both selling sides,
+// price is the min acceptable amount of the buying token for one unit of the selling token
+// params: {"BeaconHeight": uint64, "Token1IDStr": string, "Token2IDStr": string}
+func (httpServer *HttpServer) handleGetPDEOrderBook(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) == 0 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
+	}
+	data, ok := arrayParams[0].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
+	}
+	beaconHeight, ok := data["BeaconHeight"].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Beacon height is invalid"))
+	}
+	token1IDStr, ok := data["Token1IDStr"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Token1IDStr is invalid"))
+	}
+	token2IDStr, ok := data["Token2IDStr"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Token2IDStr is invalid"))
+	}
+	if token1IDStr > token2IDStr {
+		token1IDStr, token2IDStr = token2IDStr, token1IDStr
+	}
+	pdeState, rpcErr := httpServer.getPDEStateByBeaconHeight(uint64(beaconHeight))
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	type PDEOrderBookLevel struct {
+		Price         float64 `json:"Price"`
+		SellAmount    uint64  `json:"SellAmount"`
+		NumberOfOrder int     `json:"NumberOfOrder"`
+	}
+	type PDEOrderBook struct {
+		Token1IDStr     string              `json:"Token1IDStr"`
+		Token2IDStr     string              `json:"Token2IDStr"`
+		Token1PoolValue uint64              `json:"Token1PoolValue"`
+		Token2PoolValue uint64              `json:"Token2PoolValue"`
+		SellToken1      []PDEOrderBookLevel `json:"SellToken1"`
+		SellToken2      []PDEOrderBookLevel `json:"SellToken2"`
+	}
+	result := PDEOrderBook{
+		Token1IDStr: token1IDStr,
+		Token2IDStr: token2IDStr,
+		SellToken1:  []PDEOrderBookLevel{},
+		SellToken2:  []PDEOrderBookLevel{},
+	}
+	poolPair, found := pdeState.PDEPoolPairs[string(rawdbv2.BuildPDEPoolForPairKey(uint64(beaconHeight), token1IDStr, token2IDStr))]
+	if found && poolPair != nil {
+		result.Token1PoolValue = poolPair.Token1PoolValue
+		result.Token2PoolValue = poolPair.Token2PoolValue
+	}
+	levelsByPrice := map[bool]map[float64]*PDEOrderBookLevel{true: {}, false: {}}
+	for _, order := range pdeState.PDELimitOrders {
+		sellToken1 := order.TokenIDToSellStr == token1IDStr && order.TokenIDToBuyStr == token2IDStr
+		sellToken2 := order.TokenIDToSellStr == token2IDStr && order.TokenIDToBuyStr == token1IDStr
+		if !sellToken1 && !sellToken2 {
+			continue
+		}
+		price := float64(order.MinAcceptableAmount) / float64(order.SellAmount)
+		level, found := levelsByPrice[sellToken1][price]
+		if !found {
+			level = &PDEOrderBookLevel{Price: price}
+			levelsByPrice[sellToken1][price] = level
+		}
+		level.SellAmount += order.SellAmount
+		level.NumberOfOrder++
+	}
+	for _, level := range levelsByPrice[true] {
+		result.SellToken1 = append(result.SellToken1, *level)
+	}
+	for _, level := range levelsByPrice[false] {
+		result.SellToken2 = append(result.SellToken2, *level)
+	}
+	sort.Slice(result.SellToken1, func(i, j int) bool { return result.SellToken1[i].Price < result.SellToken1[j].Price })
+	sort.Slice(result.SellToken2, func(i, j int) bool { return result.SellToken2[i].Price < result.SellToken2[j].Price })
+	return result, nil
+}
+
 func (httpServer *HttpServer) handleConvertNativeTokenToPrivacyToken(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	arrayParams := common.InterfaceSlice(params)
 	data := arrayParams[0].(map[string]interface{})
@@ -634,6 +1265,27 @@ func (httpServer *HttpServer) handleGetPDETradeStatus(params interface{}, closeC
 	return status, nil
 }
 
+func (httpServer *HttpServer) handleGetPDELimitOrderStatus(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	data, ok := arrayParams[0].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload is invalid"))
+	}
+	txRequestIDStr, ok := data["TxRequestIDStr"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload is invalid"))
+	}
+	txIDHash, err := common.Hash{}.NewHashFromStr(txRequestIDStr)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDEStateError, err)
+	}
+	status, err := httpServer.blockService.GetPDEStatus(rawdbv2.PDELimitOrderStatusPrefix, txIDHash[:])
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDEStateError, err)
+	}
+	return status, nil
+}
+
 func (httpServer *HttpServer) handleGetPDEWithdrawalStatus(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	arrayParams := common.InterfaceSlice(params)
 	data := arrayParams[0].(map[string]interface{})
@@ -755,6 +1407,171 @@ func parsePDETradeInst(inst []string, beaconHeight uint64) (*PDETrade, error) {
 	return nil, nil
 }
 
+func parsePDECrossPoolTradeInst(inst []string, beaconHeight uint64) (*PDETrade, error) {
+	if len(inst) != 4 {
+		return nil, nil
+	}
+	status := inst[2]
+	shardID, err := strconv.Atoi(inst[1])
+	if err != nil {
+		return nil, err
+	}
+	if status == common.PDETradeRefundChainStatus {
+		contentBytes, err := base64.StdEncoding.DecodeString(inst[3])
+		if err != nil {
+			return nil, err
+		}
+		var pdeTradeReqAction metadata.PDECrossPoolTradeRequestAction
+		err = json.Unmarshal(contentBytes, &pdeTradeReqAction)
+		if err != nil {
+			return nil, err
+		}
+		tokenIDStrs := []string{pdeTradeReqAction.Meta.TokenIDToBuyStr, pdeTradeReqAction.Meta.TokenIDToSellStr}
+		sort.Slice(tokenIDStrs, func(i, j int) bool {
+			return tokenIDStrs[i] < tokenIDStrs[j]
+		})
+		return &PDETrade{
+			TraderAddressStr:    pdeTradeReqAction.Meta.TraderAddressStr,
+			ReceivingTokenIDStr: pdeTradeReqAction.Meta.TokenIDToSellStr,
+			ReceiveAmount:       pdeTradeReqAction.Meta.SellAmount + pdeTradeReqAction.Meta.TradingFee,
+			Token1IDStr:         tokenIDStrs[0],
+			Token2IDStr:         tokenIDStrs[1],
+			Route:               pdeTradeReqAction.Meta.Route,
+			ShardID:             byte(shardID),
+			RequestedTxID:       pdeTradeReqAction.TxReqID,
+			Status:              "refunded",
+			BeaconHeight:        beaconHeight,
+		}, nil
+	}
+	if status == common.PDETradeAcceptedChainStatus {
+		var tradeAcceptedContent metadata.PDECrossPoolTradeAcceptedContent
+		err := json.Unmarshal([]byte(inst[3]), &tradeAcceptedContent)
+		if err != nil {
+			return nil, err
+		}
+		if len(tradeAcceptedContent.Hops) == 0 {
+			return nil, errors.New("cross pool trade has no hop")
+		}
+		// the sold token of a hop is added to its pool, the bought one is deducted
+		route := []string{}
+		for _, hop := range tradeAcceptedContent.Hops {
+			soldTokenIDStr, boughtTokenIDStr := hop.Token1IDStr, hop.Token2IDStr
+			if hop.Token1PoolValueOperation.Operator != "+" {
+				soldTokenIDStr, boughtTokenIDStr = hop.Token2IDStr, hop.Token1IDStr
+			}
+			if len(route) == 0 {
+				route = append(route, soldTokenIDStr)
+			}
+			route = append(route, boughtTokenIDStr)
+		}
+		tokenIDStrs := []string{route[0], tradeAcceptedContent.TokenIDToBuyStr}
+		sort.Slice(tokenIDStrs, func(i, j int) bool {
+			return tokenIDStrs[i] < tokenIDStrs[j]
+		})
+		return &PDETrade{
+			TraderAddressStr:    tradeAcceptedContent.TraderAddressStr,
+			ReceivingTokenIDStr: tradeAcceptedContent.TokenIDToBuyStr,
+			ReceiveAmount:       tradeAcceptedContent.ReceiveAmount,
+			Token1IDStr:         tokenIDStrs[0],
+			Token2IDStr:         tokenIDStrs[1],
+			Route:               route,
+			ShardID:             byte(shardID),
+			RequestedTxID:       tradeAcceptedContent.RequestedTxID,
+			Status:              "accepted",
+			BeaconHeight:        beaconHeight,
+		}, nil
+	}
+	return nil, nil
+}
+
+// parsePDELimitOrderInst parses limit order and accepted cancel order instructions,
+// ReceiveAmount is the amount paid to the trader, zero for orders opened in the order book
+func parsePDELimitOrderInst(inst []string, beaconHeight uint64) (*PDELimitOrder, error) {
+	if len(inst) != 4 {
+		return nil, nil
+	}
+	status := inst[2]
+	shardID, err := strconv.Atoi(inst[1])
+	if err != nil {
+		return nil, err
+	}
+	var limitOrder *PDELimitOrder
+	var tokenIDStrs []string
+	switch status {
+	case common.PDELimitOrderRefundChainStatus:
+		contentBytes, err := base64.StdEncoding.DecodeString(inst[3])
+		if err != nil {
+			return nil, err
+		}
+		var pdeLimitOrderReqAction metadata.PDELimitOrderRequestAction
+		err = json.Unmarshal(contentBytes, &pdeLimitOrderReqAction)
+		if err != nil {
+			return nil, err
+		}
+		limitOrder = &PDELimitOrder{
+			OrderID:             pdeLimitOrderReqAction.TxReqID,
+			TraderAddressStr:    pdeLimitOrderReqAction.Meta.TraderAddressStr,
+			ReceivingTokenIDStr: pdeLimitOrderReqAction.Meta.TokenIDToSellStr,
+			ReceiveAmount:       pdeLimitOrderReqAction.Meta.SellAmount + pdeLimitOrderReqAction.Meta.TradingFee,
+			Status:              "refunded",
+		}
+		tokenIDStrs = []string{pdeLimitOrderReqAction.Meta.TokenIDToBuyStr, pdeLimitOrderReqAction.Meta.TokenIDToSellStr}
+	case common.PDELimitOrderOpenChainStatus:
+		var order rawdbv2.PDELimitOrder
+		err := json.Unmarshal([]byte(inst[3]), &order)
+		if err != nil {
+			return nil, err
+		}
+		limitOrder = &PDELimitOrder{
+			OrderID:             order.OrderID,
+			TraderAddressStr:    order.TraderAddressStr,
+			ReceivingTokenIDStr: order.TokenIDToBuyStr,
+			Status:              common.PDELimitOrderOpenChainStatus,
+		}
+		tokenIDStrs = []string{order.TokenIDToBuyStr, order.TokenIDToSellStr}
+	case common.PDELimitOrderFilledChainStatus:
+		var filledContent metadata.PDETradeAcceptedContent
+		err := json.Unmarshal([]byte(inst[3]), &filledContent)
+		if err != nil {
+			return nil, err
+		}
+		limitOrder = &PDELimitOrder{
+			OrderID:             filledContent.RequestedTxID,
+			TraderAddressStr:    filledContent.TraderAddressStr,
+			ReceivingTokenIDStr: filledContent.TokenIDToBuyStr,
+			ReceiveAmount:       filledContent.ReceiveAmount,
+			Status:              common.PDELimitOrderFilledChainStatus,
+		}
+		tokenIDStrs = []string{filledContent.Token1IDStr, filledContent.Token2IDStr}
+	case common.PDELimitOrderExpiredChainStatus, common.PDELimitOrderCancelledChainStatus:
+		var refundContent metadata.PDELimitOrderRefundContent
+		err := json.Unmarshal([]byte(inst[3]), &refundContent)
+		if err != nil {
+			return nil, err
+		}
+		// refund content does not name the bought token
+		limitOrder = &PDELimitOrder{
+			OrderID:             refundContent.OrderID,
+			TraderAddressStr:    refundContent.TraderAddressStr,
+			ReceivingTokenIDStr: refundContent.TokenIDStr,
+			ReceiveAmount:       refundContent.Amount,
+			Status:              status,
+		}
+	default:
+		return nil, nil
+	}
+	if len(tokenIDStrs) == 2 {
+		sort.Slice(tokenIDStrs, func(i, j int) bool {
+			return tokenIDStrs[i] < tokenIDStrs[j]
+		})
+		limitOrder.Token1IDStr = tokenIDStrs[0]
+		limitOrder.Token2IDStr = tokenIDStrs[1]
+	}
+	limitOrder.ShardID = byte(shardID)
+	limitOrder.BeaconHeight = beaconHeight
+	return limitOrder, nil
+}
+
 func parsePDEWithdrawalInst(inst []string, beaconHeight uint64) (*PDEWithdrawal, error) {
 	status := inst[2]
 	shardID, err := strconv.Atoi(inst[1])
@@ -818,6 +1635,7 @@ func (httpServer *HttpServer) handleExtractPDEInstsFromBeaconBlock(
 		PDEContributions: []*PDEContribution{},
 		PDETrades:        []*PDETrade{},
 		PDEWithdrawals:   []*PDEWithdrawal{},
+		PDELimitOrders:   []*PDELimitOrder{},
 		BeaconTimeStamp:  bcBlk.Header.Timestamp,
 	}
 	insts := bcBlk.Body.Instructions
@@ -838,6 +1656,29 @@ func (httpServer *HttpServer) handleExtractPDEInstsFromBeaconBlock(
 				continue
 			}
 			pdeInfoFromBeaconBlock.PDETrades = append(pdeInfoFromBeaconBlock.PDETrades, pdeTrade)
+		case strconv.Itoa(metadata.PDECrossPoolTradeRequestMeta):
+			pdeTrade, err := parsePDECrossPoolTradeInst(inst, bcHeight)
+			if err != nil || pdeTrade == nil {
+				continue
+			}
+			pdeInfoFromBeaconBlock.PDETrades = append(pdeInfoFromBeaconBlock.PDETrades, pdeTrade)
+		case strconv.Itoa(metadata.PDELimitOrderRequestMeta):
+			pdeLimitOrder, err := parsePDELimitOrderInst(inst, bcHeight)
+			if err != nil || pdeLimitOrder == nil {
+				continue
+			}
+			pdeInfoFromBeaconBlock.PDELimitOrders = append(pdeInfoFromBeaconBlock.PDELimitOrders, pdeLimitOrder)
+		case strconv.Itoa(metadata.PDECancelOrderRequestMeta):
+			// refunds of accepted cancellations are listed as cancelled orders
+			if len(inst) != 4 || inst[2] != common.PDECancelOrderAcceptedChainStatus {
+				continue
+			}
+			cancelInst := []string{inst[0], inst[1], common.PDELimitOrderCancelledChainStatus, inst[3]}
+			pdeLimitOrder, err := parsePDELimitOrderInst(cancelInst, bcHeight)
+			if err != nil || pdeLimitOrder == nil {
+				continue
+			}
+			pdeInfoFromBeaconBlock.PDELimitOrders = append(pdeInfoFromBeaconBlock.PDELimitOrders, pdeLimitOrder)
 		case strconv.Itoa(metadata.PDEWithdrawalRequestMeta):
 			pdeWithdrawal, err := parsePDEWithdrawalInst(inst, bcHeight)
 			if err != nil || pdeWithdrawal == nil {
@@ -0,0 +1,127 @@
+package rpcserver
+
+import (
+	"errors"
+	"math"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+)
+
+// pde history RPCs are served from the pde history index, node must be started with --pdehistory
+// heights params are optional: FromHeight defaults to 0 and ToHeight to the final beacon height
+
+func parsePDEHistoryParams(params interface{}) (map[string]interface{}, uint64, uint64, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) == 0 {
+		return nil, 0, 0, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
+	}
+	data, ok := arrayParams[0].(map[string]interface{})
+	if !ok {
+		return nil, 0, 0, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
+	}
+	fromHeight, toHeight := uint64(0), uint64(math.MaxUint64)
+	if _, ok := data["FromHeight"]; ok {
+		height, ok := data["FromHeight"].(float64)
+		if !ok || height < 0 {
+			return nil, 0, 0, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("FromHeight is invalid"))
+		}
+		fromHeight = uint64(height)
+	}
+	if _, ok := data["ToHeight"]; ok {
+		height, ok := data["ToHeight"].(float64)
+		if !ok || height < 0 {
+			return nil, 0, 0, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("ToHeight is invalid"))
+		}
+		toHeight = uint64(height)
+	}
+	return data, fromHeight, toHeight, nil
+}
+
+func parsePDEHistoryPair(data map[string]interface{}) (string, string, *rpcservice.RPCError) {
+	token1IDStr, ok := data["Token1IDStr"].(string)
+	if !ok || token1IDStr == "" {
+		return "", "", rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Token1IDStr is invalid"))
+	}
+	token2IDStr, ok := data["Token2IDStr"].(string)
+	if !ok || token2IDStr == "" || token2IDStr == token1IDStr {
+		return "", "", rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Token2IDStr is invalid"))
+	}
+	return token1IDStr, token2IDStr, nil
+}
+
+// handleGetPDECandles returns OHLC candles of a pair, prices are of the lower token id in the other one
+// params: {"Token1IDStr": string, "Token2IDStr": string, "Interval": uint64 (number of beacon blocks), "FromHeight": uint64, "ToHeight": uint64}
+func (httpServer *HttpServer) handleGetPDECandles(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, fromHeight, toHeight, rpcErr := parsePDEHistoryParams(params)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	token1IDStr, token2IDStr, rpcErr := parsePDEHistoryPair(data)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	interval, ok := data["Interval"].(float64)
+	if !ok || interval < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Interval is invalid"))
+	}
+	candles, err := httpServer.config.BlockChain.GetPDECandles(token1IDStr, token2IDStr, fromHeight, toHeight, uint64(interval))
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDEHistoryError, err)
+	}
+	return candles, nil
+}
+
+// handleGetPDEPairVolume returns the volume of accepted trades of a pair
+// params: {"Token1IDStr": string, "Token2IDStr": string, "FromHeight": uint64, "ToHeight": uint64}
+func (httpServer *HttpServer) handleGetPDEPairVolume(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, fromHeight, toHeight, rpcErr := parsePDEHistoryParams(params)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	token1IDStr, token2IDStr, rpcErr := parsePDEHistoryPair(data)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	volume, err := httpServer.config.BlockChain.GetPDEPairVolume(token1IDStr, token2IDStr, fromHeight, toHeight)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDEHistoryError, err)
+	}
+	return volume, nil
+}
+
+// handleGetPDETradeHistory returns accepted and refunded trades of a trader
+// params: {"TraderAddressStr": string, "FromHeight": uint64, "ToHeight": uint64}
+func (httpServer *HttpServer) handleGetPDETradeHistory(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, fromHeight, toHeight, rpcErr := parsePDEHistoryParams(params)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	traderAddressStr, ok := data["TraderAddressStr"].(string)
+	if !ok || traderAddressStr == "" {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("TraderAddressStr is invalid"))
+	}
+	events, err := httpServer.config.BlockChain.GetPDETradeHistory(traderAddressStr, fromHeight, toHeight)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDEHistoryError, err)
+	}
+	return events, nil
+}
+
+// handleGetPDEPoolHistory returns contributions, withdrawals and trades of a pair with pool reserves before and after each of them
+// params: {"Token1IDStr": string, "Token2IDStr": string, "FromHeight": uint64, "ToHeight": uint64}
+func (httpServer *HttpServer) handleGetPDEPoolHistory(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, fromHeight, toHeight, rpcErr := parsePDEHistoryParams(params)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	token1IDStr, token2IDStr, rpcErr := parsePDEHistoryPair(data)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	events, err := httpServer.config.BlockChain.GetPDEPoolHistory(token1IDStr, token2IDStr, fromHeight, toHeight)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDEHistoryError, err)
+	}
+	return events, nil
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/common/base58"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
@@ -40,7 +41,7 @@ func (httpServer *HttpServer) handleGetLiquidationExchangeRatesPool(params inter
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata TokenID is invalid"))
 	}
 
-	if !common.IsPortalExchangeRateToken(pTokenID) {
+	if !blockchain.IsPortalExchangeRateToken(pTokenID) {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata TokenID is not support"))
 	}
 
@@ -91,7 +92,7 @@ func (httpServer *HttpServer) handleGetAmountNeededForCustodianDepositLiquidatio
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata TokenID is invalid"))
 	}
 
-	if !common.IsPortalToken(pTokenID) {
+	if !blockchain.IsPortalToken(pTokenID) {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata TokenID is not support"))
 	}
 
@@ -219,7 +220,7 @@ func (httpServer *HttpServer) createLiquidationCustodianDeposit(params interface
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata PTokenId param is invalid"))
 	}
 
-	if !common.IsPortalToken(pTokenId) {
+	if !blockchain.IsPortalToken(pTokenId) {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata public token is not supported currently"))
 	}
 
@@ -315,7 +316,7 @@ func (httpServer *HttpServer) createTopUpWaitingPorting(params interface{}, clos
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata PTokenId param is invalid"))
 	}
 
-	if !common.IsPortalToken(pTokenId) {
+	if !blockchain.IsPortalToken(pTokenId) {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata public token is not supported currently"))
 	}
 
@@ -464,3 +465,73 @@ func (httpServer *HttpServer) handleGetReqRedeemFromLiquidationPoolByTxIDStatus(
 	}
 	return status, nil
 }
+
+func (httpServer *HttpServer) handleGetPortalCustodianRisks(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	return httpServer.getPortalCustodianRisks(params, false)
+}
+
+func (httpServer *HttpServer) handleSimulatePortalCustodianLiquidation(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	return httpServer.getPortalCustodianRisks(params, true)
+}
+
+// getPortalCustodianRisks evaluates custodians at the final exchange rates of the beacon height,
+// in what-if mode the exchange rates in params replace the final ones of their tokens
+func (httpServer *HttpServer) getPortalCustodianRisks(params interface{}, isWhatIf bool) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least 1"))
+	}
+	data, ok := arrayParams[0].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata param is invalid"))
+	}
+
+	beaconHeight, err := common.AssertAndConvertStrToNumber(data["BeaconHeight"])
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+
+	custodianAddress := ""
+	if custodianAddressParam, ok := data["CustodianAddress"]; ok {
+		custodianAddress, ok = custodianAddressParam.(string)
+		if !ok {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata CustodianAddress is invalid"))
+		}
+	}
+
+	var customExchangeRates map[string]uint64
+	if isWhatIf {
+		exchangeRatesParam, ok := data["ExchangeRates"].(map[string]interface{})
+		if !ok || len(exchangeRatesParam) == 0 {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata ExchangeRates is invalid"))
+		}
+		customExchangeRates = make(map[string]uint64)
+		for tokenID, rateParam := range exchangeRatesParam {
+			if !blockchain.IsPortalExchangeRateToken(tokenID) {
+				return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("metadata ExchangeRates: token %v is not support", tokenID))
+			}
+			rate, err := common.AssertAndConvertStrToNumber(rateParam)
+			if err != nil || rate == 0 {
+				return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("metadata ExchangeRates: rate of token %v is invalid", tokenID))
+			}
+			customExchangeRates[tokenID] = rate
+		}
+	}
+
+	featureStateRootHash, err := httpServer.config.BlockChain.GetBeaconFeatureRootHash(httpServer.config.BlockChain.GetBeaconChainDatabase(), uint64(beaconHeight))
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPortalCustodianRisksError, fmt.Errorf("Can't found FeatureStateRootHash of beacon height %+v, error %+v", beaconHeight, err))
+	}
+	stateDB, err := statedb.NewWithPrefixTrie(featureStateRootHash, statedb.NewDatabaseAccessWarper(httpServer.config.BlockChain.GetBeaconChainDatabase()))
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPortalCustodianRisksError, err)
+	}
+
+	portalParam := httpServer.config.BlockChain.GetPortalParams(uint64(beaconHeight))
+
+	result, err := httpServer.portal.GetCustodianRisks(stateDB, custodianAddress, customExchangeRates, portalParam)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPortalCustodianRisksError, err)
+	}
+	return result, nil
+}
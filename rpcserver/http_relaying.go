@@ -3,11 +3,15 @@ package rpcserver
 import (
 	"encoding/json"
 	"errors"
+	"math/big"
+
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/common/base58"
 	"github.com/incognitochain/incognito-chain/metadata"
 	bnbrelaying "github.com/incognitochain/incognito-chain/relaying/bnb"
+	ethrelaying "github.com/incognitochain/incognito-chain/relaying/eth"
 	"github.com/incognitochain/incognito-chain/rpcserver/bean"
 	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
 	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
@@ -30,6 +34,14 @@ func (httpServer *HttpServer) handleCreateRawTxWithRelayingBNBHeader(params inte
 	)
 }
 
+func (httpServer *HttpServer) handleCreateRawTxWithRelayingETHHeader(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	return httpServer.handleCreateRawTxWithRelayingHeader(
+		metadata.RelayingETHHeaderMeta,
+		params,
+		closeChan,
+	)
+}
+
 func (httpServer *HttpServer) handleCreateRawTxWithRelayingHeader(
 	metaType int,
 	params interface{},
@@ -127,6 +139,23 @@ func (httpServer *HttpServer) handleCreateAndSendTxWithRelayingBTCHeader(params
 	return result, nil
 }
 
+func (httpServer *HttpServer) handleCreateAndSendTxWithRelayingETHHeader(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, err := httpServer.handleCreateRawTxWithRelayingETHHeader(params, closeChan)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	tx := data.(jsonresult.CreateTransactionResult)
+	base58CheckData := tx.Base58CheckData
+	newParam := make([]interface{}, 0)
+	newParam = append(newParam, base58CheckData)
+	sendResult, err := httpServer.handleSendRawTransaction(newParam, closeChan)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	result := jsonresult.NewCreateTransactionResult(nil, sendResult.(jsonresult.CreateTransactionResult).TxID, nil, sendResult.(jsonresult.CreateTransactionResult).ShardID)
+	return result, nil
+}
+
 func (httpServer *HttpServer) handleGetRelayingBNBHeaderState(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	bc := httpServer.config.BlockChain
 	relayingState, err := bc.InitRelayingHeaderChainStateFromDB()
@@ -216,3 +245,63 @@ func (httpServer *HttpServer) handleGetBTCBlockByHash(params interface{}, closeC
 	}
 	return btcBlock.MsgBlock(), nil
 }
+
+func (httpServer *HttpServer) handleGetETHRelayingBestState(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	bc := httpServer.config.BlockChain
+	ethHeaderChain, err := bc.GetETHHeaderChain(bc.GetBeaconBestState().GetBeaconFeatureStateDB())
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetETHRelayingBestState, err)
+	}
+	bestHeader, err := ethHeaderChain.GetBestHeader()
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetETHRelayingBestState, err)
+	}
+	if bestHeader == nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetETHRelayingBestState, errors.New("No eth header was relayed"))
+	}
+
+	type ETHRelayingBestState struct {
+		BestHeader           *ethtypes.Header `json:"BestHeader"`
+		TotalDifficulty      *big.Int         `json:"TotalDifficulty"`
+		FinalizedBlockHeight uint64           `json:"FinalizedBlockHeight"`
+	}
+	finalizedBlockHeight := uint64(0)
+	if bestHeader.Header.Number.Uint64() >= ethrelaying.MinConfirmationBlocks {
+		finalizedBlockHeight = bestHeader.Header.Number.Uint64() - ethrelaying.MinConfirmationBlocks
+	}
+	result := ETHRelayingBestState{
+		BestHeader:           bestHeader.Header,
+		TotalDifficulty:      bestHeader.TotalDifficulty,
+		FinalizedBlockHeight: finalizedBlockHeight,
+	}
+	return result, nil
+}
+
+func (httpServer *HttpServer) handleGetRelayingETHHeaderByBlockHeight(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least one"))
+	}
+	data, ok := arrayParams[0].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
+	}
+	blockHeight, err := common.AssertAndConvertStrToNumber(data["BlockHeight"])
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+
+	bc := httpServer.config.BlockChain
+	ethHeaderChain, err := bc.GetETHHeaderChain(bc.GetBeaconBestState().GetBeaconFeatureStateDB())
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetRelayingETHHeaderError, err)
+	}
+	header, err := ethHeaderChain.GetHeaderByNumber(blockHeight)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetRelayingETHHeaderError, err)
+	}
+	if header == nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetRelayingETHHeaderError, errors.New("Eth header was not relayed"))
+	}
+	return header.Header, nil
+}
@@ -0,0 +1,58 @@
+package rpcserver
+
+import (
+	"errors"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+)
+
+// handleGetBeaconStateProof returns Merkle proof of a beacon state object at a height,
+// verifiable against the state roots in the header of the next beacon block
+// params: {"Height": uint64, "StateType": "committee" | "pdepoolpair" | "custodian" | "reward", ...state object params}
+func (httpServer *HttpServer) handleGetBeaconStateProof(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least 1"))
+	}
+	data, ok := arrayParams[0].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param is invalid"))
+	}
+	height, ok := data["Height"].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Height is invalid"))
+	}
+	stateType, ok := data["StateType"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("StateType is invalid"))
+	}
+	return httpServer.blockService.GetBeaconStateProof(uint64(height), stateType, data)
+}
+
+// handleGetShardStateProof returns Merkle proof of a shard state object at a height,
+// verifiable against the state roots in the header of the next shard block
+// params: {"ShardID": byte, "Height": uint64, "StateType": "tokeninfo" | "serialnumber" | "committee" | "reward", ...state object params}
+func (httpServer *HttpServer) handleGetShardStateProof(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least 1"))
+	}
+	data, ok := arrayParams[0].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param is invalid"))
+	}
+	shardID, ok := data["ShardID"].(float64)
+	if !ok || shardID < 0 || int(shardID) >= common.MaxShardNumber {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("ShardID is invalid"))
+	}
+	height, ok := data["Height"].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Height is invalid"))
+	}
+	stateType, ok := data["StateType"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("StateType is invalid"))
+	}
+	return httpServer.blockService.GetShardStateProof(byte(shardID), uint64(height), stateType, data)
+}
@@ -8,21 +8,27 @@ import (
 )
 
 type GetMempoolInfo struct {
-	Size          int                `json:"Size"`
-	Bytes         uint64             `json:"Bytes"`
-	Usage         uint64             `json:"Usage"`
-	MaxMempool    uint64             `json:"MaxMempool"`
-	MempoolMinFee uint64             `json:"MempoolMinFee"`
-	MempoolMaxFee uint64             `json:"MempoolMaxFee"`
-	ListTxs       []GetMempoolInfoTx `json:"ListTxs"`
+	Size          int                 `json:"Size"`
+	Bytes         uint64              `json:"Bytes"`
+	Usage         uint64              `json:"Usage"`
+	MaxMempool    uint64              `json:"MaxMempool"`
+	MempoolMinFee uint64              `json:"MempoolMinFee"`
+	MempoolMaxFee uint64              `json:"MempoolMaxFee"`
+	MinFeePerKB   uint64              `json:"MinFeePerKB"`
+	ListTxs       []GetMempoolInfoTx  `json:"ListTxs"`
+	EvictionCount map[string]uint64   `json:"EvictionCount"`
+	EvictedTxs    []mempool.EvictedTx `json:"EvictedTxs"`
 }
 
 func NewGetMempoolInfo(txMempool *mempool.TxPool) *GetMempoolInfo {
 	result := &GetMempoolInfo{
 		Size:          txMempool.Count(),
 		Bytes:         txMempool.Size(),
+		MaxMempool:    txMempool.MaxTx(),
 		MempoolMaxFee: txMempool.MaxFee(),
+		MinFeePerKB:   txMempool.MinFeePerKB(),
 	}
+	result.EvictionCount, result.EvictedTxs = txMempool.EvictionInfo()
 	// get list data from mempool
 	listTxsDetail := txMempool.ListTxsDetail()
 	if len(listTxsDetail) > 0 {
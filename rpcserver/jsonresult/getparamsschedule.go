@@ -0,0 +1,12 @@
+package jsonresult
+
+import "github.com/incognitochain/incognito-chain/blockchain"
+
+type ParamsSchedule struct {
+	Network      string `json:"Network"`
+	BeaconHeight uint64 `json:"BeaconHeight"`
+	// ActivePortalParams, ActivePDEParams are the params applying at BeaconHeight, ActivePDEParams is nil before pde params are activated
+	ActivePortalParams blockchain.PortalParams      `json:"ActivePortalParams"`
+	ActivePDEParams    *blockchain.PDEParams        `json:"ActivePDEParams"`
+	Schedule           []blockchain.ScheduledParams `json:"Schedule"`
+}
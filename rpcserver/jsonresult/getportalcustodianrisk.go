@@ -0,0 +1,39 @@
+package jsonresult
+
+type PortalCustodianRisk struct {
+	IncognitoAddress       string                              `json:"IncognitoAddress"`
+	FreeCollateral         uint64                              `json:"FreeCollateral"`
+	TokenRisks             map[string]PortalCustodianTokenRisk `json:"TokenRisks"`
+	WaitingPortingRequests []PortalCustodianPortingRequest     `json:"WaitingPortingRequests"`
+	RedeemRequests         []PortalCustodianRedeemRequest      `json:"RedeemRequests"`
+}
+
+type PortalCustodianTokenRisk struct {
+	LockedCollateral     uint64 `json:"LockedCollateral"`
+	HoldingPubToken      uint64 `json:"HoldingPubToken"`
+	HoldingPubTokenInPRV uint64 `json:"HoldingPubTokenInPRV"`
+	// CollateralRatio is in percent
+	CollateralRatio uint64 `json:"CollateralRatio"`
+	// DistanceToTP130, DistanceToTP120 are percentage points above the thresholds, negative when below
+	DistanceToTP130 int64 `json:"DistanceToTP130"`
+	DistanceToTP120 int64 `json:"DistanceToTP120"`
+	// PriceIncreaseToTP130, PriceIncreaseToTP120 are the rises in percent of the ptoken price against PRV
+	// which bring the collateral ratio down to the thresholds
+	PriceIncreaseToTP130 uint64 `json:"PriceIncreaseToTP130"`
+	PriceIncreaseToTP120 uint64 `json:"PriceIncreaseToTP120"`
+	LiquidationStatus    string `json:"LiquidationStatus"`
+}
+
+type PortalCustodianPortingRequest struct {
+	PortingID        string `json:"PortingID"`
+	TokenID          string `json:"TokenID"`
+	Amount           uint64 `json:"Amount"`
+	LockedCollateral uint64 `json:"LockedCollateral"`
+}
+
+type PortalCustodianRedeemRequest struct {
+	RedeemID string `json:"RedeemID"`
+	TokenID  string `json:"TokenID"`
+	Amount   uint64 `json:"Amount"`
+	Status   byte   `json:"Status"`
+}
@@ -12,3 +12,20 @@ type FinalExchangeRatesResult struct {
 type ExchangeRatesResult struct {
 	Rates map[string]uint64 `json:"Rates"`
 }
+
+type PortalExchangeRatesFeederRate struct {
+	Rate         uint64 `json:"Rate"`
+	BeaconHeight uint64 `json:"BeaconHeight"`
+}
+
+type PortalExchangeRatesFeeder struct {
+	FeederAddress string `json:"FeederAddress"`
+	// IsAllowed is false for feeders removed from the allowlist
+	IsAllowed bool `json:"IsAllowed"`
+	// IsLive is true if the feeder submitted rates during the aggregation window ending at the beacon height
+	IsLive                    bool                                     `json:"IsLive"`
+	LastSubmittedBeaconHeight uint64                                   `json:"LastSubmittedBeaconHeight"`
+	AcceptedSubmissions       uint64                                   `json:"AcceptedSubmissions"`
+	RejectedSubmissions       uint64                                   `json:"RejectedSubmissions"`
+	LastRates                 map[string]PortalExchangeRatesFeederRate `json:"LastRates"`
+}
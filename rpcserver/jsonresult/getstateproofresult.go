@@ -0,0 +1,31 @@
+package jsonresult
+
+import (
+	"encoding/hex"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+)
+
+type GetStateProofResult struct {
+	Height    uint64   `json:"Height"`    // height of the proven state
+	BlockHash string   `json:"BlockHash"` // hash of the block at Height+1, committing the state roots
+	Trie      string   `json:"Trie"`
+	Key       string   `json:"Key"`
+	Value     string   `json:"Value"` // hex encoded, empty if the key does not exist
+	Proof     []string `json:"Proof"` // hex encoded trie nodes
+}
+
+func NewGetStateProofResult(stateProof *blockchain.StateProof) *GetStateProofResult {
+	result := &GetStateProofResult{
+		Height:    stateProof.Height,
+		BlockHash: stateProof.BlockHash.String(),
+		Trie:      stateProof.Trie,
+		Key:       stateProof.Key.String(),
+		Value:     hex.EncodeToString(stateProof.Value),
+		Proof:     make([]string, 0, len(stateProof.Proof)),
+	}
+	for _, node := range stateProof.Proof {
+		result.Proof = append(result.Proof, hex.EncodeToString(node))
+	}
+	return result
+}
@@ -26,6 +26,7 @@ var HttpHandler = map[string]httpHandler{
 	estimateFee:              (*HttpServer).handleEstimateFee,
 	estimateFeeWithEstimator: (*HttpServer).handleEstimateFeeWithEstimator,
 	getActiveShards:          (*HttpServer).handleGetActiveShards,
+	getParamsSchedule:        (*HttpServer).handleGetParamsSchedule,
 	getMaxShardsNumber:       (*HttpServer).handleGetMaxShardsNumber,
 
 	//tx pool
@@ -120,6 +121,7 @@ var HttpHandler = map[string]httpHandler{
 	getAllBridgeTokens:              (*HttpServer).handleGetAllBridgeTokens,
 	getETHHeaderByHash:              (*HttpServer).handleGetETHHeaderByHash,
 	getBridgeReqWithStatus:          (*HttpServer).handleGetBridgeReqWithStatus,
+	getETHEndpointStatuses:          (*HttpServer).handleGetETHEndpointStatuses,
 	generateTokenID:                 (*HttpServer).handleGenerateTokenID,
 
 	// wallet
@@ -154,20 +156,40 @@ var HttpHandler = map[string]httpHandler{
 	getMinerRewardFromMiningKey: (*HttpServer).handleGetMinerRewardFromMiningKey,
 	getProducersBlackList:       (*HttpServer).handleGetProducersBlackList,
 	getProducersBlackListDetail: (*HttpServer).handleGetProducersBlackListDetail,
+	getEquivocationEvidences:    (*HttpServer).handleGetEquivocationEvidences,
+
+	createAndSendTxWithEquivocationEvidence: (*HttpServer).handleCreateAndSendTxWithEquivocationEvidence,
+
+	// state proof
+	getBeaconStateProof: (*HttpServer).handleGetBeaconStateProof,
+	getShardStateProof:  (*HttpServer).handleGetShardStateProof,
 
 	// pde
-	getPDEState:                           (*HttpServer).handleGetPDEState,
-	createAndSendTxWithWithdrawalReq:      (*HttpServer).handleCreateAndSendTxWithWithdrawalReq,
-	createAndSendTxWithPTokenTradeReq:     (*HttpServer).handleCreateAndSendTxWithPTokenTradeReq,
-	createAndSendTxWithPRVTradeReq:        (*HttpServer).handleCreateAndSendTxWithPRVTradeReq,
-	createAndSendTxWithPTokenContribution: (*HttpServer).handleCreateAndSendTxWithPTokenContribution,
-	createAndSendTxWithPRVContribution:    (*HttpServer).handleCreateAndSendTxWithPRVContribution,
-	getPDEContributionStatus:              (*HttpServer).handleGetPDEContributionStatus,
-	getPDEContributionStatusV2:            (*HttpServer).handleGetPDEContributionStatusV2,
-	getPDETradeStatus:                     (*HttpServer).handleGetPDETradeStatus,
-	getPDEWithdrawalStatus:                (*HttpServer).handleGetPDEWithdrawalStatus,
-	convertPDEPrices:                      (*HttpServer).handleConvertPDEPrices,
-	extractPDEInstsFromBeaconBlock:        (*HttpServer).handleExtractPDEInstsFromBeaconBlock,
+	getPDEState:                                (*HttpServer).handleGetPDEState,
+	getPDETradingFees:                          (*HttpServer).handleGetPDETradingFees,
+	createAndSendTxWithWithdrawalReq:           (*HttpServer).handleCreateAndSendTxWithWithdrawalReq,
+	createAndSendTxWithPTokenTradeReq:          (*HttpServer).handleCreateAndSendTxWithPTokenTradeReq,
+	createAndSendTxWithPRVTradeReq:             (*HttpServer).handleCreateAndSendTxWithPRVTradeReq,
+	createAndSendTxWithPTokenCrossPoolTradeReq: (*HttpServer).handleCreateAndSendTxWithPTokenCrossPoolTradeReq,
+	createAndSendTxWithPRVCrossPoolTradeReq:    (*HttpServer).handleCreateAndSendTxWithPRVCrossPoolTradeReq,
+	createAndSendTxWithPTokenLimitOrderReq:     (*HttpServer).handleCreateAndSendTxWithPTokenLimitOrderReq,
+	createAndSendTxWithPRVLimitOrderReq:        (*HttpServer).handleCreateAndSendTxWithPRVLimitOrderReq,
+	createAndSendTxWithCancelOrderReq:          (*HttpServer).handleCreateAndSendTxWithCancelOrderReq,
+	createAndSendTxWithPTokenContribution:      (*HttpServer).handleCreateAndSendTxWithPTokenContribution,
+	createAndSendTxWithPRVContribution:         (*HttpServer).handleCreateAndSendTxWithPRVContribution,
+	getPDEContributionStatus:                   (*HttpServer).handleGetPDEContributionStatus,
+	getPDEContributionStatusV2:                 (*HttpServer).handleGetPDEContributionStatusV2,
+	getPDETradeStatus:                          (*HttpServer).handleGetPDETradeStatus,
+	getPDELimitOrderStatus:                     (*HttpServer).handleGetPDELimitOrderStatus,
+	getPDEOpenOrders:                           (*HttpServer).handleGetPDEOpenOrders,
+	getPDEOrderBook:                            (*HttpServer).handleGetPDEOrderBook,
+	getPDEWithdrawalStatus:                     (*HttpServer).handleGetPDEWithdrawalStatus,
+	convertPDEPrices:                           (*HttpServer).handleConvertPDEPrices,
+	extractPDEInstsFromBeaconBlock:             (*HttpServer).handleExtractPDEInstsFromBeaconBlock,
+	getPDECandles:                              (*HttpServer).handleGetPDECandles,
+	getPDEPairVolume:                           (*HttpServer).handleGetPDEPairVolume,
+	getPDETradeHistory:                         (*HttpServer).handleGetPDETradeHistory,
+	getPDEPoolHistory:                          (*HttpServer).handleGetPDEPoolHistory,
 
 	getBurningAddress: (*HttpServer).handleGetBurningAddress,
 
@@ -179,6 +201,7 @@ var HttpHandler = map[string]httpHandler{
 	createAndSendRegisterPortingPublicTokens:      (*HttpServer).handleCreateAndSendRegisterPortingPublicTokens,
 	createAndSendPortalExchangeRates:              (*HttpServer).handleCreateAndSendPortalExchangeRates,
 	getPortalFinalExchangeRates:                   (*HttpServer).handleGetPortalFinalExchangeRates,
+	getPortalExchangeRatesFeeders:                 (*HttpServer).handleGetPortalExchangeRatesFeeders,
 	getPortalPortingRequestByKey:                  (*HttpServer).handleGetPortingRequestByKey,
 	getPortalPortingRequestByPortingId:            (*HttpServer).handleGetPortingRequestByPortingId,
 	convertExchangeRates:                          (*HttpServer).handleConvertExchangeRates,
@@ -206,6 +229,8 @@ var HttpHandler = map[string]httpHandler{
 	getAmountTopUpWaitingPorting:                  (*HttpServer).handleGetAmountTopUpWaitingPorting,
 	getPortalReqRedeemByTxIDStatus:                (*HttpServer).handleGetPortalReqRedeemByTxIDStatus,
 	getReqRedeemFromLiquidationPoolByTxIDStatus:   (*HttpServer).handleGetReqRedeemFromLiquidationPoolByTxIDStatus,
+	getPortalCustodianRisks:                       (*HttpServer).handleGetPortalCustodianRisks,
+	simulatePortalCustodianLiquidation:            (*HttpServer).handleSimulatePortalCustodianLiquidation,
 
 	// relaying
 	createAndSendTxWithRelayingBNBHeader: (*HttpServer).handleCreateAndSendTxWithRelayingBNBHeader,
@@ -215,6 +240,9 @@ var HttpHandler = map[string]httpHandler{
 	getBTCRelayingBestState:              (*HttpServer).handleGetBTCRelayingBestState,
 	getBTCBlockByHash:                    (*HttpServer).handleGetBTCBlockByHash,
 	getLatestBNBHeaderBlockHeight:        (*HttpServer).handleGetLatestBNBHeaderBlockHeight,
+	createAndSendTxWithRelayingETHHeader: (*HttpServer).handleCreateAndSendTxWithRelayingETHHeader,
+	getETHRelayingBestState:              (*HttpServer).handleGetETHRelayingBestState,
+	getRelayingETHHeaderByBlockHeight:    (*HttpServer).handleGetRelayingETHHeaderByBlockHeight,
 
 	// incognnito mode for sc
 	getBurnProofForDepositToSC:                (*HttpServer).handleGetBurnProofForDepositToSC,
@@ -250,6 +278,12 @@ var LimitedHttpHandler = map[string]httpHandler{
 	setTxFee:                         (*HttpServer).handleSetTxFee,
 	convertNativeTokenToPrivacyToken: (*HttpServer).handleConvertNativeTokenToPrivacyToken,
 	convertPrivacyTokenToNativeToken: (*HttpServer).handleConvertPrivacyTokenToNativeToken,
+
+	// output coin index
+	registerViewingKey:   (*HttpServer).handleRegisterViewingKey,
+	rescanViewingKey:     (*HttpServer).handleRescanViewingKey,
+	unregisterViewingKey: (*HttpServer).handleUnregisterViewingKey,
+	listViewingKeys:      (*HttpServer).handleListViewingKeys,
 }
 
 var WsHandler = map[string]wsHandler{
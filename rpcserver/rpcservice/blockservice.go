@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 
 	rCommon "github.com/ethereum/go-ethereum/common"
@@ -14,6 +15,7 @@ import (
 	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/memcache"
 	"github.com/incognitochain/incognito-chain/mempool"
 	"github.com/incognitochain/incognito-chain/metadata"
@@ -957,6 +959,147 @@ func (blockService BlockService) GetProducersBlackList(beaconHeight uint64) (map
 	return statedb.GetProducersBlackList(slashStateDB, beaconHeight), nil
 }
 
+//============================= State Proof ===============================
+// state objects which can be proven against state roots committed in block header
+const (
+	tokenInfoStateType    = "tokeninfo"
+	committeeStateType    = "committee"
+	pdePoolPairStateType  = "pdepoolpair"
+	custodianStateType    = "custodian"
+	rewardStateType       = "reward"
+	serialNumberStateType = "serialnumber"
+)
+
+func (blockService BlockService) GetBeaconStateProof(height uint64, stateType string, data map[string]interface{}) (*jsonresult.GetStateProofResult, *RPCError) {
+	var trie string
+	switch stateType {
+	case committeeStateType:
+		trie = blockchain.ConsensusStateTrie
+	case pdePoolPairStateType, custodianStateType:
+		trie = blockchain.FeatureStateTrie
+	case rewardStateType:
+		trie = blockchain.RewardStateTrie
+	default:
+		return nil, NewRPCError(RPCInvalidParamsError, fmt.Errorf("state type %+v can not be proven on beacon", stateType))
+	}
+	key, err := getStateObjectKey(stateType, 0, data)
+	if err != nil {
+		return nil, NewRPCError(RPCInvalidParamsError, err)
+	}
+	stateProof, err := blockService.BlockChain.GetBeaconStateProof(height, trie, key)
+	if err != nil {
+		return nil, NewRPCError(GetStateProofError, err)
+	}
+	return jsonresult.NewGetStateProofResult(stateProof), nil
+}
+
+func (blockService BlockService) GetShardStateProof(shardID byte, height uint64, stateType string, data map[string]interface{}) (*jsonresult.GetStateProofResult, *RPCError) {
+	var trie string
+	switch stateType {
+	case tokenInfoStateType, serialNumberStateType:
+		trie = blockchain.TransactionStateTrie
+	case committeeStateType:
+		trie = blockchain.ConsensusStateTrie
+	case rewardStateType:
+		trie = blockchain.RewardStateTrie
+	default:
+		return nil, NewRPCError(RPCInvalidParamsError, fmt.Errorf("state type %+v can not be proven on shard", stateType))
+	}
+	key, err := getStateObjectKey(stateType, shardID, data)
+	if err != nil {
+		return nil, NewRPCError(RPCInvalidParamsError, err)
+	}
+	stateProof, err := blockService.BlockChain.GetShardStateProof(shardID, height, trie, key)
+	if err != nil {
+		return nil, NewRPCError(GetStateProofError, err)
+	}
+	return jsonresult.NewGetStateProofResult(stateProof), nil
+}
+
+// getStateObjectKey builds key of a state object from its params
+func getStateObjectKey(stateType string, shardID byte, data map[string]interface{}) (common.Hash, error) {
+	getString := func(name string) (string, error) {
+		value, ok := data[name].(string)
+		if !ok || value == "" {
+			return "", fmt.Errorf("%+v is invalid", name)
+		}
+		return value, nil
+	}
+	switch stateType {
+	case tokenInfoStateType:
+		tokenIDStr, err := getString("TokenID")
+		if err != nil {
+			return common.Hash{}, err
+		}
+		tokenID, err := common.Hash{}.NewHashFromStr(tokenIDStr)
+		if err != nil {
+			return common.Hash{}, err
+		}
+		return statedb.GenerateTokenObjectKey(*tokenID), nil
+	case committeeStateType:
+		role, ok := data["Role"].(float64)
+		if !ok {
+			return common.Hash{}, errors.New("Role is invalid")
+		}
+		committeeShardID, ok := data["CommitteeShardID"].(float64)
+		if !ok {
+			return common.Hash{}, errors.New("CommitteeShardID is invalid")
+		}
+		committeePublicKeyStr, err := getString("CommitteePublicKey")
+		if err != nil {
+			return common.Hash{}, err
+		}
+		committeePublicKey := incognitokey.CommitteePublicKey{}
+		if err := committeePublicKey.FromString(committeePublicKeyStr); err != nil {
+			return common.Hash{}, err
+		}
+		return statedb.GenerateCommitteeObjectKeyWithRole(int(role), int(committeeShardID), committeePublicKey)
+	case pdePoolPairStateType:
+		tokenID1, err := getString("TokenID1")
+		if err != nil {
+			return common.Hash{}, err
+		}
+		tokenID2, err := getString("TokenID2")
+		if err != nil {
+			return common.Hash{}, err
+		}
+		tokenIDs := []string{tokenID1, tokenID2}
+		sort.Strings(tokenIDs)
+		return statedb.GeneratePDEPoolPairObjectKey(tokenIDs[0], tokenIDs[1]), nil
+	case custodianStateType:
+		incognitoAddress, err := getString("IncognitoAddress")
+		if err != nil {
+			return common.Hash{}, err
+		}
+		return statedb.GenerateCustodianStateObjectKey(incognitoAddress), nil
+	case rewardStateType:
+		incognitoPublicKey, err := getString("IncognitoPublicKey")
+		if err != nil {
+			return common.Hash{}, err
+		}
+		return statedb.GenerateCommitteeRewardObjectKey(incognitoPublicKey)
+	case serialNumberStateType:
+		tokenIDStr, err := getString("TokenID")
+		if err != nil {
+			return common.Hash{}, err
+		}
+		tokenID, err := common.Hash{}.NewHashFromStr(tokenIDStr)
+		if err != nil {
+			return common.Hash{}, err
+		}
+		serialNumberStr, err := getString("SerialNumber")
+		if err != nil {
+			return common.Hash{}, err
+		}
+		serialNumber, _, err := base58.Base58Check{}.Decode(serialNumberStr)
+		if err != nil {
+			return common.Hash{}, err
+		}
+		return statedb.GenerateSerialNumberObjectKey(*tokenID, shardID, serialNumber), nil
+	}
+	return common.Hash{}, fmt.Errorf("state type %+v is not supported", stateType)
+}
+
 //============================= Portal ===============================
 func (blockService BlockService) GetCustodianDepositStatus(depositTxID string) (*metadata.PortalCustodianDepositStatus, error) {
 	stateDB := blockService.BlockChain.GetBeaconBestState().GetBeaconFeatureStateDB()
@@ -49,6 +49,7 @@ const (
 	NoSwapConfirmInst
 	GetKeySetFromPrivateKeyError
 	GetPDEStateError
+	GetPDEHistoryError
 	ListCommitteeRewardError
 	GetRewardAmountError
 	ListOutputCoinsByKeyError
@@ -56,6 +57,7 @@ const (
 	SendRawTransactionError
 	BuildTokenParamError
 	BuildPrivacyTokenParamError
+	OutCoinIndexError
 	GetListPrivacyCustomTokenBalanceError
 	GetPrivacyTokenError
 	// reject tx
@@ -99,6 +101,8 @@ const (
 	GetCustodianTopupStatusError
 	GetCustodianTopupWaitingPortingStatusError
 	GetAmountTopUpWaitingPortingError
+	GetPortalCustodianRisksError
+	GetPortalExchangeRatesFeedersError
 
 	// relaying
 	GetRelayingBNBHeaderByBlockHeightError
@@ -106,9 +110,14 @@ const (
 	GetBTCBlockByHash
 	GetRelayingBNBHeaderError
 	GetLatestBNBHeaderBlockHeightError
+	GetETHRelayingBestState
+	GetRelayingETHHeaderError
 
 	// feature reward
 	GetRewardFeatureByFeatureNameError
+
+	// state proof
+	GetStateProofError
 )
 
 // Standard JSON-RPC 2.0 errors.
@@ -167,6 +176,7 @@ var ErrCodeMessage = map[int]struct {
 	SendRawTransactionError:          {-4007, "Send Raw Transaction Error"},
 	BuildTokenParamError:             {-4008, "Build Token Param Error"},
 	BuildPrivacyTokenParamError:      {-4009, "Build Privacy Token Param Error"},
+	OutCoinIndexError:                {-4010, "Output Coin Index Error"},
 	// socket/subcribe -5xxx
 	SubcribeError:   {-5000, "Failed to subcribe"},
 	UnsubcribeError: {-5001, "Failed to unsubcribe"},
@@ -188,7 +198,8 @@ var ErrCodeMessage = map[int]struct {
 	NoSwapConfirmInst: {-7000, "No swap confirm instruction found in block"},
 
 	// pde
-	GetPDEStateError: {-8000, "Get pde state error"},
+	GetPDEStateError:   {-8000, "Get pde state error"},
+	GetPDEHistoryError: {-8001, "Get pde history error"},
 
 	//portal
 	GetFinalExchangeRatesError:                         {-9000, "Get get final exchange rates error"},
@@ -210,6 +221,8 @@ var ErrCodeMessage = map[int]struct {
 	GetCustodianTopupWaitingPortingStatusError:         {-9016, "Get custodian top up for waiting porting status error"},
 	GetAmountTopUpWaitingPortingError:                  {-9017, "Get amount top up for waiting porting error"},
 	GetReqRedeemFromLiquidationPoolStatusError:         {-9018, "Get redeem request form liquidation pool status error"},
+	GetPortalCustodianRisksError:                       {-9019, "Get portal custodian risks error"},
+	GetPortalExchangeRatesFeedersError:                 {-9020, "Get portal exchange rates feeders error"},
 
 	// relaying
 	GetRelayingBNBHeaderByBlockHeightError: {-10001, "Get relaying bnb header by block height error"},
@@ -217,9 +230,14 @@ var ErrCodeMessage = map[int]struct {
 	GetBTCRelayingBestState:                {-10003, "Get BTC relaying best state error"},
 	GetLatestBNBHeaderBlockHeightError:     {-10004, "Get latest bnb header block height error"},
 	GetBTCBlockByHash:                      {-10005, "Get BTC block by hash error"},
+	GetETHRelayingBestState:                {-10006, "Get ETH relaying best state error"},
+	GetRelayingETHHeaderError:              {-10007, "Get relaying eth header error"},
 
 	// feature reward
 	GetRewardFeatureByFeatureNameError: {-11001, "Get feature reward by feature name error"},
+
+	// state proof
+	GetStateProofError: {-12001, "Get state proof error"},
 }
 
 // RPCError represents an error that is used as a part of a JSON-RPC JsonResponse
@@ -2,10 +2,15 @@ package rpcservice
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"math/big"
+	"sort"
 )
 
 type PortalService struct {
@@ -101,6 +106,46 @@ func (portal *PortalService) GetFinalExchangeRates(stateDB *statedb.StateDB, bea
 	return result, nil
 }
 
+// GetExchangeRatesFeeders returns the submission history of the allowed feeders and of the feeders which submitted rates before,
+// sorted by feeder address
+func (portal *PortalService) GetExchangeRatesFeeders(
+	stateDB *statedb.StateDB,
+	feederAddresses []string,
+	beaconHeight uint64,
+	portalParam blockchain.PortalParams,
+) []jsonresult.PortalExchangeRatesFeeder {
+	feeders := statedb.GetExchangeRatesFeeders(stateDB)
+	for _, feederAddress := range feederAddresses {
+		if _, ok := feeders[feederAddress]; !ok {
+			feeders[feederAddress] = statedb.NewExchangeRatesFeederStateWithValue(feederAddress, nil, 0, 0, 0)
+		}
+	}
+
+	result := make([]jsonresult.PortalExchangeRatesFeeder, 0, len(feeders))
+	for feederAddress, feeder := range feeders {
+		lastRates := make(map[string]jsonresult.PortalExchangeRatesFeederRate)
+		for tokenID, rate := range feeder.LastRates() {
+			lastRates[tokenID] = jsonresult.PortalExchangeRatesFeederRate{
+				Rate:         rate.Rate,
+				BeaconHeight: rate.BeaconHeight,
+			}
+		}
+		result = append(result, jsonresult.PortalExchangeRatesFeeder{
+			FeederAddress:             feederAddress,
+			IsAllowed:                 common.IndexOfStr(feederAddress, feederAddresses) > -1,
+			IsLive:                    feeder.AcceptedSubmissions()+feeder.RejectedSubmissions() > 0 && blockchain.IsInExchangeRatesWindow(feeder.LastSubmittedBeaconHeight(), beaconHeight, portalParam.ExchangeRatesAggregationWindow),
+			LastSubmittedBeaconHeight: feeder.LastSubmittedBeaconHeight(),
+			AcceptedSubmissions:       feeder.AcceptedSubmissions(),
+			RejectedSubmissions:       feeder.RejectedSubmissions(),
+			LastRates:                 lastRates,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].FeederAddress < result[j].FeederAddress
+	})
+	return result
+}
+
 func (portal *PortalService) ConvertExchangeRates(stateDB *statedb.StateDB, tokenID string, valuePToken uint64) (map[string]uint64, error) {
 	result := make(map[string]uint64)
 	finalExchangeRates, err := statedb.GetFinalExchangeRatesState(stateDB)
@@ -245,3 +290,150 @@ func (portal *PortalService) GetLiquidateExchangeRatesPool(
 	}
 	return result, nil
 }
+
+const (
+	CustodianRiskSafeStatus  = "Safe"
+	CustodianRiskTP130Status = "TP130"
+	CustodianRiskTP120Status = "TP120"
+)
+
+// GetCustodianRisks evaluates the collateral ratios of custodians against the liquidation thresholds,
+// customExchangeRates replace the final exchange rates of their tokens to forecast liquidations.
+// All custodians are returned if custodianAddress is empty.
+func (portal *PortalService) GetCustodianRisks(
+	stateDB *statedb.StateDB,
+	custodianAddress string,
+	customExchangeRates map[string]uint64,
+	portalParam blockchain.PortalParams,
+) ([]jsonresult.PortalCustodianRisk, error) {
+	currentPortalState, err := blockchain.InitCurrentPortalStateFromDB(stateDB)
+	if err != nil {
+		return nil, err
+	}
+	if currentPortalState.FinalExchangeRatesState == nil {
+		return nil, errors.New("final exchange rates are empty")
+	}
+
+	rates := make(map[string]statedb.FinalExchangeRatesDetail)
+	for tokenID, detail := range currentPortalState.FinalExchangeRatesState.Rates() {
+		rates[tokenID] = detail
+	}
+	for tokenID, amount := range customExchangeRates {
+		rates[tokenID] = statedb.FinalExchangeRatesDetail{Amount: amount}
+	}
+	exchangeRates := statedb.NewFinalExchangeRatesStateWithValue(rates)
+
+	custodians := make([]*statedb.CustodianState, 0)
+	for _, custodianState := range currentPortalState.CustodianPoolState {
+		if custodianAddress != "" && custodianState.GetIncognitoAddress() != custodianAddress {
+			continue
+		}
+		custodians = append(custodians, custodianState)
+	}
+	if custodianAddress != "" && len(custodians) == 0 {
+		return nil, fmt.Errorf("custodian %v not found", custodianAddress)
+	}
+	sort.Slice(custodians, func(i, j int) bool {
+		return custodians[i].GetIncognitoAddress() < custodians[j].GetIncognitoAddress()
+	})
+
+	result := make([]jsonresult.PortalCustodianRisk, 0, len(custodians))
+	for _, custodianState := range custodians {
+		collateralRatios, err := blockchain.CalCustodianCollateralRatios(currentPortalState, custodianState, exchangeRates)
+		if err != nil {
+			return nil, err
+		}
+		tokenRisks := make(map[string]jsonresult.PortalCustodianTokenRisk)
+		for tokenID, ratio := range collateralRatios {
+			tokenRisks[tokenID] = buildCustodianTokenRisk(ratio, portalParam)
+		}
+		result = append(result, jsonresult.PortalCustodianRisk{
+			IncognitoAddress:       custodianState.GetIncognitoAddress(),
+			FreeCollateral:         custodianState.GetFreeCollateral(),
+			TokenRisks:             tokenRisks,
+			WaitingPortingRequests: getCustodianWaitingPortingRequests(currentPortalState, custodianState.GetIncognitoAddress()),
+			RedeemRequests:         getCustodianRedeemRequests(currentPortalState, custodianState.GetIncognitoAddress()),
+		})
+	}
+	return result, nil
+}
+
+func buildCustodianTokenRisk(ratio *blockchain.CustodianCollateralRatio, portalParam blockchain.PortalParams) jsonresult.PortalCustodianTokenRisk {
+	status := CustodianRiskSafeStatus
+	if ratio.Percent <= portalParam.TP120 {
+		status = CustodianRiskTP120Status
+	} else if ratio.Percent <= portalParam.TP130 {
+		status = CustodianRiskTP130Status
+	}
+	return jsonresult.PortalCustodianTokenRisk{
+		LockedCollateral:     ratio.LockedCollateral,
+		HoldingPubToken:      ratio.HoldingPubToken,
+		HoldingPubTokenInPRV: ratio.HoldingPubTokenInPRV,
+		CollateralRatio:      ratio.Percent,
+		DistanceToTP130:      int64(ratio.Percent) - int64(portalParam.TP130),
+		DistanceToTP120:      int64(ratio.Percent) - int64(portalParam.TP120),
+		PriceIncreaseToTP130: calPriceIncreaseToTP(ratio.Percent, portalParam.TP130),
+		PriceIncreaseToTP120: calPriceIncreaseToTP(ratio.Percent, portalParam.TP120),
+		LiquidationStatus:    status,
+	}
+}
+
+// calPriceIncreaseToTP returns the rise in percent of the ptoken price which brings the collateral ratio down to tp,
+// the ratio is inversely proportional to the ptoken price
+func calPriceIncreaseToTP(percent uint64, tp uint64) uint64 {
+	if tp == 0 || percent <= tp {
+		return 0
+	}
+	// ceil(percent * 100 / tp) - 100
+	tmp := new(big.Int).Mul(new(big.Int).SetUint64(percent), big.NewInt(100))
+	tmp.Add(tmp, new(big.Int).SetUint64(tp-1))
+	return new(big.Int).Div(tmp, new(big.Int).SetUint64(tp)).Uint64() - 100
+}
+
+func getCustodianWaitingPortingRequests(portalState *blockchain.CurrentPortalState, custodianAddress string) []jsonresult.PortalCustodianPortingRequest {
+	result := make([]jsonresult.PortalCustodianPortingRequest, 0)
+	for _, waitingPortingReq := range portalState.WaitingPortingRequests {
+		for _, cus := range waitingPortingReq.Custodians() {
+			if cus.IncAddress != custodianAddress {
+				continue
+			}
+			result = append(result, jsonresult.PortalCustodianPortingRequest{
+				PortingID:        waitingPortingReq.UniquePortingID(),
+				TokenID:          waitingPortingReq.TokenID(),
+				Amount:           cus.Amount,
+				LockedCollateral: cus.LockedAmountCollateral,
+			})
+			break
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].PortingID < result[j].PortingID
+	})
+	return result
+}
+
+func getCustodianRedeemRequests(portalState *blockchain.CurrentPortalState, custodianAddress string) []jsonresult.PortalCustodianRedeemRequest {
+	result := make([]jsonresult.PortalCustodianRedeemRequest, 0)
+	appendRedeemRequests := func(redeemReqs map[string]*statedb.RedeemRequest, status byte) {
+		for _, redeemReq := range redeemReqs {
+			for _, cus := range redeemReq.GetCustodians() {
+				if cus.GetIncognitoAddress() != custodianAddress {
+					continue
+				}
+				result = append(result, jsonresult.PortalCustodianRedeemRequest{
+					RedeemID: redeemReq.GetUniqueRedeemID(),
+					TokenID:  redeemReq.GetTokenID(),
+					Amount:   cus.GetAmount(),
+					Status:   status,
+				})
+				break
+			}
+		}
+	}
+	appendRedeemRequests(portalState.WaitingRedeemRequests, common.PortalRedeemReqWaitingStatus)
+	appendRedeemRequests(portalState.MatchedRedeemRequests, common.PortalRedeemReqMatchedStatus)
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].RedeemID < result[j].RedeemID
+	})
+	return result
+}
@@ -0,0 +1,32 @@
+package rpcservice
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildCustodianTokenRisk(t *testing.T) {
+	portalParam := blockchain.PortalParams{TP120: 120, TP130: 130}
+	tests := []struct {
+		percent              uint64
+		status               string
+		priceIncreaseToTP130 uint64
+		priceIncreaseToTP120 uint64
+	}{
+		{percent: 150, status: CustodianRiskSafeStatus, priceIncreaseToTP130: 16, priceIncreaseToTP120: 25},
+		{percent: 131, status: CustodianRiskSafeStatus, priceIncreaseToTP130: 1, priceIncreaseToTP120: 10},
+		{percent: 130, status: CustodianRiskTP130Status, priceIncreaseToTP130: 0, priceIncreaseToTP120: 9},
+		{percent: 120, status: CustodianRiskTP120Status, priceIncreaseToTP130: 0, priceIncreaseToTP120: 0},
+		{percent: 100, status: CustodianRiskTP120Status, priceIncreaseToTP130: 0, priceIncreaseToTP120: 0},
+	}
+	for _, tc := range tests {
+		risk := buildCustodianTokenRisk(&blockchain.CustodianCollateralRatio{Percent: tc.percent}, portalParam)
+		assert.Equal(t, tc.status, risk.LiquidationStatus, "percent %v", tc.percent)
+		assert.Equal(t, int64(tc.percent)-130, risk.DistanceToTP130)
+		assert.Equal(t, int64(tc.percent)-120, risk.DistanceToTP120)
+		assert.Equal(t, tc.priceIncreaseToTP130, risk.PriceIncreaseToTP130, "percent %v", tc.percent)
+		assert.Equal(t, tc.priceIncreaseToTP120, risk.PriceIncreaseToTP120, "percent %v", tc.percent)
+	}
+}
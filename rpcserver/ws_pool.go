@@ -1,42 +1,53 @@
 package rpcserver
 
+import (
+	"errors"
+	"reflect"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/mempool"
+	"github.com/incognitochain/incognito-chain/pubsub"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+)
+
 func (wsServer *WsServer) handleSubcribeMempoolInfo(params interface{}, subcription string, cResult chan RpcSubResult, closeChan <-chan struct{}) {
-	// Logger.log.Info("Handle Subcribe Mempool Informantion", params, subcription)
-	// arrayParams := common.InterfaceSlice(params)
-	// if len(arrayParams) != 0 {
-	// 	err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Methods should only contain NO params"))
-	// 	cResult <- RpcSubResult{Error: err}
-	// 	return
-	// }
-	// subId, subChan, err := wsServer.config.PubSubManager.RegisterNewSubscriber(pubsub.MempoolInfoTopic)
-	// if err != nil {
-	// 	err := rpcservice.NewRPCError(rpcservice.SubcribeError, err)
-	// 	cResult <- RpcSubResult{Error: err}
-	// 	return
-	// }
-	// defer func() {
-	// 	Logger.log.Info("Finish Subcribe Mempool Informantion")
-	// 	wsServer.config.PubSubManager.Unsubscribe(pubsub.MempoolInfoTopic, subId)
-	// 	close(cResult)
-	// }()
-	// for {
-	// 	select {
-	// 	case msg := <-subChan:
-	// 		{
-	// 			listTxs, ok := msg.Value.([]string)
-	// 			if !ok {
-	// 				Logger.log.Errorf("Wrong Message Type from Pubsub Manager, wanted []string, have %+v", reflect.TypeOf(msg.Value))
-	// 				continue
-	// 			}
-	// 			cResult <- RpcSubResult{Result: listTxs, Error: nil}
-	// 		}
-	// 	case <-closeChan:
-	// 		{
-	// 			cResult <- RpcSubResult{Result: jsonresult.UnsubcribeResult{Message: "Unsubscribe Mempool Info"}}
-	// 			return
-	// 		}
-	// 	}
-	// }
+	Logger.log.Info("Handle Subcribe Mempool Informantion", params, subcription)
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) != 0 {
+		err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Methods should only contain NO params"))
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	subId, subChan, err := wsServer.config.PubSubManager.RegisterNewSubscriber(pubsub.MempoolInfoTopic)
+	if err != nil {
+		err := rpcservice.NewRPCError(rpcservice.SubcribeError, err)
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	defer func() {
+		Logger.log.Info("Finish Subcribe Mempool Informantion")
+		wsServer.config.PubSubManager.Unsubscribe(pubsub.MempoolInfoTopic, subId)
+		close(cResult)
+	}()
+	for {
+		select {
+		case msg := <-subChan:
+			{
+				mempoolInfo, ok := msg.Value.(*mempool.MempoolInfo)
+				if !ok {
+					Logger.log.Errorf("Wrong Message Type from Pubsub Manager, wanted *mempool.MempoolInfo, have %+v", reflect.TypeOf(msg.Value))
+					continue
+				}
+				cResult <- RpcSubResult{Result: mempoolInfo, Error: nil}
+			}
+		case <-closeChan:
+			{
+				cResult <- RpcSubResult{Result: jsonresult.UnsubcribeResult{Message: "Unsubscribe Mempool Info"}}
+				return
+			}
+		}
+	}
 }
 
 func (wsServer *WsServer) handleSubscribeBeaconPoolBestState(params interface{}, subcription string, cResult chan RpcSubResult, closeChan <-chan struct{}) {
@@ -46,6 +46,7 @@ import (
 	"github.com/incognitochain/incognito-chain/peer"
 	"github.com/incognitochain/incognito-chain/pubsub"
 	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
+	ethrelaying "github.com/incognitochain/incognito-chain/relaying/eth"
 	"github.com/incognitochain/incognito-chain/rpcserver"
 	"github.com/incognitochain/incognito-chain/transaction"
 	"github.com/incognitochain/incognito-chain/wallet"
@@ -78,6 +79,7 @@ type Server struct {
 	// userKeySet        *incognitokey.KeySet
 	miningKeys      string
 	privateKey      string
+	remoteSigner    string
 	wallet          *wallet.Wallet
 	consensusEngine *consensus.Engine
 	blockgen        *blockchain.BlockGenerator
@@ -204,6 +206,7 @@ func (serverObj *Server) NewServer(
 	protocolVer string,
 	btcChain *btcrelaying.BlockChain,
 	bnbChainState *bnbrelaying.BNBChainState,
+	ethSealVerifier ethrelaying.SealVerifier,
 	interrupt <-chan struct{},
 ) error {
 	// Init data for Server
@@ -225,7 +228,8 @@ func (serverObj *Server) NewServer(
 
 	serverObj.miningKeys = cfg.MiningKeys
 	serverObj.privateKey = cfg.PrivateKey
-	if serverObj.miningKeys == "" && serverObj.privateKey == "" {
+	serverObj.remoteSigner = cfg.RemoteSigner
+	if serverObj.miningKeys == "" && serverObj.privateKey == "" && serverObj.remoteSigner == "" {
 		if cfg.NodeMode == common.NodeModeAuto || cfg.NodeMode == common.NodeModeBeacon || cfg.NodeMode == common.NodeModeShard {
 			panic("miningkeys can't be empty in this node mode")
 		}
@@ -314,14 +318,17 @@ func (serverObj *Server) NewServer(
 		dispatcher,
 		cfg.NodeMode,
 		relayShards,
+		cfg.WireCodec,
+		cfg.NumHighways,
 	)
 
 	err = serverObj.blockChain.Init(&blockchain.Config{
-		BTCChain:      btcChain,
-		BNBChainState: bnbChainState,
-		ChainParams:   serverObj.chainParams,
-		DataBase:      serverObj.dataBase,
-		MemCache:      serverObj.memCache,
+		BTCChain:        btcChain,
+		BNBChainState:   bnbChainState,
+		ETHSealVerifier: ethSealVerifier,
+		ChainParams:     serverObj.chainParams,
+		DataBase:        serverObj.dataBase,
+		MemCache:        serverObj.memCache,
 		//MemCache:          nil,
 		BlockGen:    serverObj.blockgen,
 		Interrupt:   interrupt,
@@ -336,6 +343,10 @@ func (serverObj *Server) NewServer(
 		ConsensusEngine: serverObj.consensusEngine,
 		Highway:         serverObj.highway,
 		GenesisParams:   blockchain.GenesisParam,
+		StateDBMode:     cfg.StateDBMode,
+		PruneKeepViews:  cfg.PruneKeepViews,
+		PDEHistory:      cfg.PDEHistory,
+		OutCoinIndex:    cfg.OutCoinIndex,
 	})
 	if err != nil {
 		return err
@@ -402,6 +413,11 @@ func (serverObj *Server) NewServer(
 		serverObj.blockChain.SetFeeEstimator(feeEstimator, shardID)
 	}
 
+	// proofs verified when txs enter mempool are not verified again by the temp pool validating new blocks
+	proofCache, err := mempool.NewProofCache(cfg.TxPoolProofCacheSize)
+	if err != nil {
+		return err
+	}
 	serverObj.memPool.Init(&mempool.Config{
 		BlockChain:        serverObj.blockChain,
 		DataBase:          serverObj.dataBase,
@@ -409,10 +425,14 @@ func (serverObj *Server) NewServer(
 		FeeEstimator:      serverObj.feeEstimator,
 		TxLifeTime:        cfg.TxPoolTTL,
 		MaxTx:             cfg.TxPoolMaxTx,
+		MaxTxPerSender:    cfg.TxPoolMaxTxPerSender,
+		MaxTxPerMetaType:  cfg.TxPoolMaxTxPerMetaType,
 		DataBaseMempool:   dbmp,
 		IsLoadFromMempool: cfg.LoadMempool,
 		PersistMempool:    cfg.PersistMempool,
 		RelayShards:       relayShards,
+		ProofCache:        proofCache,
+		MaxProofWorkers:   cfg.TxPoolProofWorkers,
 		// UserKeyset:        serverObj.userKeySet,
 		PubSubManager: serverObj.pusubManager,
 	})
@@ -429,6 +449,7 @@ func (serverObj *Server) NewServer(
 		FeeEstimator:  serverObj.feeEstimator,
 		MaxTx:         cfg.TxPoolMaxTx,
 		PubSubManager: pubsubManager,
+		ProofCache:    proofCache,
 	})
 	go serverObj.tempMemPool.Start(serverObj.cQuit)
 	serverObj.blockChain.AddTempTxPool(serverObj.tempMemPool)
@@ -489,7 +510,7 @@ func (serverObj *Server) NewServer(
 
 	serverObj.connManager = connManager
 	serverObj.consensusEngine.Init(&consensus.EngineConfig{Node: serverObj, Blockchain: serverObj.blockChain, PubSubManager: serverObj.pusubManager})
-	serverObj.syncker.Init(&syncker.SynckerManagerConfig{Node: serverObj, Blockchain: serverObj.blockChain})
+	serverObj.syncker.Init(&syncker.SynckerManagerConfig{Node: serverObj, Blockchain: serverObj.blockChain, FastSync: cfg.FastSync})
 
 	// Start up persistent peers.
 	permanentPeers := cfg.ConnectPeers
@@ -734,6 +755,9 @@ func (serverObj Server) Start() {
 	//go serverObj.blockChain.Synker.Start()
 	go serverObj.syncker.Start()
 	go serverObj.blockgen.Start(serverObj.cQuit)
+	if cfg.StateDBMode == common.StateDBModePrune {
+		go serverObj.blockChain.StartStatePruning(serverObj.cQuit)
+	}
 
 	if serverObj.memPool != nil {
 		err := serverObj.memPool.LoadOrResetDatabaseMempool()
@@ -1297,7 +1321,7 @@ func (serverObj *Server) GetPeerIDsFromPublicKey(pubKey string) []libp2p.ID {
 }
 
 func (serverObj *Server) GetNodeRole() string {
-	if serverObj.miningKeys == "" && serverObj.privateKey == "" {
+	if serverObj.miningKeys == "" && serverObj.privateKey == "" && serverObj.remoteSigner == "" {
 		return ""
 	}
 	if cfg.NodeMode == "relay" {
@@ -1854,6 +1878,10 @@ func (serverObj *Server) GetPrivateKey() string {
 	return serverObj.privateKey
 }
 
+func (serverObj *Server) GetRemoteSigner() string {
+	return serverObj.remoteSigner
+}
+
 func (serverObj *Server) PushMessageToChain(msg wire.Message, chain common.ChainInterface) error {
 	chainID := chain.GetShardID()
 	if chainID == -1 {
@@ -2194,6 +2222,15 @@ func (serverObj *Server) RequestShardToBeaconBlocksByHashViaStream(ctx context.C
 	return serverObj.requestBlocksByHashViaStream(ctx, peerID, req)
 }
 
+func (serverObj *Server) RequestShardFastSyncView(ctx context.Context, peerID string, sid int) ([]byte, error) {
+	Logger.log.Infof("[FastSync] request view of shard %v from peer %v", sid, peerID)
+	return serverObj.highway.Requester.GetShardView(ctx, int32(sid), peerID)
+}
+
+func (serverObj *Server) RequestShardStateNodes(ctx context.Context, peerID string, sid int, hashes [][]byte) ([][]byte, error) {
+	return serverObj.highway.Requester.GetStateNodes(ctx, int32(sid), hashes, peerID)
+}
+
 func (serverObj *Server) requestBlocksViaStream(ctx context.Context, peerID string, req *proto.BlockByHeightRequest) (blockCh chan common.BlockInterface, err error) {
 	Logger.log.Infof("[stream] Request Block type %v from peer %v from cID %v, [%v %v] ", req.Type, peerID, req.GetFrom(), req.Heights[0], req.Heights[len(req.Heights)-1])
 	blockCh = make(chan common.BlockInterface, blockchain.DefaultMaxBlkReqPerPeer)
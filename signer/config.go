@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jessevdk/go-flags"
+)
+
+// See loadConfig for details on the configuration load process.
+type config struct {
+	SocketPath string `long:"socket" short:"s" description:"Unix socket listened for the node"`
+	DataDir    string `long:"datadir" short:"D" description:"Directory to store anti double sign record"`
+	MiningKey  string `long:"miningkey" description:"Private seed of bls mining key"`
+	PrivateKey string `long:"privatekey" description:"Wallet private key, the mining key is generated from it"`
+}
+
+// newConfigParser returns a new command line flags parser.
+func newConfigParser(cfg *config, options flags.Options) *flags.Parser {
+	parser := flags.NewParser(cfg, options)
+	return parser
+}
+
+// loadConfig
+// - set default config
+// - read config from cmd line params
+// - return config object
+func loadConfig() (*config, error) {
+	// create config object from default values
+	cfg := config{
+		SocketPath: defaultSocketPath,
+		DataDir:    defaultDataDir,
+	}
+
+	preParser := newConfigParser(&cfg, flags.HelpFlag)
+	_, err := preParser.Parse()
+	if err != nil {
+		if e, ok := err.(*flags.Error); ok && e.Type == flags.ErrHelp {
+			fmt.Fprintln(os.Stderr, err)
+			return nil, err
+		}
+	}
+	if cfg.MiningKey == "" && cfg.PrivateKey == "" {
+		return nil, fmt.Errorf("miningkey or privatekey is required")
+	}
+
+	return &cfg, nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"log"
+	"net"
+	"os"
+
+	"github.com/incognitochain/incognito-chain/consensus/blsbftv2"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+)
+
+// Signer keeps the mining key of a validator on a host separated from the P2P-facing node,
+// the node connects to its unix socket (--remotesigner) to sign proposals and votes.
+// Every signed proposal and vote is recorded so the key never signs two conflicting blocks.
+func main() {
+	log.Printf("Version %s\n", version)
+
+	cfg, err := loadConfig()
+	if err != nil {
+		log.Println("Parse config error", err.Error())
+		return
+	}
+
+	miningKeySeed := cfg.MiningKey
+	if cfg.PrivateKey != "" {
+		miningKeySeed, err = blsbftv2.LoadUserKeyFromIncPrivateKey(cfg.PrivateKey)
+		if err != nil {
+			log.Fatal("Load private key error: ", err)
+		}
+	}
+	miningKey, err := blsbftv2.GetMiningKeyFromPrivateSeed(miningKeySeed)
+	if err != nil {
+		log.Fatal("Load mining key error: ", err)
+	}
+
+	db, err := incdb.Open("leveldb", cfg.DataDir)
+	if err != nil {
+		log.Fatal("Open database error: ", err)
+	}
+	defer db.Close()
+
+	signerServer, err := blsbftv2.NewSignerServer(miningKey, db)
+	if err != nil {
+		log.Fatal("Init signer error: ", err)
+	}
+
+	// remove the socket left by previous run
+	os.Remove(cfg.SocketPath)
+	listener, err := net.Listen("unix", cfg.SocketPath)
+	if err != nil {
+		log.Fatal("Listen error: ", err)
+	}
+	defer listener.Close()
+	if err := os.Chmod(cfg.SocketPath, 0600); err != nil {
+		log.Fatal("Set socket permission error: ", err)
+	}
+
+	log.Printf("Signer of %v listens on %v\n", miningKey.GetPublicKeyBase58(), cfg.SocketPath)
+	if err := signerServer.Serve(listener); err != nil {
+		log.Fatal("Serve error: ", err)
+	}
+}
@@ -28,6 +28,9 @@ type Server interface {
 
 	RequestBeaconBlocksByHashViaStream(ctx context.Context, peerID string, hashes [][]byte) (blockCh chan common.BlockInterface, err error)
 	RequestShardBlocksByHashViaStream(ctx context.Context, peerID string, fromSID int, hashes [][]byte) (blockCh chan common.BlockInterface, err error)
+	//fast sync
+	RequestShardFastSyncView(ctx context.Context, peerID string, sid int) ([]byte, error)
+	RequestShardStateNodes(ctx context.Context, peerID string, sid int, hashes [][]byte) ([][]byte, error)
 	//database
 	FetchConfirmBeaconBlockByHeight(height uint64) (*blockchain.BeaconBlock, error)
 	GetBeaconChainDatabase() incdb.Database
@@ -45,6 +48,7 @@ type BeaconChainInterface interface {
 type ShardChainInterface interface {
 	Chain
 	GetCrossShardState() map[byte]uint64
+	NewFastSync(viewData []byte) (*blockchain.ShardFastSync, error)
 }
 
 type Chain interface {
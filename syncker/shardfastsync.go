@@ -0,0 +1,143 @@
+package syncker
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+)
+
+const (
+	// shard is fast synced only when a peer is at least that many blocks ahead
+	fastSyncMinDistance = 1000
+	// number of blocks before the view requested at once to rebuild it
+	fastSyncBlocksPerRequest = 100
+)
+
+// fast sync shard from the first peer far enough ahead, return true if chain jumped to the view of this peer
+func (s *ShardSyncProcess) fastSyncFromPeers() bool {
+	for peerID, pState := range s.getShardPeerStates() {
+		if pState.BestViewHeight < s.Chain.GetBestViewHeight()+fastSyncMinDistance {
+			continue
+		}
+		if err := s.fastSyncFromPeer(peerID); err != nil {
+			Logger.Errorf("Syncker fast sync shard %v from peer %v fail: %v", s.shardID, peerID, err)
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// download the final view of peer and its state tries, views are then synced from it as usual
+func (s *ShardSyncProcess) fastSyncFromPeer(peerID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	viewData, err := s.Server.RequestShardFastSyncView(ctx, peerID, s.shardID)
+	cancel()
+	if err != nil {
+		return err
+	}
+	fastSync, err := s.Chain.NewFastSync(viewData)
+	if err != nil {
+		return err
+	}
+	nextBlock, err := s.requestShardBlock(peerID, fastSync.Height()+1)
+	if err != nil {
+		return err
+	}
+	if err := fastSync.Start(nextBlock); err != nil {
+		return err
+	}
+	defer fastSync.Abort()
+
+	Logger.Infof("Syncker fast sync shard %v from peer %v, height %v", s.shardID, peerID, fastSync.Height())
+	if err := s.rebuildFastSyncView(peerID, fastSync); err != nil {
+		return err
+	}
+	for fastSync.Pending() > 0 {
+		if s.status != RUNNING_SYNC {
+			return errors.New("sync process is stopped")
+		}
+		hashes := fastSync.Missing(blockchain.MaxStateNodesPerRequest)
+		req := make([][]byte, len(hashes))
+		for i := range hashes {
+			req[i] = hashes[i].GetBytes()
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		data, err := s.Server.RequestShardStateNodes(ctx, peerID, s.shardID, req)
+		cancel()
+		if err != nil {
+			return err
+		}
+		processed, err := fastSync.Process(hashes, data)
+		if err != nil {
+			return err
+		}
+		if processed == 0 {
+			return errors.New("peer does not serve missing state nodes")
+		}
+		Logger.Infof("Syncker fast sync shard %v, receive %v state nodes, %v pending", s.shardID, processed, fastSync.Pending())
+	}
+	return fastSync.Finish()
+}
+
+// feed fast sync with the blocks before its view, newest first, until the view is rebuilt
+func (s *ShardSyncProcess) rebuildFastSyncView(peerID string, fastSync *blockchain.ShardFastSync) error {
+	for to := fastSync.PreviousHeight(); to > 0; to = fastSync.PreviousHeight() {
+		if s.status != RUNNING_SYNC {
+			return errors.New("sync process is stopped")
+		}
+		from := uint64(1)
+		if to > fastSyncBlocksPerRequest {
+			from = to - fastSyncBlocksPerRequest + 1
+		}
+		blocks, err := s.requestShardBlocks(peerID, from, to)
+		if err != nil {
+			return err
+		}
+		for i := len(blocks) - 1; i >= 0 && fastSync.PreviousHeight() > 0; i-- {
+			if blocks[i].Header.Height != fastSync.PreviousHeight() {
+				continue
+			}
+			if err := fastSync.AddPreviousBlock(blocks[i]); err != nil {
+				return err
+			}
+		}
+		if fastSync.PreviousHeight() == to {
+			return errors.New("peer does not serve blocks before the view")
+		}
+	}
+	return nil
+}
+
+func (s *ShardSyncProcess) requestShardBlocks(peerID string, from, to uint64) ([]*blockchain.ShardBlock, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	ch, err := s.Server.RequestShardBlocksViaStream(ctx, peerID, s.shardID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	blocks := []*blockchain.ShardBlock{}
+	for blk := range ch {
+		if isNil(blk) {
+			break
+		}
+		blocks = append(blocks, blk.(*blockchain.ShardBlock))
+	}
+	return blocks, nil
+}
+
+func (s *ShardSyncProcess) requestShardBlock(peerID string, height uint64) (*blockchain.ShardBlock, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	ch, err := s.Server.RequestShardBlocksViaStream(ctx, peerID, s.shardID, height, height)
+	if err != nil {
+		return nil, err
+	}
+	blk := <-ch
+	if isNil(blk) {
+		return nil, errors.New("peer does not return block")
+	}
+	return blk.(*blockchain.ShardBlock), nil
+}
@@ -23,6 +23,7 @@ type ShardPeerState struct {
 type ShardSyncProcess struct {
 	isCommittee           bool
 	isCatchUp             bool
+	fastSync              bool
 	shardID               int
 	status                string                    //stop, running
 	shardPeerState        map[string]ShardPeerState //peerid -> state
@@ -36,7 +37,7 @@ type ShardSyncProcess struct {
 	lock                  *sync.RWMutex
 }
 
-func NewShardSyncProcess(shardID int, server Server, beaconChain BeaconChainInterface, chain ShardChainInterface) *ShardSyncProcess {
+func NewShardSyncProcess(shardID int, server Server, beaconChain BeaconChainInterface, chain ShardChainInterface, fastSync bool) *ShardSyncProcess {
 	var isOutdatedBlock = func(blk interface{}) bool {
 		if blk.(*blockchain.ShardBlock).GetHeight() < chain.GetFinalViewHeight() {
 			return true
@@ -46,6 +47,7 @@ func NewShardSyncProcess(shardID int, server Server, beaconChain BeaconChainInte
 
 	s := &ShardSyncProcess{
 		shardID:          shardID,
+		fastSync:         fastSync,
 		status:           STOP_SYNC,
 		Server:           server,
 		Chain:            chain,
@@ -172,6 +174,10 @@ func (s *ShardSyncProcess) syncShardProcess() {
 			continue
 		}
 
+		if s.fastSync && s.fastSyncFromPeers() {
+			continue
+		}
+
 		for peerID, pState := range s.getShardPeerStates() {
 			requestCnt += s.streamFromPeer(peerID, pState)
 		}
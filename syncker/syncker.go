@@ -18,6 +18,7 @@ import (
 type SynckerManagerConfig struct {
 	Node       Server
 	Blockchain *blockchain.BlockChain
+	FastSync   bool // download the state of a finalized view instead of replaying shard blocks when far behind
 }
 
 type SynckerManager struct {
@@ -54,7 +55,7 @@ func (synckerManager *SynckerManager) Init(config *SynckerManagerConfig) {
 	//init shard sync process
 	for _, chain := range synckerManager.config.Blockchain.ShardChain {
 		sid := chain.GetShardID()
-		synckerManager.ShardSyncProcess[sid] = NewShardSyncProcess(sid, synckerManager.config.Node, synckerManager.config.Blockchain.BeaconChain, chain)
+		synckerManager.ShardSyncProcess[sid] = NewShardSyncProcess(sid, synckerManager.config.Node, synckerManager.config.Blockchain.BeaconChain, chain, synckerManager.config.FastSync)
 		synckerManager.shardPool[sid] = synckerManager.ShardSyncProcess[sid].shardPool
 		synckerManager.CrossShardSyncProcess[sid] = synckerManager.ShardSyncProcess[sid].crossShardSyncProcess
 		synckerManager.crossShardPool[sid] = synckerManager.CrossShardSyncProcess[sid].crossShardPool
@@ -2,14 +2,17 @@ package transaction
 
 import (
 	"errors"
-	"fmt"
+	"runtime"
 
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/metadata"
-	"github.com/incognitochain/incognito-chain/privacy/zeroknowledge/aggregaterange"
+	"github.com/incognitochain/incognito-chain/privacy/zeroknowledge"
 )
 
+// BatchVerificationWorkers is the number of goroutines verifying the signatures and proofs of a batch
+var BatchVerificationWorkers = runtime.NumCPU()
+
 type batchTransaction struct {
 	txs []metadata.Transaction
 }
@@ -26,17 +29,19 @@ func (b *batchTransaction) Validate(transactionStateDB *statedb.StateDB, bridgeS
 	return b.validateBatchTxsByItself(b.txs, transactionStateDB, bridgeStateDB)
 }
 
+// validateBatchTxsByItself validates txs one by one except their signatures and proofs, those of all txs are
+// verified together at the end. When the batch fails, txs are verified one by one to find the invalid one.
 func (b *batchTransaction) validateBatchTxsByItself(txList []metadata.Transaction, transactionStateDB *statedb.StateDB, bridgeStateDB *statedb.StateDB) (bool, error, int) {
 	prvCoinID := &common.Hash{}
 	err := prvCoinID.SetBytes(common.PRVCoinID[:])
 	if err != nil {
 		return false, err, -1
 	}
-	bulletProofList := make([]*aggregaterange.AggregatedRangeProof, 0)
+	batch := zkp.NewProofBatch()
 	for i, tx := range txList {
 		shardID := common.GetShardIDFromLastByte(tx.GetSenderAddrLastByte())
 		hasPrivacy := tx.IsPrivacy()
-		ok, err := tx.ValidateTransaction(hasPrivacy, transactionStateDB, bridgeStateDB, shardID, prvCoinID, true, false)
+		ok, err := validateTransactionInBatch(tx, hasPrivacy, transactionStateDB, bridgeStateDB, shardID, prvCoinID, batch)
 		if !ok {
 			return false, err, i
 		}
@@ -49,21 +54,36 @@ func (b *batchTransaction) validateBatchTxsByItself(txList []metadata.Transactio
 				return validateMetadata, NewTransactionErr(UnexpectedError, errors.New("Metadata is invalid")), i
 			}
 		}
-
-		if hasPrivacy {
-			if bulletProof := tx.GetProof().GetAggregatedRangeProof(); bulletProof != nil {
-				bulletProofList = append(bulletProofList, bulletProof)
-			}
-		}
 	}
-	//TODO: add go routine
-	ok, err, i := aggregaterange.VerifyBatchingAggregatedRangeProofs(bulletProofList)
-	if err != nil {
-		return false, NewTransactionErr(TxProofVerifyFailError, err), -1
+
+	ok, err := batch.Verify(BatchVerificationWorkers)
+	if ok {
+		return true, nil, -1
 	}
-	if !ok {
-		Logger.log.Errorf("FAILED VERIFICATION BATCH PAYMENT PROOF %d", i)
-		return false, NewTransactionErr(TxProofVerifyFailError, fmt.Errorf("FAILED VERIFICATION BATCH PAYMENT PROOF %d", i)), -1
+	Logger.log.Errorf("FAILED VERIFICATION BATCH PAYMENT PROOF: %v, verifying txs one by one", err)
+	for i, tx := range txList {
+		shardID := common.GetShardIDFromLastByte(tx.GetSenderAddrLastByte())
+		ok, err := tx.ValidateTransaction(tx.IsPrivacy(), transactionStateDB, bridgeStateDB, shardID, prvCoinID, false, false)
+		if !ok {
+			return false, err, i
+		}
 	}
+	// Every tx is valid by itself, the per-tx verification has the last word
 	return true, nil, -1
 }
+
+// validateTransactionInBatch validates tx and adds its signatures and proofs to batch
+func validateTransactionInBatch(tx metadata.Transaction, hasPrivacy bool, transactionStateDB *statedb.StateDB, bridgeStateDB *statedb.StateDB, shardID byte, tokenID *common.Hash, batch *zkp.ProofBatch) (bool, error) {
+	switch tx := tx.(type) {
+	case *Tx:
+		if tx.LockTime > ValidateTimeForOneoutOfManyProof {
+			return tx.validateTransaction(hasPrivacy, transactionStateDB, bridgeStateDB, shardID, tokenID, true, false, batch)
+		}
+	case *TxCustomTokenPrivacy:
+		if tx.LockTime > ValidateTimeForOneoutOfManyProof {
+			return tx.validateTransaction(hasPrivacy, transactionStateDB, bridgeStateDB, shardID, tokenID, true, false, batch)
+		}
+	}
+	// Old txs might have invalid one out of many proofs accepted by ValidateTransaction, they would fail the batch
+	return tx.ValidateTransaction(hasPrivacy, transactionStateDB, bridgeStateDB, shardID, tokenID, false, false)
+}
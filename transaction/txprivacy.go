@@ -423,14 +423,29 @@ func (tx *Tx) signTx() error {
 
 // verifySigTx - verify signature on tx
 func (tx *Tx) verifySigTx() (bool, error) {
+	verifyKey, signature, err := tx.parseSigTx()
+	if err != nil {
+		return false, err
+	}
+
+	// verify signature
+	/*Logger.log.Debugf(" VERIFY SIGNATURE ----------- HASH: %v\n", tx.Hash()[:])
+	if tx.Proof != nil {
+		Logger.log.Debugf(" VERIFY SIGNATURE ----------- TX Proof bytes before verifing the signature: %v\n", tx.Proof.Bytes())
+	}
+	Logger.log.Debugf(" VERIFY SIGNATURE ----------- TX meta: %v\n", tx.Metadata)*/
+	res := verifyKey.Verify(signature, tx.Hash()[:])
+
+	return res, nil
+}
+
+// parseSigTx - parse the Schnorr public key and signature of tx
+func (tx *Tx) parseSigTx() (*privacy.SchnorrPublicKey, *privacy.SchnSignature, error) {
 	// check input transaction
 	if tx.Sig == nil || tx.SigPubKey == nil {
-		return false, NewTransactionErr(UnexpectedError, errors.New("input transaction must be an signed one"))
+		return nil, nil, NewTransactionErr(UnexpectedError, errors.New("input transaction must be an signed one"))
 	}
 
-	var err error
-	res := false
-
 	/****** verify Schnorr signature *****/
 	// prepare Public key for verification
 	verifyKey := new(privacy.SchnorrPublicKey)
@@ -438,7 +453,7 @@ func (tx *Tx) verifySigTx() (bool, error) {
 
 	if err != nil {
 		Logger.log.Error(err)
-		return false, NewTransactionErr(DecompressSigPubKeyError, err)
+		return nil, nil, NewTransactionErr(DecompressSigPubKeyError, err)
 	}
 	verifyKey.Set(sigPublicKey)
 
@@ -447,24 +462,21 @@ func (tx *Tx) verifySigTx() (bool, error) {
 	err = signature.SetBytes(tx.Sig)
 	if err != nil {
 		Logger.log.Error(err)
-		return false, NewTransactionErr(InitTxSignatureFromBytesError, err)
+		return nil, nil, NewTransactionErr(InitTxSignatureFromBytesError, err)
 	}
-
-	// verify signature
-	/*Logger.log.Debugf(" VERIFY SIGNATURE ----------- HASH: %v\n", tx.Hash()[:])
-	if tx.Proof != nil {
-		Logger.log.Debugf(" VERIFY SIGNATURE ----------- TX Proof bytes before verifing the signature: %v\n", tx.Proof.Bytes())
-	}
-	Logger.log.Debugf(" VERIFY SIGNATURE ----------- TX meta: %v\n", tx.Metadata)*/
-	res = verifyKey.Verify(signature, tx.Hash()[:])
-
-	return res, nil
+	return verifyKey, signature, nil
 }
 
 // ValidateTransaction returns true if transaction is valid:
 // - Verify tx signature
 // - Verify the payment proof
 func (tx *Tx) ValidateTransaction(hasPrivacy bool, transactionStateDB *statedb.StateDB, bridgeStateDB *statedb.StateDB, shardID byte, tokenID *common.Hash, isBatch bool, isNewTransaction bool) (bool, error) {
+	return tx.validateTransaction(hasPrivacy, transactionStateDB, bridgeStateDB, shardID, tokenID, isBatch, isNewTransaction, nil)
+}
+
+// validateTransaction validates tx like ValidateTransaction, when batch is not nil the signature and proofs
+// are added to batch instead of being verified, they are verified with the other txs of the batch
+func (tx *Tx) validateTransaction(hasPrivacy bool, transactionStateDB *statedb.StateDB, bridgeStateDB *statedb.StateDB, shardID byte, tokenID *common.Hash, isBatch bool, isNewTransaction bool, batch *zkp.ProofBatch) (bool, error) {
 	//hasPrivacy = false
 	Logger.log.Debugf("VALIDATING TX........\n")
 	if tx.GetType() == common.TxRewardType {
@@ -476,7 +488,17 @@ func (tx *Tx) ValidateTransaction(hasPrivacy bool, transactionStateDB *statedb.S
 	var valid bool
 	var err error
 
-	valid, err = tx.verifySigTx()
+	if batch != nil {
+		verifyKey, signature, err := tx.parseSigTx()
+		if err != nil {
+			Logger.log.Errorf("Error verifying signature with tx hash %s: %+v \n", tx.Hash().String(), err)
+			return false, NewTransactionErr(VerifyTxSigFailError, err)
+		}
+		batch.AddSignature(verifyKey, signature, tx.Hash()[:])
+		valid = true
+	} else {
+		valid, err = tx.verifySigTx()
+	}
 	if !valid {
 		if err != nil {
 			Logger.log.Errorf("Error verifying signature with tx hash %s: %+v \n", tx.Hash().String(), err)
@@ -532,7 +554,11 @@ func (tx *Tx) ValidateTransaction(hasPrivacy bool, transactionStateDB *statedb.S
 			}
 		}
 		// Verify the payment proof
-		valid, err = tx.Proof.Verify(hasPrivacy, tx.SigPubKey, tx.Fee, transactionStateDB, shardID, tokenID, isBatch)
+		if batch != nil {
+			valid, err = tx.Proof.VerifyInBatch(hasPrivacy, tx.SigPubKey, tx.Fee, transactionStateDB, shardID, tokenID, batch)
+		} else {
+			valid, err = tx.Proof.Verify(hasPrivacy, tx.SigPubKey, tx.Fee, transactionStateDB, shardID, tokenID, isBatch)
+		}
 		if !valid {
 			if err != nil {
 				Logger.log.Error(err)
@@ -0,0 +1,332 @@
+package wire
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sync"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// A binary message is framed as magic, codec version, cmd code and flags bytes followed by the payload.
+// The magic byte is not a hex digit so a binary message is never mistaken for a legacy one.
+// Messages implementing encoding.BinaryMarshaler carry their binary layout as payload,
+// the others carry their json so the payload is only gzipped, never hex encoded.
+//
+// Codec version 1 only has binary layouts for BFT and peer state messages. Blocks, cross shard blocks
+// and txs are out of its scope: their hashes are computed from every field, so their layouts need
+// exact round trips of headers, privacy proofs and metadata which are left to a later codec version.
+// Giving a message a binary layout changes its payload, so it requires a new BinaryCodecVersion.
+const (
+	binaryMessageMagic      = byte(0xb1)
+	binaryMessageHeaderSize = 4
+
+	binaryFlagGzip = byte(1)
+
+	// payloads smaller than this are not worth gzipping
+	binaryGzipThreshold = 512
+)
+
+// cmd codes of the binary codec, a cmd code is the index of its cmd type plus one.
+// NOTE: only append to this list, a cmd code must never change
+var binaryCmdTypes = []string{
+	CmdBlockBeacon,
+	CmdBlockShard,
+	CmdCrossShard,
+	CmdBlkShardToBeacon,
+	CmdTx,
+	CmdPrivacyCustomToken,
+	CmdBFT,
+	CmdPeerState,
+	CmdGetBlockBeacon,
+	CmdGetBlockShard,
+	CmdGetCrossShard,
+	CmdGetShardToBeacon,
+	CmdVersion,
+	CmdVerack,
+	CmdGetAddr,
+	CmdAddr,
+	CmdPing,
+	CmdMsgCheck,
+	CmdMsgCheckResp,
+}
+
+func getBinaryCmdCode(cmdType string) (byte, error) {
+	for i, t := range binaryCmdTypes {
+		if t == cmdType {
+			return byte(i + 1), nil
+		}
+	}
+	return 0, fmt.Errorf("no binary cmd code for message type %v", cmdType)
+}
+
+func getBinaryCmdType(cmdCode byte) (string, error) {
+	if cmdCode == 0 || int(cmdCode) > len(binaryCmdTypes) {
+		return "", fmt.Errorf("unknown binary cmd code %v", cmdCode)
+	}
+	return binaryCmdTypes[cmdCode-1], nil
+}
+
+// IsBinaryMessage tells if data is framed by the binary codec, legacy messages are hex strings
+func IsBinaryMessage(data []byte) bool {
+	return len(data) >= binaryMessageHeaderSize && data[0] == binaryMessageMagic
+}
+
+// EncodeBinaryMessage frames msg with the binary codec
+func EncodeBinaryMessage(msg Message) ([]byte, error) {
+	cmdCode, err := getBinaryCmdCode(msg.MessageType())
+	if err != nil {
+		return nil, err
+	}
+	var payload []byte
+	if marshaler, ok := msg.(encoding.BinaryMarshaler); ok {
+		payload, err = marshaler.MarshalBinary()
+	} else {
+		payload, err = msg.JsonSerialize()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	flags := byte(0)
+	if len(payload) >= binaryGzipThreshold {
+		zipped, err := gzipPayload(payload)
+		if err != nil {
+			return nil, err
+		}
+		if len(zipped) < len(payload) {
+			payload = zipped
+			flags |= binaryFlagGzip
+		}
+	}
+
+	data := make([]byte, 0, binaryMessageHeaderSize+len(payload))
+	data = append(data, binaryMessageMagic, BinaryCodecVersion, cmdCode, flags)
+	return append(data, payload...), nil
+}
+
+// DecodeBinaryMessage parses a message framed by the binary codec
+func DecodeBinaryMessage(data []byte) (Message, error) {
+	if !IsBinaryMessage(data) {
+		return nil, fmt.Errorf("not a binary message")
+	}
+	if data[1] != BinaryCodecVersion {
+		return nil, fmt.Errorf("unsupported binary codec version %v", data[1])
+	}
+	cmdType, err := getBinaryCmdType(data[2])
+	if err != nil {
+		return nil, err
+	}
+	msg, err := MakeEmptyMessage(cmdType)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := data[binaryMessageHeaderSize:]
+	if data[3]&binaryFlagGzip != 0 {
+		payload, err = gunzipPayload(payload, msg.MaxPayloadLength(Version))
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(payload) > msg.MaxPayloadLength(Version) {
+		return nil, fmt.Errorf("message size too large %v, it must be less than %v", len(payload), msg.MaxPayloadLength(Version))
+	}
+
+	if unmarshaler, ok := msg.(encoding.BinaryUnmarshaler); ok {
+		err = unmarshaler.UnmarshalBinary(payload)
+	} else {
+		err = json.Unmarshal(payload, &msg)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// gzip writers are reused between messages, allocating one costs more than compressing a small payload
+var gzipWriterPool = sync.Pool{
+	New: func() interface{} {
+		return gzip.NewWriter(nil)
+	},
+}
+
+func gzipPayload(payload []byte) ([]byte, error) {
+	var b bytes.Buffer
+	gz := gzipWriterPool.Get().(*gzip.Writer)
+	defer gzipWriterPool.Put(gz)
+	gz.Reset(&b)
+	if _, err := gz.Write(payload); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// gunzipPayload stops decompressing after maxLength bytes so a small zipped payload can not
+// expand to an unbounded one
+func gunzipPayload(src []byte, maxLength int) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(src))
+	if err != nil {
+		return nil, err
+	}
+	payload, err := ioutil.ReadAll(io.LimitReader(gz, int64(maxLength)+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(payload) > maxLength {
+		return nil, fmt.Errorf("message size too large, it must be less than %v", maxLength)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// binaryWriter writes the fields of a binary layout, integers are varints and
+// byte slices and strings are prefixed by their length
+type binaryWriter struct {
+	buf     bytes.Buffer
+	scratch [binary.MaxVarintLen64]byte
+}
+
+func (w *binaryWriter) writeUvarint(v uint64) {
+	n := binary.PutUvarint(w.scratch[:], v)
+	w.buf.Write(w.scratch[:n])
+}
+
+func (w *binaryWriter) writeVarint(v int64) {
+	n := binary.PutVarint(w.scratch[:], v)
+	w.buf.Write(w.scratch[:n])
+}
+
+func (w *binaryWriter) writeBytes(b []byte) {
+	w.writeUvarint(uint64(len(b)))
+	w.buf.Write(b)
+}
+
+func (w *binaryWriter) writeString(s string) {
+	w.writeUvarint(uint64(len(s)))
+	w.buf.WriteString(s)
+}
+
+func (w *binaryWriter) writeByte(b byte) {
+	w.buf.WriteByte(b)
+}
+
+func (w *binaryWriter) writeHash(h common.Hash) {
+	w.buf.Write(h[:])
+}
+
+// binaryReader reads the fields written by binaryWriter, it keeps the first error
+// so a layout is read field by field and the error is checked once at the end
+type binaryReader struct {
+	data []byte
+	err  error
+}
+
+func (r *binaryReader) readUvarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(r.data)
+	if n <= 0 {
+		r.err = io.ErrUnexpectedEOF
+		return 0
+	}
+	r.data = r.data[n:]
+	return v
+}
+
+func (r *binaryReader) readVarint() int64 {
+	if r.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(r.data)
+	if n <= 0 {
+		r.err = io.ErrUnexpectedEOF
+		return 0
+	}
+	r.data = r.data[n:]
+	return v
+}
+
+// readLen reads a length prefix and checks the data holds at least length items of itemSize bytes
+func (r *binaryReader) readLen(itemSize int) int {
+	length := r.readUvarint()
+	if r.err != nil {
+		return 0
+	}
+	if length > uint64(len(r.data)/itemSize) {
+		r.err = io.ErrUnexpectedEOF
+		return 0
+	}
+	return int(length)
+}
+
+func (r *binaryReader) readBytes() []byte {
+	length := r.readLen(1)
+	if r.err != nil || length == 0 {
+		return nil
+	}
+	b := make([]byte, length)
+	copy(b, r.data[:length])
+	r.data = r.data[length:]
+	return b
+}
+
+func (r *binaryReader) readString() string {
+	length := r.readLen(1)
+	if r.err != nil {
+		return ""
+	}
+	s := string(r.data[:length])
+	r.data = r.data[length:]
+	return s
+}
+
+func (r *binaryReader) readByte() byte {
+	if r.err != nil {
+		return 0
+	}
+	if len(r.data) < 1 {
+		r.err = io.ErrUnexpectedEOF
+		return 0
+	}
+	b := r.data[0]
+	r.data = r.data[1:]
+	return b
+}
+
+func (r *binaryReader) readHash() common.Hash {
+	h := common.Hash{}
+	if r.err != nil {
+		return h
+	}
+	if len(r.data) < common.HashSize {
+		r.err = io.ErrUnexpectedEOF
+		return h
+	}
+	copy(h[:], r.data[:common.HashSize])
+	r.data = r.data[common.HashSize:]
+	return h
+}
+
+// done returns the first error, or an error if bytes are left after the layout
+func (r *binaryReader) done() error {
+	if r.err != nil {
+		return r.err
+	}
+	if len(r.data) != 0 {
+		return fmt.Errorf("%v unexpected bytes after binary layout", len(r.data))
+	}
+	return nil
+}
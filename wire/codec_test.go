@@ -0,0 +1,420 @@
+package wire
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/hex"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// encodeLegacyMessage encodes msg like peerv2 does with the legacy codec: json, header, gzip then hex
+func encodeLegacyMessage(msg Message) ([]byte, error) {
+	messageBytes, err := msg.JsonSerialize()
+	if err != nil {
+		return nil, err
+	}
+	headerBytes := make([]byte, MessageHeaderSize)
+	copy(headerBytes, msg.MessageType())
+	headerBytes[MessageCmdTypeSize] = 's'
+	messageBytes = append(messageBytes, headerBytes...)
+	messageBytes, err = common.GZipFromBytes(messageBytes)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(hex.EncodeToString(messageBytes)), nil
+}
+
+// decodeLegacyMessage decodes a message like peerv2 does with the legacy codec
+func decodeLegacyMessage(data []byte) (Message, error) {
+	zipped, err := hex.DecodeString(string(data))
+	if err != nil {
+		return nil, err
+	}
+	messageBytes, err := common.GZipToBytes(zipped)
+	if err != nil {
+		return nil, err
+	}
+	messageHeader := messageBytes[len(messageBytes)-MessageHeaderSize:]
+	msg, err := MakeEmptyMessage(string(bytes.Trim(messageHeader[:MessageCmdTypeSize], "\x00")))
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(messageBytes[:len(messageBytes)-MessageHeaderSize], &msg)
+	if err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+func newTestBlockShard() *MessageBlockShard {
+	block := blockchain.NewShardBlock()
+	block.Header = blockchain.ShardHeader{
+		Producer:          "1Hp6sZuU4E1wVYvjZgTT3jCPMn2GKMATvHYDZpSRnmyBBB9dCyPFjk3RdJRuoExM7L6qUcJ8GQRyaRd4VeiEjFibq",
+		ProducerPubKeyStr: "121VhftSAygpEJZ6i9jGkCVwX5W7tZY6McnoXxZ3xArZQcKduS78P6F6B6T8sjNkoxN7pfjJruViCG3o4X5CiEtHCv9Ufnn",
+		ShardID:           1,
+		Version:           2,
+		PreviousBlockHash: common.HashH([]byte("previous")),
+		Height:            123456,
+		Round:             1,
+		Epoch:             350,
+		BeaconHeight:      123400,
+		BeaconHash:        common.HashH([]byte("beacon")),
+		TotalTxsFee:       map[common.Hash]uint64{common.PRVCoinID: 1000},
+		ConsensusType:     common.BlsConsensus,
+		Timestamp:         1590000000,
+		TxRoot:            common.HashH([]byte("tx")),
+		ShardTxRoot:       common.HashH([]byte("shard tx")),
+		InstructionsRoot:  common.HashH([]byte("instructions")),
+		CommitteeRoot:     common.HashH([]byte("committee")),
+		Proposer:          "1Hp6sZuU4E1wVYvjZgTT3jCPMn2GKMATvHYDZpSRnmyBBB9dCyPFjk3RdJRuoExM7L6qUcJ8GQRyaRd4VeiEjFibq",
+		ProposeTime:       1590000000,
+	}
+	block.ValidationData = `{"ProducerBLSSig":"` + strings.Repeat("QmSPa4gxx6PRmoNR", 6) + `","ValidatiorsIdx":[0,1,2,3]}`
+	for i := 0; i < 20; i++ {
+		block.Body.Instructions = append(block.Body.Instructions, []string{"stake", strings.Repeat("121VhftSAygpEJZ6i9jGkCVwX5W7tZY6", 4), "shard", common.HashH([]byte{byte(i)}).String()})
+	}
+	return &MessageBlockShard{Block: block}
+}
+
+func newTestMessageBFTPropose() *MessageBFT {
+	blockBytes, _ := json.Marshal(newTestBlockShard().Block)
+	content, _ := json.Marshal(map[string][]byte{"Block": blockBytes})
+	return &MessageBFT{
+		PeerID:    "QmSPa4gxx6PRmoNRu6P2iFwEwmayaoLdR5By3i3MgM9gMv",
+		Type:      "propose",
+		Content:   content,
+		ChainKey:  "shard-1",
+		Timestamp: 1590000000,
+		TimeSlot:  159000000,
+	}
+}
+
+func newTestMessageBFTVote() *MessageBFT {
+	content, _ := json.Marshal(map[string]interface{}{
+		"Validator":    "121VhftSAygpEJZ6i9jGkCVwX5W7tZY6McnoXxZ3xArZQcKduS78P6F6B6T8sjNkoxN7pfjJruViCG3o4X5CiEtHCv9Ufnn",
+		"BlockHash":    common.HashH([]byte("block")).String(),
+		"Bls":          bytes.Repeat([]byte{7}, 48),
+		"Bri":          bytes.Repeat([]byte{9}, 65),
+		"Confirmation": bytes.Repeat([]byte{3}, 65),
+	})
+	return &MessageBFT{
+		PeerID:    "QmSPa4gxx6PRmoNRu6P2iFwEwmayaoLdR5By3i3MgM9gMv",
+		Type:      "vote",
+		Content:   content,
+		ChainKey:  "shard-1",
+		Timestamp: 1590000000,
+		TimeSlot:  159000000,
+	}
+}
+
+func newTestMessagePeerState() *MessagePeerState {
+	msg := &MessagePeerState{
+		Beacon: ChainState{
+			Timestamp:     1590000000,
+			Height:        123400,
+			BlockHash:     common.HashH([]byte("beacon")),
+			BestStateHash: common.HashH([]byte("beacon best state")),
+		},
+		Shards:                make(map[byte]ChainState),
+		ShardToBeaconPool:     make(map[byte][]uint64),
+		CrossShardPool:        make(map[byte]map[byte][]uint64),
+		Timestamp:             1590000000,
+		SenderID:              "QmSPa4gxx6PRmoNRu6P2iFwEwmayaoLdR5By3i3MgM9gMv",
+		SenderMiningPublicKey: "121VhftSAygpEJZ6i9jGkCVwX5W7tZY6McnoXxZ3xArZQcKduS78P6F6B6T8sjNkoxN7pfjJruViCG3o4X5CiEtHCv9Ufnn",
+	}
+	for shardID := byte(0); shardID < 8; shardID++ {
+		msg.Shards[shardID] = ChainState{
+			Timestamp:     1590000000,
+			Height:        123456 + uint64(shardID),
+			BlockHash:     common.HashH([]byte{shardID}),
+			BestStateHash: common.HashH([]byte{shardID, shardID}),
+		}
+		msg.ShardToBeaconPool[shardID] = []uint64{123450, 123451, 123452}
+		msg.CrossShardPool[shardID] = map[byte][]uint64{(shardID + 1) % 8: {123450, 123451}}
+	}
+	return msg
+}
+
+func TestBinaryMessageRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  Message
+	}{
+		{name: "bft propose", msg: newTestMessageBFTPropose()},
+		{name: "bft vote", msg: newTestMessageBFTVote()},
+		{name: "empty bft", msg: &MessageBFT{}},
+		{name: "peer state", msg: newTestMessagePeerState()},
+		{name: "empty peer state", msg: &MessagePeerState{
+			Shards:            make(map[byte]ChainState),
+			ShardToBeaconPool: make(map[byte][]uint64),
+			CrossShardPool:    make(map[byte]map[byte][]uint64),
+		}},
+		{name: "block shard", msg: newTestBlockShard()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := EncodeBinaryMessage(tt.msg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !IsBinaryMessage(data) {
+				t.Fatal("encoded message is not a binary message")
+			}
+			got, err := DecodeBinaryMessage(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if reflect.TypeOf(got) != reflect.TypeOf(tt.msg) {
+				t.Fatalf("want type %v but got %v", reflect.TypeOf(tt.msg), reflect.TypeOf(got))
+			}
+			// the binary codec must decode what the legacy codec decodes
+			legacy, err := encodeLegacyMessage(tt.msg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, err := decodeLegacyMessage(legacy)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Hash() != want.Hash() {
+				t.Fatalf("want %+v but got %+v", want, got)
+			}
+		})
+	}
+}
+
+func TestBinaryMessageLayoutRoundTrip(t *testing.T) {
+	bft := newTestMessageBFTVote()
+	data, err := bft.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	gotBFT := &MessageBFT{}
+	err = gotBFT.UnmarshalBinary(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(bft, gotBFT) {
+		t.Fatalf("want %+v but got %+v", bft, gotBFT)
+	}
+
+	peerState := newTestMessagePeerState()
+	data, err = peerState.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	gotPeerState := &MessagePeerState{}
+	err = gotPeerState.UnmarshalBinary(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(peerState, gotPeerState) {
+		t.Fatalf("want %+v but got %+v", peerState, gotPeerState)
+	}
+}
+
+// TestBinaryLayoutsOfCodecVersion lists the messages with a binary layout in the current codec version,
+// giving a layout to another message requires a new BinaryCodecVersion
+func TestBinaryLayoutsOfCodecVersion(t *testing.T) {
+	if BinaryCodecVersion != 1 {
+		t.Fatalf("update the binary layouts of codec version %v", BinaryCodecVersion)
+	}
+	want := []string{CmdBFT, CmdPeerState}
+	got := []string{}
+	for _, cmdType := range binaryCmdTypes {
+		msg, err := MakeEmptyMessage(cmdType)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := msg.(encoding.BinaryMarshaler); ok {
+			got = append(got, cmdType)
+		}
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("want binary layouts of %v but got %v", want, got)
+	}
+}
+
+func TestDecodeBinaryMessageErrors(t *testing.T) {
+	data, err := EncodeBinaryMessage(newTestMessagePeerState())
+	if err != nil {
+		t.Fatal(err)
+	}
+	legacy, err := encodeLegacyMessage(newTestMessagePeerState())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if IsBinaryMessage(legacy) {
+		t.Fatal("legacy message is taken for a binary message")
+	}
+
+	withByte := func(index int, b byte) []byte {
+		modified := append([]byte{}, data...)
+		modified[index] = b
+		return modified
+	}
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "legacy message", data: legacy},
+		{name: "unknown version", data: withByte(1, BinaryCodecVersion+1)},
+		{name: "unknown cmd code", data: withByte(2, byte(len(binaryCmdTypes)+1))},
+		{name: "zero cmd code", data: withByte(2, 0)},
+		{name: "truncated payload", data: data[:len(data)-1]},
+		{name: "trailing bytes", data: append(append([]byte{}, data...), 0)},
+		{name: "header only", data: data[:binaryMessageHeaderSize]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodeBinaryMessage(tt.data)
+			if err == nil {
+				t.Fatal("want error but got none")
+			}
+		})
+	}
+}
+
+func TestDecodeBinaryMessageGzipBomb(t *testing.T) {
+	data, err := EncodeBinaryMessage(newTestMessagePeerState())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a few kb of zeros zipped expand far beyond the max payload of peer state
+	bomb, err := gzipPayload(make([]byte, MaxPeerStatePayload+1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bomb) >= MaxPeerStatePayload/100 {
+		t.Fatalf("zipped payload of %v bytes is not small", len(bomb))
+	}
+	data = append(append([]byte{}, data[:binaryMessageHeaderSize]...), bomb...)
+	data[3] |= binaryFlagGzip
+	_, err = DecodeBinaryMessage(data)
+	if err == nil || !strings.Contains(err.Error(), "message size too large") {
+		t.Fatalf("want message size error but got %v", err)
+	}
+}
+
+// TestBinaryMessageSize checks the binary codec puts less bytes on the wire than the legacy one
+func TestBinaryMessageSize(t *testing.T) {
+	for name, msg := range map[string]Message{
+		"bft propose": newTestMessageBFTPropose(),
+		"bft vote":    newTestMessageBFTVote(),
+		"peer state":  newTestMessagePeerState(),
+		"block shard": newTestBlockShard(),
+	} {
+		legacy, err := encodeLegacyMessage(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := EncodeBinaryMessage(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Logf("%v: legacy %v bytes, binary %v bytes", name, len(legacy), len(data))
+		if len(data) >= len(legacy) {
+			t.Fatalf("%v: binary message of %v bytes is not smaller than legacy message of %v bytes", name, len(data), len(legacy))
+		}
+	}
+}
+
+func benchmarkEncode(b *testing.B, msg Message, encode func(Message) ([]byte, error)) {
+	data, err := encode(msg)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := encode(msg)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(len(data)), "wirebytes/op")
+}
+
+func benchmarkDecode(b *testing.B, msg Message, encode func(Message) ([]byte, error), decode func([]byte) (Message, error)) {
+	data, err := encode(msg)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := decode(data)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(len(data)), "wirebytes/op")
+}
+
+func BenchmarkEncodeLegacyBFTPropose(b *testing.B) {
+	benchmarkEncode(b, newTestMessageBFTPropose(), encodeLegacyMessage)
+}
+
+func BenchmarkEncodeBinaryBFTPropose(b *testing.B) {
+	benchmarkEncode(b, newTestMessageBFTPropose(), EncodeBinaryMessage)
+}
+
+func BenchmarkDecodeLegacyBFTPropose(b *testing.B) {
+	benchmarkDecode(b, newTestMessageBFTPropose(), encodeLegacyMessage, decodeLegacyMessage)
+}
+
+func BenchmarkDecodeBinaryBFTPropose(b *testing.B) {
+	benchmarkDecode(b, newTestMessageBFTPropose(), EncodeBinaryMessage, DecodeBinaryMessage)
+}
+
+func BenchmarkEncodeLegacyBFTVote(b *testing.B) {
+	benchmarkEncode(b, newTestMessageBFTVote(), encodeLegacyMessage)
+}
+
+func BenchmarkEncodeBinaryBFTVote(b *testing.B) {
+	benchmarkEncode(b, newTestMessageBFTVote(), EncodeBinaryMessage)
+}
+
+func BenchmarkDecodeLegacyBFTVote(b *testing.B) {
+	benchmarkDecode(b, newTestMessageBFTVote(), encodeLegacyMessage, decodeLegacyMessage)
+}
+
+func BenchmarkDecodeBinaryBFTVote(b *testing.B) {
+	benchmarkDecode(b, newTestMessageBFTVote(), EncodeBinaryMessage, DecodeBinaryMessage)
+}
+
+func BenchmarkEncodeLegacyPeerState(b *testing.B) {
+	benchmarkEncode(b, newTestMessagePeerState(), encodeLegacyMessage)
+}
+
+func BenchmarkEncodeBinaryPeerState(b *testing.B) {
+	benchmarkEncode(b, newTestMessagePeerState(), EncodeBinaryMessage)
+}
+
+func BenchmarkDecodeLegacyPeerState(b *testing.B) {
+	benchmarkDecode(b, newTestMessagePeerState(), encodeLegacyMessage, decodeLegacyMessage)
+}
+
+func BenchmarkDecodeBinaryPeerState(b *testing.B) {
+	benchmarkDecode(b, newTestMessagePeerState(), EncodeBinaryMessage, DecodeBinaryMessage)
+}
+
+func BenchmarkEncodeLegacyBlockShard(b *testing.B) {
+	benchmarkEncode(b, newTestBlockShard(), encodeLegacyMessage)
+}
+
+func BenchmarkEncodeBinaryBlockShard(b *testing.B) {
+	benchmarkEncode(b, newTestBlockShard(), EncodeBinaryMessage)
+}
+
+func BenchmarkDecodeLegacyBlockShard(b *testing.B) {
+	benchmarkDecode(b, newTestBlockShard(), encodeLegacyMessage, decodeLegacyMessage)
+}
+
+func BenchmarkDecodeBinaryBlockShard(b *testing.B) {
+	benchmarkDecode(b, newTestBlockShard(), EncodeBinaryMessage, DecodeBinaryMessage)
+}
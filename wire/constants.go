@@ -14,3 +14,16 @@ const (
 
 	MaxGetAddrPayload = 1000 // 1 1Kb
 )
+
+// codecs of messages published to highway topics
+const (
+	WireCodecLegacy = "legacy" // json, gzip then hex on highway topics
+	WireCodecDual   = "dual"   // legacy on highway topics and binary on their binary topics
+	WireCodecBinary = "binary" // binary on binary topics only
+
+	// binary messages of a highway topic are published to the topic name followed by this suffix
+	BinaryTopicSuffix = "/bin"
+
+	// version of the binary codec, a node drops binary messages of an unknown version
+	BinaryCodecVersion = 1
+)
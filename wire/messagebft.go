@@ -47,6 +47,29 @@ func (msg *MessageBFT) JsonDeserialize(jsonStr string) error {
 	return err
 }
 
+// MarshalBinary writes the binary layout of the message, Content is kept as raw bytes instead of base64
+func (msg *MessageBFT) MarshalBinary() ([]byte, error) {
+	w := &binaryWriter{}
+	w.writeString(msg.PeerID)
+	w.writeString(msg.Type)
+	w.writeBytes(msg.Content)
+	w.writeString(msg.ChainKey)
+	w.writeVarint(msg.Timestamp)
+	w.writeVarint(msg.TimeSlot)
+	return w.buf.Bytes(), nil
+}
+
+func (msg *MessageBFT) UnmarshalBinary(data []byte) error {
+	r := &binaryReader{data: data}
+	msg.PeerID = r.readString()
+	msg.Type = r.readString()
+	msg.Content = r.readBytes()
+	msg.ChainKey = r.readString()
+	msg.Timestamp = r.readVarint()
+	msg.TimeSlot = r.readVarint()
+	return r.done()
+}
+
 func (msg *MessageBFT) SetSenderID(senderID peer.ID) error {
 	return nil
 }
@@ -2,6 +2,7 @@ package wire
 
 import (
 	"encoding/json"
+	"sort"
 
 	peer "github.com/libp2p/go-libp2p-peer"
 
@@ -56,6 +57,126 @@ func (msg *MessagePeerState) JsonDeserialize(jsonStr string) error {
 	return err
 }
 
+// MarshalBinary writes the binary layout of the message, map entries are ordered by key
+func (msg *MessagePeerState) MarshalBinary() ([]byte, error) {
+	w := &binaryWriter{}
+	writeChainState(w, msg.Beacon)
+	shardIDs := make([]byte, 0, len(msg.Shards))
+	for shardID := range msg.Shards {
+		shardIDs = append(shardIDs, shardID)
+	}
+	sortShardIDs(shardIDs)
+	w.writeUvarint(uint64(len(shardIDs)))
+	for _, shardID := range shardIDs {
+		w.writeByte(shardID)
+		writeChainState(w, msg.Shards[shardID])
+	}
+	shardIDs = make([]byte, 0, len(msg.ShardToBeaconPool))
+	for shardID := range msg.ShardToBeaconPool {
+		shardIDs = append(shardIDs, shardID)
+	}
+	sortShardIDs(shardIDs)
+	w.writeUvarint(uint64(len(shardIDs)))
+	for _, shardID := range shardIDs {
+		w.writeByte(shardID)
+		writeHeights(w, msg.ShardToBeaconPool[shardID])
+	}
+	shardIDs = make([]byte, 0, len(msg.CrossShardPool))
+	for shardID := range msg.CrossShardPool {
+		shardIDs = append(shardIDs, shardID)
+	}
+	sortShardIDs(shardIDs)
+	w.writeUvarint(uint64(len(shardIDs)))
+	for _, shardID := range shardIDs {
+		w.writeByte(shardID)
+		pool := msg.CrossShardPool[shardID]
+		fromShardIDs := make([]byte, 0, len(pool))
+		for fromShardID := range pool {
+			fromShardIDs = append(fromShardIDs, fromShardID)
+		}
+		sortShardIDs(fromShardIDs)
+		w.writeUvarint(uint64(len(fromShardIDs)))
+		for _, fromShardID := range fromShardIDs {
+			w.writeByte(fromShardID)
+			writeHeights(w, pool[fromShardID])
+		}
+	}
+	w.writeVarint(msg.Timestamp)
+	w.writeString(msg.SenderID)
+	w.writeString(msg.SenderMiningPublicKey)
+	return w.buf.Bytes(), nil
+}
+
+func (msg *MessagePeerState) UnmarshalBinary(data []byte) error {
+	r := &binaryReader{data: data}
+	msg.Beacon = readChainState(r)
+	msg.Shards = make(map[byte]ChainState)
+	for i := r.readLen(1); i > 0; i-- {
+		shardID := r.readByte()
+		msg.Shards[shardID] = readChainState(r)
+	}
+	msg.ShardToBeaconPool = make(map[byte][]uint64)
+	for i := r.readLen(1); i > 0; i-- {
+		shardID := r.readByte()
+		msg.ShardToBeaconPool[shardID] = readHeights(r)
+	}
+	msg.CrossShardPool = make(map[byte]map[byte][]uint64)
+	for i := r.readLen(1); i > 0; i-- {
+		shardID := r.readByte()
+		pool := make(map[byte][]uint64)
+		for j := r.readLen(1); j > 0; j-- {
+			fromShardID := r.readByte()
+			pool[fromShardID] = readHeights(r)
+		}
+		msg.CrossShardPool[shardID] = pool
+	}
+	msg.Timestamp = r.readVarint()
+	msg.SenderID = r.readString()
+	msg.SenderMiningPublicKey = r.readString()
+	return r.done()
+}
+
+func writeChainState(w *binaryWriter, state ChainState) {
+	w.writeVarint(state.Timestamp)
+	w.writeUvarint(state.Height)
+	w.writeHash(state.BlockHash)
+	w.writeHash(state.BestStateHash)
+}
+
+func readChainState(r *binaryReader) ChainState {
+	return ChainState{
+		Timestamp:     r.readVarint(),
+		Height:        r.readUvarint(),
+		BlockHash:     r.readHash(),
+		BestStateHash: r.readHash(),
+	}
+}
+
+func writeHeights(w *binaryWriter, heights []uint64) {
+	w.writeUvarint(uint64(len(heights)))
+	for _, height := range heights {
+		w.writeUvarint(height)
+	}
+}
+
+func readHeights(r *binaryReader) []uint64 {
+	length := r.readLen(1)
+	if r.err != nil {
+		return nil
+	}
+	heights := make([]uint64, length)
+	for i := range heights {
+		heights[i] = r.readUvarint()
+	}
+	return heights
+}
+
+func sortShardIDs(shardIDs []byte) {
+	sort.Slice(shardIDs, func(i, j int) bool {
+		return shardIDs[i] < shardIDs[j]
+	})
+}
+
 func (msg *MessagePeerState) SetSenderID(senderID peer.ID) error {
 	msg.SenderID = senderID.Pretty()
 	return nil